@@ -1,10 +1,13 @@
 package cmd
 
 import (
+	"github.com/hcd233/aris-blog-api/internal/logger"
 	"github.com/hcd233/aris-blog-api/internal/resource/database"
+	"github.com/hcd233/aris-blog-api/internal/resource/database/dao"
 	"github.com/hcd233/aris-blog-api/internal/resource/database/model"
 	"github.com/samber/lo"
 	"github.com/spf13/cobra"
+	"go.uber.org/zap"
 )
 
 var databaseCmd = &cobra.Command{
@@ -21,6 +24,19 @@ var migrateDatabaseCmd = &cobra.Command{
 		database.InitDatabase()
 		db := database.GetDBInstance(cmd.Context())
 		lo.Must0(db.AutoMigrate(model.Models...))
+
+		// 文章版本唯一索引已加入语言维度，删除旧索引
+		if db.Migrator().HasIndex(&model.ArticleVersion{}, "idx_article_version") {
+			lo.Must0(db.Migrator().DropIndex(&model.ArticleVersion{}, "idx_article_version"))
+		}
+
+		// 第三方绑定ID未绑定时为NULL，清理历史空字符串
+		lo.Must0(dao.GetUserDAO().NullifyEmptyBindIDs(db))
+
+		// 权限等级迁移为同名内置角色，已分配角色的用户不受影响
+		lo.Must0(dao.GetRoleDAO().EnsureBuiltinRoles(db))
+		assigned := lo.Must1(dao.GetUserDAO().AssignBuiltinRolesByPermission(db))
+		logger.Logger().Info("[Migrate] builtin roles assigned", zap.Int64("users", assigned))
 	},
 }
 
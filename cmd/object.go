@@ -4,8 +4,11 @@ import (
 	"context"
 
 	"github.com/hcd233/aris-blog-api/internal/logger"
+	"github.com/hcd233/aris-blog-api/internal/resource/cache"
+	"github.com/hcd233/aris-blog-api/internal/resource/database"
 	"github.com/hcd233/aris-blog-api/internal/resource/storage"
 	objdao "github.com/hcd233/aris-blog-api/internal/resource/storage/obj_dao"
+	"github.com/hcd233/aris-blog-api/internal/service"
 	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
@@ -46,8 +49,120 @@ var createBucketCmd = &cobra.Command{
 	},
 }
 
+var assetCmd = &cobra.Command{
+	Use:   "asset",
+	Short: "图片资产相关命令组",
+	Long:  `提供一组用于维护图片资产表的命令。`,
+}
+
+var backfillAssetCmd = &cobra.Command{
+	Use:   "backfill",
+	Short: "补录图片资产",
+	Long:  `为资产表上线前上传的图片补录资产：去除元数据后按内容摘要转存，并删除原对象及其缩略图与变体，缩略图由后台任务重新生成。`,
+	Run: func(cmd *cobra.Command, _ []string) {
+		database.InitDatabase()
+		cache.InitCache()
+		storage.InitObjectStorage()
+
+		backfilled := lo.Must1(service.NewAssetService().BackfillImageAssets(cmd.Context()))
+		logger.Logger().Info("[Object Storage] Image assets backfilled", zap.Int("images", backfilled))
+	},
+}
+
+var usageCmd = &cobra.Command{
+	Use:   "usage",
+	Short: "存储用量相关命令组",
+	Long:  `提供一组用于维护用户存储用量的命令。`,
+}
+
+var recalculateUsageCmd = &cobra.Command{
+	Use:   "recalculate",
+	Short: "校正存储用量",
+	Long:  `按对象存储中原图、缩略图、附件与附件预览图的实际大小重新计算用户的存储用量，修正记账偏差；未指定用户时校正全部用户。`,
+	Run: func(cmd *cobra.Command, _ []string) {
+		userID := lo.Must1(cmd.Flags().GetUint("user"))
+
+		database.InitDatabase()
+		storage.InitObjectStorage()
+
+		users := lo.Must1(service.NewAssetService().RecalculateStorageUsage(cmd.Context(), userID))
+		logger.Logger().Info("[Object Storage] Storage usage recalculated", zap.Int("users", users))
+	},
+}
+
+var gcCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "回收孤立图片与附件",
+	Long:  `回收超过宽限期且未被任何文章版本内容或用户头像引用的图片及其缩略图，以及原图已不存在的缩略图；同时回收已删除文章的附件、未确认上传遗留的附件对象与直传图片的暂存对象；宽限期由ASSET_GC_GRACE_PERIOD配置。`,
+	Run: func(cmd *cobra.Command, _ []string) {
+		dryRun := lo.Must1(cmd.Flags().GetBool("dry-run"))
+
+		database.InitDatabase()
+		storage.InitObjectStorage()
+
+		result := lo.Must1(service.NewAssetService().CollectOrphanedAssets(cmd.Context(), dryRun))
+		logger.Logger().Info("[Object Storage] Orphaned assets collected",
+			zap.Bool("dryRun", dryRun),
+			zap.Int("users", result.Users),
+			zap.Int("failed", result.Failed),
+			zap.Int("assets", result.Assets),
+			zap.Int("images", result.Images),
+			zap.Int("thumbnails", result.Thumbnails),
+			zap.Int("attachments", result.Attachments),
+			zap.Int("staging", result.Staging),
+			zap.Int64("freedBytes", result.FreedBytes))
+	},
+}
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "在存储提供商之间迁移对象",
+	Long:  `按用户目录将原图、缩略图、附件与附件预览图从源提供商复制到目标提供商，复制后重新下载校验SHA-256摘要，进度记录在状态文件中，中断后重新执行即可续传；两个提供商的连接配置都须填写，目标桶须已通过object bucket create创建，变体在首次请求时重新生成。`,
+	Run: func(cmd *cobra.Command, _ []string) {
+		from := lo.Must1(storage.ParseProvider(lo.Must1(cmd.Flags().GetString("from"))))
+		to := lo.Must1(storage.ParseProvider(lo.Must1(cmd.Flags().GetString("to"))))
+		opts := &service.ObjectMigrationOptions{
+			From:        from,
+			To:          to,
+			Concurrency: lo.Must1(cmd.Flags().GetInt("concurrency")),
+			StateFile:   lo.Must1(cmd.Flags().GetString("state")),
+			DryRun:      lo.Must1(cmd.Flags().GetBool("dry-run")),
+		}
+
+		database.InitDatabase()
+		storage.InitProvider(from)
+		storage.InitProvider(to)
+
+		result := lo.Must1(service.NewObjectMigrationService().MigrateObjects(cmd.Context(), opts))
+		logger.Logger().Info("[Object Storage] Objects migrated",
+			zap.String("from", string(from)),
+			zap.String("to", string(to)),
+			zap.Bool("dryRun", opts.DryRun),
+			zap.Int("users", result.Users),
+			zap.Int("copied", result.Copied),
+			zap.Int("skipped", result.Skipped),
+			zap.Int("failed", result.Failed),
+			zap.Int64("copiedBytes", result.CopiedBytes))
+	},
+}
+
 func init() {
 	bucketCmd.AddCommand(createBucketCmd)
 	objectCmd.AddCommand(bucketCmd)
+	assetCmd.AddCommand(backfillAssetCmd)
+	objectCmd.AddCommand(assetCmd)
+	recalculateUsageCmd.Flags().Uint("user", 0, "只校正指定用户，0表示全部用户")
+	usageCmd.AddCommand(recalculateUsageCmd)
+	objectCmd.AddCommand(usageCmd)
+	gcCmd.Flags().Bool("dry-run", false, "只报告将被回收的图片，不实际删除")
+	objectCmd.AddCommand(gcCmd)
+	migrateCmd.Flags().String("from", "", "源存储提供商：minio、cos、s3、local")
+	migrateCmd.Flags().String("to", "", "目标存储提供商：minio、cos、s3、local")
+	migrateCmd.Flags().Int("concurrency", 4, "同时复制的对象数")
+	migrateCmd.Flags().String("state", "object_migrate_state.json", "迁移进度文件，重新执行时跳过其中已完成的对象")
+	migrateCmd.Flags().Bool("dry-run", false, "只统计待复制的对象，不实际复制")
+	lo.Must0(migrateCmd.MarkFlagRequired("from"))
+	lo.Must0(migrateCmd.MarkFlagRequired("to"))
+	objectCmd.AddCommand(migrateCmd)
 	rootCmd.AddCommand(objectCmd)
 }
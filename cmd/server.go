@@ -1,12 +1,16 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"runtime/debug"
+	"time"
 
 	"github.com/hcd233/aris-blog-api/internal/api"
+	"github.com/hcd233/aris-blog-api/internal/config"
 	"github.com/hcd233/aris-blog-api/internal/cron"
+	"github.com/hcd233/aris-blog-api/internal/job"
 	"github.com/hcd233/aris-blog-api/internal/logger"
 	"github.com/hcd233/aris-blog-api/internal/middleware"
 	"go.uber.org/zap"
@@ -16,6 +20,7 @@ import (
 	"github.com/hcd233/aris-blog-api/internal/resource/llm"
 	"github.com/hcd233/aris-blog-api/internal/resource/storage"
 	"github.com/hcd233/aris-blog-api/internal/router"
+	"github.com/hcd233/aris-blog-api/internal/service"
 	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 )
@@ -39,6 +44,9 @@ var startServerCmd = &cobra.Command{
 			os.Exit(0)
 		}()
 		host, port := lo.Must1(cmd.Flags().GetString("host")), lo.Must1(cmd.Flags().GetString("port"))
+		embeddedWorker := lo.Must1(cmd.Flags().GetBool("worker"))
+
+		config.ValidateOauth2Credentials()
 
 		database.InitDatabase()
 		cache.InitCache()
@@ -59,8 +67,29 @@ var startServerCmd = &cobra.Command{
 
 		router.RegisterDocsRouter()
 		router.RegisterAPIRouter()
+		router.RegisterLocalObjectRouter()
+
+		var worker *job.Worker
+		if embeddedWorker {
+			service.RegisterJobHandlers()
+			worker = job.NewWorker([]string{job.QueueDefault}, 1)
+			worker.Start()
+		}
+
+		go func() {
+			waitForShutdownSignal()
+			lo.Must0(app.ShutdownWithTimeout(30 * time.Second))
+		}()
 
 		lo.Must0(app.Listen(fmt.Sprintf("%s:%s", host, port)))
+
+		if worker != nil {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			if err := worker.Shutdown(ctx); err != nil {
+				logger.Logger().Error("[Server] Shutdown embedded worker error", zap.Error(err))
+			}
+		}
 	},
 }
 
@@ -70,4 +99,5 @@ func init() {
 
 	startServerCmd.Flags().StringP("host", "", "localhost", "监听的主机")
 	startServerCmd.Flags().StringP("port", "p", "8080", "监听的端口")
+	startServerCmd.Flags().Bool("worker", true, "是否在服务器进程内运行后台任务执行器，独立部署执行器时可关闭")
 }
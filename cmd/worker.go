@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"runtime/debug"
+	"syscall"
+	"time"
+
+	"github.com/hcd233/aris-blog-api/internal/job"
+	"github.com/hcd233/aris-blog-api/internal/logger"
+	"github.com/hcd233/aris-blog-api/internal/resource/cache"
+	"github.com/hcd233/aris-blog-api/internal/resource/database"
+	"github.com/hcd233/aris-blog-api/internal/resource/llm"
+	"github.com/hcd233/aris-blog-api/internal/resource/storage"
+	"github.com/hcd233/aris-blog-api/internal/service"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var workerCmd = &cobra.Command{
+	Use:   "worker",
+	Short: "后台任务命令组",
+	Long:  `包含后台任务执行器相关操作的命令组`,
+}
+
+var startWorkerCmd = &cobra.Command{
+	Use:   "start",
+	Short: "启动后台任务执行器",
+	Long:  `启动独立的后台任务执行器进程，从Redis队列中拉取并执行任务，收到退出信号后等待执行中的任务完成`,
+	Run: func(cmd *cobra.Command, _ []string) {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Logger().Error("[Worker] Start worker panic", zap.Any("error", r), zap.ByteString("stack", debug.Stack()))
+				os.Exit(1)
+			}
+			os.Exit(0)
+		}()
+		queues := lo.Must1(cmd.Flags().GetStringSlice("queues"))
+		concurrency := lo.Must1(cmd.Flags().GetInt("concurrency"))
+		drainTimeout := lo.Must1(cmd.Flags().GetDuration("drain-timeout"))
+
+		database.InitDatabase()
+		cache.InitCache()
+		storage.InitObjectStorage()
+		llm.InitOpenAIClient()
+
+		service.RegisterJobHandlers()
+
+		worker := job.NewWorker(queues, concurrency)
+		worker.Start()
+
+		waitForShutdownSignal()
+
+		ctx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+		defer cancel()
+		if err := worker.Shutdown(ctx); err != nil {
+			logger.Logger().Error("[Worker] Shutdown worker error", zap.Error(err))
+		}
+	},
+}
+
+// waitForShutdownSignal 阻塞直到收到退出信号
+func waitForShutdownSignal() {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	sig := <-sigCh
+	logger.Logger().Info("[Command] Received shutdown signal", zap.String("signal", sig.String()))
+}
+
+func init() {
+	workerCmd.AddCommand(startWorkerCmd)
+	rootCmd.AddCommand(workerCmd)
+
+	startWorkerCmd.Flags().StringSliceP("queues", "q", []string{job.QueueDefault}, "监听的队列，按顺序轮询")
+	startWorkerCmd.Flags().IntP("concurrency", "c", 4, "并发执行的任务数")
+	startWorkerCmd.Flags().Duration("drain-timeout", 30*time.Second, "退出时等待执行中任务完成的最长时间")
+}
@@ -24,6 +24,11 @@ func init() {
 						In:          "header",
 						Description: "JWT Authentication，Please pass the JWT token in the Authorization header.",
 					},
+					"patAuth": {
+						Type:        "http",
+						Scheme:      "bearer",
+						Description: "Personal access token authentication, accepted wherever jwtAuth is. Pass 'Bearer aris_pat_...' in the Authorization header.",
+					},
 				},
 			},
 		},
@@ -2,6 +2,10 @@
 package config
 
 import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -38,10 +42,6 @@ var (
 	//	update 2024-06-22 08:59:17
 	Oauth2GithubClientSecret string
 
-	// Oauth2StateString string Github OAuth2 State String
-	//	update 2024-06-22 08:59:11
-	Oauth2StateString string
-
 	// Oauth2GithubRedirectURL string Github OAuth2 Redirect URL
 	//	update 2024-06-22 08:59:07
 	Oauth2GithubRedirectURL string
@@ -97,6 +97,22 @@ var (
 	// RedisPassword string Redis密码
 	RedisPassword string
 
+	// ObjectStorageProvider string 对象存储提供商，必须显式指定minio、cos、s3或local
+	//	update 2025-11-15 09:42:17
+	ObjectStorageProvider string
+
+	// LocalStorageDir string 本地对象存储根目录
+	//	update 2025-11-14 16:08:45
+	LocalStorageDir string
+
+	// LocalStorageSecret string 本地对象存储下载链接的签名密钥
+	//	update 2025-11-14 16:08:45
+	LocalStorageSecret string
+
+	// LocalStorageBaseURL string 本地对象存储下载链接的对外访问地址
+	//	update 2025-11-14 16:08:45
+	LocalStorageBaseURL string
+
 	// MinioEndpoint string Minio Endpoint
 	MinioEndpoint string
 
@@ -130,6 +146,42 @@ var (
 	// CosAppID string Cos App ID
 	CosAppID string
 
+	// S3Endpoint string S3兼容存储的Endpoint，如s3.amazonaws.com、<account>.r2.cloudflarestorage.com
+	//	update 2025-11-15 09:42:17
+	S3Endpoint string
+
+	// S3TLS bool S3兼容存储是否使用HTTPS
+	//	update 2025-11-15 09:42:17
+	S3TLS bool
+
+	// S3Region string S3兼容存储的区域，R2使用auto
+	//	update 2025-11-15 09:42:17
+	S3Region string
+
+	// S3PathStyle bool 是否使用路径风格寻址，Ceph等自建服务通常需要开启，否则使用虚拟主机风格
+	//	update 2025-11-15 09:42:17
+	S3PathStyle bool
+
+	// S3BucketName string S3兼容存储的桶名
+	//	update 2025-11-15 09:42:17
+	S3BucketName string
+
+	// S3AccessID string S3兼容存储的Access Key ID
+	//	update 2025-11-15 09:42:17
+	S3AccessID string
+
+	// S3AccessKey string S3兼容存储的Secret Access Key
+	//	update 2025-11-15 09:42:17
+	S3AccessKey string
+
+	// S3SSE string 服务端加密方式，可选空、AES256、aws:kms
+	//	update 2025-11-15 09:42:17
+	S3SSE string
+
+	// S3SSEKMSKeyID string 使用aws:kms加密时的KMS密钥ID，为空时使用桶的默认密钥
+	//	update 2025-11-15 09:42:17
+	S3SSEKMSKeyID string
+
 	// OpenAIModel string OpenAI Model
 	OpenAIModel string
 
@@ -163,8 +215,81 @@ var (
 	// JwtRefreshTokenSecret string Jwt Refresh Token密钥
 	//	update 2024-06-22 11:15:55
 	JwtRefreshTokenSecret string
+
+	// JwtAlgorithm string Jwt签名算法，可选HS256、RS256、EdDSA
+	//	update 2025-11-12 10:24:06
+	JwtAlgorithm string
+
+	// JwtKeyRotationInterval time.Duration 非对称签名密钥的轮换周期
+	//	update 2025-11-12 10:24:06
+	JwtKeyRotationInterval time.Duration
+
+	// JwtLegacyHS256Until time.Time 签名算法迁移为非对称后继续接受共享密钥签名的HS256令牌的截止时间，零值表示不再接受
+	//	update 2025-11-20 16:58:03
+	JwtLegacyHS256Until time.Time
+
+	// CreatorApplicationCooldown time.Duration 创作者申请被拒绝后再次申请的冷却时间
+	//	update 2025-11-11 10:20:36
+	CreatorApplicationCooldown time.Duration
+
+	// AccountDeletionGracePeriod time.Duration 申请注销账号后的冷静期，期间可撤销
+	//	update 2025-11-14 10:35:21
+	AccountDeletionGracePeriod time.Duration
+
+	// DataExportRetention time.Duration 数据导出归档的保留时间
+	//	update 2025-11-14 10:35:21
+	DataExportRetention time.Duration
+
+	// ImageVariantWidths []int 图片响应式变体的宽度，升序排列
+	//	update 2025-11-16 10:12:48
+	ImageVariantWidths []int
+
+	// ImageVariantFormats []string 上传时预先生成的变体格式，可选webp、avif、jpeg、png
+	//	update 2025-11-16 10:12:48
+	ImageVariantFormats []string
+
+	// ImageVariantQuality int 有损格式变体的编码质量，范围1~100
+	//	update 2025-11-16 10:12:48
+	ImageVariantQuality int
+
+	// AssetGCGracePeriod time.Duration 未被引用的图片超过该时长后才会被回收，避免误删刚上传尚未插入文章的图片
+	//	update 2025-11-17 15:08:53
+	AssetGCGracePeriod time.Duration
+
+	// AssetGCDryRun bool 定时回收只报告孤立图片而不删除
+	//	update 2025-11-17 15:08:53
+	AssetGCDryRun bool
+
+	// ImageImportTimeout time.Duration 从外部链接导入图片的超时时间，包含连接、重定向与读取
+	//	update 2025-11-18 16:05:42
+	ImageImportTimeout time.Duration
+
+	// ImageImportMaxSize int64 从外部链接导入图片的大小上限，单位字节
+	//	update 2025-11-18 16:05:42
+	ImageImportMaxSize int64
+
+	// Oauth2OIDCProviders []*Oauth2OIDCProvider 通用OIDC提供商，按oauth2.oidc.providers中的名称逐个读取
+	//	update 2025-11-13 19:26:03
+	Oauth2OIDCProviders []*Oauth2OIDCProvider
 )
 
+var oidcProviderNamePattern = regexp.MustCompile(`^[a-z0-9]+$`)
+
+// Oauth2OIDCProvider 通用OIDC提供商配置，名称即登录路径中的provider；
+// TrustEmail表示IdP不返回email_verified时仍信任其邮箱，仅在IdP保证邮箱已验证时开启
+//
+//	author centonhuang
+//	update 2025-11-19 18:03:25
+type Oauth2OIDCProvider struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	TrustEmail   bool
+}
+
 func init() {
 	initEnvironment()
 }
@@ -182,6 +307,30 @@ func initEnvironment() {
 
 	config.SetDefault("postgres.sslmode", "disable")
 
+	config.SetDefault("local.storage.dir", "./data/object")
+	config.SetDefault("local.storage.base.url", "http://localhost:8080")
+
+	config.SetDefault("s3.endpoint", "s3.amazonaws.com")
+	config.SetDefault("s3.tls", true)
+
+	config.SetDefault("jwt.algorithm", "HS256")
+	config.SetDefault("jwt.key.rotation.interval", "720h")
+
+	config.SetDefault("creator.application.cooldown", "168h")
+
+	config.SetDefault("account.deletion.grace.period", "336h")
+	config.SetDefault("data.export.retention", "168h")
+
+	config.SetDefault("image.variant.widths", "320,640,1024,1920")
+	config.SetDefault("image.variant.formats", "webp")
+	config.SetDefault("image.variant.quality", 80)
+
+	config.SetDefault("asset.gc.grace.period", "720h")
+	config.SetDefault("asset.gc.dry.run", true)
+
+	config.SetDefault("image.import.timeout", "15s")
+	config.SetDefault("image.import.max.size", 10*1024*1024)
+
 	config.AutomaticEnv()
 
 	ReadTimeout = time.Duration(config.GetInt("read.timeout")) * time.Second
@@ -193,7 +342,6 @@ func initEnvironment() {
 
 	Oauth2GithubClientID = config.GetString("oauth2.github.client.id")
 	Oauth2GithubClientSecret = config.GetString("oauth2.github.client.secret")
-	Oauth2StateString = config.GetString("oauth2.state.string")
 	Oauth2GithubRedirectURL = config.GetString("oauth2.github.redirect.url")
 
 	Oauth2QQClientID = config.GetString("oauth2.qq.client.id")
@@ -215,6 +363,12 @@ func initEnvironment() {
 	RedisPort = config.GetString("redis.port")
 	RedisPassword = config.GetString("redis.password")
 
+	ObjectStorageProvider = config.GetString("object.storage.provider")
+
+	LocalStorageDir = config.GetString("local.storage.dir")
+	LocalStorageSecret = config.GetString("local.storage.secret")
+	LocalStorageBaseURL = strings.TrimSuffix(config.GetString("local.storage.base.url"), "/")
+
 	MinioEndpoint = config.GetString("minio.endpoint")
 	MinioTLS = config.GetBool("minio.tls")
 	MinioRegion = config.GetString("minio.region")
@@ -228,6 +382,16 @@ func initEnvironment() {
 	CosSecretID = config.GetString("cos.secret.id")
 	CosSecretKey = config.GetString("cos.secret.key")
 
+	S3Endpoint = config.GetString("s3.endpoint")
+	S3TLS = config.GetBool("s3.tls")
+	S3Region = config.GetString("s3.region")
+	S3PathStyle = config.GetBool("s3.path.style")
+	S3BucketName = config.GetString("s3.bucket.name")
+	S3AccessID = config.GetString("s3.access.id")
+	S3AccessKey = config.GetString("s3.access.key")
+	S3SSE = config.GetString("s3.sse")
+	S3SSEKMSKeyID = config.GetString("s3.sse.kms.key.id")
+
 	OpenAIModel = config.GetString("openai.model")
 	OpenAIAPIKey = config.GetString("openai.api.key")
 	OpenAIBaseURL = config.GetString("openai.base.url")
@@ -242,6 +406,47 @@ func initEnvironment() {
 	JwtRefreshTokenExpired = config.GetDuration("jwt.refresh.token.expired")
 	JwtRefreshTokenSecret = config.GetString("jwt.refresh.token.secret")
 
+	JwtAlgorithm = config.GetString("jwt.algorithm")
+	JwtKeyRotationInterval = config.GetDuration("jwt.key.rotation.interval")
+	JwtLegacyHS256Until = config.GetTime("jwt.legacy.hs256.until")
+
+	CreatorApplicationCooldown = config.GetDuration("creator.application.cooldown")
+
+	AccountDeletionGracePeriod = config.GetDuration("account.deletion.grace.period")
+	DataExportRetention = config.GetDuration("data.export.retention")
+
+	ImageVariantWidths = loadImageVariantWidths(config)
+	ImageVariantFormats = loadImageVariantFormats(config)
+	ImageVariantQuality = config.GetInt("image.variant.quality")
+
+	AssetGCGracePeriod = config.GetDuration("asset.gc.grace.period")
+	AssetGCDryRun = config.GetBool("asset.gc.dry.run")
+
+	ImageImportTimeout = config.GetDuration("image.import.timeout")
+	ImageImportMaxSize = config.GetInt64("image.import.max.size")
+
+	Oauth2OIDCProviders = loadOIDCProviders(config)
+
+	switch JwtAlgorithm {
+	case "HS256", "RS256", "EdDSA":
+	default:
+		panic("jwt.algorithm must be one of HS256, RS256 and EdDSA")
+	}
+
+	if ImageVariantQuality < 1 || ImageVariantQuality > 100 {
+		panic("image.variant.quality must be between 1 and 100")
+	}
+
+	if ImageImportTimeout <= 0 || ImageImportMaxSize <= 0 {
+		panic("image.import.timeout and image.import.max.size must be positive")
+	}
+}
+
+// ValidateOauth2Credentials 校验服务器登录依赖的OAuth2凭据，仅在启动API服务器时调用，其他命令与单元测试不依赖这些凭据
+//
+//	author centonhuang
+//	update 2025-11-20 15:02:17
+func ValidateOauth2Credentials() {
 	if Oauth2GithubClientID == "" {
 		panic("oauth2.github.client.id is required")
 	}
@@ -250,3 +455,74 @@ func initEnvironment() {
 		panic("oauth2.github.client.secret is required")
 	}
 }
+
+func loadOIDCProviders(config *viper.Viper) []*Oauth2OIDCProvider {
+	var providers []*Oauth2OIDCProvider
+	for _, name := range strings.Split(config.GetString("oauth2.oidc.providers"), ",") {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		if !oidcProviderNamePattern.MatchString(name) {
+			panic(fmt.Sprintf("oauth2 oidc provider name %q must match %s", name, oidcProviderNamePattern))
+		}
+		switch name {
+		case "github", "google", "qq":
+			panic(fmt.Sprintf("oauth2 oidc provider name %q conflicts with a builtin provider", name))
+		}
+
+		prefix := "oauth2.oidc." + name + "."
+		config.SetDefault(prefix+"scopes", "openid,profile,email")
+		provider := &Oauth2OIDCProvider{
+			Name:         name,
+			Issuer:       config.GetString(prefix + "issuer"),
+			ClientID:     config.GetString(prefix + "client.id"),
+			ClientSecret: config.GetString(prefix + "client.secret"),
+			RedirectURL:  config.GetString(prefix + "redirect.url"),
+			Scopes:       strings.Split(config.GetString(prefix+"scopes"), ","),
+			TrustEmail:   config.GetBool(prefix + "trust.email"),
+		}
+		if provider.Issuer == "" || provider.ClientID == "" || provider.RedirectURL == "" {
+			panic(fmt.Sprintf("oauth2 oidc provider %q requires issuer, client id and redirect url", name))
+		}
+		providers = append(providers, provider)
+	}
+	return providers
+}
+
+func loadImageVariantWidths(config *viper.Viper) []int {
+	var widths []int
+	for _, raw := range strings.Split(config.GetString("image.variant.widths"), ",") {
+		if raw = strings.TrimSpace(raw); raw == "" {
+			continue
+		}
+		width, err := strconv.Atoi(raw)
+		if err != nil || width <= 0 || width > 8192 {
+			panic(fmt.Sprintf("invalid image variant width %q", raw))
+		}
+		widths = append(widths, width)
+	}
+	if len(widths) == 0 {
+		panic("image.variant.widths is required")
+	}
+
+	slices.Sort(widths)
+	return slices.Compact(widths)
+}
+
+func loadImageVariantFormats(config *viper.Viper) []string {
+	var formats []string
+	for _, raw := range strings.Split(config.GetString("image.variant.formats"), ",") {
+		format := strings.ToLower(strings.TrimSpace(raw))
+		switch format {
+		case "":
+			continue
+		case "webp", "avif", "jpeg", "png":
+		default:
+			panic(fmt.Sprintf("unsupported image variant format %q", raw))
+		}
+		if !slices.Contains(formats, format) {
+			formats = append(formats, format)
+		}
+	}
+	return formats
+}
@@ -4,12 +4,17 @@ package constant
 const (
 	CtxKeyUserID     = "userID"
 	CtxKeyUserName   = "userName"
+	CtxKeySessionID  = "sessionID"
+	CtxKeyPATID      = "personalAccessTokenID"
 	CtxKeyPermission = "permission"
+	CtxKeyScopes     = "scopes"
 	CtxKeyBody       = "body"
 	CtxKeyURI        = "uri"
 	CtxKeyParam      = "param"
 	CtxKeyTraceID    = "traceID"
 	CtxKeyLimiter    = "limiter"
+	CtxKeyClientIP   = "clientIP"
+	CtxKeyUserAgent  = "userAgent"
 
 	// ListArticleVersionContentLength 分页查询文章版本中的内容长度限制
 	//	update 2025-01-18 23:20:20
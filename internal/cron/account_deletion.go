@@ -0,0 +1,61 @@
+package cron
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/hcd233/aris-blog-api/internal/constant"
+	"github.com/hcd233/aris-blog-api/internal/logger"
+	"github.com/hcd233/aris-blog-api/internal/service"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+// AccountDeletionCron 账号注销执行定时任务
+//
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+type AccountDeletionCron struct {
+	cron *cron.Cron
+	svc  service.AccountDeletionService
+}
+
+// NewAccountDeletionCron 创建账号注销执行定时任务
+//
+//	return Cron
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+func NewAccountDeletionCron() Cron {
+	return &AccountDeletionCron{
+		cron: cron.New(
+			cron.WithLogger(newCronLoggerAdapter("AccountDeletionCron", logger.Logger())),
+			cron.WithChain(cron.SkipIfStillRunning(newCronLoggerAdapter("AccountDeletionCron", logger.Logger()))),
+		),
+		svc: service.NewAccountDeletionService(),
+	}
+}
+
+// Start 启动定时任务
+//
+//	receiver c *AccountDeletionCron
+//	return error
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+func (c *AccountDeletionCron) Start() error {
+	entryID, err := c.cron.AddFunc("0 * * * *", c.processDueDeletions)
+	if err != nil {
+		logger.Logger().Error("[AccountDeletionCron] add func error", zap.Error(err))
+		return err
+	}
+
+	logger.Logger().Info("[AccountDeletionCron] add func success", zap.Int("entryID", int(entryID)))
+
+	c.cron.Start()
+
+	return nil
+}
+
+func (c *AccountDeletionCron) processDueDeletions() {
+	ctx := context.WithValue(context.Background(), constant.CtxKeyTraceID, uuid.New().String())
+	c.svc.ProcessDueAccountDeletions(ctx)
+}
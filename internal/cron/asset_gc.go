@@ -0,0 +1,64 @@
+package cron
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/hcd233/aris-blog-api/internal/config"
+	"github.com/hcd233/aris-blog-api/internal/constant"
+	"github.com/hcd233/aris-blog-api/internal/logger"
+	"github.com/hcd233/aris-blog-api/internal/service"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+// AssetGCCron 孤立图片回收定时任务
+//
+//	author centonhuang
+//	update 2025-11-17 15:08:53
+type AssetGCCron struct {
+	cron *cron.Cron
+	svc  service.AssetService
+}
+
+// NewAssetGCCron 创建孤立图片回收定时任务
+//
+//	return Cron
+//	author centonhuang
+//	update 2025-11-17 15:08:53
+func NewAssetGCCron() Cron {
+	return &AssetGCCron{
+		cron: cron.New(
+			cron.WithLogger(newCronLoggerAdapter("AssetGCCron", logger.Logger())),
+			cron.WithChain(cron.SkipIfStillRunning(newCronLoggerAdapter("AssetGCCron", logger.Logger()))),
+		),
+		svc: service.NewAssetService(),
+	}
+}
+
+// Start 启动定时任务
+//
+//	receiver c *AssetGCCron
+//	return error
+//	author centonhuang
+//	update 2025-11-17 15:08:53
+func (c *AssetGCCron) Start() error {
+	entryID, err := c.cron.AddFunc("30 3 * * *", c.collectOrphanedAssets)
+	if err != nil {
+		logger.Logger().Error("[AssetGCCron] add func error", zap.Error(err))
+		return err
+	}
+
+	logger.Logger().Info("[AssetGCCron] add func success", zap.Int("entryID", int(entryID)), zap.Bool("dryRun", config.AssetGCDryRun))
+
+	c.cron.Start()
+
+	return nil
+}
+
+func (c *AssetGCCron) collectOrphanedAssets() {
+	ctx := context.WithValue(context.Background(), constant.CtxKeyTraceID, uuid.New().String())
+	if _, err := c.svc.CollectOrphanedAssets(ctx, config.AssetGCDryRun); err != nil {
+		logger.WithCtx(ctx).Error("[AssetGCCron] collect orphaned assets error", zap.Error(err))
+	}
+}
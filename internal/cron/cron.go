@@ -27,6 +27,18 @@ func InitCronJobs() {
 	quotaCron := NewQuotaCron()
 	lo.Must0(quotaCron.Start())
 
+	jwtKeyCron := NewJwtKeyCron()
+	lo.Must0(jwtKeyCron.Start())
+
+	dataExportCron := NewDataExportCron()
+	lo.Must0(dataExportCron.Start())
+
+	accountDeletionCron := NewAccountDeletionCron()
+	lo.Must0(accountDeletionCron.Start())
+
+	assetGCCron := NewAssetGCCron()
+	lo.Must0(assetGCCron.Start())
+
 	logger.Logger().Info("[Cron] Init cron jobs")
 }
 
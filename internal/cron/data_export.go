@@ -0,0 +1,61 @@
+package cron
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/hcd233/aris-blog-api/internal/constant"
+	"github.com/hcd233/aris-blog-api/internal/logger"
+	"github.com/hcd233/aris-blog-api/internal/service"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+// DataExportCron 用户数据导出打包与过期清理定时任务
+//
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+type DataExportCron struct {
+	cron *cron.Cron
+	svc  service.DataExportService
+}
+
+// NewDataExportCron 创建用户数据导出打包与过期清理定时任务
+//
+//	return Cron
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+func NewDataExportCron() Cron {
+	return &DataExportCron{
+		cron: cron.New(
+			cron.WithLogger(newCronLoggerAdapter("DataExportCron", logger.Logger())),
+			cron.WithChain(cron.SkipIfStillRunning(newCronLoggerAdapter("DataExportCron", logger.Logger()))),
+		),
+		svc: service.NewDataExportService(),
+	}
+}
+
+// Start 启动定时任务
+//
+//	receiver c *DataExportCron
+//	return error
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+func (c *DataExportCron) Start() error {
+	entryID, err := c.cron.AddFunc("*/10 * * * *", c.processDueExports)
+	if err != nil {
+		logger.Logger().Error("[DataExportCron] add func error", zap.Error(err))
+		return err
+	}
+
+	logger.Logger().Info("[DataExportCron] add func success", zap.Int("entryID", int(entryID)))
+
+	c.cron.Start()
+
+	return nil
+}
+
+func (c *DataExportCron) processDueExports() {
+	ctx := context.WithValue(context.Background(), constant.CtxKeyTraceID, uuid.New().String())
+	c.svc.ProcessDueDataExports(ctx)
+}
@@ -0,0 +1,64 @@
+package cron
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/hcd233/aris-blog-api/internal/constant"
+	"github.com/hcd233/aris-blog-api/internal/jwt"
+	"github.com/hcd233/aris-blog-api/internal/logger"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+// JwtKeyCron JWT签名密钥轮换定时任务
+//
+//	author centonhuang
+//	update 2025-11-12 10:24:06
+type JwtKeyCron struct {
+	cron *cron.Cron
+}
+
+// NewJwtKeyCron 创建JWT签名密钥轮换定时任务
+//
+//	return Cron
+//	author centonhuang
+//	update 2025-11-12 10:24:06
+func NewJwtKeyCron() Cron {
+	return &JwtKeyCron{
+		cron: cron.New(
+			cron.WithLogger(newCronLoggerAdapter("JwtKeyCron", logger.Logger())),
+			cron.WithChain(cron.SkipIfStillRunning(newCronLoggerAdapter("JwtKeyCron", logger.Logger()))),
+		),
+	}
+}
+
+// Start 启动定时任务，启动时先确保存在可用的签名密钥
+//
+//	receiver c *JwtKeyCron
+//	return error
+//	author centonhuang
+//	update 2025-11-12 10:24:06
+func (c *JwtKeyCron) Start() error {
+	c.rotateSigningKey()
+
+	entryID, err := c.cron.AddFunc("0 * * * *", c.rotateSigningKey)
+	if err != nil {
+		logger.Logger().Error("[JwtKeyCron] add func error", zap.Error(err))
+		return err
+	}
+
+	logger.Logger().Info("[JwtKeyCron] add func success", zap.Int("entryID", int(entryID)))
+
+	c.cron.Start()
+
+	return nil
+}
+
+func (c *JwtKeyCron) rotateSigningKey() {
+	ctx := context.WithValue(context.Background(), constant.CtxKeyTraceID, uuid.New().String())
+
+	if _, err := jwt.RotateSigningKey(ctx, false); err != nil {
+		logger.WithCtx(ctx).Error("[JwtKeyCron] failed to rotate signing key", zap.Error(err))
+	}
+}
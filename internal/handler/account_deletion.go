@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/hcd233/aris-blog-api/internal/protocol"
+	dto "github.com/hcd233/aris-blog-api/internal/protocol/dto"
+	"github.com/hcd233/aris-blog-api/internal/service"
+	"github.com/hcd233/aris-blog-api/internal/util"
+)
+
+// AccountDeletionHandler 账号注销处理器
+//
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+type AccountDeletionHandler interface {
+	HandleRequestAccountDeletion(ctx context.Context, req *dto.RequestAccountDeletionRequest) (*protocol.HTTPResponse[*dto.RequestAccountDeletionResponse], error)
+	HandleGetCurrentAccountDeletion(ctx context.Context, req *dto.EmptyRequest) (*protocol.HTTPResponse[*dto.GetCurrentAccountDeletionResponse], error)
+	HandleCancelAccountDeletion(ctx context.Context, req *dto.EmptyRequest) (*protocol.HTTPResponse[*dto.EmptyResponse], error)
+}
+
+type accountDeletionHandler struct {
+	svc service.AccountDeletionService
+}
+
+// NewAccountDeletionHandler 创建账号注销处理器
+//
+//	return AccountDeletionHandler
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+func NewAccountDeletionHandler() AccountDeletionHandler {
+	return &accountDeletionHandler{
+		svc: service.NewAccountDeletionService(),
+	}
+}
+
+func (h *accountDeletionHandler) HandleRequestAccountDeletion(ctx context.Context, req *dto.RequestAccountDeletionRequest) (*protocol.HTTPResponse[*dto.RequestAccountDeletionResponse], error) {
+	return util.WrapHTTPResponse(h.svc.RequestAccountDeletion(ctx, req))
+}
+
+func (h *accountDeletionHandler) HandleGetCurrentAccountDeletion(ctx context.Context, req *dto.EmptyRequest) (*protocol.HTTPResponse[*dto.GetCurrentAccountDeletionResponse], error) {
+	return util.WrapHTTPResponse(h.svc.GetCurrentAccountDeletion(ctx, req))
+}
+
+func (h *accountDeletionHandler) HandleCancelAccountDeletion(ctx context.Context, req *dto.EmptyRequest) (*protocol.HTTPResponse[*dto.EmptyResponse], error) {
+	return util.WrapHTTPResponse(h.svc.CancelAccountDeletion(ctx, req))
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/hcd233/aris-blog-api/internal/protocol"
+	dto "github.com/hcd233/aris-blog-api/internal/protocol/dto"
+	"github.com/hcd233/aris-blog-api/internal/service"
+	"github.com/hcd233/aris-blog-api/internal/util"
+)
+
+// AdminHandler 管理员处理器
+//
+//	author centonhuang
+//	update 2025-11-10 15:32:08
+type AdminHandler interface {
+	HandleListUsers(ctx context.Context, req *dto.AdminListUsersRequest) (*protocol.HTTPResponse[*dto.AdminListUsersResponse], error)
+	HandleGetUser(ctx context.Context, req *dto.AdminGetUserRequest) (*protocol.HTTPResponse[*dto.AdminGetUserResponse], error)
+	HandleUpdateUserPermission(ctx context.Context, req *dto.AdminUpdateUserPermissionRequest) (*protocol.HTTPResponse[*dto.EmptyResponse], error)
+	HandleUpdateUserQuota(ctx context.Context, req *dto.AdminUpdateUserQuotaRequest) (*protocol.HTTPResponse[*dto.EmptyResponse], error)
+	HandleUpdateUserStatus(ctx context.Context, req *dto.AdminUpdateUserStatusRequest) (*protocol.HTTPResponse[*dto.EmptyResponse], error)
+	HandleListUserArticles(ctx context.Context, req *dto.AdminListUserArticlesRequest) (*protocol.HTTPResponse[*dto.AdminListUserArticlesResponse], error)
+	HandleListUserComments(ctx context.Context, req *dto.AdminListUserCommentsRequest) (*protocol.HTTPResponse[*dto.AdminListUserCommentsResponse], error)
+	HandleUpdateUserRoles(ctx context.Context, req *dto.AdminUpdateUserRolesRequest) (*protocol.HTTPResponse[*dto.EmptyResponse], error)
+}
+
+type adminHandler struct {
+	svc service.AdminService
+}
+
+// NewAdminHandler 创建管理员处理器
+//
+//	return AdminHandler
+//	author centonhuang
+//	update 2025-11-10 15:32:08
+func NewAdminHandler() AdminHandler {
+	return &adminHandler{
+		svc: service.NewAdminService(),
+	}
+}
+
+func (h *adminHandler) HandleListUsers(ctx context.Context, req *dto.AdminListUsersRequest) (*protocol.HTTPResponse[*dto.AdminListUsersResponse], error) {
+	return util.WrapHTTPResponse(h.svc.ListUsers(ctx, req))
+}
+
+func (h *adminHandler) HandleGetUser(ctx context.Context, req *dto.AdminGetUserRequest) (*protocol.HTTPResponse[*dto.AdminGetUserResponse], error) {
+	return util.WrapHTTPResponse(h.svc.GetUser(ctx, req))
+}
+
+func (h *adminHandler) HandleUpdateUserPermission(ctx context.Context, req *dto.AdminUpdateUserPermissionRequest) (*protocol.HTTPResponse[*dto.EmptyResponse], error) {
+	return util.WrapHTTPResponse(h.svc.UpdateUserPermission(ctx, req))
+}
+
+func (h *adminHandler) HandleUpdateUserQuota(ctx context.Context, req *dto.AdminUpdateUserQuotaRequest) (*protocol.HTTPResponse[*dto.EmptyResponse], error) {
+	return util.WrapHTTPResponse(h.svc.UpdateUserQuota(ctx, req))
+}
+
+func (h *adminHandler) HandleUpdateUserStatus(ctx context.Context, req *dto.AdminUpdateUserStatusRequest) (*protocol.HTTPResponse[*dto.EmptyResponse], error) {
+	return util.WrapHTTPResponse(h.svc.UpdateUserStatus(ctx, req))
+}
+
+func (h *adminHandler) HandleListUserArticles(ctx context.Context, req *dto.AdminListUserArticlesRequest) (*protocol.HTTPResponse[*dto.AdminListUserArticlesResponse], error) {
+	return util.WrapHTTPResponse(h.svc.ListUserArticles(ctx, req))
+}
+
+func (h *adminHandler) HandleListUserComments(ctx context.Context, req *dto.AdminListUserCommentsRequest) (*protocol.HTTPResponse[*dto.AdminListUserCommentsResponse], error) {
+	return util.WrapHTTPResponse(h.svc.ListUserComments(ctx, req))
+}
+
+func (h *adminHandler) HandleUpdateUserRoles(ctx context.Context, req *dto.AdminUpdateUserRolesRequest) (*protocol.HTTPResponse[*dto.EmptyResponse], error) {
+	return util.WrapHTTPResponse(h.svc.UpdateUserRoles(ctx, req))
+}
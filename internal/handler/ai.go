@@ -23,6 +23,7 @@ type AIHandler interface {
 	// SSE streaming methods - will return special responses
 	HandleGenerateContentCompletion(ctx context.Context, req *dto.GenerateContentCompletionRequest, sender sse.Sender)
 	HandleGenerateArticleSummary(ctx context.Context, req *dto.GenerateArticleSummaryRequest, sender sse.Sender)
+	HandleGenerateArticleTranslation(ctx context.Context, req *dto.GenerateArticleTranslationRequest, sender sse.Sender)
 	HandleGenerateArticleQA(ctx context.Context, req *dto.GenerateArticleQARequest, sender sse.Sender)
 	HandleGenerateTermExplaination(ctx context.Context, req *dto.GenerateTermExplainationRequest, sender sse.Sender)
 }
@@ -65,6 +66,11 @@ func (h *aiHandler) HandleGenerateArticleSummary(ctx context.Context, req *dto.G
 	util.SendStreamEventResponses(sender, tokenChan, errChan)
 }
 
+func (h *aiHandler) HandleGenerateArticleTranslation(ctx context.Context, req *dto.GenerateArticleTranslationRequest, sender sse.Sender) {
+	tokenChan, errChan := h.svc.GenerateArticleTranslation(ctx, req)
+	util.SendStreamEventResponses(sender, tokenChan, errChan)
+}
+
 func (h *aiHandler) HandleGenerateArticleQA(ctx context.Context, req *dto.GenerateArticleQARequest, sender sse.Sender) {
 	tokenChan, errChan := h.svc.GenerateArticleQA(ctx, req)
 	util.SendStreamEventResponses(sender, tokenChan, errChan)
@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/hcd233/aris-blog-api/internal/protocol"
+	dto "github.com/hcd233/aris-blog-api/internal/protocol/dto"
+	"github.com/hcd233/aris-blog-api/internal/service"
+	"github.com/hcd233/aris-blog-api/internal/util"
+)
+
+// ArticleSuggestionHandler 文章AI建议处理器
+type ArticleSuggestionHandler interface {
+	HandleGetArticleSuggestion(ctx context.Context, req *dto.GetArticleSuggestionRequest) (*protocol.HTTPResponse[*dto.GetArticleSuggestionResponse], error)
+	HandleReviewArticleSuggestion(ctx context.Context, req *dto.ReviewArticleSuggestionRequest) (*protocol.HTTPResponse[*dto.EmptyResponse], error)
+}
+
+type articleSuggestionHandler struct {
+	svc service.ArticleSuggestionService
+}
+
+// NewArticleSuggestionHandler 创建文章AI建议处理器
+func NewArticleSuggestionHandler() ArticleSuggestionHandler {
+	return &articleSuggestionHandler{
+		svc: service.NewArticleSuggestionService(),
+	}
+}
+
+func (h *articleSuggestionHandler) HandleGetArticleSuggestion(ctx context.Context, req *dto.GetArticleSuggestionRequest) (*protocol.HTTPResponse[*dto.GetArticleSuggestionResponse], error) {
+	return util.WrapHTTPResponse(h.svc.GetArticleSuggestion(ctx, req))
+}
+
+func (h *articleSuggestionHandler) HandleReviewArticleSuggestion(ctx context.Context, req *dto.ReviewArticleSuggestionRequest) (*protocol.HTTPResponse[*dto.EmptyResponse], error) {
+	return util.WrapHTTPResponse(h.svc.ReviewArticleSuggestion(ctx, req))
+}
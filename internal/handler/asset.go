@@ -16,6 +16,11 @@ type AssetHandler interface {
 	HandleListUserLikeTags(ctx context.Context, req *dto.ListUserLikeTagsRequest) (*protocol.HTTPResponse[*dto.ListUserLikeTagsResponse], error)
 	HandleListImages(ctx context.Context, req *dto.EmptyRequest) (*protocol.HTTPResponse[*dto.ListImagesResponse], error)
 	HandleUploadImage(ctx context.Context, req *dto.UploadImageRequest) (*protocol.HTTPResponse[*dto.EmptyResponse], error)
+	HandlePresignImageUpload(ctx context.Context, req *dto.PresignImageUploadRequest) (*protocol.HTTPResponse[*dto.PresignImageUploadResponse], error)
+	HandleConfirmImageUpload(ctx context.Context, req *dto.ConfirmImageUploadRequest) (*protocol.HTTPResponse[*dto.ConfirmImageUploadResponse], error)
+	HandleImportImage(ctx context.Context, req *dto.ImportImageRequest) (*protocol.HTTPResponse[*dto.ImportImageResponse], error)
+	HandleImportArticleVersionImages(ctx context.Context, req *dto.ImportArticleVersionImagesRequest) (*protocol.HTTPResponse[*dto.ImportArticleVersionImagesResponse], error)
+	HandleGetImageVariant(ctx context.Context, req *dto.GetImageVariantRequest) (*protocol.RedirectResponse, error)
 	HandleGetImage(ctx context.Context, req *dto.GetImageRequest) (*protocol.RedirectResponse, error)
 	HandleDeleteImage(ctx context.Context, req *dto.DeleteImageRequest) (*protocol.HTTPResponse[*dto.EmptyResponse], error)
 	HandleListUserViewArticles(ctx context.Context, req *dto.ListUserViewArticlesRequest) (*protocol.HTTPResponse[*dto.ListUserViewArticlesResponse], error)
@@ -53,6 +58,26 @@ func (h *assetHandler) HandleUploadImage(ctx context.Context, req *dto.UploadIma
 	return util.WrapHTTPResponse(h.svc.UploadImage(ctx, req))
 }
 
+func (h *assetHandler) HandlePresignImageUpload(ctx context.Context, req *dto.PresignImageUploadRequest) (*protocol.HTTPResponse[*dto.PresignImageUploadResponse], error) {
+	return util.WrapHTTPResponse(h.svc.PresignImageUpload(ctx, req))
+}
+
+func (h *assetHandler) HandleConfirmImageUpload(ctx context.Context, req *dto.ConfirmImageUploadRequest) (*protocol.HTTPResponse[*dto.ConfirmImageUploadResponse], error) {
+	return util.WrapHTTPResponse(h.svc.ConfirmImageUpload(ctx, req))
+}
+
+func (h *assetHandler) HandleImportImage(ctx context.Context, req *dto.ImportImageRequest) (*protocol.HTTPResponse[*dto.ImportImageResponse], error) {
+	return util.WrapHTTPResponse(h.svc.ImportImage(ctx, req))
+}
+
+func (h *assetHandler) HandleImportArticleVersionImages(ctx context.Context, req *dto.ImportArticleVersionImagesRequest) (*protocol.HTTPResponse[*dto.ImportArticleVersionImagesResponse], error) {
+	return util.WrapHTTPResponse(h.svc.ImportArticleVersionImages(ctx, req))
+}
+
+func (h *assetHandler) HandleGetImageVariant(ctx context.Context, req *dto.GetImageVariantRequest) (*protocol.RedirectResponse, error) {
+	return util.RedirectURL(h.svc.GetImageVariant(ctx, req))
+}
+
 func (h *assetHandler) HandleGetImage(ctx context.Context, req *dto.GetImageRequest) (*protocol.RedirectResponse, error) {
 	return util.RedirectURL(h.svc.GetImage(ctx, req))
 }
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/hcd233/aris-blog-api/internal/protocol"
+	dto "github.com/hcd233/aris-blog-api/internal/protocol/dto"
+	"github.com/hcd233/aris-blog-api/internal/service"
+	"github.com/hcd233/aris-blog-api/internal/util"
+)
+
+// AttachmentHandler 文章附件处理器
+type AttachmentHandler interface {
+	HandleListArticleAttachments(ctx context.Context, req *dto.ListArticleAttachmentsRequest) (*protocol.HTTPResponse[*dto.ListArticleAttachmentsResponse], error)
+	HandlePresignAttachmentUpload(ctx context.Context, req *dto.PresignAttachmentUploadRequest) (*protocol.HTTPResponse[*dto.PresignAttachmentUploadResponse], error)
+	HandleConfirmAttachmentUpload(ctx context.Context, req *dto.ConfirmAttachmentUploadRequest) (*protocol.HTTPResponse[*dto.ConfirmAttachmentUploadResponse], error)
+	HandleGetAttachment(ctx context.Context, req *dto.GetAttachmentRequest) (*protocol.HTTPResponse[*dto.URLResponse], error)
+	HandleDeleteAttachment(ctx context.Context, req *dto.DeleteAttachmentRequest) (*protocol.HTTPResponse[*dto.EmptyResponse], error)
+}
+
+type attachmentHandler struct {
+	svc service.AttachmentService
+}
+
+// NewAttachmentHandler 创建文章附件处理器
+func NewAttachmentHandler() AttachmentHandler {
+	return &attachmentHandler{
+		svc: service.NewAttachmentService(),
+	}
+}
+
+func (h *attachmentHandler) HandleListArticleAttachments(ctx context.Context, req *dto.ListArticleAttachmentsRequest) (*protocol.HTTPResponse[*dto.ListArticleAttachmentsResponse], error) {
+	return util.WrapHTTPResponse(h.svc.ListArticleAttachments(ctx, req))
+}
+
+func (h *attachmentHandler) HandlePresignAttachmentUpload(ctx context.Context, req *dto.PresignAttachmentUploadRequest) (*protocol.HTTPResponse[*dto.PresignAttachmentUploadResponse], error) {
+	return util.WrapHTTPResponse(h.svc.PresignAttachmentUpload(ctx, req))
+}
+
+func (h *attachmentHandler) HandleConfirmAttachmentUpload(ctx context.Context, req *dto.ConfirmAttachmentUploadRequest) (*protocol.HTTPResponse[*dto.ConfirmAttachmentUploadResponse], error) {
+	return util.WrapHTTPResponse(h.svc.ConfirmAttachmentUpload(ctx, req))
+}
+
+func (h *attachmentHandler) HandleGetAttachment(ctx context.Context, req *dto.GetAttachmentRequest) (*protocol.HTTPResponse[*dto.URLResponse], error) {
+	return util.WrapHTTPResponse(h.svc.GetAttachment(ctx, req))
+}
+
+func (h *attachmentHandler) HandleDeleteAttachment(ctx context.Context, req *dto.DeleteAttachmentRequest) (*protocol.HTTPResponse[*dto.EmptyResponse], error) {
+	return util.WrapHTTPResponse(h.svc.DeleteAttachment(ctx, req))
+}
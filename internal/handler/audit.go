@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/hcd233/aris-blog-api/internal/api"
+	"github.com/hcd233/aris-blog-api/internal/protocol"
+	dto "github.com/hcd233/aris-blog-api/internal/protocol/dto"
+	"github.com/hcd233/aris-blog-api/internal/service"
+	"github.com/hcd233/aris-blog-api/internal/util"
+)
+
+// AuditHandler 审计处理器
+//
+//	author centonhuang
+//	update 2025-11-11 14:48:02
+type AuditHandler interface {
+	HandleListAuditEvents(ctx context.Context, req *dto.ListAuditEventsRequest) (*protocol.HTTPResponse[*dto.ListAuditEventsResponse], error)
+	HandleExportAuditEvents(ctx context.Context, req *dto.ExportAuditEventsRequest) (*huma.StreamResponse, error)
+}
+
+type auditHandler struct {
+	svc service.AuditService
+}
+
+// NewAuditHandler 创建审计处理器
+//
+//	return AuditHandler
+//	author centonhuang
+//	update 2025-11-11 14:48:02
+func NewAuditHandler() AuditHandler {
+	return &auditHandler{
+		svc: service.NewAuditService(),
+	}
+}
+
+func (h *auditHandler) HandleListAuditEvents(ctx context.Context, req *dto.ListAuditEventsRequest) (*protocol.HTTPResponse[*dto.ListAuditEventsResponse], error) {
+	return util.WrapHTTPResponse(h.svc.ListAuditEvents(ctx, req))
+}
+
+func (h *auditHandler) HandleExportAuditEvents(ctx context.Context, req *dto.ExportAuditEventsRequest) (*huma.StreamResponse, error) {
+	return &huma.StreamResponse{
+		Body: func(humaCtx huma.Context) {
+			humaCtx.SetHeader("Content-Type", "application/x-ndjson")
+			humaCtx.SetHeader("Content-Disposition", `attachment; filename="audit.ndjson"`)
+
+			if err := h.svc.ExportAuditEvents(ctx, req, humaCtx.BodyWriter()); err != nil {
+				_, statusErr := util.WrapHTTPResponse[any](nil, err)
+				huma.WriteErr(api.GetHumaAPI(), humaCtx, statusErr.GetStatus(), statusErr.Error(), statusErr)
+			}
+		},
+	}, nil
+}
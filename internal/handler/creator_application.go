@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/hcd233/aris-blog-api/internal/protocol"
+	dto "github.com/hcd233/aris-blog-api/internal/protocol/dto"
+	"github.com/hcd233/aris-blog-api/internal/service"
+	"github.com/hcd233/aris-blog-api/internal/util"
+)
+
+// CreatorApplicationHandler 创作者申请处理器
+//
+//	author centonhuang
+//	update 2025-11-11 10:20:36
+type CreatorApplicationHandler interface {
+	HandleSubmitCreatorApplication(ctx context.Context, req *dto.SubmitCreatorApplicationRequest) (*protocol.HTTPResponse[*dto.SubmitCreatorApplicationResponse], error)
+	HandleGetCurrentCreatorApplication(ctx context.Context, req *dto.EmptyRequest) (*protocol.HTTPResponse[*dto.GetCurrentCreatorApplicationResponse], error)
+	HandleListCreatorApplications(ctx context.Context, req *dto.ListCreatorApplicationsRequest) (*protocol.HTTPResponse[*dto.ListCreatorApplicationsResponse], error)
+	HandleReviewCreatorApplication(ctx context.Context, req *dto.ReviewCreatorApplicationRequest) (*protocol.HTTPResponse[*dto.EmptyResponse], error)
+}
+
+type creatorApplicationHandler struct {
+	svc service.CreatorApplicationService
+}
+
+// NewCreatorApplicationHandler 创建创作者申请处理器
+//
+//	return CreatorApplicationHandler
+//	author centonhuang
+//	update 2025-11-11 10:20:36
+func NewCreatorApplicationHandler() CreatorApplicationHandler {
+	return &creatorApplicationHandler{
+		svc: service.NewCreatorApplicationService(),
+	}
+}
+
+func (h *creatorApplicationHandler) HandleSubmitCreatorApplication(ctx context.Context, req *dto.SubmitCreatorApplicationRequest) (*protocol.HTTPResponse[*dto.SubmitCreatorApplicationResponse], error) {
+	return util.WrapHTTPResponse(h.svc.SubmitCreatorApplication(ctx, req))
+}
+
+func (h *creatorApplicationHandler) HandleGetCurrentCreatorApplication(ctx context.Context, req *dto.EmptyRequest) (*protocol.HTTPResponse[*dto.GetCurrentCreatorApplicationResponse], error) {
+	return util.WrapHTTPResponse(h.svc.GetCurrentCreatorApplication(ctx, req))
+}
+
+func (h *creatorApplicationHandler) HandleListCreatorApplications(ctx context.Context, req *dto.ListCreatorApplicationsRequest) (*protocol.HTTPResponse[*dto.ListCreatorApplicationsResponse], error) {
+	return util.WrapHTTPResponse(h.svc.ListCreatorApplications(ctx, req))
+}
+
+func (h *creatorApplicationHandler) HandleReviewCreatorApplication(ctx context.Context, req *dto.ReviewCreatorApplicationRequest) (*protocol.HTTPResponse[*dto.EmptyResponse], error) {
+	return util.WrapHTTPResponse(h.svc.ReviewCreatorApplication(ctx, req))
+}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/hcd233/aris-blog-api/internal/protocol"
+	dto "github.com/hcd233/aris-blog-api/internal/protocol/dto"
+	"github.com/hcd233/aris-blog-api/internal/service"
+	"github.com/hcd233/aris-blog-api/internal/util"
+)
+
+// DataExportHandler 用户数据导出处理器
+//
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+type DataExportHandler interface {
+	HandleRequestDataExport(ctx context.Context, req *dto.EmptyRequest) (*protocol.HTTPResponse[*dto.RequestDataExportResponse], error)
+	HandleGetCurrentDataExport(ctx context.Context, req *dto.EmptyRequest) (*protocol.HTTPResponse[*dto.GetCurrentDataExportResponse], error)
+}
+
+type dataExportHandler struct {
+	svc service.DataExportService
+}
+
+// NewDataExportHandler 创建用户数据导出处理器
+//
+//	return DataExportHandler
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+func NewDataExportHandler() DataExportHandler {
+	return &dataExportHandler{
+		svc: service.NewDataExportService(),
+	}
+}
+
+func (h *dataExportHandler) HandleRequestDataExport(ctx context.Context, req *dto.EmptyRequest) (*protocol.HTTPResponse[*dto.RequestDataExportResponse], error) {
+	return util.WrapHTTPResponse(h.svc.RequestDataExport(ctx, req))
+}
+
+func (h *dataExportHandler) HandleGetCurrentDataExport(ctx context.Context, req *dto.EmptyRequest) (*protocol.HTTPResponse[*dto.GetCurrentDataExportResponse], error) {
+	return util.WrapHTTPResponse(h.svc.GetCurrentDataExport(ctx, req))
+}
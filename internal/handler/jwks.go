@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"context"
+
+	dto "github.com/hcd233/aris-blog-api/internal/protocol/dto"
+	"github.com/hcd233/aris-blog-api/internal/service"
+	"github.com/hcd233/aris-blog-api/internal/util"
+)
+
+// JWKSHandler JWKS处理器
+//
+//	author centonhuang
+//	update 2025-11-12 10:24:06
+type JWKSHandler interface {
+	HandleGetJWKS(ctx context.Context, req *dto.EmptyRequest) (*dto.GetJWKSResponse, error)
+}
+
+type jwksHandler struct {
+	svc service.JWKSService
+}
+
+// NewJWKSHandler 创建JWKS处理器
+//
+//	return JWKSHandler
+//	author centonhuang
+//	update 2025-11-12 10:24:06
+func NewJWKSHandler() JWKSHandler {
+	return &jwksHandler{
+		svc: service.NewJWKSService(),
+	}
+}
+
+func (h *jwksHandler) HandleGetJWKS(ctx context.Context, req *dto.EmptyRequest) (*dto.GetJWKSResponse, error) {
+	jwks, err := h.svc.GetJWKS(ctx, req)
+	if err != nil {
+		_, statusErr := util.WrapHTTPResponse[any](nil, err)
+		return nil, statusErr
+	}
+	return &dto.GetJWKSResponse{CacheControl: "public, max-age=300", Body: jwks}, nil
+}
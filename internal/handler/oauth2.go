@@ -17,6 +17,9 @@ import (
 type Oauth2Handler interface {
 	HandleLogin(ctx context.Context, req *dto.LoginRequest) (*protocol.HTTPResponse[*dto.LoginResponse], error)
 	HandleCallback(ctx context.Context, req *dto.CallbackRequest) (*protocol.HTTPResponse[*dto.CallbackResponse], error)
+	HandleLinkLogin(ctx context.Context, req *dto.LoginRequest) (*protocol.HTTPResponse[*dto.LoginResponse], error)
+	HandleLink(ctx context.Context, req *dto.LinkOauth2Request) (*protocol.HTTPResponse[*dto.EmptyResponse], error)
+	HandleUnlink(ctx context.Context, req *dto.UnlinkOauth2Request) (*protocol.HTTPResponse[*dto.EmptyResponse], error)
 }
 
 type oauth2Handler struct{}
@@ -39,7 +42,10 @@ func NewOauth2Handler() Oauth2Handler {
 //	@author centonhuang 
 //	@update 2025-11-02 04:16:14 
 func (h *oauth2Handler) HandleLogin(ctx context.Context, req *dto.LoginRequest) (*protocol.HTTPResponse[*dto.LoginResponse], error) {
-	svc := h.getService(oauth2.ProviderType(req.Provider))
+	svc, err := h.getService(req.Provider)
+	if err != nil {
+		return util.WrapHTTPResponse[*dto.LoginResponse](nil, err)
+	}
 	return util.WrapHTTPResponse(svc.Login(ctx, req))
 }
 
@@ -52,24 +58,83 @@ func (h *oauth2Handler) HandleLogin(ctx context.Context, req *dto.LoginRequest)
 //	@author centonhuang 
 //	@update 2025-11-02 04:16:22 
 func (h *oauth2Handler) HandleCallback(ctx context.Context, req *dto.CallbackRequest) (*protocol.HTTPResponse[*dto.CallbackResponse], error) {
-	svc := h.getService(oauth2.ProviderType(req.Provider))
+	svc, err := h.getService(req.Provider)
+	if err != nil {
+		return util.WrapHTTPResponse[*dto.CallbackResponse](nil, err)
+	}
 	return util.WrapHTTPResponse(svc.Callback(ctx, req))
 }
 
-// getService 根据provider获取对应的service
+// HandleLinkLogin 发起绑定第三方账号的授权
+//
+//	receiver h *oauth2Handler
+//	param ctx context.Context
+//	param req *dto.LoginRequest
+//	return *protocol.HTTPResponse[*dto.LoginResponse]
+//	return error
+//	author centonhuang
+//	update 2025-11-13 15:40:18
+func (h *oauth2Handler) HandleLinkLogin(ctx context.Context, req *dto.LoginRequest) (*protocol.HTTPResponse[*dto.LoginResponse], error) {
+	svc, err := h.getService(req.Provider)
+	if err != nil {
+		return util.WrapHTTPResponse[*dto.LoginResponse](nil, err)
+	}
+	return util.WrapHTTPResponse(svc.LinkLogin(ctx, req))
+}
+
+// HandleLink 绑定第三方账号
+//
+//	receiver h *oauth2Handler
+//	param ctx context.Context
+//	param req *dto.LinkOauth2Request
+//	return *protocol.HTTPResponse[*dto.EmptyResponse]
+//	return error
+//	author centonhuang
+//	update 2025-11-13 10:12:37
+func (h *oauth2Handler) HandleLink(ctx context.Context, req *dto.LinkOauth2Request) (*protocol.HTTPResponse[*dto.EmptyResponse], error) {
+	svc, err := h.getService(req.Provider)
+	if err != nil {
+		return util.WrapHTTPResponse[*dto.EmptyResponse](nil, err)
+	}
+	return util.WrapHTTPResponse(svc.Link(ctx, req))
+}
+
+// HandleUnlink 解绑第三方账号
+//
+//	receiver h *oauth2Handler
+//	param ctx context.Context
+//	param req *dto.UnlinkOauth2Request
+//	return *protocol.HTTPResponse[*dto.EmptyResponse]
+//	return error
+//	author centonhuang
+//	update 2025-11-13 10:12:37
+func (h *oauth2Handler) HandleUnlink(ctx context.Context, req *dto.UnlinkOauth2Request) (*protocol.HTTPResponse[*dto.EmptyResponse], error) {
+	svc, err := h.getService(req.Provider)
+	if err != nil {
+		return util.WrapHTTPResponse[*dto.EmptyResponse](nil, err)
+	}
+	return util.WrapHTTPResponse(svc.Unlink(ctx, req))
+}
+
+// getService 根据provider获取对应的service，内置提供商以外的名称按OIDC提供商查找
 //
 //	receiver h *oauth2Handler
 //	param provider string
 //	return service.Oauth2Service
+//	return error 未配置的提供商返回ErrDataNotExists
 //	author centonhuang
-//	update 2025-01-05 21:00:00
-func (h *oauth2Handler) getService(provider oauth2.ProviderType) service.Oauth2Service {
-	switch provider {
+//	update 2025-11-13 19:26:03
+func (h *oauth2Handler) getService(provider string) (service.Oauth2Service, error) {
+	switch oauth2.ProviderType(provider) {
 	case oauth2.ProviderTypeGithub:
-		return service.NewGithubOauth2Service()
+		return service.NewGithubOauth2Service(), nil
 	case oauth2.ProviderTypeGoogle:
-		return service.NewGoogleOauth2Service()
-	default:
-		return service.NewGithubOauth2Service() // 默认返回 github
+		return service.NewGoogleOauth2Service(), nil
+	case oauth2.ProviderTypeQQ:
+		return service.NewQQOauth2Service(), nil
+	}
+	if svc, ok := service.NewOIDCOauth2Service(provider); ok {
+		return svc, nil
 	}
+	return nil, protocol.ErrDataNotExists
 }
@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hcd233/aris-blog-api/internal/logger"
+	objdao "github.com/hcd233/aris-blog-api/internal/resource/storage/obj_dao"
+	"go.uber.org/zap"
+)
+
+// LocalObjectHandler 本地对象存储签名链接处理器
+//
+//	author centonhuang
+//	update 2025-11-15 15:21:06
+type LocalObjectHandler interface {
+	HandleDownloadLocalObject(c *fiber.Ctx) error
+	HandleUploadLocalObject(c *fiber.Ctx) error
+}
+
+type localObjectHandler struct{}
+
+// NewLocalObjectHandler 创建本地对象存储下载处理器
+//
+//	return LocalObjectHandler
+//	author centonhuang
+//	update 2025-11-14 16:08:45
+func NewLocalObjectHandler() LocalObjectHandler {
+	return &localObjectHandler{}
+}
+
+// HandleDownloadLocalObject 校验签名链接并返回本地存储的对象，替代对象存储的预签名下载
+//
+//	receiver h *localObjectHandler
+//	param c *fiber.Ctx
+//	return error
+//	author centonhuang
+//	update 2025-11-14 16:08:45
+func (h *localObjectHandler) HandleDownloadLocalObject(c *fiber.Ctx) error {
+	dao, ok := objdao.GetLocalObjDAOByType(objdao.ObjectType(c.Params("objectType")))
+	if !ok {
+		return fiber.ErrNotFound
+	}
+
+	userID, err := strconv.ParseUint(c.Params("userID"), 10, 0)
+	if err != nil {
+		return fiber.ErrNotFound
+	}
+
+	expires, err := strconv.ParseInt(c.Query("expires"), 10, 64)
+	if err != nil {
+		return fiber.ErrForbidden
+	}
+
+	// 对象名可能含有子路径，通配参数需要整体解码后参与签名校验
+	objectName, err := url.PathUnescape(c.Params("*"))
+	if err != nil {
+		return fiber.ErrNotFound
+	}
+
+	file, objectInfo, err := dao.OpenSignedObject(uint(userID), objectName, expires, c.Query("signature"))
+	switch {
+	case err == nil:
+	case errors.Is(err, objdao.ErrInvalidSignature):
+		return fiber.ErrForbidden
+	case errors.Is(err, objdao.ErrInvalidObjectName), errors.Is(err, fs.ErrNotExist):
+		return fiber.ErrNotFound
+	default:
+		logger.WithFCtx(c).Error("[LocalObjectHandler] Open object error",
+			zap.Uint64("userID", userID), zap.String("objectName", objectName), zap.Error(err))
+		return fiber.ErrInternalServerError
+	}
+
+	c.Set(fiber.HeaderContentType, objectInfo.ContentType)
+	c.Set(fiber.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": objectInfo.ObjectName}))
+	c.Set(fiber.HeaderETag, strconv.Quote(objectInfo.ETag))
+	c.Set(fiber.HeaderLastModified, objectInfo.LastModified.Format(http.TimeFormat))
+	c.Set(fiber.HeaderCacheControl, "private, no-store")
+
+	return c.SendStream(file, int(objectInfo.Size))
+}
+
+// HandleUploadLocalObject 校验签名直传链接并写入本地存储，替代对象存储的预签名上传
+//
+//	receiver h *localObjectHandler
+//	param c *fiber.Ctx
+//	return error
+//	author centonhuang
+//	update 2025-11-15 15:21:06
+func (h *localObjectHandler) HandleUploadLocalObject(c *fiber.Ctx) error {
+	dao, ok := objdao.GetLocalObjDAOByType(objdao.ObjectType(c.Params("objectType")))
+	if !ok {
+		return fiber.ErrNotFound
+	}
+
+	userID, err := strconv.ParseUint(c.Params("userID"), 10, 0)
+	if err != nil {
+		return fiber.ErrNotFound
+	}
+
+	expires, err := strconv.ParseInt(c.Query("expires"), 10, 64)
+	if err != nil {
+		return fiber.ErrForbidden
+	}
+
+	objectName, err := url.PathUnescape(c.Params("*"))
+	if err != nil {
+		return fiber.ErrNotFound
+	}
+
+	body := c.Body()
+	size := int64(len(body))
+
+	err = dao.VerifySignedUpload(uint(userID), objectName, string(c.Request().Header.ContentType()), size, expires, c.Query("signature"))
+	switch {
+	case err == nil:
+	case errors.Is(err, objdao.ErrInvalidSignature):
+		return fiber.ErrForbidden
+	case errors.Is(err, objdao.ErrInvalidObjectName):
+		return fiber.ErrNotFound
+	default:
+		return fiber.ErrInternalServerError
+	}
+
+	if err = dao.UploadObject(c.UserContext(), uint(userID), objectName, size, bytes.NewReader(body)); err != nil {
+		logger.WithFCtx(c).Error("[LocalObjectHandler] Upload object error",
+			zap.Uint64("userID", userID), zap.String("objectName", objectName), zap.Error(err))
+		return fiber.ErrInternalServerError
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/hcd233/aris-blog-api/internal/protocol"
+	dto "github.com/hcd233/aris-blog-api/internal/protocol/dto"
+	"github.com/hcd233/aris-blog-api/internal/service"
+	"github.com/hcd233/aris-blog-api/internal/util"
+)
+
+// PersonalAccessTokenHandler 个人访问令牌处理器
+//
+//	author centonhuang
+//	update 2025-11-12 14:02:51
+type PersonalAccessTokenHandler interface {
+	HandleListTokens(ctx context.Context, req *dto.EmptyRequest) (*protocol.HTTPResponse[*dto.ListPersonalAccessTokensResponse], error)
+	HandleCreateToken(ctx context.Context, req *dto.CreatePersonalAccessTokenRequest) (*protocol.HTTPResponse[*dto.CreatePersonalAccessTokenResponse], error)
+	HandleRevokeToken(ctx context.Context, req *dto.RevokePersonalAccessTokenRequest) (*protocol.HTTPResponse[*dto.EmptyResponse], error)
+}
+
+type personalAccessTokenHandler struct {
+	svc service.PersonalAccessTokenService
+}
+
+// NewPersonalAccessTokenHandler 创建个人访问令牌处理器
+//
+//	return PersonalAccessTokenHandler
+//	author centonhuang
+//	update 2025-11-12 14:02:51
+func NewPersonalAccessTokenHandler() PersonalAccessTokenHandler {
+	return &personalAccessTokenHandler{
+		svc: service.NewPersonalAccessTokenService(),
+	}
+}
+
+func (h *personalAccessTokenHandler) HandleListTokens(ctx context.Context, req *dto.EmptyRequest) (*protocol.HTTPResponse[*dto.ListPersonalAccessTokensResponse], error) {
+	return util.WrapHTTPResponse(h.svc.ListTokens(ctx, req))
+}
+
+func (h *personalAccessTokenHandler) HandleCreateToken(ctx context.Context, req *dto.CreatePersonalAccessTokenRequest) (*protocol.HTTPResponse[*dto.CreatePersonalAccessTokenResponse], error) {
+	return util.WrapHTTPResponse(h.svc.CreateToken(ctx, req))
+}
+
+func (h *personalAccessTokenHandler) HandleRevokeToken(ctx context.Context, req *dto.RevokePersonalAccessTokenRequest) (*protocol.HTTPResponse[*dto.EmptyResponse], error) {
+	return util.WrapHTTPResponse(h.svc.RevokeToken(ctx, req))
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/hcd233/aris-blog-api/internal/protocol"
+	dto "github.com/hcd233/aris-blog-api/internal/protocol/dto"
+	"github.com/hcd233/aris-blog-api/internal/service"
+	"github.com/hcd233/aris-blog-api/internal/util"
+)
+
+// RoleHandler 角色处理器
+//
+//	author centonhuang
+//	update 2025-11-11 17:05:24
+type RoleHandler interface {
+	HandleListRoles(ctx context.Context, req *dto.EmptyRequest) (*protocol.HTTPResponse[*dto.ListRolesResponse], error)
+	HandleListScopes(ctx context.Context, req *dto.EmptyRequest) (*protocol.HTTPResponse[*dto.ListScopesResponse], error)
+	HandleCreateRole(ctx context.Context, req *dto.CreateRoleRequest) (*protocol.HTTPResponse[*dto.CreateRoleResponse], error)
+	HandleUpdateRole(ctx context.Context, req *dto.UpdateRoleRequest) (*protocol.HTTPResponse[*dto.EmptyResponse], error)
+	HandleDeleteRole(ctx context.Context, req *dto.DeleteRoleRequest) (*protocol.HTTPResponse[*dto.EmptyResponse], error)
+}
+
+type roleHandler struct {
+	svc service.RoleService
+}
+
+// NewRoleHandler 创建角色处理器
+//
+//	return RoleHandler
+//	author centonhuang
+//	update 2025-11-11 17:05:24
+func NewRoleHandler() RoleHandler {
+	return &roleHandler{
+		svc: service.NewRoleService(),
+	}
+}
+
+func (h *roleHandler) HandleListRoles(ctx context.Context, req *dto.EmptyRequest) (*protocol.HTTPResponse[*dto.ListRolesResponse], error) {
+	return util.WrapHTTPResponse(h.svc.ListRoles(ctx, req))
+}
+
+func (h *roleHandler) HandleListScopes(ctx context.Context, req *dto.EmptyRequest) (*protocol.HTTPResponse[*dto.ListScopesResponse], error) {
+	return util.WrapHTTPResponse(h.svc.ListScopes(ctx, req))
+}
+
+func (h *roleHandler) HandleCreateRole(ctx context.Context, req *dto.CreateRoleRequest) (*protocol.HTTPResponse[*dto.CreateRoleResponse], error) {
+	return util.WrapHTTPResponse(h.svc.CreateRole(ctx, req))
+}
+
+func (h *roleHandler) HandleUpdateRole(ctx context.Context, req *dto.UpdateRoleRequest) (*protocol.HTTPResponse[*dto.EmptyResponse], error) {
+	return util.WrapHTTPResponse(h.svc.UpdateRole(ctx, req))
+}
+
+func (h *roleHandler) HandleDeleteRole(ctx context.Context, req *dto.DeleteRoleRequest) (*protocol.HTTPResponse[*dto.EmptyResponse], error) {
+	return util.WrapHTTPResponse(h.svc.DeleteRole(ctx, req))
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/hcd233/aris-blog-api/internal/protocol"
+	dto "github.com/hcd233/aris-blog-api/internal/protocol/dto"
+	"github.com/hcd233/aris-blog-api/internal/service"
+	"github.com/hcd233/aris-blog-api/internal/util"
+)
+
+// SessionHandler 登录会话处理器
+//
+//	author centonhuang
+//	update 2025-11-11 20:12:40
+type SessionHandler interface {
+	HandleListSessions(ctx context.Context, req *dto.EmptyRequest) (*protocol.HTTPResponse[*dto.ListSessionsResponse], error)
+	HandleRevokeSession(ctx context.Context, req *dto.RevokeSessionRequest) (*protocol.HTTPResponse[*dto.EmptyResponse], error)
+	HandleRevokeAllSessions(ctx context.Context, req *dto.EmptyRequest) (*protocol.HTTPResponse[*dto.RevokeAllSessionsResponse], error)
+}
+
+type sessionHandler struct {
+	svc service.SessionService
+}
+
+// NewSessionHandler 创建登录会话处理器
+//
+//	return SessionHandler
+//	author centonhuang
+//	update 2025-11-11 20:12:40
+func NewSessionHandler() SessionHandler {
+	return &sessionHandler{
+		svc: service.NewSessionService(),
+	}
+}
+
+func (h *sessionHandler) HandleListSessions(ctx context.Context, req *dto.EmptyRequest) (*protocol.HTTPResponse[*dto.ListSessionsResponse], error) {
+	return util.WrapHTTPResponse(h.svc.ListSessions(ctx, req))
+}
+
+func (h *sessionHandler) HandleRevokeSession(ctx context.Context, req *dto.RevokeSessionRequest) (*protocol.HTTPResponse[*dto.EmptyResponse], error) {
+	return util.WrapHTTPResponse(h.svc.RevokeSession(ctx, req))
+}
+
+func (h *sessionHandler) HandleRevokeAllSessions(ctx context.Context, req *dto.EmptyRequest) (*protocol.HTTPResponse[*dto.RevokeAllSessionsResponse], error) {
+	return util.WrapHTTPResponse(h.svc.RevokeAllSessions(ctx, req))
+}
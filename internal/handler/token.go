@@ -15,6 +15,7 @@ import (
 //	update 2025-01-05 21:00:00
 type TokenHandler interface {
 	HandleRefreshToken(ctx context.Context, req *dto.RefreshTokenRequest) (*protocol.HTTPResponse[*dto.RefreshTokenResponse], error)
+	HandleLogout(ctx context.Context, req *dto.EmptyRequest) (*protocol.HTTPResponse[*dto.EmptyResponse], error)
 }
 
 type tokenHandler struct {
@@ -43,3 +44,7 @@ func NewTokenHandler() TokenHandler {
 func (h *tokenHandler) HandleRefreshToken(ctx context.Context, req *dto.RefreshTokenRequest) (*protocol.HTTPResponse[*dto.RefreshTokenResponse], error) {
 	return util.WrapHTTPResponse(h.svc.RefreshToken(ctx, req))
 }
+
+func (h *tokenHandler) HandleLogout(ctx context.Context, req *dto.EmptyRequest) (*protocol.HTTPResponse[*dto.EmptyResponse], error) {
+	return util.WrapHTTPResponse(h.svc.Logout(ctx, req))
+}
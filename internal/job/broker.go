@@ -0,0 +1,174 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/hcd233/aris-blog-api/internal/resource/cache"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	keyPrefix = "job"
+
+	promoteBatchSize = 100
+	deadQueueMaxLen  = 10000
+)
+
+var (
+	brokerSingleton *broker
+	brokerOnce      sync.Once
+)
+
+// promoteScript 将到期的延迟任务原子地移入就绪队列
+var promoteScript = redis.NewScript(`
+local jobs = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, ARGV[2])
+for _, job in ipairs(jobs) do
+	redis.call('ZREM', KEYS[1], job)
+	redis.call('LPUSH', KEYS[2], job)
+end
+return #jobs
+`)
+
+type broker struct {
+	rdb *redis.Client
+}
+
+func getBroker() *broker {
+	brokerOnce.Do(func() {
+		brokerSingleton = &broker{rdb: cache.GetRedisClient()}
+	})
+	return brokerSingleton
+}
+
+func readyKey(queue string) string {
+	return fmt.Sprintf("%s:queue:%s:ready", keyPrefix, queue)
+}
+
+func delayedKey(queue string) string {
+	return fmt.Sprintf("%s:queue:%s:delayed", keyPrefix, queue)
+}
+
+func deadKey(queue string) string {
+	return fmt.Sprintf("%s:queue:%s:dead", keyPrefix, queue)
+}
+
+func processingKey(queue, workerID string) string {
+	return fmt.Sprintf("%s:queue:%s:processing:%s", keyPrefix, queue, workerID)
+}
+
+func heartbeatKey(workerID string) string {
+	return fmt.Sprintf("%s:worker:%s", keyPrefix, workerID)
+}
+
+func (b *broker) push(ctx context.Context, job *Job, processAt time.Time) error {
+	raw, err := sonic.MarshalString(job)
+	if err != nil {
+		return fmt.Errorf("marshal job: %w", err)
+	}
+
+	if processAt.After(time.Now()) {
+		return b.rdb.ZAdd(ctx, delayedKey(job.Queue), redis.Z{Score: float64(processAt.UnixMilli()), Member: raw}).Err()
+	}
+	return b.rdb.LPush(ctx, readyKey(job.Queue), raw).Err()
+}
+
+// fetch 阻塞获取就绪任务并移入当前worker的处理中队列，超时返回空
+func (b *broker) fetch(ctx context.Context, queue, workerID string, timeout time.Duration) (raw string, job *Job, err error) {
+	raw, err = b.rdb.BLMove(ctx, readyKey(queue), processingKey(queue, workerID), "RIGHT", "LEFT", timeout).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return "", nil, nil
+		}
+		return "", nil, err
+	}
+
+	job = &Job{}
+	if err = sonic.UnmarshalString(raw, job); err != nil {
+		return raw, nil, fmt.Errorf("unmarshal job: %w", err)
+	}
+	return raw, job, nil
+}
+
+func (b *broker) ack(ctx context.Context, queue, workerID, raw string) error {
+	return b.rdb.LRem(ctx, processingKey(queue, workerID), 1, raw).Err()
+}
+
+func (b *broker) retry(ctx context.Context, job *Job, workerID, raw string, processAt time.Time) error {
+	retryRaw, err := sonic.MarshalString(job)
+	if err != nil {
+		return fmt.Errorf("marshal job: %w", err)
+	}
+
+	_, err = b.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.LRem(ctx, processingKey(job.Queue, workerID), 1, raw)
+		pipe.ZAdd(ctx, delayedKey(job.Queue), redis.Z{Score: float64(processAt.UnixMilli()), Member: retryRaw})
+		return nil
+	})
+	return err
+}
+
+func (b *broker) bury(ctx context.Context, queue, workerID, raw string, job *Job) error {
+	deadRaw := raw
+	if job != nil {
+		var err error
+		if deadRaw, err = sonic.MarshalString(job); err != nil {
+			return fmt.Errorf("marshal job: %w", err)
+		}
+	}
+
+	_, err := b.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.LRem(ctx, processingKey(queue, workerID), 1, raw)
+		pipe.LPush(ctx, deadKey(queue), deadRaw)
+		pipe.LTrim(ctx, deadKey(queue), 0, deadQueueMaxLen-1)
+		return nil
+	})
+	return err
+}
+
+func (b *broker) promote(ctx context.Context, queue string) (int, error) {
+	return promoteScript.Run(ctx, b.rdb, []string{delayedKey(queue), readyKey(queue)}, time.Now().UnixMilli(), promoteBatchSize).Int()
+}
+
+func (b *broker) heartbeat(ctx context.Context, workerID string, ttl time.Duration) error {
+	return b.rdb.Set(ctx, heartbeatKey(workerID), time.Now().UTC().Format(time.RFC3339), ttl).Err()
+}
+
+func (b *broker) unregister(ctx context.Context, workerID string) error {
+	return b.rdb.Del(ctx, heartbeatKey(workerID)).Err()
+}
+
+// recoverOrphans 将心跳已过期的worker遗留在处理中队列的任务重新放回就绪队列
+func (b *broker) recoverOrphans(ctx context.Context, queue string) (recovered int, err error) {
+	prefix := processingKey(queue, "")
+	iter := b.rdb.Scan(ctx, 0, prefix+"*", 100).Iterator()
+	for iter.Next(ctx) {
+		key := iter.Val()
+		workerID := strings.TrimPrefix(key, prefix)
+
+		alive, err := b.rdb.Exists(ctx, heartbeatKey(workerID)).Result()
+		if err != nil {
+			return recovered, err
+		}
+		if alive > 0 {
+			continue
+		}
+
+		for {
+			_, err := b.rdb.LMove(ctx, key, readyKey(queue), "RIGHT", "LEFT").Result()
+			if errors.Is(err, redis.Nil) {
+				break
+			}
+			if err != nil {
+				return recovered, err
+			}
+			recovered++
+		}
+	}
+	return recovered, iter.Err()
+}
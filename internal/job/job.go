@@ -0,0 +1,217 @@
+// Package job 基于Redis的后台任务模块
+//
+//	update 2025-11-10 11:05:47
+package job
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/google/uuid"
+	"github.com/hcd233/aris-blog-api/internal/constant"
+)
+
+// Type 任务类型
+//
+//	author centonhuang
+//	update 2025-11-10 11:05:47
+type Type string
+
+const (
+
+	// TypeArticleSuggestion Type 文章AI摘要与标签建议
+	//	update 2025-11-10 11:05:47
+	TypeArticleSuggestion Type = "articleSuggestion"
+
+	// TypeDataExport Type 用户数据导出打包
+	//	update 2025-11-14 10:35:21
+	TypeDataExport Type = "dataExport"
+
+	// TypeImageThumbnail Type 直传图片的缩略图生成
+	//	update 2025-11-15 15:21:06
+	TypeImageThumbnail Type = "imageThumbnail"
+
+	// TypeImageVariants Type 图片响应式变体生成
+	//	update 2025-11-16 10:12:48
+	TypeImageVariants Type = "imageVariants"
+
+	// TypeAttachmentPreview Type 附件预览图生成
+	//	update 2025-11-18 10:21:37
+	TypeAttachmentPreview Type = "attachmentPreview"
+)
+
+const (
+
+	// QueueDefault string 默认队列
+	//	update 2025-11-10 11:05:47
+	QueueDefault = "default"
+
+	defaultMaxRetries = 5
+	baseBackoff       = 2 * time.Second
+	maxBackoff        = time.Hour
+)
+
+// Job 任务
+//
+//	author centonhuang
+//	update 2025-11-10 11:05:47
+type Job struct {
+	ID         string    `json:"id"`
+	Type       Type      `json:"type"`
+	Queue      string    `json:"queue"`
+	Payload    []byte    `json:"payload"`
+	Attempt    int       `json:"attempt"`
+	MaxRetries int       `json:"maxRetries"`
+	TraceID    string    `json:"traceID,omitempty"`
+	LastError  string    `json:"lastError,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// Handler 任务处理函数
+//
+//	author centonhuang
+//	update 2025-11-10 11:05:47
+type Handler func(ctx context.Context, payload []byte) error
+
+var (
+	handlers   = map[Type]Handler{}
+	handlersMu sync.RWMutex
+)
+
+// Register 注册类型化的任务处理函数
+//
+//	param jobType Type
+//	param fn func(ctx context.Context, payload *T) error
+//	author centonhuang
+//	update 2025-11-10 11:05:47
+func Register[T any](jobType Type, fn func(ctx context.Context, payload *T) error) {
+	handlersMu.Lock()
+	defer handlersMu.Unlock()
+
+	if _, ok := handlers[jobType]; ok {
+		panic(fmt.Sprintf("job handler of type %s already registered", jobType))
+	}
+
+	handlers[jobType] = func(ctx context.Context, raw []byte) error {
+		payload := new(T)
+		if err := sonic.Unmarshal(raw, payload); err != nil {
+			return fmt.Errorf("unmarshal payload of %s: %w", jobType, err)
+		}
+		return fn(ctx, payload)
+	}
+}
+
+func getHandler(jobType Type) (Handler, bool) {
+	handlersMu.RLock()
+	defer handlersMu.RUnlock()
+
+	handler, ok := handlers[jobType]
+	return handler, ok
+}
+
+type enqueueOptions struct {
+	queue      string
+	processAt  time.Time
+	maxRetries int
+}
+
+// Option 入队选项
+//
+//	author centonhuang
+//	update 2025-11-10 11:05:47
+type Option func(*enqueueOptions)
+
+// WithQueue 指定队列
+//
+//	param queue string
+//	return Option
+//	author centonhuang
+//	update 2025-11-10 11:05:47
+func WithQueue(queue string) Option {
+	return func(o *enqueueOptions) {
+		o.queue = queue
+	}
+}
+
+// WithDelay 延迟执行
+//
+//	param delay time.Duration
+//	return Option
+//	author centonhuang
+//	update 2025-11-10 11:05:47
+func WithDelay(delay time.Duration) Option {
+	return func(o *enqueueOptions) {
+		o.processAt = time.Now().Add(delay)
+	}
+}
+
+// WithProcessAt 在指定时间执行
+//
+//	param processAt time.Time
+//	return Option
+//	author centonhuang
+//	update 2025-11-10 11:05:47
+func WithProcessAt(processAt time.Time) Option {
+	return func(o *enqueueOptions) {
+		o.processAt = processAt
+	}
+}
+
+// WithMaxRetries 指定最大重试次数，超过后进入死信队列
+//
+//	param maxRetries int
+//	return Option
+//	author centonhuang
+//	update 2025-11-10 11:05:47
+func WithMaxRetries(maxRetries int) Option {
+	return func(o *enqueueOptions) {
+		o.maxRetries = maxRetries
+	}
+}
+
+// Enqueue 投递任务
+//
+//	param ctx context.Context
+//	param jobType Type
+//	param payload *T
+//	param opts ...Option
+//	return jobID string
+//	return err error
+//	author centonhuang
+//	update 2025-11-10 11:05:47
+func Enqueue[T any](ctx context.Context, jobType Type, payload *T, opts ...Option) (jobID string, err error) {
+	options := &enqueueOptions{queue: QueueDefault, maxRetries: defaultMaxRetries}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	raw, err := sonic.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("marshal payload of %s: %w", jobType, err)
+	}
+
+	traceID, _ := ctx.Value(constant.CtxKeyTraceID).(string)
+	job := &Job{
+		ID:         uuid.New().String(),
+		Type:       jobType,
+		Queue:      options.queue,
+		Payload:    raw,
+		MaxRetries: options.maxRetries,
+		TraceID:    traceID,
+		CreatedAt:  time.Now().UTC(),
+	}
+
+	if err = getBroker().push(ctx, job, options.processAt); err != nil {
+		return "", err
+	}
+	return job.ID, nil
+}
+
+// backoff 计算第attempt次失败后的重试等待时间
+func backoff(attempt int) time.Duration {
+	delay := baseBackoff << min(attempt-1, 20)
+	return min(delay, maxBackoff)
+}
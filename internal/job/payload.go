@@ -0,0 +1,43 @@
+package job
+
+// ArticleSuggestionPayload 文章AI建议任务参数
+//
+//	author centonhuang
+//	update 2025-11-10 11:05:47
+type ArticleSuggestionPayload struct {
+	SuggestionID uint `json:"suggestionID"`
+}
+
+// DataExportPayload 用户数据导出任务参数
+//
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+type DataExportPayload struct {
+	ExportID uint `json:"exportID"`
+}
+
+// ImageThumbnailPayload 图片缩略图生成任务参数
+//
+//	author centonhuang
+//	update 2025-11-15 15:21:06
+type ImageThumbnailPayload struct {
+	UserID     uint   `json:"userID"`
+	ObjectName string `json:"objectName"`
+}
+
+// ImageVariantsPayload 图片响应式变体生成任务参数
+//
+//	author centonhuang
+//	update 2025-11-16 10:12:48
+type ImageVariantsPayload struct {
+	UserID     uint   `json:"userID"`
+	ObjectName string `json:"objectName"`
+}
+
+// AttachmentPreviewPayload 附件预览图生成任务参数
+//
+//	author centonhuang
+//	update 2025-11-18 10:21:37
+type AttachmentPreviewPayload struct {
+	AttachmentID uint `json:"attachmentID"`
+}
@@ -0,0 +1,298 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hcd233/aris-blog-api/internal/constant"
+	"github.com/hcd233/aris-blog-api/internal/logger"
+	"github.com/robfig/cron/v3"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+const (
+	fetchTimeout      = time.Second
+	promoteInterval   = time.Second
+	heartbeatInterval = 10 * time.Second
+	heartbeatTTL      = 30 * time.Second
+	recoverInterval   = heartbeatTTL
+	handlerTimeout    = 10 * time.Minute
+)
+
+// Worker 任务执行器
+//
+//	author centonhuang
+//	update 2025-11-10 11:05:47
+type Worker struct {
+	id          string
+	queues      []string
+	concurrency int
+	broker      *broker
+	cron        *cron.Cron
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewWorker 创建任务执行器
+//
+//	param queues []string
+//	param concurrency int
+//	return *Worker
+//	author centonhuang
+//	update 2025-11-10 11:05:47
+func NewWorker(queues []string, concurrency int) *Worker {
+	if len(queues) == 0 {
+		queues = []string{QueueDefault}
+	}
+	return &Worker{
+		id:          uuid.New().String(),
+		queues:      queues,
+		concurrency: max(concurrency, 1),
+		broker:      getBroker(),
+		cron:        cron.New(),
+		stopCh:      make(chan struct{}),
+	}
+}
+
+// Schedule 注册周期任务，按cron表达式投递，多个worker同时运行时每个周期只投递一次
+//
+//	param w *Worker
+//	param spec string
+//	param jobType Type
+//	param payload *T
+//	param opts ...Option
+//	return err error
+//	author centonhuang
+//	update 2025-11-10 11:05:47
+func Schedule[T any](w *Worker, spec string, jobType Type, payload *T, opts ...Option) (err error) {
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return fmt.Errorf("parse schedule %s: %w", spec, err)
+	}
+
+	_ = w.cron.Schedule(schedule, cron.FuncJob(func() {
+		ctx := context.WithValue(context.Background(), constant.CtxKeyTraceID, uuid.New().String())
+		logger := logger.WithCtx(ctx)
+
+		now := time.Now().Truncate(time.Second)
+		lockKey := fmt.Sprintf("%s:schedule:%s:%d", keyPrefix, jobType, now.Unix())
+		ok, err := w.broker.rdb.SetNX(ctx, lockKey, w.id, time.Until(schedule.Next(now))+time.Minute).Result()
+		if err != nil {
+			logger.Error("[Job] failed to acquire schedule lock", zap.String("type", string(jobType)), zap.Error(err))
+			return
+		}
+		if !ok {
+			return
+		}
+
+		jobID, err := Enqueue(ctx, jobType, payload, opts...)
+		if err != nil {
+			logger.Error("[Job] failed to enqueue scheduled job", zap.String("type", string(jobType)), zap.Error(err))
+			return
+		}
+		logger.Info("[Job] scheduled job enqueued", zap.String("type", string(jobType)), zap.String("jobID", jobID))
+	}))
+	return nil
+}
+
+// Start 启动任务执行器
+//
+//	receiver w *Worker
+//	author centonhuang
+//	update 2025-11-10 11:05:47
+func (w *Worker) Start() {
+	ctx := context.Background()
+
+	if err := w.broker.heartbeat(ctx, w.id, heartbeatTTL); err != nil {
+		logger.Logger().Error("[Job] failed to register worker", zap.String("workerID", w.id), zap.Error(err))
+	}
+
+	w.recoverOrphans()
+
+	w.wg.Add(3)
+	go w.loop(heartbeatInterval, w.beat)
+	go w.loop(promoteInterval, w.promote)
+	go w.loop(recoverInterval, w.recoverOrphans)
+
+	for i := 0; i < w.concurrency; i++ {
+		w.wg.Add(1)
+		go w.process()
+	}
+
+	w.cron.Start()
+
+	logger.Logger().Info("[Job] worker started",
+		zap.String("workerID", w.id),
+		zap.Strings("queues", w.queues),
+		zap.Int("concurrency", w.concurrency))
+}
+
+// Shutdown 停止拉取新任务并等待执行中的任务完成
+//
+//	receiver w *Worker
+//	param ctx context.Context 超时后放弃等待，未完成的任务会被其他worker恢复
+//	return err error
+//	author centonhuang
+//	update 2025-11-10 11:05:47
+func (w *Worker) Shutdown(ctx context.Context) (err error) {
+	<-w.cron.Stop().Done()
+	close(w.stopCh)
+
+	done := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		_ = w.broker.unregister(context.Background(), w.id)
+		logger.Logger().Info("[Job] worker drained", zap.String("workerID", w.id))
+		return nil
+	case <-ctx.Done():
+		logger.Logger().Warn("[Job] worker drain timeout", zap.String("workerID", w.id))
+		return ctx.Err()
+	}
+}
+
+func (w *Worker) loop(interval time.Duration, fn func()) {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stopCh:
+			return
+		case <-ticker.C:
+			fn()
+		}
+	}
+}
+
+func (w *Worker) beat() {
+	if err := w.broker.heartbeat(context.Background(), w.id, heartbeatTTL); err != nil {
+		logger.Logger().Error("[Job] failed to send heartbeat", zap.String("workerID", w.id), zap.Error(err))
+	}
+}
+
+func (w *Worker) promote() {
+	for _, queue := range w.queues {
+		if _, err := w.broker.promote(context.Background(), queue); err != nil {
+			logger.Logger().Error("[Job] failed to promote delayed jobs", zap.String("queue", queue), zap.Error(err))
+		}
+	}
+}
+
+// recoverOrphans 按心跳有效期周期执行，运行期间其他worker失联时其处理中的任务也能被及时恢复，而不必等到下次启动
+func (w *Worker) recoverOrphans() {
+	for _, queue := range w.queues {
+		recovered, err := w.broker.recoverOrphans(context.Background(), queue)
+		if err != nil {
+			logger.Logger().Error("[Job] failed to recover orphan jobs", zap.String("queue", queue), zap.Error(err))
+		} else if recovered > 0 {
+			logger.Logger().Info("[Job] recovered orphan jobs", zap.String("queue", queue), zap.Int("count", recovered))
+		}
+	}
+}
+
+func (w *Worker) process() {
+	defer w.wg.Done()
+
+	for {
+		for _, queue := range w.queues {
+			select {
+			case <-w.stopCh:
+				return
+			default:
+			}
+
+			raw, job, err := w.broker.fetch(context.Background(), queue, w.id, fetchTimeout)
+			if err != nil {
+				logger.Logger().Error("[Job] failed to fetch job", zap.String("queue", queue), zap.Error(err))
+				if raw != "" {
+					_ = w.broker.bury(context.Background(), queue, w.id, raw, nil)
+				} else {
+					time.Sleep(fetchTimeout)
+				}
+				continue
+			}
+			if job == nil {
+				continue
+			}
+
+			w.handle(queue, raw, job)
+		}
+	}
+}
+
+func (w *Worker) handle(queue, raw string, job *Job) {
+	ctx := context.WithValue(context.Background(), constant.CtxKeyTraceID, lo.Ternary(job.TraceID != "", job.TraceID, uuid.New().String()))
+	logger := logger.WithCtx(ctx).With(
+		zap.String("jobID", job.ID),
+		zap.String("type", string(job.Type)),
+		zap.String("queue", queue),
+		zap.Int("attempt", job.Attempt+1))
+
+	handler, ok := getHandler(job.Type)
+	if !ok {
+		logger.Error("[Job] handler not registered, move to dead queue")
+		job.LastError = "handler not registered"
+		if err := w.broker.bury(context.Background(), queue, w.id, raw, job); err != nil {
+			logger.Error("[Job] failed to bury job", zap.Error(err))
+		}
+		return
+	}
+
+	start := time.Now()
+	err := w.run(ctx, handler, job.Payload)
+	if err == nil {
+		if err := w.broker.ack(context.Background(), queue, w.id, raw); err != nil {
+			logger.Error("[Job] failed to ack job", zap.Error(err))
+		}
+		logger.Info("[Job] job done", zap.Duration("cost", time.Since(start)))
+		return
+	}
+
+	job.Attempt++
+	job.LastError = err.Error()
+	if job.Attempt > job.MaxRetries {
+		logger.Error("[Job] job failed, move to dead queue", zap.Error(err))
+		if err := w.broker.bury(context.Background(), queue, w.id, raw, job); err != nil {
+			logger.Error("[Job] failed to bury job", zap.Error(err))
+		}
+		return
+	}
+
+	delay := backoff(job.Attempt)
+	logger.Warn("[Job] job failed, retry later", zap.Duration("delay", delay), zap.Error(err))
+	if err := w.broker.retry(context.Background(), job, w.id, raw, time.Now().Add(delay)); err != nil {
+		logger.Error("[Job] failed to schedule retry", zap.Error(err))
+	}
+}
+
+func (w *Worker) run(ctx context.Context, handler Handler, payload []byte) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, handlerTimeout)
+	defer cancel()
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+			logger.WithCtx(ctx).Error("[Job] handler panic", zap.Any("error", r), zap.ByteString("stack", debug.Stack()))
+		}
+	}()
+
+	err = handler(ctx, payload)
+	if errors.Is(err, context.DeadlineExceeded) {
+		err = fmt.Errorf("handler timeout after %s: %w", handlerTimeout, err)
+	}
+	return err
+}
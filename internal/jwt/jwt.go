@@ -8,16 +8,24 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/hcd233/aris-blog-api/internal/config"
+)
+
+const (
+	tokenTypeAccess  = "access"
+	tokenTypeRefresh = "refresh"
 )
 
 // Claims 鉴权结构体
 //
 //	author centonhuang
-//	update 2024-06-22 11:07:06
+//	update 2025-11-12 10:24:06
 type Claims struct {
 	jwt.RegisteredClaims
 
-	UserID uint `json:"user_id"`
+	UserID    uint   `json:"user_id"`
+	SessionID uint   `json:"sid,omitempty"`
+	TokenType string `json:"typ,omitempty"`
 }
 
 // TokenSigner JWT token 生成器
@@ -25,48 +33,66 @@ type Claims struct {
 //	author centonhuang
 //	update 2025-01-04 16:01:15
 type TokenSigner interface {
-	EncodeToken(userID uint) (token string, err error)
-	DecodeToken(tokenString string) (userID uint, err error)
+	EncodeToken(userID, sessionID uint, tokenID string) (token string, err error)
+	DecodeToken(tokenString string) (claims *Claims, err error)
 }
 
 type tokenSigner struct {
+	TokenType       string
 	JwtTokenSecret  string
 	JwtTokenExpired time.Duration
 }
 
 // EncodeToken 生成JWT token
 //
+// 配置为HS256时使用共享密钥签名，否则使用当前的非对称签名密钥并在头部写入kid
+//
 //	param userID uint
+//	param sessionID uint 登录会话ID
+//	param tokenID string 令牌唯一ID(jti)，为空时不写入
 //	return token string
 //	return err error
 //	author centonhuang
-//	update 2024-09-21 02:57:11
-func (s *tokenSigner) EncodeToken(userID uint) (token string, err error) {
+//	update 2025-11-12 10:24:06
+func (s *tokenSigner) EncodeToken(userID, sessionID uint, tokenID string) (token string, err error) {
 	claims := Claims{
-		UserID: userID,
+		UserID:    userID,
+		SessionID: sessionID,
+		TokenType: s.TokenType,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
 			ExpiresAt: jwt.NewNumericDate(time.Now().UTC().Add(s.JwtTokenExpired)),
 		},
 	}
 
-	token, err = jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(s.JwtTokenSecret))
+	if config.JwtAlgorithm == jwt.SigningMethodHS256.Alg() {
+		token, err = jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(s.JwtTokenSecret))
+		return
+	}
+
+	key, err := defaultKeyring.signingKey()
+	if err != nil {
+		return
+	}
+
+	jwtToken := jwt.NewWithClaims(key.method, claims)
+	jwtToken.Header["kid"] = key.kid
+	token, err = jwtToken.SignedString(key.private)
 	return
 }
 
 // DecodeToken 解析JWT token
 //
+// 共享密钥签名的HS256令牌仅在配置为HS256或迁移截止时间之前接受，非对称签名的令牌按kid查找未退役的密钥验签
+//
 //	param tokenString string
-//	return userID uint
+//	return claims *Claims
 //	return err error
 //	author centonhuang
-//	update 2024-06-22 11:25:00
-func (s *tokenSigner) DecodeToken(tokenString string) (userID uint, err error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("unexpected signing method")
-		}
-		return []byte(s.JwtTokenSecret), nil
-	})
+//	update 2025-11-20 16:58:03
+func (s *tokenSigner) DecodeToken(tokenString string) (claims *Claims, err error) {
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, s.verificationKey,
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg(), jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}))
 	if err != nil {
 		return
 	}
@@ -78,6 +104,33 @@ func (s *tokenSigner) DecodeToken(tokenString string) (userID uint, err error) {
 		return
 	}
 
-	userID = claims.UserID
+	if claims.TokenType != s.TokenType {
+		err = errors.New("unexpected token type")
+		return
+	}
+
 	return
 }
+
+func (s *tokenSigner) verificationKey(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+		// 迁移到非对称签名后，旧密钥须能真正退役，轮换密钥才能吊销已签发的令牌
+		if config.JwtAlgorithm != jwt.SigningMethodHS256.Alg() && !time.Now().Before(config.JwtLegacyHS256Until) {
+			return nil, errors.New("legacy hmac token is no longer accepted")
+		}
+		if s.JwtTokenSecret == "" {
+			return nil, errors.New("hmac secret is not configured")
+		}
+		return []byte(s.JwtTokenSecret), nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	key, err := defaultKeyring.verificationKey(kid)
+	if err != nil {
+		return nil, err
+	}
+	if key.method.Alg() != token.Method.Alg() {
+		return nil, errors.New("unexpected signing method")
+	}
+	return key.public, nil
+}
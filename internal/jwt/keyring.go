@@ -0,0 +1,299 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/hcd233/aris-blog-api/internal/config"
+	"github.com/hcd233/aris-blog-api/internal/logger"
+	"github.com/hcd233/aris-blog-api/internal/resource/database"
+	"github.com/hcd233/aris-blog-api/internal/resource/database/dao"
+	"github.com/hcd233/aris-blog-api/internal/resource/database/model"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	keyringRefreshInterval     = time.Minute
+	keyringMissRefreshInterval = 10 * time.Second
+	rsaKeyBits                 = 2048
+)
+
+var jwtKeyFields = []string{"id", "kid", "algorithm", "private_key", "public_key", "created_at", "expires_at"}
+
+// JWK JSON Web Key公钥
+//
+//	author centonhuang
+//	update 2025-11-12 10:24:06
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type signingKey struct {
+	kid     string
+	method  jwt.SigningMethod
+	private crypto.PrivateKey
+	public  crypto.PublicKey
+}
+
+// keyring 缓存数据库中未退役的签名密钥，多实例通过数据库共享密钥
+type keyring struct {
+	mu       sync.RWMutex
+	current  *signingKey
+	keys     map[string]*signingKey
+	ordered  []*signingKey
+	loadedAt time.Time
+}
+
+var defaultKeyring = &keyring{}
+
+func (k *keyring) signingKey() (*signingKey, error) {
+	k.mu.RLock()
+	current, stale := k.current, time.Since(k.loadedAt) > keyringRefreshInterval
+	k.mu.RUnlock()
+
+	if current != nil && !stale {
+		return current, nil
+	}
+
+	if current == nil {
+		if _, err := RotateSigningKey(context.Background(), false); err != nil {
+			return nil, err
+		}
+	} else if err := k.reload(); err != nil {
+		logger.Logger().Error("[JwtKeyring] failed to reload keys, using cached signing key", zap.Error(err))
+		return current, nil
+	}
+
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	if k.current == nil {
+		return nil, fmt.Errorf("no signing key for algorithm %s", config.JwtAlgorithm)
+	}
+	return k.current, nil
+}
+
+func (k *keyring) verificationKey(kid string) (*signingKey, error) {
+	k.mu.RLock()
+	key, ok := k.keys[kid]
+	sinceLoad := time.Since(k.loadedAt)
+	k.mu.RUnlock()
+
+	if ok && sinceLoad <= keyringRefreshInterval {
+		return key, nil
+	}
+	// 未知kid可能来自其他实例刚轮换出的新密钥，限制重新加载频率
+	if ok || sinceLoad > keyringMissRefreshInterval {
+		if err := k.reload(); err != nil {
+			logger.Logger().Error("[JwtKeyring] failed to reload keys", zap.Error(err))
+		}
+	}
+
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	if key, ok = k.keys[kid]; !ok {
+		return nil, fmt.Errorf("unknown or retired key id %q", kid)
+	}
+	return key, nil
+}
+
+func (k *keyring) reload() error {
+	db := database.GetDBInstance(context.Background())
+
+	jwtKeys, err := dao.GetJwtKeyDAO().ListUnretired(db, time.Now().UTC(), jwtKeyFields)
+	if err != nil {
+		return err
+	}
+
+	var current *signingKey
+	keys := make(map[string]*signingKey, len(*jwtKeys))
+	ordered := make([]*signingKey, 0, len(*jwtKeys))
+	for _, jwtKey := range *jwtKeys {
+		key, err := parseSigningKey(&jwtKey)
+		if err != nil {
+			logger.Logger().Error("[JwtKeyring] failed to parse key", zap.String("kid", jwtKey.KID), zap.Error(err))
+			continue
+		}
+		keys[key.kid] = key
+		ordered = append(ordered, key)
+		if current == nil && jwtKey.ExpiresAt.IsZero() && jwtKey.Algorithm == config.JwtAlgorithm {
+			current = key
+		}
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.current, k.keys, k.ordered, k.loadedAt = current, keys, ordered, time.Now()
+	return nil
+}
+
+// RotateSigningKey 轮换非对称签名密钥
+//
+// 当前算法没有签名密钥、已到轮换周期或force为true时生成新密钥，
+// 其余仍在签名的密钥转为仅验签，并在最长的刷新令牌有效期过后退役。HS256模式下不做任何操作
+//
+//	param ctx context.Context
+//	param force bool
+//	return rotated bool
+//	return err error
+//	author centonhuang
+//	update 2025-11-12 10:24:06
+func RotateSigningKey(ctx context.Context, force bool) (rotated bool, err error) {
+	if config.JwtAlgorithm == jwt.SigningMethodHS256.Alg() {
+		return false, nil
+	}
+
+	db := database.GetDBInstance(ctx)
+	jwtKeyDAO := dao.GetJwtKeyDAO()
+
+	latest, err := jwtKeyDAO.GetLatestSigning(db, config.JwtAlgorithm, []string{"id", "kid", "created_at"})
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, err
+	}
+	if err == nil && !force && time.Since(latest.CreatedAt) < config.JwtKeyRotationInterval {
+		return false, defaultKeyring.reload()
+	}
+
+	jwtKey, err := generateJwtKey(config.JwtAlgorithm)
+	if err != nil {
+		return false, err
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := jwtKeyDAO.Create(tx, jwtKey); err != nil {
+			return err
+		}
+		return jwtKeyDAO.ExpireSigningExcept(tx, jwtKey.ID, time.Now().UTC().Add(config.JwtRefreshTokenExpired))
+	})
+	if err != nil {
+		return false, err
+	}
+
+	logger.WithCtx(ctx).Info("[JwtKeyring] signing key rotated", zap.String("kid", jwtKey.KID), zap.String("algorithm", jwtKey.Algorithm))
+
+	return true, defaultKeyring.reload()
+}
+
+// GetJWKS 获取全部未退役密钥的公钥，HS256模式且没有遗留非对称密钥时为空
+//
+//	return jwks []*JWK
+//	return err error
+//	author centonhuang
+//	update 2025-11-12 10:24:06
+func GetJWKS() (jwks []*JWK, err error) {
+	k := defaultKeyring
+
+	k.mu.RLock()
+	stale := time.Since(k.loadedAt) > keyringRefreshInterval
+	k.mu.RUnlock()
+
+	if stale {
+		if err = k.reload(); err != nil {
+			return nil, err
+		}
+	}
+
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	jwks = make([]*JWK, 0, len(k.ordered))
+	for _, key := range k.ordered {
+		jwk := &JWK{Kid: key.kid, Use: "sig", Alg: key.method.Alg()}
+		switch public := key.public.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty, jwk.Crv = "OKP", "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		}
+		jwks = append(jwks, jwk)
+	}
+	return jwks, nil
+}
+
+func generateJwtKey(algorithm string) (*model.JwtKey, error) {
+	var private crypto.PrivateKey
+	var public crypto.PublicKey
+
+	switch algorithm {
+	case jwt.SigningMethodRS256.Alg():
+		rsaKey, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+		if err != nil {
+			return nil, err
+		}
+		private, public = rsaKey, &rsaKey.PublicKey
+	case jwt.SigningMethodEdDSA.Alg():
+		edPublic, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		private, public = edPrivate, edPublic
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm %s", algorithm)
+	}
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return nil, err
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(public)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.JwtKey{
+		KID:        uuid.NewString(),
+		Algorithm:  algorithm,
+		PrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER})),
+		PublicKey:  string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})),
+	}, nil
+}
+
+func parseSigningKey(jwtKey *model.JwtKey) (*signingKey, error) {
+	method := jwt.GetSigningMethod(jwtKey.Algorithm)
+	if method == nil {
+		return nil, fmt.Errorf("unsupported signing algorithm %s", jwtKey.Algorithm)
+	}
+
+	privateBlock, _ := pem.Decode([]byte(jwtKey.PrivateKey))
+	if privateBlock == nil {
+		return nil, errors.New("invalid private key pem")
+	}
+	private, err := x509.ParsePKCS8PrivateKey(privateBlock.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	publicBlock, _ := pem.Decode([]byte(jwtKey.PublicKey))
+	if publicBlock == nil {
+		return nil, errors.New("invalid public key pem")
+	}
+	public, err := x509.ParsePKIXPublicKey(publicBlock.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &signingKey{kid: jwtKey.KID, method: method, private: private, public: public}, nil
+}
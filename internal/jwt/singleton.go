@@ -19,11 +19,13 @@ func GetRefreshTokenSigner() TokenSigner {
 
 func init() {
 	accessTokenSvc = &tokenSigner{
+		TokenType:       tokenTypeAccess,
 		JwtTokenSecret:  config.JwtAccessTokenSecret,
 		JwtTokenExpired: config.JwtAccessTokenExpired,
 	}
 
 	refreshTokenSvc = &tokenSigner{
+		TokenType:       tokenTypeRefresh,
 		JwtTokenSecret:  config.JwtRefreshTokenSecret,
 		JwtTokenExpired: config.JwtRefreshTokenExpired,
 	}
@@ -4,46 +4,109 @@
 package middleware
 
 import (
+	"strings"
+	"time"
+
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/gofiber/fiber/v2"
 	"github.com/hcd233/aris-blog-api/internal/constant"
 	"github.com/hcd233/aris-blog-api/internal/jwt"
+	"github.com/hcd233/aris-blog-api/internal/logger"
 	"github.com/hcd233/aris-blog-api/internal/resource/database"
 	"github.com/hcd233/aris-blog-api/internal/resource/database/dao"
+	"github.com/hcd233/aris-blog-api/internal/resource/database/model"
+	"github.com/hcd233/aris-blog-api/internal/util"
+	"go.uber.org/zap"
 )
 
+// patLastUsedInterval 个人访问令牌最近使用时间的更新间隔
+const patLastUsedInterval = time.Minute
+
 // JwtMiddleware JWT 中间件
 //
+// Authorization头直接携带JWT访问令牌；以"Bearer aris_pat_"开头时按个人访问令牌认证，
+// 此时权限项为令牌权限项与用户当前角色权限项的交集，且只能访问通过RequireScope声明了所需权限项的路由
+//
 //	@return ctx huma.Context
 //	@return next func(huma.Context)
 //	@return func(ctx huma.Context, next func(huma.Context))
 //	@author centonhuang
-//	@update 2025-11-02 04:17:04
+//	@update 2025-11-19 16:40:18
 func JwtMiddleware() func(ctx huma.Context, next func(huma.Context)) {
-	dao := dao.GetUserDAO()
+	userDAO, sessionDAO, patDAO := dao.GetUserDAO(), dao.GetSessionDAO(), dao.GetPersonalAccessTokenDAO()
 	accessTokenSvc := jwt.GetAccessTokenSigner()
 
 	return func(ctx huma.Context, next func(huma.Context)) {
 		db := database.GetDBInstance(ctx.Context())
+		now := time.Now().UTC()
 
 		tokenString := ctx.Header("Authorization")
 		if tokenString == "" {
 			ctx.SetStatus(fiber.StatusUnauthorized)
 			return
 		}
-		userID, err := accessTokenSvc.DecodeToken(tokenString)
-		if err != nil {
-			ctx.SetStatus(fiber.StatusUnauthorized)
-			return
+
+		var userID uint
+		var session *model.Session
+		var pat *model.PersonalAccessToken
+
+		if patString, ok := strings.CutPrefix(tokenString, "Bearer "); ok && strings.HasPrefix(patString, util.PersonalAccessTokenPrefix) {
+			var err error
+			pat, err = patDAO.GetByHash(db, util.HashToken(patString), []string{"id", "user_id", "scopes", "expires_at", "revoked_at"})
+			if err != nil || !pat.IsActive(now) {
+				ctx.SetStatus(fiber.StatusUnauthorized)
+				return
+			}
+			// 默认拒绝：未声明所需权限项的路由不向个人访问令牌开放
+			if len(requiredScopes(ctx.Operation())) == 0 {
+				logger.WithCtx(ctx.Context()).Info("[JwtMiddleware] route not available to pat",
+					zap.Uint("patID", pat.ID),
+					zap.String("operationID", ctx.Operation().OperationID))
+				writeNoPermission(ctx)
+				return
+			}
+			ip, _ := ctx.Context().Value(constant.CtxKeyClientIP).(string)
+			if err := patDAO.TouchLastUsed(db, pat, ip, patLastUsedInterval); err != nil {
+				logger.WithCtx(ctx.Context()).Error("[JwtMiddleware] failed to update pat last used", zap.Uint("patID", pat.ID), zap.Error(err))
+			}
+			userID = pat.UserID
+		} else {
+			claims, err := accessTokenSvc.DecodeToken(tokenString)
+			if err != nil {
+				ctx.SetStatus(fiber.StatusUnauthorized)
+				return
+			}
+			// 会话登出或吊销后，其签发的访问令牌立即失效
+			session, err = sessionDAO.GetByID(db, claims.SessionID, []string{"id", "user_id", "expires_at", "revoked_at"}, []string{})
+			if err != nil || session.UserID != claims.UserID || !session.IsActive(now) {
+				ctx.SetStatus(fiber.StatusUnauthorized)
+				return
+			}
+			userID = claims.UserID
 		}
-		user, err := dao.GetByID(db, userID, []string{"id", "name", "permission"}, []string{})
+
+		user, err := userDAO.GetByID(db, userID, []string{"id", "name", "permission", "status", "suspended_until"}, []string{"Roles"})
 		if err != nil {
 			ctx.SetStatus(fiber.StatusInternalServerError)
 			return
 		}
+		if user.IsBlocked(now) {
+			ctx.SetStatus(fiber.StatusForbidden)
+			return
+		}
+
+		scopes := user.GetScopes()
+		if pat != nil {
+			scopes = model.IntersectScopes(scopes, pat.Scopes)
+			ctx = huma.WithValue(ctx, constant.CtxKeyPATID, pat.ID)
+		} else {
+			ctx = huma.WithValue(ctx, constant.CtxKeySessionID, session.ID)
+		}
+
 		ctx = huma.WithValue(ctx, constant.CtxKeyUserID, user.ID)
 		ctx = huma.WithValue(ctx, constant.CtxKeyUserName, user.Name)
 		ctx = huma.WithValue(ctx, constant.CtxKeyPermission, user.Permission)
+		ctx = huma.WithValue(ctx, constant.CtxKeyScopes, scopes)
 		next(ctx)
 	}
 }
@@ -1,6 +1,8 @@
 package middleware
 
 import (
+	"maps"
+
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/hcd233/aris-blog-api/internal/api"
 	"github.com/hcd233/aris-blog-api/internal/constant"
@@ -11,30 +13,60 @@ import (
 	"go.uber.org/zap"
 )
 
-// LimitUserPermissionMiddleware 限制用户权限中间件
-//	@param serviceName string 
-//	@param requiredPermission model.Permission 
-//	@return ctx huma.Context 
-//	@return next func(huma.Context) 
-//	@return func(ctx huma.Context, next func(huma.Context)) 
-//	@author centonhuang 
-//	@update 2025-11-02 04:16:51 
-func LimitUserPermissionMiddleware(serviceName string, requiredPermission model.Permission) func(ctx huma.Context, next func(huma.Context)) {
+// requiredScopesMetadataKey 路由声明的所需权限项在Operation元数据中的键，个人访问令牌只能访问声明了权限项的路由
+const requiredScopesMetadataKey = "requiredScopes"
+
+// RequireScope 为路由组声明所需权限项并校验，声明记录在路由元数据中供JwtMiddleware限制个人访问令牌
+//
+//	@param group *huma.Group
+//	@param scope model.Scope
+//	@author centonhuang
+//	@update 2025-11-19 16:40:18
+func RequireScope(group *huma.Group, scope model.Scope) {
+	group.UseSimpleModifier(func(op *huma.Operation) {
+		// 元数据在文档注册与路由注册间共享，复制后再修改
+		metadata := make(map[string]any, len(op.Metadata)+1)
+		maps.Copy(metadata, op.Metadata)
+		scopes, _ := metadata[requiredScopesMetadataKey].([]model.Scope)
+		metadata[requiredScopesMetadataKey] = append(append([]model.Scope{}, scopes...), scope)
+		op.Metadata = metadata
+	})
+	group.UseMiddleware(RequirePermission(scope))
+}
+
+// requiredScopes 获取路由声明的所需权限项
+func requiredScopes(op *huma.Operation) []model.Scope {
+	if op == nil {
+		return nil
+	}
+	scopes, _ := op.Metadata[requiredScopesMetadataKey].([]model.Scope)
+	return scopes
+}
+
+// writeNoPermission 写入无权限的错误响应
+func writeNoPermission(ctx huma.Context) {
+	_, err := util.WrapHTTPResponse[any](nil, protocol.ErrNoPermission)
+	huma.WriteErr(api.GetHumaAPI(), ctx, err.GetStatus(), err.Error(), err)
+}
+
+// RequirePermission 要求当前用户的角色包含指定权限项，需在JwtMiddleware之后使用；
+// 路由组应通过RequireScope使用，直接使用时路由不会向个人访问令牌开放
+//
+//	@param scope model.Scope
+//	@return ctx huma.Context
+//	@return next func(huma.Context)
+//	@return func(ctx huma.Context, next func(huma.Context))
+//	@author centonhuang
+//	@update 2025-11-11 17:05:24
+func RequirePermission(scope model.Scope) func(ctx huma.Context, next func(huma.Context)) {
 	return func(ctx huma.Context, next func(huma.Context)) {
-		permission, ok := ctx.Context().Value(constant.CtxKeyPermission).(model.Permission)
-		if !ok {
-			_, err := util.WrapHTTPResponse[any](nil, protocol.ErrNoPermission)
-			huma.WriteErr(api.GetHumaAPI(), ctx, err.GetStatus(), err.Error(), err)
-			return
-		}
+		scopes, _ := ctx.Context().Value(constant.CtxKeyScopes).([]model.Scope)
 
-		if model.PermissionLevelMapping[permission] < model.PermissionLevelMapping[requiredPermission] {
-			logger.WithCtx(ctx.Context()).Info("[LimitUserPermissionMiddleware] permission denied",
-				zap.String("serviceName", serviceName),
-				zap.String("requiredPermission", string(requiredPermission)),
-				zap.String("permission", string(permission)))
-			_, err := util.WrapHTTPResponse[any](nil, protocol.ErrNoPermission)
-			huma.WriteErr(api.GetHumaAPI(), ctx, err.GetStatus(), err.Error(), err)
+		if !model.HasScope(scopes, scope) {
+			logger.WithCtx(ctx.Context()).Info("[RequirePermission] permission denied",
+				zap.String("requiredScope", string(scope)),
+				zap.Any("scopes", scopes))
+			writeNoPermission(ctx)
 			return
 		}
 
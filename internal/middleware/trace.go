@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
 	"github.com/google/uuid"
 	"github.com/hcd233/aris-blog-api/internal/constant"
 )
@@ -20,6 +21,9 @@ func TraceMiddleware() fiber.Handler {
 		}
 
 		c.Locals(constant.CtxKeyTraceID, traceID)
+		// fiber的字符串引用请求缓冲区，需要拷贝后才能在请求外使用
+		c.Locals(constant.CtxKeyClientIP, utils.CopyString(c.IP()))
+		c.Locals(constant.CtxKeyUserAgent, utils.CopyString(c.Get(fiber.HeaderUserAgent)))
 
 		c.Set("X-Trace-Id", traceID)
 
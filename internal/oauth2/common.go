@@ -15,6 +15,8 @@ const (
 	ProviderTypeGithub ProviderType = "github"
 	// ProviderTypeGoogle Google OAuth2提供商
 	ProviderTypeGoogle ProviderType = "google"
+	// ProviderTypeQQ QQ OAuth2提供商
+	ProviderTypeQQ ProviderType = "qq"
 )
 
 // UserInfo 用户信息
@@ -27,12 +29,12 @@ type UserInfo interface {
 
 // Provider OAuth2提供商接口
 type Provider interface {
-	// GetAuthURL 获取授权URL
-	GetAuthURL() string
-	// ExchangeToken 通过授权码获取Access Token
-	ExchangeToken(ctx context.Context, code string) (*oauth2.Token, error)
+	// GetAuthURL 获取携带state与PKCE challenge的授权URL
+	GetAuthURL(ctx context.Context, state, verifier string) (string, error)
+	// ExchangeToken 通过授权码与PKCE verifier获取Access Token
+	ExchangeToken(ctx context.Context, code, verifier string) (*oauth2.Token, error)
 	// GetUserInfo 获取用户信息
 	GetUserInfo(ctx context.Context, token *oauth2.Token) (UserInfo, error)
-	// GetBindField 获取绑定字段名
+	// GetBindField 获取用户表中的绑定字段名，为空表示身份保存在OIDC身份表中
 	GetBindField() string
 }
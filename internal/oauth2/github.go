@@ -93,12 +93,12 @@ func NewGithubProvider() Provider {
 	}
 }
 
-func (p *githubProvider) GetAuthURL() string {
-	return p.oauth2Config.AuthCodeURL(config.Oauth2StateString, oauth2.AccessTypeOffline)
+func (p *githubProvider) GetAuthURL(_ context.Context, state, verifier string) (string, error) {
+	return p.oauth2Config.AuthCodeURL(state, oauth2.AccessTypeOffline, oauth2.S256ChallengeOption(verifier)), nil
 }
 
-func (p *githubProvider) ExchangeToken(ctx context.Context, code string) (*oauth2.Token, error) {
-	return p.oauth2Config.Exchange(ctx, code)
+func (p *githubProvider) ExchangeToken(ctx context.Context, code, verifier string) (*oauth2.Token, error) {
+	return p.oauth2Config.Exchange(ctx, code, oauth2.VerifierOption(verifier))
 }
 
 func (p *githubProvider) GetUserInfo(ctx context.Context, token *oauth2.Token) (UserInfo, error) {
@@ -89,11 +89,11 @@ func NewGoogleProvider() Provider {
 	}
 }
 
-func (p *googleProvider) GetAuthURL() string {
-	return p.oauth2Config.AuthCodeURL(config.Oauth2StateString, oauth2.AccessTypeOffline)
+func (p *googleProvider) GetAuthURL(_ context.Context, state, verifier string) (string, error) {
+	return p.oauth2Config.AuthCodeURL(state, oauth2.AccessTypeOffline, oauth2.S256ChallengeOption(verifier)), nil
 }
 
-func (p *googleProvider) ExchangeToken(ctx context.Context, code string) (*oauth2.Token, error) {
+func (p *googleProvider) ExchangeToken(ctx context.Context, code, verifier string) (*oauth2.Token, error) {
 	logger := logger.WithCtx(ctx)
 
 	logger.Info("[GoogleOauth2] exchanging code for token",
@@ -101,7 +101,7 @@ func (p *googleProvider) ExchangeToken(ctx context.Context, code string) (*oauth
 		zap.String("redirectURL", p.oauth2Config.RedirectURL),
 		zap.Strings("scopes", p.oauth2Config.Scopes))
 
-	token, err := p.oauth2Config.Exchange(ctx, code)
+	token, err := p.oauth2Config.Exchange(ctx, code, oauth2.VerifierOption(verifier))
 	if err != nil {
 		logger.Error("[GoogleOauth2] token exchange failed", zap.Error(err))
 		return nil, err
@@ -0,0 +1,457 @@
+package oauth2
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/hcd233/aris-blog-api/internal/config"
+	"github.com/samber/lo"
+	"golang.org/x/oauth2"
+)
+
+const (
+	oidcDiscoveryPath           = "/.well-known/openid-configuration"
+	oidcHTTPTimeout             = 10 * time.Second
+	oidcJWKSRefreshInterval     = time.Hour
+	oidcJWKSMissRefreshInterval = time.Minute
+	oidcClockSkew               = time.Minute
+)
+
+// oidcSigningAlgs 允许的ID Token签名算法，排除none与依赖client secret的HMAC
+var oidcSigningAlgs = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+var (
+	oidcHTTPClient = &http.Client{Timeout: oidcHTTPTimeout}
+
+	oidcProviders     map[string]*oidcProvider
+	oidcProvidersOnce sync.Once
+)
+
+// OIDCUserInfo OIDC用户信息，取自校验通过的ID Token，缺失的资料由UserInfo端点补充
+type OIDCUserInfo struct {
+	Subject string
+	Name    string
+	Email   string
+	Picture string
+}
+
+// GetID 获取OIDC用户sub
+//
+//	@receiver u *OIDCUserInfo
+//	@return string
+//	@author centonhuang
+//	@update 2025-11-13 19:26:03
+func (u *OIDCUserInfo) GetID() string {
+	return u.Subject
+}
+
+// GetName 获取OIDC用户名
+//
+//	@receiver u *OIDCUserInfo
+//	@return string
+//	@author centonhuang
+//	@update 2025-11-13 19:26:03
+func (u *OIDCUserInfo) GetName() string {
+	return u.Name
+}
+
+// GetEmail 获取OIDC用户已验证的邮箱
+//
+//	@receiver u *OIDCUserInfo
+//	@return string
+//	@author centonhuang
+//	@update 2025-11-13 19:26:03
+func (u *OIDCUserInfo) GetEmail() string {
+	return u.Email
+}
+
+// GetAvatar 获取OIDC用户头像
+//
+//	@receiver u *OIDCUserInfo
+//	@return string
+//	@author centonhuang
+//	@update 2025-11-13 19:26:03
+func (u *OIDCUserInfo) GetAvatar() string {
+	return u.Picture
+}
+
+type oidcDiscovery struct {
+	Issuer                 string   `json:"issuer"`
+	AuthorizationEndpoint  string   `json:"authorization_endpoint"`
+	TokenEndpoint          string   `json:"token_endpoint"`
+	UserinfoEndpoint       string   `json:"userinfo_endpoint"`
+	JwksURI                string   `json:"jwks_uri"`
+	IDTokenSigningAlgs     []string `json:"id_token_signing_alg_values_supported"`
+	TokenEndpointAuthMeths []string `json:"token_endpoint_auth_methods_supported"`
+}
+
+type oidcJWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type oidcClaims struct {
+	jwt.RegisteredClaims
+	AuthorizedParty   string `json:"azp"`
+	Name              string `json:"name"`
+	PreferredUsername string `json:"preferred_username"`
+	Email             string `json:"email"`
+	EmailVerified     any    `json:"email_verified"`
+	Picture           string `json:"picture"`
+}
+
+// oidcProvider 通用OIDC提供商实现，首次使用时执行发现并缓存JWKS
+type oidcProvider struct {
+	cfg *config.Oauth2OIDCProvider
+
+	mu            sync.RWMutex
+	discovery     *oidcDiscovery
+	oauth2Config  *oauth2.Config
+	validMethods  []string
+	keys          map[string]crypto.PublicKey
+	keysLoadedAt  time.Time
+	keysAttempted time.Time
+}
+
+// GetOIDCProvider 获取已配置的OIDC提供商
+//
+//	@param name string
+//	@return Provider
+//	@return bool 未配置该名称时为false
+//	@author centonhuang
+//	@update 2025-11-13 19:26:03
+func GetOIDCProvider(name string) (Provider, bool) {
+	oidcProvidersOnce.Do(func() {
+		oidcProviders = lo.SliceToMap(config.Oauth2OIDCProviders, func(cfg *config.Oauth2OIDCProvider) (string, *oidcProvider) {
+			return cfg.Name, &oidcProvider{cfg: cfg}
+		})
+	})
+	provider, ok := oidcProviders[name]
+	return provider, ok
+}
+
+func (p *oidcProvider) GetAuthURL(ctx context.Context, state, verifier string) (string, error) {
+	oauth2Config, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	return oauth2Config.AuthCodeURL(state, oauth2.S256ChallengeOption(verifier)), nil
+}
+
+func (p *oidcProvider) ExchangeToken(ctx context.Context, code, verifier string) (*oauth2.Token, error) {
+	oauth2Config, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return oauth2Config.Exchange(context.WithValue(ctx, oauth2.HTTPClient, oidcHTTPClient), code, oauth2.VerifierOption(verifier))
+}
+
+func (p *oidcProvider) GetUserInfo(ctx context.Context, token *oauth2.Token) (UserInfo, error) {
+	rawIDToken, _ := token.Extra("id_token").(string)
+	if rawIDToken == "" {
+		return nil, errors.New("token response has no id_token")
+	}
+
+	claims, err := p.verifyIDToken(ctx, rawIDToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// ID Token中资料不全时通过UserInfo端点补充，sub必须一致
+	if claims.Email == "" || (claims.Name == "" && claims.PreferredUsername == "") {
+		p.mu.RLock()
+		userinfoEndpoint := p.discovery.UserinfoEndpoint
+		p.mu.RUnlock()
+
+		if userinfoEndpoint != "" {
+			var extra oidcClaims
+			if err := p.fetchJSON(ctx, userinfoEndpoint, token.AccessToken, &extra); err != nil {
+				return nil, err
+			}
+			if extra.Subject != claims.Subject {
+				return nil, errors.New("userinfo subject does not match id_token subject")
+			}
+			if claims.Email == "" {
+				claims.Email, claims.EmailVerified = extra.Email, extra.EmailVerified
+			}
+			claims.Name = firstNonEmpty(claims.Name, extra.Name)
+			claims.PreferredUsername = firstNonEmpty(claims.PreferredUsername, extra.PreferredUsername)
+			claims.Picture = firstNonEmpty(claims.Picture, extra.Picture)
+		}
+	}
+
+	userInfo := &OIDCUserInfo{
+		Subject: claims.Subject,
+		Name:    firstNonEmpty(claims.PreferredUsername, claims.Name),
+		Picture: claims.Picture,
+	}
+	// 未验证的邮箱不参与账号匹配
+	if isEmailVerified(claims.EmailVerified, p.cfg.TrustEmail) {
+		userInfo.Email = claims.Email
+	}
+	return userInfo, nil
+}
+
+// GetBindField OIDC身份保存在独立的表中，用户表没有对应字段
+func (p *oidcProvider) GetBindField() string {
+	return ""
+}
+
+func (p *oidcProvider) discover(ctx context.Context) (*oauth2.Config, error) {
+	p.mu.RLock()
+	oauth2Config := p.oauth2Config
+	p.mu.RUnlock()
+	if oauth2Config != nil {
+		return oauth2Config, nil
+	}
+
+	var discovery oidcDiscovery
+	if err := p.fetchJSON(ctx, strings.TrimSuffix(p.cfg.Issuer, "/")+oidcDiscoveryPath, "", &discovery); err != nil {
+		return nil, fmt.Errorf("oidc discovery for %s: %w", p.cfg.Name, err)
+	}
+	if discovery.Issuer != p.cfg.Issuer {
+		return nil, fmt.Errorf("oidc discovery for %s: issuer %q does not match configured issuer %q", p.cfg.Name, discovery.Issuer, p.cfg.Issuer)
+	}
+	if discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.JwksURI == "" {
+		return nil, fmt.Errorf("oidc discovery for %s: missing required endpoints", p.cfg.Name)
+	}
+
+	validMethods := []string{jwt.SigningMethodRS256.Alg()}
+	if len(discovery.IDTokenSigningAlgs) > 0 {
+		validMethods = lo.Intersect(oidcSigningAlgs, discovery.IDTokenSigningAlgs)
+	}
+	if len(validMethods) == 0 {
+		return nil, fmt.Errorf("oidc discovery for %s: no supported id_token signing algorithm in %v", p.cfg.Name, discovery.IDTokenSigningAlgs)
+	}
+
+	authStyle := oauth2.AuthStyleInHeader
+	if len(discovery.TokenEndpointAuthMeths) > 0 && !lo.Contains(discovery.TokenEndpointAuthMeths, "client_secret_basic") {
+		authStyle = oauth2.AuthStyleInParams
+	}
+
+	oauth2Config = &oauth2.Config{
+		Endpoint: oauth2.Endpoint{
+			AuthURL:   discovery.AuthorizationEndpoint,
+			TokenURL:  discovery.TokenEndpoint,
+			AuthStyle: authStyle,
+		},
+		Scopes:       p.cfg.Scopes,
+		ClientID:     p.cfg.ClientID,
+		ClientSecret: p.cfg.ClientSecret,
+		RedirectURL:  p.cfg.RedirectURL,
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.discovery, p.oauth2Config, p.validMethods = &discovery, oauth2Config, validMethods
+	return oauth2Config, nil
+}
+
+func (p *oidcProvider) verifyIDToken(ctx context.Context, rawIDToken string) (*oidcClaims, error) {
+	p.mu.RLock()
+	issuer, validMethods := p.discovery.Issuer, p.validMethods
+	p.mu.RUnlock()
+
+	claims := &oidcClaims{}
+	_, err := jwt.ParseWithClaims(rawIDToken, claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		return p.verificationKey(ctx, kid)
+	},
+		jwt.WithValidMethods(validMethods),
+		jwt.WithIssuer(issuer),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(oidcClockSkew),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id_token: %w", err)
+	}
+
+	// 存在多个受众或声明了azp时，授权方必须是本应用
+	if (len(claims.Audience) > 1 || claims.AuthorizedParty != "") && claims.AuthorizedParty != p.cfg.ClientID {
+		return nil, fmt.Errorf("invalid id_token: authorized party %q is not this client", claims.AuthorizedParty)
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("invalid id_token: missing subject")
+	}
+	return claims, nil
+}
+
+func (p *oidcProvider) verificationKey(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	p.mu.RLock()
+	key, ok := lookupKey(p.keys, kid)
+	stale := time.Since(p.keysLoadedAt) > oidcJWKSRefreshInterval
+	attempted := time.Since(p.keysAttempted)
+	p.mu.RUnlock()
+
+	if ok && !stale {
+		return key, nil
+	}
+	// 未知kid可能来自IdP刚轮换出的新密钥，限制重新拉取频率
+	if ok || attempted > oidcJWKSMissRefreshInterval {
+		if err := p.loadKeys(ctx); err != nil && !ok {
+			return nil, err
+		}
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if key, ok = lookupKey(p.keys, kid); !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+	return key, nil
+}
+
+func (p *oidcProvider) loadKeys(ctx context.Context) error {
+	p.mu.Lock()
+	p.keysAttempted = time.Now()
+	jwksURI := p.discovery.JwksURI
+	p.mu.Unlock()
+
+	var jwks struct {
+		Keys []oidcJWK `json:"keys"`
+	}
+	if err := p.fetchJSON(ctx, jwksURI, "", &jwks); err != nil {
+		return fmt.Errorf("fetch jwks for %s: %w", p.cfg.Name, err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := parseJWK(&jwk)
+		if err != nil {
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.keys, p.keysLoadedAt = keys, time.Now()
+	return nil
+}
+
+func (p *oidcProvider) fetchJSON(ctx context.Context, endpoint, accessToken string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+
+	resp, err := oidcHTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, endpoint)
+	}
+	return sonic.ConfigDefault.NewDecoder(resp.Body).Decode(v)
+}
+
+// lookupKey 按kid查找公钥，ID Token未声明kid且JWKS只有一个密钥时使用该密钥
+func lookupKey(keys map[string]crypto.PublicKey, kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(keys) == 1 {
+		for _, key := range keys {
+			return key, true
+		}
+	}
+	key, ok := keys[kid]
+	return key, ok
+}
+
+func parseJWK(jwk *oidcJWK) (crypto.PublicKey, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %s", jwk.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	case "OKP":
+		if jwk.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %s", jwk.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid ed25519 public key size")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %s", jwk.Kty)
+	}
+}
+
+func firstNonEmpty(values ...string) string {
+	value, _ := lo.Coalesce(values...)
+	return value
+}
+
+// isEmailVerified 解析email_verified，部分IdP以字符串形式返回；未声明时视为未验证，除非提供商配置了信任邮箱
+func isEmailVerified(emailVerified any, trustEmail bool) bool {
+	switch v := emailVerified.(type) {
+	case nil:
+		return trustEmail
+	case bool:
+		return v
+	case string:
+		return v == "true"
+	default:
+		return false
+	}
+}
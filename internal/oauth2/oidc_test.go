@@ -0,0 +1,316 @@
+package oauth2
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/hcd233/aris-blog-api/internal/config"
+	"golang.org/x/oauth2"
+)
+
+const testClientID = "aris-client"
+
+// testIdP 模拟的OIDC提供商，提供发现文档、JWKS与UserInfo端点，并签发ID Token
+type testIdP struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	issuer    string
+	keys      map[string]*rsa.PrivateKey
+	jwksHits  int
+	userinfo  map[string]any
+	published []string
+}
+
+func newTestIdP(t *testing.T) *testIdP {
+	t.Helper()
+
+	idp := &testIdP{keys: map[string]*rsa.PrivateKey{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc(oidcDiscoveryPath, func(w http.ResponseWriter, _ *http.Request) {
+		idp.mu.Lock()
+		defer idp.mu.Unlock()
+		writeTestJSON(w, map[string]any{
+			"issuer":                                idp.issuer,
+			"authorization_endpoint":                idp.URL + "/authorize",
+			"token_endpoint":                        idp.URL + "/token",
+			"userinfo_endpoint":                     idp.URL + "/userinfo",
+			"jwks_uri":                              idp.URL + "/jwks",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, _ *http.Request) {
+		idp.mu.Lock()
+		defer idp.mu.Unlock()
+		idp.jwksHits++
+		keys := make([]map[string]string, 0, len(idp.published))
+		for _, kid := range idp.published {
+			key := idp.keys[kid].PublicKey
+			keys = append(keys, map[string]string{
+				"kty": "RSA",
+				"kid": kid,
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			})
+		}
+		writeTestJSON(w, map[string]any{"keys": keys})
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		idp.mu.Lock()
+		defer idp.mu.Unlock()
+		if r.Header.Get("Authorization") != "Bearer access-token" || idp.userinfo == nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		writeTestJSON(w, idp.userinfo)
+	})
+
+	idp.Server = httptest.NewServer(mux)
+	t.Cleanup(idp.Close)
+	idp.issuer = idp.URL
+	idp.rotateKey(t, "key-1")
+	return idp
+}
+
+// rotateKey 生成新的签名密钥并发布到JWKS
+func (idp *testIdP) rotateKey(t *testing.T, kid string) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate rsa key: %v", err)
+	}
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+	idp.keys[kid] = key
+	idp.published = append(idp.published, kid)
+}
+
+func (idp *testIdP) sign(t *testing.T, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+
+	idp.mu.Lock()
+	key := idp.keys[kid]
+	idp.mu.Unlock()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("sign id_token: %v", err)
+	}
+	return signed
+}
+
+// claims 返回一组有效的ID Token声明，可按用例覆盖
+func (idp *testIdP) claims(overrides jwt.MapClaims) jwt.MapClaims {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":            idp.URL,
+		"sub":            "user-1",
+		"aud":            testClientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+		"name":           "Aris",
+		"email":          "aris@example.com",
+		"email_verified": true,
+	}
+	for k, v := range overrides {
+		if v == nil {
+			delete(claims, k)
+			continue
+		}
+		claims[k] = v
+	}
+	return claims
+}
+
+func (idp *testIdP) jwksFetches() int {
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+	return idp.jwksHits
+}
+
+func newTestProvider(t *testing.T, idp *testIdP, trustEmail bool) *oidcProvider {
+	t.Helper()
+
+	provider := &oidcProvider{cfg: &config.Oauth2OIDCProvider{
+		Name:        "test",
+		Issuer:      idp.URL,
+		ClientID:    testClientID,
+		RedirectURL: "http://localhost/v1/oauth2/test/callback",
+		Scopes:      []string{"openid", "profile", "email"},
+		TrustEmail:  trustEmail,
+	}}
+	if _, err := provider.discover(context.Background()); err != nil {
+		t.Fatalf("discover: %v", err)
+	}
+	return provider
+}
+
+func tokenWithIDToken(rawIDToken string) *oauth2.Token {
+	return (&oauth2.Token{AccessToken: "access-token"}).WithExtra(map[string]any{"id_token": rawIDToken})
+}
+
+func writeTestJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	data, _ := sonic.Marshal(v)
+	_, _ = w.Write(data)
+}
+
+func TestOIDCGetUserInfo(t *testing.T) {
+	idp := newTestIdP(t)
+	provider := newTestProvider(t, idp, false)
+
+	userInfo, err := provider.GetUserInfo(context.Background(), tokenWithIDToken(idp.sign(t, "key-1", idp.claims(nil))))
+	if err != nil {
+		t.Fatalf("GetUserInfo: %v", err)
+	}
+	if userInfo.GetID() != "user-1" || userInfo.GetName() != "Aris" || userInfo.GetEmail() != "aris@example.com" {
+		t.Fatalf("unexpected user info: %+v", userInfo)
+	}
+}
+
+func TestOIDCDiscoveryIssuerMismatch(t *testing.T) {
+	idp := newTestIdP(t)
+	idp.mu.Lock()
+	idp.issuer = "https://evil.example.com"
+	idp.mu.Unlock()
+
+	provider := &oidcProvider{cfg: &config.Oauth2OIDCProvider{Name: "test", Issuer: idp.URL, ClientID: testClientID}}
+	if _, err := provider.discover(context.Background()); err == nil || !strings.Contains(err.Error(), "does not match configured issuer") {
+		t.Fatalf("expected issuer mismatch error, got %v", err)
+	}
+}
+
+func TestOIDCRejectsInvalidIDToken(t *testing.T) {
+	idp := newTestIdP(t)
+	provider := newTestProvider(t, idp, false)
+
+	testCases := []struct {
+		name      string
+		overrides jwt.MapClaims
+	}{
+		{name: "issuer mismatch", overrides: jwt.MapClaims{"iss": "https://evil.example.com"}},
+		{name: "wrong audience", overrides: jwt.MapClaims{"aud": "other-client"}},
+		{name: "multiple audiences without azp", overrides: jwt.MapClaims{"aud": []string{testClientID, "other-client"}}},
+		{name: "wrong authorized party", overrides: jwt.MapClaims{"aud": []string{testClientID, "other-client"}, "azp": "other-client"}},
+		{name: "expired", overrides: jwt.MapClaims{"iat": time.Now().Add(-2 * time.Hour).Unix(), "exp": time.Now().Add(-time.Hour).Unix()}},
+		{name: "missing expiration", overrides: jwt.MapClaims{"exp": nil}},
+		{name: "missing subject", overrides: jwt.MapClaims{"sub": nil}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rawIDToken := idp.sign(t, "key-1", idp.claims(tc.overrides))
+			if _, err := provider.GetUserInfo(context.Background(), tokenWithIDToken(rawIDToken)); err == nil {
+				t.Fatal("expected id_token to be rejected")
+			}
+		})
+	}
+}
+
+func TestOIDCUnknownKeyRefreshesJWKS(t *testing.T) {
+	idp := newTestIdP(t)
+	provider := newTestProvider(t, idp, false)
+	ctx := context.Background()
+
+	if _, err := provider.GetUserInfo(ctx, tokenWithIDToken(idp.sign(t, "key-1", idp.claims(nil)))); err != nil {
+		t.Fatalf("GetUserInfo with initial key: %v", err)
+	}
+	if fetches := idp.jwksFetches(); fetches != 1 {
+		t.Fatalf("expected 1 jwks fetch, got %d", fetches)
+	}
+
+	// IdP轮换密钥后，刚拉取过JWKS时未知kid不会立即触发重新拉取
+	idp.rotateKey(t, "key-2")
+	rotated := tokenWithIDToken(idp.sign(t, "key-2", idp.claims(nil)))
+	if _, err := provider.GetUserInfo(ctx, rotated); err == nil || !strings.Contains(err.Error(), "unknown key id") {
+		t.Fatalf("expected unknown key error within refresh interval, got %v", err)
+	}
+	if fetches := idp.jwksFetches(); fetches != 1 {
+		t.Fatalf("expected jwks refresh to be rate limited, got %d fetches", fetches)
+	}
+
+	// 超过重新拉取间隔后，未知kid触发JWKS刷新并使用新密钥校验
+	provider.mu.Lock()
+	provider.keysAttempted = time.Now().Add(-2 * oidcJWKSMissRefreshInterval)
+	provider.mu.Unlock()
+	if _, err := provider.GetUserInfo(ctx, rotated); err != nil {
+		t.Fatalf("GetUserInfo with rotated key: %v", err)
+	}
+	if fetches := idp.jwksFetches(); fetches != 2 {
+		t.Fatalf("expected jwks to be refreshed once, got %d fetches", fetches)
+	}
+}
+
+func TestOIDCUserinfoSubjectMismatch(t *testing.T) {
+	idp := newTestIdP(t)
+	provider := newTestProvider(t, idp, false)
+
+	idp.mu.Lock()
+	idp.userinfo = map[string]any{"sub": "user-2", "email": "other@example.com", "email_verified": true}
+	idp.mu.Unlock()
+
+	rawIDToken := idp.sign(t, "key-1", idp.claims(jwt.MapClaims{"email": nil, "email_verified": nil}))
+	if _, err := provider.GetUserInfo(context.Background(), tokenWithIDToken(rawIDToken)); err == nil || !strings.Contains(err.Error(), "userinfo subject") {
+		t.Fatalf("expected userinfo subject mismatch error, got %v", err)
+	}
+}
+
+func TestOIDCEmailVerification(t *testing.T) {
+	idp := newTestIdP(t)
+
+	testCases := []struct {
+		name       string
+		overrides  jwt.MapClaims
+		userinfo   map[string]any
+		trustEmail bool
+		wantEmail  string
+	}{
+		{name: "verified", wantEmail: "aris@example.com"},
+		{name: "verified as string", overrides: jwt.MapClaims{"email_verified": "true"}, wantEmail: "aris@example.com"},
+		{name: "unverified", overrides: jwt.MapClaims{"email_verified": false}},
+		{name: "unverified as string", overrides: jwt.MapClaims{"email_verified": "false"}},
+		{name: "claim missing", overrides: jwt.MapClaims{"email_verified": nil}},
+		{name: "claim missing with trusted provider", overrides: jwt.MapClaims{"email_verified": nil}, trustEmail: true, wantEmail: "aris@example.com"},
+		{name: "explicitly unverified with trusted provider", overrides: jwt.MapClaims{"email_verified": false}, trustEmail: true},
+		{
+			name:      "unverified from userinfo",
+			overrides: jwt.MapClaims{"email": nil, "email_verified": nil},
+			userinfo:  map[string]any{"sub": "user-1", "email": "aris@example.com", "email_verified": false},
+		},
+		{
+			name:      "claim missing from userinfo",
+			overrides: jwt.MapClaims{"email": nil, "email_verified": nil},
+			userinfo:  map[string]any{"sub": "user-1", "email": "aris@example.com"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			idp.mu.Lock()
+			idp.userinfo = tc.userinfo
+			idp.mu.Unlock()
+
+			provider := newTestProvider(t, idp, tc.trustEmail)
+			userInfo, err := provider.GetUserInfo(context.Background(), tokenWithIDToken(idp.sign(t, "key-1", idp.claims(tc.overrides))))
+			if err != nil {
+				t.Fatalf("GetUserInfo: %v", err)
+			}
+			if userInfo.GetEmail() != tc.wantEmail {
+				t.Fatalf("expected email %q, got %q", tc.wantEmail, userInfo.GetEmail())
+			}
+		})
+	}
+}
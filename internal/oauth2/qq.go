@@ -0,0 +1,167 @@
+package oauth2
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/hcd233/aris-blog-api/internal/config"
+	"golang.org/x/oauth2"
+)
+
+const (
+	qqAuthURL     = "https://graph.qq.com/oauth2.0/authorize"
+	qqTokenURL    = "https://graph.qq.com/oauth2.0/token"
+	qqOpenIDURL   = "https://graph.qq.com/oauth2.0/me"
+	qqUserInfoURL = "https://graph.qq.com/user/get_user_info"
+	qqHTTPTimeout = 10 * time.Second
+)
+
+var qqUserScopes = []string{"get_user_info"}
+
+// qqHTTPClient 请求QQ互联的客户端，超时避免上游响应缓慢时登录请求一直挂起
+var qqHTTPClient = &http.Client{Timeout: qqHTTPTimeout}
+
+// QQUserInfo QQ用户信息结构体
+type QQUserInfo struct {
+	OpenID    string `json:"openid"`
+	Nickname  string `json:"nickname"`
+	AvatarURL string `json:"figureurl_qq_2"`
+}
+
+// GetID 获取QQ用户OpenID
+//
+//	@receiver u *QQUserInfo
+//	@return string
+//	@author centonhuang
+//	@update 2025-11-13 10:12:37
+func (u *QQUserInfo) GetID() string {
+	return u.OpenID
+}
+
+// GetName 获取QQ用户昵称
+//
+//	@receiver u *QQUserInfo
+//	@return string
+//	@author centonhuang
+//	@update 2025-11-13 10:12:37
+func (u *QQUserInfo) GetName() string {
+	return u.Nickname
+}
+
+// GetEmail QQ不提供用户邮箱，始终为空
+//
+//	@receiver u *QQUserInfo
+//	@return string
+//	@author centonhuang
+//	@update 2025-11-13 10:12:37
+func (u *QQUserInfo) GetEmail() string {
+	return ""
+}
+
+// GetAvatar 获取QQ用户头像
+//
+//	@receiver u *QQUserInfo
+//	@return string
+//	@author centonhuang
+//	@update 2025-11-13 10:12:37
+func (u *QQUserInfo) GetAvatar() string {
+	return u.AvatarURL
+}
+
+// qqProvider QQ OAuth2提供商实现
+type qqProvider struct {
+	oauth2Config *oauth2.Config
+}
+
+// NewQQProvider QQ提供商
+//
+//	@return Provider
+//	@author centonhuang
+//	@update 2025-11-13 10:12:37
+func NewQQProvider() Provider {
+	return &qqProvider{
+		oauth2Config: &oauth2.Config{
+			Endpoint: oauth2.Endpoint{
+				AuthURL:   qqAuthURL,
+				TokenURL:  qqTokenURL,
+				AuthStyle: oauth2.AuthStyleInParams,
+			},
+			Scopes:       qqUserScopes,
+			ClientID:     config.Oauth2QQClientID,
+			ClientSecret: config.Oauth2QQClientSecret,
+			RedirectURL:  config.Oauth2QQRedirectURL,
+		},
+	}
+}
+
+func (p *qqProvider) GetAuthURL(_ context.Context, state, verifier string) (string, error) {
+	return p.oauth2Config.AuthCodeURL(state, oauth2.S256ChallengeOption(verifier)), nil
+}
+
+func (p *qqProvider) ExchangeToken(ctx context.Context, code, verifier string) (*oauth2.Token, error) {
+	// 默认返回urlencoded格式，指定fmt=json以便按JSON解析
+	return p.oauth2Config.Exchange(context.WithValue(ctx, oauth2.HTTPClient, qqHTTPClient), code, oauth2.VerifierOption(verifier), oauth2.SetAuthURLParam("fmt", "json"))
+}
+
+func (p *qqProvider) GetUserInfo(ctx context.Context, token *oauth2.Token) (UserInfo, error) {
+	// 先通过access token换取openid
+	var me struct {
+		OpenID           string `json:"openid"`
+		Error            int    `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := qqGet(ctx, qqOpenIDURL, url.Values{
+		"access_token": {token.AccessToken},
+		"fmt":          {"json"},
+	}, &me); err != nil {
+		return nil, err
+	}
+	if me.Error != 0 || me.OpenID == "" {
+		return nil, fmt.Errorf("failed to get qq openid: %d %s", me.Error, me.ErrorDescription)
+	}
+
+	var userInfo struct {
+		QQUserInfo
+		Ret int    `json:"ret"`
+		Msg string `json:"msg"`
+	}
+	if err := qqGet(ctx, qqUserInfoURL, url.Values{
+		"access_token":       {token.AccessToken},
+		"oauth_consumer_key": {p.oauth2Config.ClientID},
+		"openid":             {me.OpenID},
+	}, &userInfo); err != nil {
+		return nil, err
+	}
+	if userInfo.Ret != 0 {
+		return nil, fmt.Errorf("failed to get qq user info: %d %s", userInfo.Ret, userInfo.Msg)
+	}
+
+	userInfo.OpenID = me.OpenID
+	return &userInfo.QQUserInfo, nil
+}
+
+func (p *qqProvider) GetBindField() string {
+	return "qq_bind_id"
+}
+
+func qqGet(ctx context.Context, endpoint string, query url.Values, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := qqHTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, endpoint)
+	}
+	return sonic.ConfigDefault.NewDecoder(resp.Body).Decode(v)
+}
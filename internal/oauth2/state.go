@@ -0,0 +1,97 @@
+package oauth2
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/hcd233/aris-blog-api/internal/resource/cache"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/oauth2"
+)
+
+const (
+	stateKeyPrefix = "oauth2:state"
+	stateTTL       = 10 * time.Minute
+)
+
+// ErrStateNotFound state不存在、已过期或已被使用
+var ErrStateNotFound = errors.New("oauth2 state not found or expired")
+
+// State 一次授权流程的上下文，以随机state为键暂存于Redis，回调时一次性取出
+//
+//	author centonhuang
+//	update 2025-11-13 15:40:18
+type State struct {
+	Provider    ProviderType `json:"provider"`
+	Verifier    string       `json:"verifier"`
+	RedirectURL string       `json:"redirectURL"`
+	UserID      uint         `json:"userID"`
+}
+
+// SaveState 生成随机state与PKCE verifier并保存授权上下文
+//
+//	param ctx context.Context
+//	param data *State 由调用方填写Provider、RedirectURL与UserID，Verifier在此生成
+//	return state string
+//	return err error
+//	author centonhuang
+//	update 2025-11-13 15:40:18
+func SaveState(ctx context.Context, data *State) (state string, err error) {
+	buf := make([]byte, 32)
+	if _, err = rand.Read(buf); err != nil {
+		return "", err
+	}
+	state = base64.RawURLEncoding.EncodeToString(buf)
+	data.Verifier = oauth2.GenerateVerifier()
+
+	raw, err := sonic.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	if err = cache.GetRedisClient().Set(ctx, stateKey(state), raw, stateTTL).Err(); err != nil {
+		return "", err
+	}
+	return state, nil
+}
+
+// ConsumeState 取出并删除授权上下文，同一个state只能使用一次
+//
+//	param ctx context.Context
+//	param provider ProviderType
+//	param state string
+//	return data *State
+//	return err error
+//	author centonhuang
+//	update 2025-11-13 15:40:18
+func ConsumeState(ctx context.Context, provider ProviderType, state string) (data *State, err error) {
+	if state == "" {
+		return nil, ErrStateNotFound
+	}
+
+	raw, err := cache.GetRedisClient().GetDel(ctx, stateKey(state)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, ErrStateNotFound
+		}
+		return nil, err
+	}
+
+	data = &State{}
+	if err = sonic.Unmarshal(raw, data); err != nil {
+		return nil, err
+	}
+	// 授权码只能回到发起授权的提供商
+	if data.Provider != provider {
+		return nil, ErrStateNotFound
+	}
+	return data, nil
+}
+
+func stateKey(state string) string {
+	return fmt.Sprintf("%s:%s", stateKeyPrefix, state)
+}
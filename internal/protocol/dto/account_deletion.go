@@ -0,0 +1,35 @@
+package dto
+
+// RequestAccountDeletionRequestBody 申请注销账号请求体
+//
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+type RequestAccountDeletionRequestBody struct {
+	ArticleDisposition string `json:"articleDisposition" doc:"Delete the articles or transfer them to another creator" enum:"delete,transfer"`
+	TransferToUserID   uint   `json:"transferToUserID,omitempty" doc:"User who receives the articles, required when articles are transferred"`
+	Reason             string `json:"reason,omitempty" doc:"Why the user leaves" maxLength:"500"`
+}
+
+// RequestAccountDeletionRequest 申请注销账号请求
+//
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+type RequestAccountDeletionRequest struct {
+	Body *RequestAccountDeletionRequestBody `json:"body" doc:"Deletion options"`
+}
+
+// RequestAccountDeletionResponse 申请注销账号响应
+//
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+type RequestAccountDeletionResponse struct {
+	Deletion *AccountDeletion `json:"deletion" doc:"Scheduled deletion"`
+}
+
+// GetCurrentAccountDeletionResponse 获取当前用户注销申请响应
+//
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+type GetCurrentAccountDeletionResponse struct {
+	Deletion *AccountDeletion `json:"deletion" doc:"Pending deletion of the current user"`
+}
@@ -0,0 +1,136 @@
+package dto
+
+// AdminUserPathParam 管理员操作的用户路径参数
+//
+//	author centonhuang
+//	update 2025-11-10 15:32:08
+type AdminUserPathParam struct {
+	UserID uint `path:"userID" doc:"User ID"`
+}
+
+// AdminListUsersRequest 管理员用户列表请求
+//
+//	author centonhuang
+//	update 2025-11-10 15:32:08
+type AdminListUsersRequest struct {
+	CommonParam
+	Permission string `query:"permission" doc:"Filter by permission" enum:"reader,creator,admin"`
+	Status     string `query:"status" doc:"Filter by account status" enum:"active,suspended,banned"`
+}
+
+// AdminListUsersResponse 管理员用户列表响应
+//
+//	author centonhuang
+//	update 2025-11-10 15:32:08
+type AdminListUsersResponse struct {
+	Users    []*AdminUser `json:"users" doc:"List of users"`
+	PageInfo *PageInfo    `json:"pageInfo" doc:"Pagination information"`
+}
+
+// AdminGetUserRequest 管理员获取用户详情请求
+//
+//	author centonhuang
+//	update 2025-11-10 15:32:08
+type AdminGetUserRequest struct {
+	AdminUserPathParam
+}
+
+// AdminGetUserResponse 管理员获取用户详情响应
+//
+//	author centonhuang
+//	update 2025-11-10 15:32:08
+type AdminGetUserResponse struct {
+	User     *AdminUser    `json:"user" doc:"User details"`
+	Articles int64         `json:"articles" doc:"Number of articles"`
+	Comments int64         `json:"comments" doc:"Number of comments"`
+	Storage  *StorageUsage `json:"storage" doc:"Object storage usage"`
+}
+
+// AdminUpdateUserPermissionRequestBody 修改用户权限请求体
+//
+//	author centonhuang
+//	update 2025-11-10 15:32:08
+type AdminUpdateUserPermissionRequestBody struct {
+	Permission string `json:"permission" doc:"New permission" enum:"reader,creator,admin"`
+}
+
+// AdminUpdateUserPermissionRequest 修改用户权限请求
+//
+//	author centonhuang
+//	update 2025-11-10 15:32:08
+type AdminUpdateUserPermissionRequest struct {
+	AdminUserPathParam
+	Body *AdminUpdateUserPermissionRequestBody `json:"body" doc:"Permission to set"`
+}
+
+// AdminUpdateUserQuotaRequestBody 调整用户LLM配额请求体
+//
+//	author centonhuang
+//	update 2025-11-10 15:32:08
+type AdminUpdateUserQuotaRequestBody struct {
+	LLMQuota int `json:"llmQuota" doc:"New LLM quota" minimum:"0" maximum:"127"`
+}
+
+// AdminUpdateUserQuotaRequest 调整用户LLM配额请求
+//
+//	author centonhuang
+//	update 2025-11-10 15:32:08
+type AdminUpdateUserQuotaRequest struct {
+	AdminUserPathParam
+	Body *AdminUpdateUserQuotaRequestBody `json:"body" doc:"Quota to set"`
+}
+
+// AdminUpdateUserStatusRequestBody 修改用户账号状态请求体
+//
+//	author centonhuang
+//	update 2025-11-10 15:32:08
+type AdminUpdateUserStatusRequestBody struct {
+	Status       string `json:"status" doc:"New account status" enum:"active,suspended,banned"`
+	Reason       string `json:"reason,omitempty" doc:"Reason shown to the user" maxLength:"255"`
+	SuspendHours int    `json:"suspendHours,omitempty" doc:"Suspension duration in hours, required when suspending" minimum:"0" maximum:"8760"`
+}
+
+// AdminUpdateUserStatusRequest 修改用户账号状态请求
+//
+//	author centonhuang
+//	update 2025-11-10 15:32:08
+type AdminUpdateUserStatusRequest struct {
+	AdminUserPathParam
+	Body *AdminUpdateUserStatusRequestBody `json:"body" doc:"Status to set"`
+}
+
+// AdminListUserArticlesRequest 管理员获取用户文章列表请求
+//
+//	author centonhuang
+//	update 2025-11-10 15:32:08
+type AdminListUserArticlesRequest struct {
+	AdminUserPathParam
+	CommonParam
+}
+
+// AdminListUserArticlesResponse 管理员获取用户文章列表响应
+//
+//	author centonhuang
+//	update 2025-11-10 15:32:08
+type AdminListUserArticlesResponse struct {
+	Articles []*Article `json:"articles" doc:"List of articles"`
+	PageInfo *PageInfo  `json:"pageInfo" doc:"Pagination information"`
+}
+
+// AdminListUserCommentsRequest 管理员获取用户评论列表请求
+//
+//	author centonhuang
+//	update 2025-11-10 15:32:08
+type AdminListUserCommentsRequest struct {
+	AdminUserPathParam
+	CommonParam
+}
+
+// AdminListUserCommentsResponse 管理员获取用户评论列表响应
+//
+//	author centonhuang
+//	update 2025-11-10 15:32:08
+type AdminListUserCommentsResponse struct {
+	Comments []*Comment `json:"comments" doc:"List of comments"`
+	PageInfo *PageInfo  `json:"pageInfo" doc:"Pagination information"`
+}
@@ -4,7 +4,7 @@ package dto
 
 // TaskPathParam 任务路径参数
 type TaskPathParam struct {
-	TaskName string `path:"taskName" doc:"Task name" enum:"contentCompletion,articleSummary,articleTranslation,articleQA,termExplaination"`
+	TaskName string `path:"taskName" doc:"Task name" enum:"contentCompletion,articleSummary,articleTranslation,articleQA,termExplaination,articleTagSuggestion"`
 }
 
 // PromptVersionPathParam 提示词版本路径参数
@@ -86,6 +86,18 @@ type GenerateArticleSummaryRequest struct {
 	Body *GenerateArticleSummaryRequestBody `json:"body" doc:"Fields for article summary"`
 }
 
+// GenerateArticleTranslationRequestBody 生成文章翻译请求体
+type GenerateArticleTranslationRequestBody struct {
+	AIAppRequestBody
+	ArticleID      uint   `json:"articleID" doc:"Article ID to translate"`
+	TargetLanguage string `json:"targetLanguage" doc:"Target language of the translation" enum:"en,ja,ko,fr,de,es,ru"`
+}
+
+// GenerateArticleTranslationRequest 生成文章翻译请求
+type GenerateArticleTranslationRequest struct {
+	Body *GenerateArticleTranslationRequestBody `json:"body" doc:"Fields for article translation"`
+}
+
 // GenerateArticleQARequestBody 生成文章问答请求体
 type GenerateArticleQARequestBody struct {
 	AIAppRequestBody
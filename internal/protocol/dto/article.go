@@ -34,6 +34,7 @@ type ArticleSlugPathParam struct {
 // GetArticleRequest 获取文章详情请求
 type GetArticleRequest struct {
 	ArticlePathParam
+	LanguageParam
 }
 
 // GetArticleResponse 获取文章详情响应
@@ -44,6 +45,7 @@ type GetArticleResponse struct {
 // GetArticleBySlugRequest 通过别名获取文章请求
 type GetArticleBySlugRequest struct {
 	ArticleSlugPathParam
+	LanguageParam
 }
 
 // GetArticleBySlugResponse 通过别名获取文章响应
@@ -0,0 +1,23 @@
+package dto
+
+// GetArticleSuggestionRequest 获取文章AI建议请求
+type GetArticleSuggestionRequest struct {
+	ArticlePathParam
+}
+
+// GetArticleSuggestionResponse 获取文章AI建议响应
+type GetArticleSuggestionResponse struct {
+	Suggestion *ArticleSuggestion `json:"suggestion" doc:"Latest AI suggestion of the article"`
+}
+
+// ReviewArticleSuggestionRequestBody 审核文章AI建议请求体
+type ReviewArticleSuggestionRequestBody struct {
+	Action   string   `json:"action" doc:"Accept or reject the suggestion" enum:"accept,reject"`
+	TagSlugs []string `json:"tagSlugs,omitempty" doc:"Suggested tag slugs to accept, defaults to all suggested tags"`
+}
+
+// ReviewArticleSuggestionRequest 审核文章AI建议请求
+type ReviewArticleSuggestionRequest struct {
+	ArticlePathParam
+	Body *ReviewArticleSuggestionRequestBody `json:"body" doc:"Review decision"`
+}
@@ -11,9 +11,15 @@ type ArticleVersionPathParam struct {
 	Version uint `path:"version" doc:"Version number"`
 }
 
+// ArticleVersionLanguageQueryParam 文章版本语言查询参数
+type ArticleVersionLanguageQueryParam struct {
+	Lang string `query:"lang" doc:"Version language, defaults to the original language" enum:"zh,en,ja,ko,fr,de,es,ru"`
+}
+
 // CreateArticleVersionRequestBody 创建文章版本请求体
 type CreateArticleVersionRequestBody struct {
-	Content string `json:"content" doc:"Version content"`
+	Content  string `json:"content" doc:"Version content"`
+	Language string `json:"language,omitempty" doc:"Version language, defaults to the original language" enum:"zh,en,ja,ko,fr,de,es,ru"`
 }
 
 // CreateArticleVersionRequest 创建文章版本请求
@@ -36,6 +42,7 @@ type CreateArticleVersionResponse struct {
 // GetArticleVersionRequest 获取文章版本请求
 type GetArticleVersionRequest struct {
 	ArticleVersionPathParam
+	ArticleVersionLanguageQueryParam
 }
 
 // GetArticleVersionResponse 获取文章版本响应
@@ -48,6 +55,7 @@ type GetArticleVersionResponse struct {
 // GetLatestArticleVersionRequest 获取最新文章版本请求
 type GetLatestArticleVersionRequest struct {
 	ArticleVersionArticlePathParam
+	LanguageParam
 }
 
 // GetLatestArticleVersionResponse 获取最新文章版本响应
@@ -60,6 +68,7 @@ type GetLatestArticleVersionResponse struct {
 // ListArticleVersionsRequest 列出文章版本请求
 type ListArticleVersionsRequest struct {
 	ArticleVersionArticlePathParam
+	ArticleVersionLanguageQueryParam
 	CommonParam
 }
 
@@ -68,3 +77,41 @@ type ListArticleVersionsResponse struct {
 	Versions []*ArticleVersion `json:"versions" doc:"List of article versions"`
 	PageInfo *PageInfo         `json:"pageInfo" doc:"Pagination information"`
 }
+
+// ImportArticleVersionImagesRequest 导入文章版本外部图片请求
+//
+//	author centonhuang
+//	update 2025-11-18 16:05:42
+type ImportArticleVersionImagesRequest struct {
+	ArticleVersionPathParam
+	ArticleVersionLanguageQueryParam
+}
+
+// ImportedImageLink 已导入的外部图片链接
+//
+//	author centonhuang
+//	update 2025-11-18 16:05:42
+type ImportedImageLink struct {
+	URL        string `json:"url" doc:"Original external URL"`
+	ObjectName string `json:"objectName" doc:"Name of the stored image"`
+	Link       string `json:"link" doc:"Link that replaced the external URL in the content"`
+}
+
+// FailedImageLink 导入失败的外部图片链接，内容中保留原链接
+//
+//	author centonhuang
+//	update 2025-11-18 16:05:42
+type FailedImageLink struct {
+	URL    string `json:"url" doc:"Original external URL, kept unchanged in the content"`
+	Reason string `json:"reason" doc:"Why the image could not be imported"`
+}
+
+// ImportArticleVersionImagesResponse 导入文章版本外部图片响应
+//
+//	author centonhuang
+//	update 2025-11-18 16:05:42
+type ImportArticleVersionImagesResponse struct {
+	ArticleVersion *ArticleVersion      `json:"articleVersion,omitempty" doc:"New version with rewritten image links, omitted when no link was imported"`
+	Imported       []*ImportedImageLink `json:"imported" doc:"External images stored and rewritten"`
+	Failed         []*FailedImageLink   `json:"failed" doc:"External images left unchanged"`
+}
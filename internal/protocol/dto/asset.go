@@ -94,3 +94,102 @@ type GetImageRequest struct {
 type DeleteImageRequest struct {
 	ObjectPathParam
 }
+
+// PresignImageUploadRequestBody 申请图片直传请求体
+//
+//	author centonhuang
+//	update 2025-11-15 15:21:06
+type PresignImageUploadRequestBody struct {
+	FileName    string `json:"fileName" doc:"Image file name, used as the object name" minLength:"1" maxLength:"255"`
+	ContentType string `json:"contentType" doc:"Content type of the image, must be sent unchanged when uploading" enum:"image/png,image/jpeg,image/gif,image/webp"`
+	Size        int64  `json:"size" doc:"Exact size of the image in bytes, must match the uploaded body" minimum:"1"`
+}
+
+// PresignImageUploadRequest 申请图片直传请求
+//
+//	author centonhuang
+//	update 2025-11-15 15:21:06
+type PresignImageUploadRequest struct {
+	Body *PresignImageUploadRequestBody `json:"body" doc:"Image to upload"`
+}
+
+// PresignedUpload 预签名直传信息
+//
+//	author centonhuang
+//	update 2025-11-15 15:21:06
+type PresignedUpload struct {
+	Method    string            `json:"method" doc:"HTTP method to upload with"`
+	URL       string            `json:"url" doc:"Presigned upload URL"`
+	Headers   map[string]string `json:"headers" doc:"Headers that must be sent unchanged with the upload"`
+	ExpiresAt string            `json:"expiresAt" doc:"Time after which the URL is no longer accepted"`
+}
+
+// PresignImageUploadResponse 申请图片直传响应
+//
+//	author centonhuang
+//	update 2025-11-16 16:38:25
+type PresignImageUploadResponse struct {
+	ObjectName string           `json:"objectName" doc:"Object name to confirm after the upload finishes"`
+	UploadID   string           `json:"uploadID" doc:"Upload ID to send when confirming the upload"`
+	Upload     *PresignedUpload `json:"upload" doc:"Upload the image body straight to storage with this request"`
+}
+
+// ConfirmImageUploadRequestBody 确认图片直传请求体
+//
+//	author centonhuang
+//	update 2025-11-16 16:38:25
+type ConfirmImageUploadRequestBody struct {
+	UploadID string `json:"uploadID" doc:"Upload ID returned when the upload was presigned" format:"uuid"`
+}
+
+// ConfirmImageUploadRequest 确认图片直传请求
+//
+//	author centonhuang
+//	update 2025-11-16 16:38:25
+type ConfirmImageUploadRequest struct {
+	ObjectPathParam
+	Body *ConfirmImageUploadRequestBody `json:"body" doc:"Upload to confirm"`
+}
+
+// ConfirmImageUploadResponse 确认图片直传响应
+//
+//	author centonhuang
+//	update 2025-11-15 15:21:06
+type ConfirmImageUploadResponse struct {
+	Image *Image `json:"image" doc:"Uploaded image with metadata stripped, the thumbnail is generated in the background"`
+}
+
+// ImportImageRequestBody 从外部链接导入图片请求体
+//
+//	author centonhuang
+//	update 2025-11-18 16:05:42
+type ImportImageRequestBody struct {
+	URL        string `json:"url" doc:"Public http or https URL of the image" format:"uri" maxLength:"2048"`
+	ObjectName string `json:"objectName,omitempty" doc:"Image name to store as, derived from the URL when omitted; the extension follows the detected content" maxLength:"255"`
+}
+
+// ImportImageRequest 从外部链接导入图片请求
+//
+//	author centonhuang
+//	update 2025-11-18 16:05:42
+type ImportImageRequest struct {
+	Body *ImportImageRequestBody `json:"body" doc:"Image to import"`
+}
+
+// ImportImageResponse 从外部链接导入图片响应
+//
+//	author centonhuang
+//	update 2025-11-18 16:05:42
+type ImportImageResponse struct {
+	Image *Image `json:"image" doc:"Imported image with metadata stripped, the thumbnail is generated in the background"`
+}
+
+// GetImageVariantRequest 获取图片变体请求
+//
+//	author centonhuang
+//	update 2025-11-16 10:12:48
+type GetImageVariantRequest struct {
+	ObjectPathParam
+	Width  int    `query:"w" doc:"Requested width, rounded up to the nearest configured variant width; the largest variant when omitted" minimum:"0"`
+	Format string `query:"fmt" doc:"Output format" enum:"webp,avif,jpeg,png" default:"webp"`
+}
@@ -0,0 +1,94 @@
+package dto
+
+// AttachmentPathParam 文章附件路径参数
+type AttachmentPathParam struct {
+	ArticlePathParam
+	AttachmentID uint `path:"attachmentID" doc:"Attachment ID"`
+}
+
+// ListArticleAttachmentsRequest 列出文章附件请求
+//
+//	author centonhuang
+//	update 2025-11-18 10:21:37
+type ListArticleAttachmentsRequest struct {
+	ArticlePathParam
+}
+
+// ListArticleAttachmentsResponse 列出文章附件响应
+//
+//	author centonhuang
+//	update 2025-11-18 10:21:37
+type ListArticleAttachmentsResponse struct {
+	Attachments []*Attachment `json:"attachments" doc:"Attachments in upload order"`
+}
+
+// PresignAttachmentUploadRequestBody 申请附件直传请求体
+//
+//	author centonhuang
+//	update 2025-11-18 10:21:37
+type PresignAttachmentUploadRequestBody struct {
+	FileName string `json:"fileName" doc:"Attachment file name, its extension decides the allowed content and size limit" minLength:"1" maxLength:"255"`
+	Size     int64  `json:"size" doc:"Exact size of the file in bytes, must match the uploaded body" minimum:"1"`
+}
+
+// PresignAttachmentUploadRequest 申请附件直传请求
+//
+//	author centonhuang
+//	update 2025-11-18 10:21:37
+type PresignAttachmentUploadRequest struct {
+	ArticlePathParam
+	Body *PresignAttachmentUploadRequestBody `json:"body" doc:"Attachment to upload"`
+}
+
+// PresignAttachmentUploadResponse 申请附件直传响应
+//
+//	author centonhuang
+//	update 2025-11-18 10:21:37
+type PresignAttachmentUploadResponse struct {
+	FileName string           `json:"fileName" doc:"File name to confirm after the upload finishes"`
+	UploadID string           `json:"uploadID" doc:"Upload ID to send when confirming the upload"`
+	Upload   *PresignedUpload `json:"upload" doc:"Upload the file body straight to storage with this request"`
+}
+
+// ConfirmAttachmentUploadRequestBody 确认附件直传请求体
+//
+//	author centonhuang
+//	update 2025-11-18 10:21:37
+type ConfirmAttachmentUploadRequestBody struct {
+	FileName string `json:"fileName" doc:"File name returned when the upload was presigned" minLength:"1" maxLength:"255"`
+	UploadID string `json:"uploadID" doc:"Upload ID returned when the upload was presigned" format:"uuid"`
+}
+
+// ConfirmAttachmentUploadRequest 确认附件直传请求
+//
+//	author centonhuang
+//	update 2025-11-18 10:21:37
+type ConfirmAttachmentUploadRequest struct {
+	ArticlePathParam
+	Body *ConfirmAttachmentUploadRequestBody `json:"body" doc:"Upload to confirm"`
+}
+
+// ConfirmAttachmentUploadResponse 确认附件直传响应
+//
+//	author centonhuang
+//	update 2025-11-18 10:21:37
+type ConfirmAttachmentUploadResponse struct {
+	Attachment *Attachment `json:"attachment" doc:"Uploaded attachment, the preview is generated in the background"`
+}
+
+// GetAttachmentRequest 获取附件请求
+//
+//	author centonhuang
+//	update 2025-11-18 10:21:37
+type GetAttachmentRequest struct {
+	AttachmentPathParam
+	Preview bool `query:"preview" doc:"Return the preview image instead of the file"`
+}
+
+// DeleteAttachmentRequest 删除附件请求
+//
+//	author centonhuang
+//	update 2025-11-18 10:21:37
+type DeleteAttachmentRequest struct {
+	AttachmentPathParam
+}
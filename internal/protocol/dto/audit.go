@@ -0,0 +1,42 @@
+package dto
+
+import "time"
+
+// AuditEventFilterParam 审计事件过滤参数
+//
+//	author centonhuang
+//	update 2025-11-11 14:48:02
+type AuditEventFilterParam struct {
+	ActorID    uint      `query:"actorID" doc:"Filter by actor user ID"`
+	Action     string    `query:"action" doc:"Filter by action, e.g. user.permission.update"`
+	TargetType string    `query:"targetType" doc:"Filter by target type" enum:"user,creator_application,article,tag,prompt"`
+	TargetID   uint      `query:"targetID" doc:"Filter by target ID, usually combined with targetType"`
+	Since      time.Time `query:"since" doc:"Only events created at or after this time (RFC 3339)"`
+	Until      time.Time `query:"until" doc:"Only events created before this time (RFC 3339)"`
+}
+
+// ListAuditEventsRequest 审计事件列表请求
+//
+//	author centonhuang
+//	update 2025-11-11 14:48:02
+type ListAuditEventsRequest struct {
+	PageParam
+	AuditEventFilterParam
+}
+
+// ListAuditEventsResponse 审计事件列表响应
+//
+//	author centonhuang
+//	update 2025-11-11 14:48:02
+type ListAuditEventsResponse struct {
+	Events   []*AuditEvent `json:"events" doc:"List of audit events, newest first"`
+	PageInfo *PageInfo     `json:"pageInfo" doc:"Pagination information"`
+}
+
+// ExportAuditEventsRequest 导出审计事件请求
+//
+//	author centonhuang
+//	update 2025-11-11 14:48:02
+type ExportAuditEventsRequest struct {
+	AuditEventFilterParam
+}
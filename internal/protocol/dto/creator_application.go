@@ -0,0 +1,78 @@
+package dto
+
+// CreatorApplicationPathParam 创作者申请路径参数
+//
+//	author centonhuang
+//	update 2025-11-11 10:20:36
+type CreatorApplicationPathParam struct {
+	ApplicationID uint `path:"applicationID" doc:"Creator application ID"`
+}
+
+// SubmitCreatorApplicationRequestBody 提交创作者申请请求体
+//
+//	author centonhuang
+//	update 2025-11-11 10:20:36
+type SubmitCreatorApplicationRequestBody struct {
+	Reason string   `json:"reason" doc:"Why the user wants to become a creator" minLength:"10" maxLength:"2000"`
+	Links  []string `json:"links,omitempty" doc:"Links to previous works, must be http or https URLs" maxItems:"10"`
+}
+
+// SubmitCreatorApplicationRequest 提交创作者申请请求
+//
+//	author centonhuang
+//	update 2025-11-11 10:20:36
+type SubmitCreatorApplicationRequest struct {
+	Body *SubmitCreatorApplicationRequestBody `json:"body" doc:"Application content"`
+}
+
+// SubmitCreatorApplicationResponse 提交创作者申请响应
+//
+//	author centonhuang
+//	update 2025-11-11 10:20:36
+type SubmitCreatorApplicationResponse struct {
+	Application *CreatorApplication `json:"application" doc:"Submitted application"`
+}
+
+// GetCurrentCreatorApplicationResponse 获取当前用户创作者申请响应
+//
+//	author centonhuang
+//	update 2025-11-11 10:20:36
+type GetCurrentCreatorApplicationResponse struct {
+	Application *CreatorApplication `json:"application" doc:"Latest application of the current user"`
+}
+
+// ListCreatorApplicationsRequest 创作者申请列表请求
+//
+//	author centonhuang
+//	update 2025-11-11 10:20:36
+type ListCreatorApplicationsRequest struct {
+	PageParam
+	Status string `query:"status" doc:"Filter by application status" enum:"pending,approved,rejected"`
+}
+
+// ListCreatorApplicationsResponse 创作者申请列表响应
+//
+//	author centonhuang
+//	update 2025-11-11 10:20:36
+type ListCreatorApplicationsResponse struct {
+	Applications []*CreatorApplication `json:"applications" doc:"List of applications"`
+	PageInfo     *PageInfo             `json:"pageInfo" doc:"Pagination information"`
+}
+
+// ReviewCreatorApplicationRequestBody 审核创作者申请请求体
+//
+//	author centonhuang
+//	update 2025-11-11 10:20:36
+type ReviewCreatorApplicationRequestBody struct {
+	Action  string `json:"action" doc:"Review action" enum:"approve,reject"`
+	Comment string `json:"comment,omitempty" doc:"Review comment shown to the applicant" maxLength:"500"`
+}
+
+// ReviewCreatorApplicationRequest 审核创作者申请请求
+//
+//	author centonhuang
+//	update 2025-11-11 10:20:36
+type ReviewCreatorApplicationRequest struct {
+	CreatorApplicationPathParam
+	Body *ReviewCreatorApplicationRequestBody `json:"body" doc:"Review decision"`
+}
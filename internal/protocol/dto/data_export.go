@@ -0,0 +1,17 @@
+package dto
+
+// RequestDataExportResponse 申请数据导出响应
+//
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+type RequestDataExportResponse struct {
+	Export *DataExport `json:"export" doc:"Created export, the archive is built in the background"`
+}
+
+// GetCurrentDataExportResponse 获取当前用户数据导出响应
+//
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+type GetCurrentDataExportResponse struct {
+	Export *DataExport `json:"export" doc:"Latest export of the current user"`
+}
@@ -0,0 +1,33 @@
+package dto
+
+// JWK JSON Web Key公钥
+//
+//	author centonhuang
+//	update 2025-11-12 10:24:06
+type JWK struct {
+	Kty string `json:"kty" doc:"Key type, RSA or OKP"`
+	Kid string `json:"kid" doc:"Key ID referenced by the kid header of issued tokens"`
+	Use string `json:"use" doc:"Public key use, always sig"`
+	Alg string `json:"alg" doc:"Signing algorithm, RS256 or EdDSA"`
+	N   string `json:"n,omitempty" doc:"RSA modulus"`
+	E   string `json:"e,omitempty" doc:"RSA public exponent"`
+	Crv string `json:"crv,omitempty" doc:"Curve of the OKP key"`
+	X   string `json:"x,omitempty" doc:"OKP public key"`
+}
+
+// JWKS JSON Web Key集合
+//
+//	author centonhuang
+//	update 2025-11-12 10:24:06
+type JWKS struct {
+	Keys []*JWK `json:"keys" doc:"Public keys that can verify tokens issued by this service"`
+}
+
+// GetJWKSResponse 获取JWKS响应，按RFC 7517格式直接返回密钥集合
+//
+//	author centonhuang
+//	update 2025-11-12 10:24:06
+type GetJWKSResponse struct {
+	CacheControl string `header:"Cache-Control"`
+	Body         *JWKS
+}
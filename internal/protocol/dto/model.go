@@ -4,15 +4,59 @@ package dto
 // User represents a user entity
 //
 //	author centonhuang
-//	update 2025-01-05 11:37:01
+//	update 2025-11-17 10:26:42
 type User struct {
-	UserID     uint   `json:"userID" doc:"Unique identifier for the user"`
-	Name       string `json:"name" doc:"Display name of the user"`
-	Email      string `json:"email,omitempty" doc:"Email address of the user"`
-	Avatar     string `json:"avatar" doc:"URL or path to the user's avatar image"`
-	CreatedAt  string `json:"createdAt,omitempty" doc:"Timestamp when the user account was created"`
-	LastLogin  string `json:"lastLogin,omitempty" doc:"Timestamp of the user's last login"`
-	Permission string `json:"permission,omitempty" doc:"Permission level of the user"`
+	UserID          uint          `json:"userID" doc:"Unique identifier for the user"`
+	Name            string        `json:"name" doc:"Display name of the user"`
+	Email           string        `json:"email,omitempty" doc:"Email address of the user"`
+	Avatar          string        `json:"avatar" doc:"URL or path to the user's avatar image"`
+	CreatedAt       string        `json:"createdAt,omitempty" doc:"Timestamp when the user account was created"`
+	LastLogin       string        `json:"lastLogin,omitempty" doc:"Timestamp of the user's last login"`
+	Permission      string        `json:"permission,omitempty" doc:"Permission level of the user"`
+	LinkedProviders []string      `json:"linkedProviders,omitempty" doc:"OAuth2 providers linked to the user, each one can be used to log in"`
+	Storage         *StorageQuota `json:"storage,omitempty" doc:"Storage used by images and thumbnails against the quota of the permission level"`
+}
+
+// StorageQuota 存储配额与用量
+//
+//	author centonhuang
+//	update 2025-11-17 10:26:42
+type StorageQuota struct {
+	UsedBytes      int64 `json:"usedBytes" doc:"Bytes used by images and thumbnails"`
+	QuotaBytes     int64 `json:"quotaBytes" doc:"Storage quota of the permission level in bytes"`
+	RemainingBytes int64 `json:"remainingBytes" doc:"Bytes that can still be uploaded"`
+}
+
+// AdminUser 管理员视角的用户信息
+//
+//	author centonhuang
+//	update 2025-11-10 15:32:08
+type AdminUser struct {
+	UserID         uint     `json:"userID" doc:"Unique identifier for the user"`
+	Name           string   `json:"name" doc:"Display name of the user"`
+	Email          string   `json:"email" doc:"Email address of the user"`
+	Avatar         string   `json:"avatar" doc:"URL or path to the user's avatar image"`
+	Permission     string   `json:"permission" doc:"Permission level of the user"`
+	Roles          []string `json:"roles" doc:"Names of the roles held by the user"`
+	LLMQuota       int      `json:"llmQuota" doc:"Remaining LLM quota"`
+	Status         string   `json:"status" doc:"Account status"`
+	StatusReason   string   `json:"statusReason,omitempty" doc:"Reason of the latest status change"`
+	SuspendedUntil string   `json:"suspendedUntil,omitempty" doc:"Suspension end time"`
+	CreatedAt      string   `json:"createdAt" doc:"Timestamp when the user account was created"`
+	LastLogin      string   `json:"lastLogin" doc:"Timestamp of the user's last login"`
+}
+
+// StorageUsage 对象存储用量
+//
+//	author centonhuang
+//	update 2025-11-17 10:26:42
+type StorageUsage struct {
+	Images         int   `json:"images" doc:"Number of images"`
+	ImageBytes     int64 `json:"imageBytes" doc:"Total size of images in bytes"`
+	Thumbnails     int   `json:"thumbnails" doc:"Number of thumbnails"`
+	ThumbnailBytes int64 `json:"thumbnailBytes" doc:"Total size of thumbnails in bytes"`
+	TrackedBytes   int64 `json:"trackedBytes" doc:"Usage counted against the quota, differs from the listed total when it has drifted"`
+	QuotaBytes     int64 `json:"quotaBytes" doc:"Storage quota of the permission level in bytes"`
 }
 
 // Tag 标签信息
@@ -34,18 +78,20 @@ type Tag struct {
 //	author centonhuang
 //	update 2025-10-31 05:36:00
 type Article struct {
-	ArticleID   uint   `json:"articleID" doc:"Article ID"`
-	Title       string `json:"title" doc:"Article title"`
-	Slug        string `json:"slug" doc:"Article slug"`
-	Status      string `json:"status" doc:"Article status"`
-	User        *User  `json:"user" doc:"Author information"`
-	CreatedAt   string `json:"createdAt" doc:"Creation timestamp"`
-	UpdatedAt   string `json:"updatedAt" doc:"Update timestamp"`
-	PublishedAt string `json:"publishedAt" doc:"Publication timestamp"`
-	Likes       uint   `json:"likes" doc:"Number of likes"`
-	Views       uint   `json:"views" doc:"Number of views"`
-	Tags        []*Tag `json:"tags" doc:"List of tags"`
-	Comments    int    `json:"comments" doc:"Number of comments"`
+	ArticleID   uint     `json:"articleID" doc:"Article ID"`
+	Title       string   `json:"title" doc:"Article title"`
+	Slug        string   `json:"slug" doc:"Article slug"`
+	Status      string   `json:"status" doc:"Article status"`
+	User        *User    `json:"user" doc:"Author information"`
+	CreatedAt   string   `json:"createdAt" doc:"Creation timestamp"`
+	UpdatedAt   string   `json:"updatedAt" doc:"Update timestamp"`
+	PublishedAt string   `json:"publishedAt" doc:"Publication timestamp"`
+	Likes       uint     `json:"likes" doc:"Number of likes"`
+	Views       uint     `json:"views" doc:"Number of views"`
+	Tags        []*Tag   `json:"tags" doc:"List of tags"`
+	Comments    int      `json:"comments" doc:"Number of comments"`
+	Languages   []string `json:"languages" doc:"Languages that have article versions"`
+	Language    string   `json:"language,omitempty" doc:"Negotiated language for reading"`
 }
 
 // ArticleVersion 文章版本信息
@@ -56,11 +102,64 @@ type ArticleVersion struct {
 	ArticleVersionID uint   `json:"versionID" doc:"Version ID"`
 	ArticleID        uint   `json:"articleID" doc:"Article ID"`
 	VersionID        uint   `json:"version" doc:"Version number"`
+	Language         string `json:"language" doc:"Version language"`
 	Content          string `json:"content" doc:"Version content"`
+	Summary          string `json:"summary,omitempty" doc:"Version summary"`
+	CreatedAt        string `json:"createdAt" doc:"Creation timestamp"`
+	UpdatedAt        string `json:"updatedAt" doc:"Update timestamp"`
+}
+
+// ArticleSuggestion 文章AI建议信息
+//
+//	author centonhuang
+//	update 2025-11-09 16:40:12
+type ArticleSuggestion struct {
+	SuggestionID     uint   `json:"suggestionID" doc:"Suggestion ID"`
+	ArticleID        uint   `json:"articleID" doc:"Article ID"`
+	ArticleVersionID uint   `json:"articleVersionID" doc:"Article version ID the suggestion is generated from"`
+	Status           string `json:"status" doc:"Suggestion status"`
+	Summary          string `json:"summary" doc:"Generated summary"`
+	Tags             []*Tag `json:"tags" doc:"Suggested tags"`
+	Retries          uint   `json:"retries" doc:"Number of failed attempts"`
+	LastError        string `json:"lastError,omitempty" doc:"Last failure reason"`
 	CreatedAt        string `json:"createdAt" doc:"Creation timestamp"`
 	UpdatedAt        string `json:"updatedAt" doc:"Update timestamp"`
 }
 
+// CreatorApplication 创作者申请信息
+//
+//	author centonhuang
+//	update 2025-11-11 10:20:36
+type CreatorApplication struct {
+	ApplicationID uint     `json:"applicationID" doc:"Application ID"`
+	User          *User    `json:"user,omitempty" doc:"Applicant information"`
+	Reason        string   `json:"reason" doc:"Application reason"`
+	Links         []string `json:"links" doc:"Links to previous works"`
+	Status        string   `json:"status" doc:"Application status"`
+	ReviewComment string   `json:"reviewComment,omitempty" doc:"Review comment"`
+	CreatedAt     string   `json:"createdAt" doc:"Submission timestamp"`
+	ReviewedAt    string   `json:"reviewedAt,omitempty" doc:"Review timestamp"`
+	ReapplyAt     string   `json:"reapplyAt,omitempty" doc:"Earliest time to reapply after rejection"`
+}
+
+// AuditEvent 审计事件信息
+//
+//	author centonhuang
+//	update 2025-11-11 14:48:02
+type AuditEvent struct {
+	EventID    uint           `json:"eventID" doc:"Audit event ID"`
+	ActorID    uint           `json:"actorID" doc:"User ID of the actor"`
+	Action     string         `json:"action" doc:"Action performed"`
+	TargetType string         `json:"targetType" doc:"Type of the target"`
+	TargetID   uint           `json:"targetID" doc:"ID of the target"`
+	Before     map[string]any `json:"before,omitempty" doc:"Changed fields before the action"`
+	After      map[string]any `json:"after,omitempty" doc:"Changed fields after the action"`
+	IP         string         `json:"ip" doc:"Client IP"`
+	UserAgent  string         `json:"userAgent" doc:"Client user agent"`
+	TraceID    string         `json:"traceID" doc:"Trace ID of the request"`
+	CreatedAt  string         `json:"createdAt" doc:"Creation timestamp"`
+}
+
 // UserView 用户浏览
 type UserView struct {
 	ViewID       uint   `json:"viewID" doc:"View record ID"`
@@ -72,9 +171,41 @@ type UserView struct {
 
 // Image 图片信息
 type Image struct {
-	Name      string `json:"name" doc:"Image name"`
-	Size      int64  `json:"size" doc:"Image size in bytes"`
-	CreatedAt string `json:"createdAt" doc:"Creation timestamp"`
+	Name          string         `json:"name" doc:"Image name"`
+	Size          int64          `json:"size" doc:"Image size in bytes, after metadata was stripped"`
+	ContentType   string         `json:"contentType" doc:"Content type of the image"`
+	Width         int            `json:"width" doc:"Width in pixels"`
+	Height        int            `json:"height" doc:"Height in pixels"`
+	Hash          string         `json:"hash" doc:"SHA-256 of the stored content, hex encoded"`
+	BlurHash      string         `json:"blurHash" doc:"BlurHash placeholder to show while the image loads"`
+	DominantColor string         `json:"dominantColor" doc:"Average color of the image as #rrggbb"`
+	CreatedAt     string         `json:"createdAt" doc:"Creation timestamp"`
+	SrcSets       []*ImageSrcSet `json:"srcSets,omitempty" doc:"Responsive variants per format, ready for <source type srcset> in a <picture> element"`
+}
+
+// Attachment 文章附件信息
+//
+//	author centonhuang
+//	update 2025-11-18 10:21:37
+type Attachment struct {
+	AttachmentID uint   `json:"attachmentID" doc:"Attachment ID"`
+	ArticleID    uint   `json:"articleID" doc:"Article ID"`
+	FileName     string `json:"fileName" doc:"File name"`
+	Kind         string `json:"kind" doc:"Attachment kind" enum:"document,audio,video,archive"`
+	ContentType  string `json:"contentType" doc:"Content type detected from the file content"`
+	Size         int64  `json:"size" doc:"File size in bytes"`
+	HasPreview   bool   `json:"hasPreview" doc:"Whether a preview image (PDF first page image, audio or video cover art) is available"`
+	CreatedAt    string `json:"createdAt" doc:"Creation timestamp"`
+}
+
+// ImageSrcSet 图片某一格式的响应式变体
+//
+//	author centonhuang
+//	update 2025-11-16 10:12:48
+type ImageSrcSet struct {
+	Format string `json:"format" doc:"Variant format"`
+	Type   string `json:"type" doc:"Content type of the variants"`
+	SrcSet string `json:"srcSet" doc:"Comma separated variant URLs with width descriptors"`
 }
 
 // Template 提示词模板
@@ -92,3 +223,75 @@ type Prompt struct {
 	Templates []Template `json:"templates" doc:"Prompt templates"`
 	Variables []string   `json:"variables,omitempty" doc:"Template variables"`
 }
+
+// Role 角色
+//
+//	author centonhuang
+//	update 2025-11-11 17:05:24
+type Role struct {
+	RoleID      uint     `json:"roleID" doc:"Unique identifier for the role"`
+	Name        string   `json:"name" doc:"Role name"`
+	Description string   `json:"description" doc:"Role description"`
+	Scopes      []string `json:"scopes" doc:"Permissions granted by the role"`
+	BuiltIn     bool     `json:"builtIn" doc:"Whether the role is a builtin role that cannot be deleted"`
+	CreatedAt   string   `json:"createdAt" doc:"Timestamp when the role was created"`
+	UpdatedAt   string   `json:"updatedAt" doc:"Timestamp when the role was last updated"`
+}
+
+// Session 登录会话
+//
+//	author centonhuang
+//	update 2025-11-11 20:12:40
+type Session struct {
+	SessionID  uint   `json:"sessionID" doc:"Unique identifier for the session"`
+	IP         string `json:"ip" doc:"Client IP of the latest token refresh"`
+	UserAgent  string `json:"userAgent" doc:"Client user agent of the latest token refresh"`
+	Current    bool   `json:"current" doc:"Whether the session issued the token of this request"`
+	CreatedAt  string `json:"createdAt" doc:"Timestamp when the session was created"`
+	LastUsedAt string `json:"lastUsedAt" doc:"Timestamp when the session was last refreshed"`
+	ExpiresAt  string `json:"expiresAt" doc:"Timestamp when the session expires"`
+}
+
+// PersonalAccessToken 个人访问令牌
+//
+//	author centonhuang
+//	update 2025-11-12 14:02:51
+type PersonalAccessToken struct {
+	TokenID    uint     `json:"tokenID" doc:"Unique identifier for the token"`
+	Name       string   `json:"name" doc:"Token name"`
+	Prefix     string   `json:"prefix" doc:"Leading characters of the token to help recognize it"`
+	Scopes     []string `json:"scopes" doc:"Permissions granted to the token"`
+	Expired    bool     `json:"expired" doc:"Whether the token has expired"`
+	CreatedAt  string   `json:"createdAt" doc:"Timestamp when the token was created"`
+	ExpiresAt  string   `json:"expiresAt" doc:"Timestamp when the token expires"`
+	LastUsedAt string   `json:"lastUsedAt,omitempty" doc:"Timestamp when the token was last used"`
+	LastUsedIP string   `json:"lastUsedIP,omitempty" doc:"Client IP that last used the token"`
+}
+
+// DataExport 用户数据导出
+//
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+type DataExport struct {
+	ExportID    uint   `json:"exportID" doc:"Unique identifier for the export"`
+	Status      string `json:"status" doc:"Export status" enum:"pending,failed,ready,expired"`
+	Size        int64  `json:"size,omitempty" doc:"Size of the archive in bytes"`
+	DownloadURL string `json:"downloadURL,omitempty" doc:"Presigned URL to download the archive, valid for a few minutes"`
+	CreatedAt   string `json:"createdAt" doc:"Timestamp when the export was requested"`
+	ReadyAt     string `json:"readyAt,omitempty" doc:"Timestamp when the archive was built"`
+	ExpiresAt   string `json:"expiresAt,omitempty" doc:"Timestamp when the archive will be deleted"`
+}
+
+// AccountDeletion 账号注销申请
+//
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+type AccountDeletion struct {
+	DeletionID         uint   `json:"deletionID" doc:"Unique identifier for the deletion request"`
+	Status             string `json:"status" doc:"Deletion status" enum:"pending,cancelled,completed"`
+	ArticleDisposition string `json:"articleDisposition" doc:"What happens to the user's articles" enum:"delete,transfer"`
+	TransferToUserID   uint   `json:"transferToUserID,omitempty" doc:"User who receives the articles when they are transferred"`
+	Reason             string `json:"reason,omitempty" doc:"Reason given by the user"`
+	CreatedAt          string `json:"createdAt" doc:"Timestamp when the deletion was requested"`
+	ScheduledAt        string `json:"scheduledAt" doc:"Timestamp when the account will be deleted, it can be cancelled before then"`
+}
@@ -6,7 +6,8 @@ package dto
 //	author centonhuang
 //	update 2025-01-05 21:00:00
 type LoginRequest struct {
-	Provider string `json:"provider" path:"provider" enum:"github,google" doc:"OAuth2 provider name (github or google)"`
+	Provider    string `json:"provider" path:"provider" pattern:"^[a-z0-9]+$" doc:"OAuth2 provider name, github, google, qq or a configured OIDC provider"`
+	RedirectURL string `json:"redirectURL" query:"redirectURL" maxLength:"512" doc:"Relative path to return to after the flow completes, echoed back by the callback"`
 }
 
 // LoginResponse represents the response containing the OAuth2 authorization URL
@@ -22,9 +23,9 @@ type LoginResponse struct {
 //	author centonhuang
 //	update 2025-01-05 21:00:00
 type CallbackRequest struct {
-	Provider string `json:"provider" path:"provider" enum:"github,google" doc:"OAuth2 provider name (github or google)"`
+	Provider string `json:"provider" path:"provider" pattern:"^[a-z0-9]+$" doc:"OAuth2 provider name, github, google, qq or a configured OIDC provider"`
 	Code     string `json:"code" query:"code" doc:"Authorization code returned by the OAuth2 provider"`
-	State    string `json:"state" query:"state" doc:"State parameter for CSRF protection, must match the initial state"`
+	State    string `json:"state" query:"state" doc:"One-time state parameter issued by the login endpoint for CSRF protection"`
 }
 
 // CallbackResponse represents the response containing access and refresh tokens after successful OAuth2 authentication
@@ -34,4 +35,31 @@ type CallbackRequest struct {
 type CallbackResponse struct {
 	AccessToken  string `json:"accessToken" doc:"JWT access token for API authentication"`
 	RefreshToken string `json:"refreshToken" doc:"JWT refresh token for obtaining future access tokens"`
+	RedirectURL  string `json:"redirectURL,omitempty" doc:"Relative path passed to the login endpoint to return to after login"`
+}
+
+// LinkOauth2Request represents a request to link another OAuth2 provider account to the current user
+//
+//	author centonhuang
+//	update 2025-11-13 10:12:37
+type LinkOauth2Request struct {
+	Provider string                 `json:"provider" path:"provider" pattern:"^[a-z0-9]+$" doc:"OAuth2 provider name, github, google, qq or a configured OIDC provider"`
+	Body     *LinkOauth2RequestBody `json:"body" doc:"Authorization result returned by the OAuth2 provider"`
+}
+
+// LinkOauth2RequestBody contains the authorization code and state returned by the OAuth2 provider
+//
+//	author centonhuang
+//	update 2025-11-13 10:12:37
+type LinkOauth2RequestBody struct {
+	Code  string `json:"code" doc:"Authorization code returned by the OAuth2 provider"`
+	State string `json:"state" doc:"One-time state parameter issued by the link login endpoint for CSRF protection"`
+}
+
+// UnlinkOauth2Request represents a request to unlink an OAuth2 provider from the current user
+//
+//	author centonhuang
+//	update 2025-11-13 10:12:37
+type UnlinkOauth2Request struct {
+	Provider string `json:"provider" path:"provider" pattern:"^[a-z0-9]+$" doc:"OAuth2 provider name, github, google, qq or a configured OIDC provider"`
 }
@@ -35,3 +35,12 @@ type PageInfo struct {
 	PageSize int   `json:"pageSize" doc:"Items per page"`
 	Total    int64 `json:"total" doc:"Total items"`
 }
+
+// LanguageParam 语言协商参数
+//
+//	author centonhuang
+//	update 2025-11-09 10:12:36
+type LanguageParam struct {
+	Lang           string `query:"lang" doc:"Preferred language, takes precedence over Accept-Language" enum:"zh,en,ja,ko,fr,de,es,ru"`
+	AcceptLanguage string `header:"Accept-Language" doc:"Preferred languages of the client"`
+}
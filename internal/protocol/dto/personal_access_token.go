@@ -0,0 +1,44 @@
+package dto
+
+// ListPersonalAccessTokensResponse 个人访问令牌列表响应
+//
+//	author centonhuang
+//	update 2025-11-12 14:02:51
+type ListPersonalAccessTokensResponse struct {
+	Tokens []*PersonalAccessToken `json:"tokens" doc:"Personal access tokens that have not been revoked"`
+}
+
+// CreatePersonalAccessTokenRequestBody 创建个人访问令牌请求体
+//
+//	author centonhuang
+//	update 2025-11-12 14:02:51
+type CreatePersonalAccessTokenRequestBody struct {
+	Name          string   `json:"name" doc:"Token name, e.g. the CI pipeline using it" minLength:"1" maxLength:"64"`
+	Scopes        []string `json:"scopes" doc:"Permissions granted to the token, must be held by the current user"`
+	ExpiresInDays int      `json:"expiresInDays" doc:"Days until the token expires" minimum:"1" maximum:"366"`
+}
+
+// CreatePersonalAccessTokenRequest 创建个人访问令牌请求
+//
+//	author centonhuang
+//	update 2025-11-12 14:02:51
+type CreatePersonalAccessTokenRequest struct {
+	Body *CreatePersonalAccessTokenRequestBody `json:"body" doc:"Token to create"`
+}
+
+// CreatePersonalAccessTokenResponse 创建个人访问令牌响应
+//
+//	author centonhuang
+//	update 2025-11-12 14:02:51
+type CreatePersonalAccessTokenResponse struct {
+	Token      *PersonalAccessToken `json:"token" doc:"Created token"`
+	PlainToken string               `json:"plainToken" doc:"The token value, only returned once. Send it as 'Authorization: Bearer <token>'"`
+}
+
+// RevokePersonalAccessTokenRequest 吊销个人访问令牌请求
+//
+//	author centonhuang
+//	update 2025-11-12 14:02:51
+type RevokePersonalAccessTokenRequest struct {
+	TokenID uint `path:"tokenID" doc:"Token ID"`
+}
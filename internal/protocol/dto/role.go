@@ -0,0 +1,94 @@
+package dto
+
+// RolePathParam 角色路径参数
+//
+//	author centonhuang
+//	update 2025-11-11 17:05:24
+type RolePathParam struct {
+	RoleID uint `path:"roleID" doc:"Role ID"`
+}
+
+// ListRolesResponse 角色列表响应
+//
+//	author centonhuang
+//	update 2025-11-11 17:05:24
+type ListRolesResponse struct {
+	Roles []*Role `json:"roles" doc:"List of roles"`
+}
+
+// ListScopesResponse 权限项列表响应
+//
+//	author centonhuang
+//	update 2025-11-11 17:05:24
+type ListScopesResponse struct {
+	Scopes []string `json:"scopes" doc:"All permissions that can be granted to a role"`
+}
+
+// CreateRoleRequestBody 创建角色请求体
+//
+//	author centonhuang
+//	update 2025-11-11 17:05:24
+type CreateRoleRequestBody struct {
+	Name        string   `json:"name" doc:"Role name" minLength:"1" maxLength:"64" pattern:"^[a-z][a-z0-9_-]*$"`
+	Description string   `json:"description,omitempty" doc:"Role description" maxLength:"255"`
+	Scopes      []string `json:"scopes" doc:"Permissions granted by the role"`
+}
+
+// CreateRoleRequest 创建角色请求
+//
+//	author centonhuang
+//	update 2025-11-11 17:05:24
+type CreateRoleRequest struct {
+	Body *CreateRoleRequestBody `json:"body" doc:"Role to create"`
+}
+
+// CreateRoleResponse 创建角色响应
+//
+//	author centonhuang
+//	update 2025-11-11 17:05:24
+type CreateRoleResponse struct {
+	Role *Role `json:"role" doc:"Created role"`
+}
+
+// UpdateRoleRequestBody 更新角色请求体
+//
+//	author centonhuang
+//	update 2025-11-11 17:05:24
+type UpdateRoleRequestBody struct {
+	Description *string  `json:"description,omitempty" doc:"New role description" maxLength:"255"`
+	Scopes      []string `json:"scopes,omitempty" doc:"New permissions granted by the role"`
+}
+
+// UpdateRoleRequest 更新角色请求
+//
+//	author centonhuang
+//	update 2025-11-11 17:05:24
+type UpdateRoleRequest struct {
+	RolePathParam
+	Body *UpdateRoleRequestBody `json:"body" doc:"Fields to update"`
+}
+
+// DeleteRoleRequest 删除角色请求
+//
+//	author centonhuang
+//	update 2025-11-11 17:05:24
+type DeleteRoleRequest struct {
+	RolePathParam
+}
+
+// AdminUpdateUserRolesRequestBody 设置用户角色请求体
+//
+//	author centonhuang
+//	update 2025-11-11 17:05:24
+type AdminUpdateUserRolesRequestBody struct {
+	Roles []string `json:"roles" doc:"Names of the roles to hold, replacing the current ones"`
+}
+
+// AdminUpdateUserRolesRequest 设置用户角色请求
+//
+//	author centonhuang
+//	update 2025-11-11 17:05:24
+type AdminUpdateUserRolesRequest struct {
+	AdminUserPathParam
+	Body *AdminUpdateUserRolesRequestBody `json:"body" doc:"Roles to set"`
+}
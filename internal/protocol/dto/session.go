@@ -0,0 +1,25 @@
+package dto
+
+// ListSessionsResponse 会话列表响应
+//
+//	author centonhuang
+//	update 2025-11-11 20:12:40
+type ListSessionsResponse struct {
+	Sessions []*Session `json:"sessions" doc:"Active sessions of the current user"`
+}
+
+// RevokeSessionRequest 吊销会话请求
+//
+//	author centonhuang
+//	update 2025-11-11 20:12:40
+type RevokeSessionRequest struct {
+	SessionID uint `path:"sessionID" doc:"Session ID"`
+}
+
+// RevokeAllSessionsResponse 吊销全部会话响应
+//
+//	author centonhuang
+//	update 2025-11-11 20:12:40
+type RevokeAllSessionsResponse struct {
+	Revoked int64 `json:"revoked" doc:"Number of sessions revoked"`
+}
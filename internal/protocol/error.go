@@ -47,6 +47,11 @@ var (
 	//	update 2025-01-05 18:41:32
 	ErrInsufficientQuota = errors.New("InsufficientQuota")
 
+	// ErrStorageQuotaExceeded 存储配额不足错误
+	//
+	//	update 2025-11-17 10:26:42
+	ErrStorageQuotaExceeded = errors.New("StorageQuotaExceeded")
+
 	// ErrNoImplement 未实现错误
 	//
 	//	update 2025-01-05 18:41:32
@@ -0,0 +1,121 @@
+package dao
+
+import (
+	"time"
+
+	"github.com/hcd233/aris-blog-api/internal/resource/database/model"
+	"gorm.io/gorm"
+)
+
+// AccountDeletionDAO 账号注销申请DAO
+//
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+type AccountDeletionDAO struct {
+	baseDAO[model.AccountDeletion]
+}
+
+// GetPendingByUserID 获取用户冷静期中的注销申请
+//
+//	receiver dao *AccountDeletionDAO
+//	param db *gorm.DB
+//	param userID uint
+//	param fields []string
+//	return deletion *model.AccountDeletion
+//	return err error
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+func (dao *AccountDeletionDAO) GetPendingByUserID(db *gorm.DB, userID uint, fields []string) (deletion *model.AccountDeletion, err error) {
+	err = db.Select(fields).
+		Where(&model.AccountDeletion{UserID: userID, Status: model.AccountDeletionStatusPending}).
+		Last(&deletion).Error
+	return
+}
+
+// ListDue 列出冷静期已结束的注销申请
+//
+//	receiver dao *AccountDeletionDAO
+//	param db *gorm.DB
+//	param now time.Time
+//	param limit int
+//	param fields []string
+//	return deletions *[]model.AccountDeletion
+//	return err error
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+func (dao *AccountDeletionDAO) ListDue(db *gorm.DB, now time.Time, limit int, fields []string) (deletions *[]model.AccountDeletion, err error) {
+	err = db.Select(fields).
+		Where("status = ?", model.AccountDeletionStatusPending).
+		Where("scheduled_at <= ?", now).
+		Order("scheduled_at").
+		Limit(limit).
+		Find(&deletions).Error
+	return
+}
+
+// Claim 抢占到期的注销申请，抢占成功后在租约到期前不会被再次执行
+//
+//	receiver dao *AccountDeletionDAO
+//	param db *gorm.DB
+//	param deletion *model.AccountDeletion
+//	param now time.Time
+//	param leaseUntil time.Time
+//	return claimed bool
+//	return err error
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+func (dao *AccountDeletionDAO) Claim(db *gorm.DB, deletion *model.AccountDeletion, now, leaseUntil time.Time) (claimed bool, err error) {
+	result := db.Model(&model.AccountDeletion{}).
+		Where("id = ?", deletion.ID).
+		Where("status = ?", model.AccountDeletionStatusPending).
+		Where("scheduled_at <= ?", now).
+		Updates(map[string]interface{}{"scheduled_at": leaseUntil, "updated_at": now})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// Cancel 撤销冷静期中的注销申请，申请已被执行时不做修改
+//
+//	receiver dao *AccountDeletionDAO
+//	param db *gorm.DB
+//	param deletion *model.AccountDeletion
+//	return cancelled bool
+//	return err error
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+func (dao *AccountDeletionDAO) Cancel(db *gorm.DB, deletion *model.AccountDeletion) (cancelled bool, err error) {
+	result := db.Model(deletion).
+		Where("status = ?", model.AccountDeletionStatusPending).
+		Updates(map[string]interface{}{"status": model.AccountDeletionStatusCancelled, "updated_at": time.Now().UTC()})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// Complete 将冷静期中的注销申请标记为已完成，申请已被撤销时不做修改
+//
+//	receiver dao *AccountDeletionDAO
+//	param db *gorm.DB
+//	param deletion *model.AccountDeletion
+//	param completedAt time.Time
+//	return completed bool
+//	return err error
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+func (dao *AccountDeletionDAO) Complete(db *gorm.DB, deletion *model.AccountDeletion, completedAt time.Time) (completed bool, err error) {
+	result := db.Model(deletion).
+		Where("status = ?", model.AccountDeletionStatusPending).
+		Updates(map[string]interface{}{
+			"status":       model.AccountDeletionStatusCompleted,
+			"completed_at": completedAt,
+			"last_error":   "",
+			"updated_at":   completedAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
@@ -254,3 +254,37 @@ func (dao *ArticleDAO) PaginateByStatus(db *gorm.DB, status model.ArticleStatus,
 	err = db.Model(&articles).Where(&model.Article{Status: model.ArticleStatusPublish}).Count(&pageInfo.Total).Error
 	return
 }
+
+// CountByUserID 统计用户的文章数
+//
+//	receiver dao *ArticleDAO
+//	param db *gorm.DB
+//	param userID uint
+//	return count int64
+//	return err error
+//	author centonhuang
+//	update 2025-11-10 15:32:08
+func (dao *ArticleDAO) CountByUserID(db *gorm.DB, userID uint) (count int64, err error) {
+	err = db.Model(&model.Article{}).Where(&model.Article{UserID: userID}).Count(&count).Error
+	return
+}
+
+// ListByUserID 列出用户的全部文章，包括草稿
+//
+//	receiver dao *ArticleDAO
+//	param db *gorm.DB
+//	param userID uint
+//	param fields []string
+//	param preloads []string
+//	return articles *[]model.Article
+//	return err error
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+func (dao *ArticleDAO) ListByUserID(db *gorm.DB, userID uint, fields, preloads []string) (articles *[]model.Article, err error) {
+	sql := db.Select(fields)
+	for _, preload := range preloads {
+		sql = sql.Preload(preload)
+	}
+	err = sql.Where(&model.Article{UserID: userID}).Order("id").Find(&articles).Error
+	return
+}
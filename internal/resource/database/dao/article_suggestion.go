@@ -0,0 +1,59 @@
+package dao
+
+import (
+	"time"
+
+	"github.com/hcd233/aris-blog-api/internal/resource/database/model"
+	"gorm.io/gorm"
+)
+
+// ArticleSuggestionDAO 文章AI建议DAO
+//
+//	author centonhuang
+//	update 2025-11-09 16:40:12
+type ArticleSuggestionDAO struct {
+	baseDAO[model.ArticleSuggestion]
+}
+
+// GetLatestByArticleID 获取文章最新的AI建议
+//
+//	receiver dao *ArticleSuggestionDAO
+//	param db *gorm.DB
+//	param articleID uint
+//	param fields []string
+//	param preloads []string
+//	return suggestion *model.ArticleSuggestion
+//	return err error
+//	author centonhuang
+//	update 2025-11-09 16:40:12
+func (dao *ArticleSuggestionDAO) GetLatestByArticleID(db *gorm.DB, articleID uint, fields, preloads []string) (suggestion *model.ArticleSuggestion, err error) {
+	sql := db.Select(fields)
+	for _, preload := range preloads {
+		sql = sql.Preload(preload)
+	}
+	err = sql.Where(&model.ArticleSuggestion{ArticleID: articleID}).Last(&suggestion).Error
+	return
+}
+
+// Claim 抢占待执行的AI建议，抢占成功后在租约到期前不会被再次执行
+//
+//	receiver dao *ArticleSuggestionDAO
+//	param db *gorm.DB
+//	param suggestion *model.ArticleSuggestion
+//	param now time.Time
+//	param leaseUntil time.Time
+//	return claimed bool
+//	return err error
+//	author centonhuang
+//	update 2025-11-09 16:40:12
+func (dao *ArticleSuggestionDAO) Claim(db *gorm.DB, suggestion *model.ArticleSuggestion, now, leaseUntil time.Time) (claimed bool, err error) {
+	result := db.Model(&model.ArticleSuggestion{}).
+		Where("id = ?", suggestion.ID).
+		Where("status IN ?", []model.ArticleSuggestionStatus{model.ArticleSuggestionStatusPending, model.ArticleSuggestionStatusFailed}).
+		Where("next_retry_at <= ?", now).
+		Updates(map[string]interface{}{"next_retry_at": leaseUntil, "updated_at": now})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
@@ -14,50 +14,53 @@ type ArticleVersionDAO struct {
 	baseDAO[model.ArticleVersion]
 }
 
-// GetLatestByArticleID 通过文章ID获取最新文章版本
+// GetLatestByArticleID 通过文章ID和语言获取最新文章版本
 //
 //	receiver dao *ArticleVersionDAO
 //	param db *gorm.DB
 //	param articleID uint
+//	param language model.Language
 //	param fields []string
 //	return articleVersion *model.ArticleVersion
 //	return err error
 //	author centonhuang
 //	update 2024-10-17 08:14:09
-func (dao *ArticleVersionDAO) GetLatestByArticleID(db *gorm.DB, articleID uint, fields, preloads []string) (articleVersion *model.ArticleVersion, err error) {
+func (dao *ArticleVersionDAO) GetLatestByArticleID(db *gorm.DB, articleID uint, language model.Language, fields, preloads []string) (articleVersion *model.ArticleVersion, err error) {
 	sql := db.Select(fields)
 	for _, preload := range preloads {
 		sql = sql.Preload(preload)
 	}
-	err = sql.Where(&model.ArticleVersion{ArticleID: articleID}).Last(&articleVersion).Error
+	err = sql.Where(&model.ArticleVersion{ArticleID: articleID, Language: language}).Order("version").Last(&articleVersion).Error
 	return
 }
 
-// GetByArticleIDAndVersion 通过文章ID和版本号获取文章版本
+// GetByArticleIDAndVersion 通过文章ID、语言和版本号获取文章版本
 //
 //	receiver dao *ArticleVersionDAO
 //	param db *gorm.DB
 //	param articleID uint
+//	param language model.Language
 //	param version uint
 //	param fields []string
 //	return articleVersion *model.ArticleVersion
 //	return err error
 //	author centonhuang
 //	update 2024-10-18 03:17:06
-func (dao *ArticleVersionDAO) GetByArticleIDAndVersion(db *gorm.DB, articleID, version uint, fields, preloads []string) (articleVersion *model.ArticleVersion, err error) {
+func (dao *ArticleVersionDAO) GetByArticleIDAndVersion(db *gorm.DB, articleID uint, language model.Language, version uint, fields, preloads []string) (articleVersion *model.ArticleVersion, err error) {
 	sql := db.Select(fields)
 	for _, preload := range preloads {
 		sql = sql.Preload(preload)
 	}
-	err = sql.Where(&model.ArticleVersion{ArticleID: articleID, Version: version}).Last(&articleVersion).Error
+	err = sql.Where(&model.ArticleVersion{ArticleID: articleID, Language: language, Version: version}).Last(&articleVersion).Error
 	return
 }
 
-// PaginateByArticleID 通过文章ID获取文章版本列表
+// PaginateByArticleID 通过文章ID和语言获取文章版本列表
 //
 //	receiver dao *ArticleVersionDAO
 //	param db *gorm.DB
 //	param articleID uint
+//	param language model.Language
 //	param fields []string
 //	param page int
 //	param pageSize int
@@ -66,7 +69,7 @@ func (dao *ArticleVersionDAO) GetByArticleIDAndVersion(db *gorm.DB, articleID, v
 //	return err error
 //	author centonhuang
 //	update 2024-11-01 07:08:50
-func (dao *ArticleVersionDAO) PaginateByArticleID(db *gorm.DB, articleID uint, fields, preloads []string, param *CommonParam) (articleVersions *[]model.ArticleVersion, pageInfo *PageInfo, err error) {
+func (dao *ArticleVersionDAO) PaginateByArticleID(db *gorm.DB, articleID uint, language model.Language, fields, preloads []string, param *CommonParam) (articleVersions *[]model.ArticleVersion, pageInfo *PageInfo, err error) {
 	limit, offset := param.PageSize, (param.Page-1)*param.PageSize
 
 	sql := db.Select(fields)
@@ -92,7 +95,7 @@ func (dao *ArticleVersionDAO) PaginateByArticleID(db *gorm.DB, articleID uint, f
 		}
 	}
 
-	err = sql.Where(&model.ArticleVersion{ArticleID: articleID}).Limit(limit).Offset(offset).Find(&articleVersions).Error
+	err = sql.Where(&model.ArticleVersion{ArticleID: articleID, Language: language}).Limit(limit).Offset(offset).Find(&articleVersions).Error
 	if err != nil {
 		return
 	}
@@ -102,6 +105,65 @@ func (dao *ArticleVersionDAO) PaginateByArticleID(db *gorm.DB, articleID uint, f
 		PageSize: param.PageSize,
 	}
 
-	err = db.Model(&articleVersions).Where(&model.ArticleVersion{ArticleID: articleID}).Count(&pageInfo.Total).Error
+	err = db.Model(&articleVersions).Where(&model.ArticleVersion{ArticleID: articleID, Language: language}).Count(&pageInfo.Total).Error
+	return
+}
+
+// ListLanguagesByArticleIDs 获取文章已有的版本语言
+//
+//	receiver dao *ArticleVersionDAO
+//	param db *gorm.DB
+//	param articleIDs []uint
+//	return languages map[uint][]model.Language
+//	return err error
+//	author centonhuang
+//	update 2025-11-09 10:12:36
+func (dao *ArticleVersionDAO) ListLanguagesByArticleIDs(db *gorm.DB, articleIDs []uint) (languages map[uint][]model.Language, err error) {
+	var rows []model.ArticleVersion
+	err = db.Model(&model.ArticleVersion{}).
+		Distinct("article_id", "language").
+		Where("article_id IN ?", articleIDs).
+		Order("article_id").Order("language").
+		Find(&rows).Error
+	if err != nil {
+		return
+	}
+
+	languages = make(map[uint][]model.Language, len(articleIDs))
+	for _, row := range rows {
+		languages[row.ArticleID] = append(languages[row.ArticleID], row.Language)
+	}
+	return
+}
+
+// ListByArticleID 列出文章全部语言的全部版本
+//
+//	receiver dao *ArticleVersionDAO
+//	param db *gorm.DB
+//	param articleID uint
+//	param fields []string
+//	return articleVersions *[]model.ArticleVersion
+//	return err error
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+func (dao *ArticleVersionDAO) ListByArticleID(db *gorm.DB, articleID uint, fields []string) (articleVersions *[]model.ArticleVersion, err error) {
+	err = db.Select(fields).Where(&model.ArticleVersion{ArticleID: articleID}).Order("language, version").Find(&articleVersions).Error
+	return
+}
+
+// ListContentsByUserID 列出用户全部未删除文章的全部版本内容，包括各语言的历史版本
+//
+//	receiver dao *ArticleVersionDAO
+//	param db *gorm.DB
+//	param userID uint
+//	return contents []string
+//	return err error
+//	author centonhuang
+//	update 2025-11-17 15:08:53
+func (dao *ArticleVersionDAO) ListContentsByUserID(db *gorm.DB, userID uint) (contents []string, err error) {
+	err = db.Model(&model.ArticleVersion{}).
+		Joins("JOIN articles ON articles.id = article_versions.article_id AND articles.deleted_at IS NULL").
+		Where("articles.user_id = ?", userID).
+		Pluck("article_versions.content", &contents).Error
 	return
 }
@@ -0,0 +1,102 @@
+package dao
+
+import (
+	"github.com/hcd233/aris-blog-api/internal/resource/database/model"
+	"gorm.io/gorm"
+)
+
+// AssetDAO 图片资产DAO
+//
+//	author centonhuang
+//	update 2025-11-16 16:38:25
+type AssetDAO struct {
+	baseDAO[model.Asset]
+}
+
+// GetByObjectName 通过图片名称获取用户的图片资产
+//
+//	receiver dao *AssetDAO
+//	param db *gorm.DB
+//	param userID uint
+//	param objectName string
+//	param fields []string
+//	return asset *model.Asset
+//	return err error
+//	author centonhuang
+//	update 2025-11-16 16:38:25
+func (dao *AssetDAO) GetByObjectName(db *gorm.DB, userID uint, objectName string, fields []string) (asset *model.Asset, err error) {
+	err = db.Select(fields).Where(&model.Asset{UserID: userID, ObjectName: objectName}).First(&asset).Error
+	return
+}
+
+// GetByContentHash 通过内容摘要获取用户任一内容相同的图片资产，用于复用已存储的对象
+//
+//	receiver dao *AssetDAO
+//	param db *gorm.DB
+//	param userID uint
+//	param contentHash string
+//	param fields []string
+//	return asset *model.Asset
+//	return err error
+//	author centonhuang
+//	update 2025-11-16 16:38:25
+func (dao *AssetDAO) GetByContentHash(db *gorm.DB, userID uint, contentHash string, fields []string) (asset *model.Asset, err error) {
+	err = db.Select(fields).Where(&model.Asset{UserID: userID, ContentHash: contentHash}).First(&asset).Error
+	return
+}
+
+// ListByUserID 按创建时间倒序列出用户的图片资产
+//
+//	receiver dao *AssetDAO
+//	param db *gorm.DB
+//	param userID uint
+//	param fields []string
+//	return assets *[]model.Asset
+//	return err error
+//	author centonhuang
+//	update 2025-11-16 16:38:25
+func (dao *AssetDAO) ListByUserID(db *gorm.DB, userID uint, fields []string) (assets *[]model.Asset, err error) {
+	err = db.Select(fields).Where(&model.Asset{UserID: userID}).Order("created_at DESC").Find(&assets).Error
+	return
+}
+
+// CountByStorageName 统计引用同一存储对象的图片资产数量
+//
+//	receiver dao *AssetDAO
+//	param db *gorm.DB
+//	param userID uint
+//	param storageName string
+//	return count int64
+//	return err error
+//	author centonhuang
+//	update 2025-11-16 16:38:25
+func (dao *AssetDAO) CountByStorageName(db *gorm.DB, userID uint, storageName string) (count int64, err error) {
+	err = db.Model(&model.Asset{}).Where(&model.Asset{UserID: userID, StorageName: storageName}).Count(&count).Error
+	return
+}
+
+// HardDelete 物理删除图片资产，释放图片名称的唯一约束
+//
+//	receiver dao *AssetDAO
+//	param db *gorm.DB
+//	param asset *model.Asset
+//	return err error
+//	author centonhuang
+//	update 2025-11-16 16:38:25
+func (dao *AssetDAO) HardDelete(db *gorm.DB, asset *model.Asset) (err error) {
+	err = db.Unscoped().Delete(asset).Error
+	return
+}
+
+// DeleteByUserID 删除用户的全部图片资产
+//
+//	receiver dao *AssetDAO
+//	param db *gorm.DB
+//	param userID uint
+//	return err error
+//	author centonhuang
+//	update 2025-11-16 16:38:25
+func (dao *AssetDAO) DeleteByUserID(db *gorm.DB, userID uint) (err error) {
+	err = db.Unscoped().Where(&model.Asset{UserID: userID}).Delete(&model.Asset{}).Error
+	return
+}
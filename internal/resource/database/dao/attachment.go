@@ -0,0 +1,140 @@
+package dao
+
+import (
+	"github.com/hcd233/aris-blog-api/internal/resource/database/model"
+	"gorm.io/gorm"
+)
+
+// AttachmentDAO 文章附件DAO
+//
+//	author centonhuang
+//	update 2025-11-18 10:21:37
+type AttachmentDAO struct {
+	baseDAO[model.Attachment]
+}
+
+// GetByArticleID 获取文章下的指定附件
+//
+//	receiver dao *AttachmentDAO
+//	param db *gorm.DB
+//	param articleID uint
+//	param attachmentID uint
+//	param fields []string
+//	return attachment *model.Attachment
+//	return err error
+//	author centonhuang
+//	update 2025-11-18 10:21:37
+func (dao *AttachmentDAO) GetByArticleID(db *gorm.DB, articleID, attachmentID uint, fields []string) (attachment *model.Attachment, err error) {
+	err = db.Select(fields).Where(&model.Attachment{ID: attachmentID, ArticleID: articleID}).First(&attachment).Error
+	return
+}
+
+// GetByFileName 通过文件名获取文章下的附件
+//
+//	receiver dao *AttachmentDAO
+//	param db *gorm.DB
+//	param articleID uint
+//	param fileName string
+//	param fields []string
+//	return attachment *model.Attachment
+//	return err error
+//	author centonhuang
+//	update 2025-11-18 10:21:37
+func (dao *AttachmentDAO) GetByFileName(db *gorm.DB, articleID uint, fileName string, fields []string) (attachment *model.Attachment, err error) {
+	err = db.Select(fields).Where(&model.Attachment{ArticleID: articleID, FileName: fileName}).First(&attachment).Error
+	return
+}
+
+// ListByArticleID 按上传顺序列出文章的附件
+//
+//	receiver dao *AttachmentDAO
+//	param db *gorm.DB
+//	param articleID uint
+//	param fields []string
+//	return attachments *[]model.Attachment
+//	return err error
+//	author centonhuang
+//	update 2025-11-18 10:21:37
+func (dao *AttachmentDAO) ListByArticleID(db *gorm.DB, articleID uint, fields []string) (attachments *[]model.Attachment, err error) {
+	err = db.Select(fields).Where(&model.Attachment{ArticleID: articleID}).Order("created_at, id").Find(&attachments).Error
+	return
+}
+
+// ListByUserID 列出用户的全部附件
+//
+//	receiver dao *AttachmentDAO
+//	param db *gorm.DB
+//	param userID uint
+//	param fields []string
+//	return attachments *[]model.Attachment
+//	return err error
+//	author centonhuang
+//	update 2025-11-18 10:21:37
+func (dao *AttachmentDAO) ListByUserID(db *gorm.DB, userID uint, fields []string) (attachments *[]model.Attachment, err error) {
+	err = db.Select(fields).Where(&model.Attachment{UserID: userID}).Order("article_id, created_at, id").Find(&attachments).Error
+	return
+}
+
+// ListOfDeletedArticles 列出用户所属文章已删除的附件
+//
+//	receiver dao *AttachmentDAO
+//	param db *gorm.DB
+//	param userID uint
+//	param fields []string 附件表的字段
+//	return attachments *[]model.Attachment
+//	return err error
+//	author centonhuang
+//	update 2025-11-18 10:21:37
+func (dao *AttachmentDAO) ListOfDeletedArticles(db *gorm.DB, userID uint, fields []string) (attachments *[]model.Attachment, err error) {
+	columns := make([]string, 0, len(fields))
+	for _, field := range fields {
+		columns = append(columns, "attachments."+field)
+	}
+
+	err = db.Select(columns).
+		Joins("LEFT JOIN articles ON articles.id = attachments.article_id").
+		Where(&model.Attachment{UserID: userID}).
+		Where("articles.id IS NULL OR articles.deleted_at IS NOT NULL").
+		Find(&attachments).Error
+	return
+}
+
+// HardDelete 物理删除附件，释放文件名的唯一约束
+//
+//	receiver dao *AttachmentDAO
+//	param db *gorm.DB
+//	param attachment *model.Attachment
+//	return err error
+//	author centonhuang
+//	update 2025-11-18 10:21:37
+func (dao *AttachmentDAO) HardDelete(db *gorm.DB, attachment *model.Attachment) (err error) {
+	err = db.Unscoped().Delete(attachment).Error
+	return
+}
+
+// TransferByArticleID 将文章的全部附件转给受让用户
+//
+//	receiver dao *AttachmentDAO
+//	param db *gorm.DB
+//	param articleID uint
+//	param targetID uint
+//	return err error
+//	author centonhuang
+//	update 2025-11-20 14:26:08
+func (dao *AttachmentDAO) TransferByArticleID(db *gorm.DB, articleID, targetID uint) (err error) {
+	err = db.Model(&model.Attachment{}).Where(&model.Attachment{ArticleID: articleID}).Update("user_id", targetID).Error
+	return
+}
+
+// DeleteByUserID 删除用户的全部附件
+//
+//	receiver dao *AttachmentDAO
+//	param db *gorm.DB
+//	param userID uint
+//	return err error
+//	author centonhuang
+//	update 2025-11-18 10:21:37
+func (dao *AttachmentDAO) DeleteByUserID(db *gorm.DB, userID uint) (err error) {
+	err = db.Unscoped().Where(&model.Attachment{UserID: userID}).Delete(&model.Attachment{}).Error
+	return
+}
@@ -0,0 +1,92 @@
+package dao
+
+import (
+	"time"
+
+	"github.com/hcd233/aris-blog-api/internal/resource/database/model"
+	"gorm.io/gorm"
+)
+
+// AuditEventDAO 审计事件DAO
+//
+//	author centonhuang
+//	update 2025-11-10 15:32:08
+type AuditEventDAO struct {
+	baseDAO[model.AuditEvent]
+}
+
+// AuditEventFilter 审计事件过滤条件，零值表示不过滤
+//
+//	author centonhuang
+//	update 2025-11-11 14:48:02
+type AuditEventFilter struct {
+	ActorID    uint
+	Action     model.AuditAction
+	TargetType model.AuditTargetType
+	TargetID   uint
+	Since      time.Time
+	Until      time.Time
+}
+
+func (f *AuditEventFilter) scope(db *gorm.DB) *gorm.DB {
+	db = db.Where(&model.AuditEvent{
+		ActorID:    f.ActorID,
+		Action:     f.Action,
+		TargetType: f.TargetType,
+		TargetID:   f.TargetID,
+	})
+	if !f.Since.IsZero() {
+		db = db.Where("created_at >= ?", f.Since)
+	}
+	if !f.Until.IsZero() {
+		db = db.Where("created_at < ?", f.Until)
+	}
+	return db
+}
+
+// PaginateByFilter 按条件倒序分页获取审计事件
+//
+//	receiver dao *AuditEventDAO
+//	param db *gorm.DB
+//	param filter *AuditEventFilter
+//	param fields []string
+//	param param *PageParam
+//	return events *[]model.AuditEvent
+//	return pageInfo *PageInfo
+//	return err error
+//	author centonhuang
+//	update 2025-11-11 14:48:02
+func (dao *AuditEventDAO) PaginateByFilter(db *gorm.DB, filter *AuditEventFilter, fields []string, param *PageParam) (events *[]model.AuditEvent, pageInfo *PageInfo, err error) {
+	limit, offset := param.PageSize, (param.Page-1)*param.PageSize
+
+	err = db.Select(fields).Scopes(filter.scope).Order("id DESC").Limit(limit).Offset(offset).Find(&events).Error
+	if err != nil {
+		return
+	}
+
+	pageInfo = &PageInfo{
+		Page:     param.Page,
+		PageSize: param.PageSize,
+	}
+
+	err = db.Model(&model.AuditEvent{}).Scopes(filter.scope).Count(&pageInfo.Total).Error
+	return
+}
+
+// FindInBatchesByFilter 按条件分批顺序遍历审计事件
+//
+//	receiver dao *AuditEventDAO
+//	param db *gorm.DB
+//	param filter *AuditEventFilter
+//	param fields []string
+//	param batchSize int
+//	param fn func(events []model.AuditEvent) error
+//	return err error
+//	author centonhuang
+//	update 2025-11-11 14:48:02
+func (dao *AuditEventDAO) FindInBatchesByFilter(db *gorm.DB, filter *AuditEventFilter, fields []string, batchSize int, fn func(events []model.AuditEvent) error) (err error) {
+	var events []model.AuditEvent
+	return db.Select(fields).Scopes(filter.scope).FindInBatches(&events, batchSize, func(_ *gorm.DB, _ int) error {
+		return fn(events)
+	}).Error
+}
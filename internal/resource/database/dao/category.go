@@ -185,3 +185,16 @@ func (dao *CategoryDAO) reclusiveFindChildrenIDsByID(db *gorm.DB, categoryID uin
 
 	return
 }
+
+// DeleteByUserID 删除用户的全部类别
+//
+//	receiver dao *CategoryDAO
+//	param db *gorm.DB
+//	param userID uint
+//	return err error
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+func (dao *CategoryDAO) DeleteByUserID(db *gorm.DB, userID uint) (err error) {
+	err = db.Where(&model.Category{UserID: userID}).Delete(&model.Category{}).Error
+	return
+}
@@ -204,3 +204,88 @@ func (dao *CommentDAO) reclusiveFindChildrenIDsByID(db *gorm.DB, commentID uint,
 
 	return
 }
+
+// PaginateByUserID 分页获取用户发表的评论
+//
+//	receiver dao *CommentDAO
+//	param db *gorm.DB
+//	param userID uint
+//	param fields []string
+//	param preloads []string
+//	param param *CommonParam
+//	return comments *[]model.Comment
+//	return pageInfo *PageInfo
+//	return err error
+//	author centonhuang
+//	update 2025-11-10 15:32:08
+func (dao *CommentDAO) PaginateByUserID(db *gorm.DB, userID uint, fields, preloads []string, param *CommonParam) (comments *[]model.Comment, pageInfo *PageInfo, err error) {
+	limit, offset := param.PageSize, (param.Page-1)*param.PageSize
+
+	sql := db.Select(fields)
+	for _, preload := range preloads {
+		sql = sql.Preload(preload)
+	}
+
+	filter := func(tx *gorm.DB) *gorm.DB {
+		tx = tx.Where(&model.Comment{UserID: userID})
+		if param.Query != "" {
+			tx = tx.Where(clause.Like{Column: clause.Column{Name: "content"}, Value: "%" + param.Query + "%"})
+		}
+		return tx
+	}
+
+	err = sql.Scopes(filter).Order("id DESC").Limit(limit).Offset(offset).Find(&comments).Error
+	if err != nil {
+		return
+	}
+
+	pageInfo = &PageInfo{
+		Page:     param.Page,
+		PageSize: param.PageSize,
+	}
+
+	err = db.Model(&model.Comment{}).Scopes(filter).Count(&pageInfo.Total).Error
+	return
+}
+
+// CountByUserID 统计用户发表的评论数
+//
+//	receiver dao *CommentDAO
+//	param db *gorm.DB
+//	param userID uint
+//	return count int64
+//	return err error
+//	author centonhuang
+//	update 2025-11-10 15:32:08
+func (dao *CommentDAO) CountByUserID(db *gorm.DB, userID uint) (count int64, err error) {
+	err = db.Model(&model.Comment{}).Where(&model.Comment{UserID: userID}).Count(&count).Error
+	return
+}
+
+// ListByUserID 列出用户发表的全部评论
+//
+//	receiver dao *CommentDAO
+//	param db *gorm.DB
+//	param userID uint
+//	param fields []string
+//	return comments *[]model.Comment
+//	return err error
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+func (dao *CommentDAO) ListByUserID(db *gorm.DB, userID uint, fields []string) (comments *[]model.Comment, err error) {
+	err = db.Select(fields).Where(&model.Comment{UserID: userID}).Order("id").Find(&comments).Error
+	return
+}
+
+// AnonymizeByUserID 解除评论与作者的关联，评论内容保留
+//
+//	receiver dao *CommentDAO
+//	param db *gorm.DB
+//	param userID uint
+//	return err error
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+func (dao *CommentDAO) AnonymizeByUserID(db *gorm.DB, userID uint) (err error) {
+	err = db.Unscoped().Model(&model.Comment{}).Where("user_id = ?", userID).UpdateColumn("user_id", gorm.Expr("NULL")).Error
+	return
+}
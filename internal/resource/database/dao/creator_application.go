@@ -0,0 +1,88 @@
+package dao
+
+import (
+	"time"
+
+	"github.com/hcd233/aris-blog-api/internal/resource/database/model"
+	"gorm.io/gorm"
+)
+
+// CreatorApplicationDAO 创作者申请DAO
+//
+//	author centonhuang
+//	update 2025-11-11 10:20:36
+type CreatorApplicationDAO struct {
+	baseDAO[model.CreatorApplication]
+}
+
+// GetLatestByUserID 获取用户最近一次创作者申请
+//
+//	receiver dao *CreatorApplicationDAO
+//	param db *gorm.DB
+//	param userID uint
+//	param fields []string
+//	param preloads []string
+//	return application *model.CreatorApplication
+//	return err error
+//	author centonhuang
+//	update 2025-11-11 10:20:36
+func (dao *CreatorApplicationDAO) GetLatestByUserID(db *gorm.DB, userID uint, fields, preloads []string) (application *model.CreatorApplication, err error) {
+	sql := db.Select(fields)
+	for _, preload := range preloads {
+		sql = sql.Preload(preload)
+	}
+	err = sql.Where(&model.CreatorApplication{UserID: userID}).Last(&application).Error
+	return
+}
+
+// PaginateByStatus 按状态分页获取创作者申请，空状态表示不过滤
+//
+//	receiver dao *CreatorApplicationDAO
+//	param db *gorm.DB
+//	param status model.CreatorApplicationStatus
+//	param fields []string
+//	param preloads []string
+//	param param *PageParam
+//	return applications *[]model.CreatorApplication
+//	return pageInfo *PageInfo
+//	return err error
+//	author centonhuang
+//	update 2025-11-11 10:20:36
+func (dao *CreatorApplicationDAO) PaginateByStatus(db *gorm.DB, status model.CreatorApplicationStatus, fields, preloads []string, param *PageParam) (applications *[]model.CreatorApplication, pageInfo *PageInfo, err error) {
+	limit, offset := param.PageSize, (param.Page-1)*param.PageSize
+
+	sql := db.Select(fields)
+	for _, preload := range preloads {
+		sql = sql.Preload(preload)
+	}
+
+	condition := &model.CreatorApplication{Status: status}
+	err = sql.Where(condition).Order("id").Limit(limit).Offset(offset).Find(&applications).Error
+	if err != nil {
+		return
+	}
+
+	pageInfo = &PageInfo{
+		Page:     param.Page,
+		PageSize: param.PageSize,
+	}
+
+	err = db.Model(&model.CreatorApplication{}).Where(condition).Count(&pageInfo.Total).Error
+	return
+}
+
+// UpdatePending 仅当申请仍处于待审核状态时更新，避免重复审核
+//
+//	receiver dao *CreatorApplicationDAO
+//	param db *gorm.DB
+//	param application *model.CreatorApplication
+//	param info map[string]interface{}
+//	return updated bool
+//	return err error
+//	author centonhuang
+//	update 2025-11-11 10:20:36
+func (dao *CreatorApplicationDAO) UpdatePending(db *gorm.DB, application *model.CreatorApplication, info map[string]interface{}) (updated bool, err error) {
+	info["updated_at"] = time.Now().UTC()
+	result := db.Model(application).Where("status = ?", model.CreatorApplicationStatusPending).Updates(info)
+	return result.RowsAffected > 0, result.Error
+}
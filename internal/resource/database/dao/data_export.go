@@ -0,0 +1,98 @@
+package dao
+
+import (
+	"time"
+
+	"github.com/hcd233/aris-blog-api/internal/resource/database/model"
+	"gorm.io/gorm"
+)
+
+// DataExportDAO 用户数据导出DAO
+//
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+type DataExportDAO struct {
+	baseDAO[model.DataExport]
+}
+
+// GetLatestByUserID 获取用户最近一次数据导出
+//
+//	receiver dao *DataExportDAO
+//	param db *gorm.DB
+//	param userID uint
+//	param fields []string
+//	return export *model.DataExport
+//	return err error
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+func (dao *DataExportDAO) GetLatestByUserID(db *gorm.DB, userID uint, fields []string) (export *model.DataExport, err error) {
+	err = db.Select(fields).Where(&model.DataExport{UserID: userID}).Last(&export).Error
+	return
+}
+
+// ListDue 列出到期待打包的数据导出，包括失败待重试的导出
+//
+//	receiver dao *DataExportDAO
+//	param db *gorm.DB
+//	param now time.Time
+//	param maxRetries uint
+//	param limit int
+//	param fields []string
+//	return exports *[]model.DataExport
+//	return err error
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+func (dao *DataExportDAO) ListDue(db *gorm.DB, now time.Time, maxRetries uint, limit int, fields []string) (exports *[]model.DataExport, err error) {
+	err = db.Select(fields).
+		Where("status IN ?", []model.DataExportStatus{model.DataExportStatusPending, model.DataExportStatusFailed}).
+		Where("next_retry_at <= ?", now).
+		Where("retries < ?", maxRetries).
+		Order("next_retry_at").
+		Limit(limit).
+		Find(&exports).Error
+	return
+}
+
+// ListExpired 列出已过期但归档尚未删除的数据导出
+//
+//	receiver dao *DataExportDAO
+//	param db *gorm.DB
+//	param now time.Time
+//	param limit int
+//	param fields []string
+//	return exports *[]model.DataExport
+//	return err error
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+func (dao *DataExportDAO) ListExpired(db *gorm.DB, now time.Time, limit int, fields []string) (exports *[]model.DataExport, err error) {
+	err = db.Select(fields).
+		Where("status = ?", model.DataExportStatusReady).
+		Where("expires_at <= ?", now).
+		Order("expires_at").
+		Limit(limit).
+		Find(&exports).Error
+	return
+}
+
+// Claim 抢占待打包的数据导出，抢占成功后在租约到期前不会被再次执行
+//
+//	receiver dao *DataExportDAO
+//	param db *gorm.DB
+//	param export *model.DataExport
+//	param now time.Time
+//	param leaseUntil time.Time
+//	return claimed bool
+//	return err error
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+func (dao *DataExportDAO) Claim(db *gorm.DB, export *model.DataExport, now, leaseUntil time.Time) (claimed bool, err error) {
+	result := db.Model(&model.DataExport{}).
+		Where("id = ?", export.ID).
+		Where("status IN ?", []model.DataExportStatus{model.DataExportStatusPending, model.DataExportStatusFailed}).
+		Where("next_retry_at <= ?", now).
+		Updates(map[string]interface{}{"next_retry_at": leaseUntil, "updated_at": now})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
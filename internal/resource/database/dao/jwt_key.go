@@ -0,0 +1,68 @@
+package dao
+
+import (
+	"time"
+
+	"github.com/hcd233/aris-blog-api/internal/resource/database/model"
+	"gorm.io/gorm"
+)
+
+// JwtKeyDAO JWT签名密钥DAO
+//
+//	author centonhuang
+//	update 2025-11-12 10:24:06
+type JwtKeyDAO struct {
+	baseDAO[model.JwtKey]
+}
+
+// ListUnretired 列出尚未停止验签的密钥，按创建时间倒序
+//
+//	receiver dao *JwtKeyDAO
+//	param db *gorm.DB
+//	param now time.Time
+//	param fields []string
+//	return keys *[]model.JwtKey
+//	return err error
+//	author centonhuang
+//	update 2025-11-12 10:24:06
+func (dao *JwtKeyDAO) ListUnretired(db *gorm.DB, now time.Time, fields []string) (keys *[]model.JwtKey, err error) {
+	err = db.Select(fields).
+		Where("expires_at IS NULL OR expires_at > ?", now).
+		Order("created_at DESC, id DESC").
+		Find(&keys).Error
+	return
+}
+
+// GetLatestSigning 获取指定算法最新的签名密钥
+//
+//	receiver dao *JwtKeyDAO
+//	param db *gorm.DB
+//	param algorithm string
+//	param fields []string
+//	return key *model.JwtKey
+//	return err error
+//	author centonhuang
+//	update 2025-11-12 10:24:06
+func (dao *JwtKeyDAO) GetLatestSigning(db *gorm.DB, algorithm string, fields []string) (key *model.JwtKey, err error) {
+	err = db.Select(fields).
+		Where("algorithm = ? AND expires_at IS NULL", algorithm).
+		Order("created_at DESC, id DESC").
+		First(&key).Error
+	return
+}
+
+// ExpireSigningExcept 将除指定密钥外仍在签名的密钥改为仅验签，并设置停止验签时间
+//
+//	receiver dao *JwtKeyDAO
+//	param db *gorm.DB
+//	param exceptID uint
+//	param expiresAt time.Time
+//	return err error
+//	author centonhuang
+//	update 2025-11-12 10:24:06
+func (dao *JwtKeyDAO) ExpireSigningExcept(db *gorm.DB, exceptID uint, expiresAt time.Time) (err error) {
+	err = db.Model(&model.JwtKey{}).
+		Where("id <> ? AND expires_at IS NULL", exceptID).
+		Updates(map[string]interface{}{"expires_at": expiresAt, "updated_at": time.Now().UTC()}).Error
+	return
+}
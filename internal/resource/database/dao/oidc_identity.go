@@ -0,0 +1,43 @@
+package dao
+
+import (
+	"github.com/hcd233/aris-blog-api/internal/resource/database/model"
+	"gorm.io/gorm"
+)
+
+// OidcIdentityDAO OIDC身份DAO
+//
+//	author centonhuang
+//	update 2025-11-13 19:26:03
+type OidcIdentityDAO struct {
+	baseDAO[model.OidcIdentity]
+}
+
+// GetBySubject 通过提供商名称与sub获取身份
+//
+//	receiver dao *OidcIdentityDAO
+//	param db *gorm.DB
+//	param provider string
+//	param subject string
+//	param fields []string
+//	return identity *model.OidcIdentity
+//	return err error
+//	author centonhuang
+//	update 2025-11-13 19:26:03
+func (dao *OidcIdentityDAO) GetBySubject(db *gorm.DB, provider, subject string, fields []string) (identity *model.OidcIdentity, err error) {
+	err = db.Select(fields).Where(&model.OidcIdentity{Provider: provider, Subject: subject}).First(&identity).Error
+	return
+}
+
+// DeleteByUserID 删除用户在指定提供商的身份，物理删除以便之后重新绑定
+//
+//	receiver dao *OidcIdentityDAO
+//	param db *gorm.DB
+//	param userID uint
+//	param provider string
+//	return err error
+//	author centonhuang
+//	update 2025-11-13 19:26:03
+func (dao *OidcIdentityDAO) DeleteByUserID(db *gorm.DB, userID uint, provider string) error {
+	return db.Unscoped().Where(&model.OidcIdentity{UserID: userID, Provider: provider}).Delete(&model.OidcIdentity{}).Error
+}
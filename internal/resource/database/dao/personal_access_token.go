@@ -0,0 +1,97 @@
+package dao
+
+import (
+	"time"
+
+	"github.com/hcd233/aris-blog-api/internal/resource/database/model"
+	"gorm.io/gorm"
+)
+
+// PersonalAccessTokenDAO 个人访问令牌DAO
+//
+//	author centonhuang
+//	update 2025-11-12 14:02:51
+type PersonalAccessTokenDAO struct {
+	baseDAO[model.PersonalAccessToken]
+}
+
+// GetByHash 通过令牌摘要获取令牌
+//
+//	receiver dao *PersonalAccessTokenDAO
+//	param db *gorm.DB
+//	param tokenHash string
+//	param fields []string
+//	return token *model.PersonalAccessToken
+//	return err error
+//	author centonhuang
+//	update 2025-11-12 14:02:51
+func (dao *PersonalAccessTokenDAO) GetByHash(db *gorm.DB, tokenHash string, fields []string) (token *model.PersonalAccessToken, err error) {
+	err = db.Select(fields).Where(&model.PersonalAccessToken{TokenHash: tokenHash}).First(&token).Error
+	return
+}
+
+// ListByUserID 按创建时间倒序列出用户未吊销的令牌，包括已过期的令牌
+//
+//	receiver dao *PersonalAccessTokenDAO
+//	param db *gorm.DB
+//	param userID uint
+//	param fields []string
+//	return tokens *[]model.PersonalAccessToken
+//	return err error
+//	author centonhuang
+//	update 2025-11-12 14:02:51
+func (dao *PersonalAccessTokenDAO) ListByUserID(db *gorm.DB, userID uint, fields []string) (tokens *[]model.PersonalAccessToken, err error) {
+	err = db.Select(fields).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Order("created_at DESC").
+		Find(&tokens).Error
+	return
+}
+
+// TouchLastUsed 记录令牌的最近使用信息，间隔内重复使用不再写库
+//
+//	receiver dao *PersonalAccessTokenDAO
+//	param db *gorm.DB
+//	param token *model.PersonalAccessToken
+//	param ip string
+//	param interval time.Duration
+//	return err error
+//	author centonhuang
+//	update 2025-11-12 14:02:51
+func (dao *PersonalAccessTokenDAO) TouchLastUsed(db *gorm.DB, token *model.PersonalAccessToken, ip string, interval time.Duration) (err error) {
+	now := time.Now().UTC()
+	err = db.Model(token).
+		Where("last_used_at IS NULL OR last_used_at < ?", now.Add(-interval)).
+		UpdateColumns(map[string]interface{}{"last_used_at": now, "last_used_ip": ip}).Error
+	return
+}
+
+// Revoke 吊销令牌
+//
+//	receiver dao *PersonalAccessTokenDAO
+//	param db *gorm.DB
+//	param token *model.PersonalAccessToken
+//	return err error
+//	author centonhuang
+//	update 2025-11-12 14:02:51
+func (dao *PersonalAccessTokenDAO) Revoke(db *gorm.DB, token *model.PersonalAccessToken) (err error) {
+	now := time.Now().UTC()
+	err = db.Model(token).Where("revoked_at IS NULL").Updates(map[string]interface{}{"revoked_at": now, "updated_at": now}).Error
+	return
+}
+
+// RevokeByUserID 吊销用户全部未吊销的令牌
+//
+//	receiver dao *PersonalAccessTokenDAO
+//	param db *gorm.DB
+//	param userID uint
+//	return err error
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+func (dao *PersonalAccessTokenDAO) RevokeByUserID(db *gorm.DB, userID uint) (err error) {
+	now := time.Now().UTC()
+	err = db.Model(&model.PersonalAccessToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Updates(map[string]interface{}{"revoked_at": now, "updated_at": now}).Error
+	return
+}
@@ -0,0 +1,112 @@
+package dao
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hcd233/aris-blog-api/internal/resource/database/model"
+	"gorm.io/gorm"
+)
+
+// RoleDAO 角色DAO
+//
+//	author centonhuang
+//	update 2025-11-11 17:05:24
+type RoleDAO struct {
+	baseDAO[model.Role]
+}
+
+// Delete 删除角色并解除与用户的关联，名称追加随机后缀以释放唯一索引
+//
+//	receiver dao *RoleDAO
+//	param db *gorm.DB
+//	param role *model.Role
+//	return err error
+//	author centonhuang
+//	update 2025-11-11 17:05:24
+func (dao *RoleDAO) Delete(db *gorm.DB, role *model.Role) (err error) {
+	if err = db.Model(role).Association("Users").Clear(); err != nil {
+		return
+	}
+	err = db.Model(role).Updates(map[string]interface{}{"name": fmt.Sprintf("%s-%s", role.Name, uuid.New().String()), "deleted_at": time.Now().UTC()}).Error
+	return
+}
+
+// GetByName 通过名称获取角色
+//
+//	receiver dao *RoleDAO
+//	param db *gorm.DB
+//	param name string
+//	param fields []string
+//	param preloads []string
+//	return role *model.Role
+//	return err error
+//	author centonhuang
+//	update 2025-11-11 17:05:24
+func (dao *RoleDAO) GetByName(db *gorm.DB, name string, fields, preloads []string) (role *model.Role, err error) {
+	sql := db.Select(fields)
+	for _, preload := range preloads {
+		sql = sql.Preload(preload)
+	}
+	err = sql.Where(&model.Role{Name: name}).First(&role).Error
+	return
+}
+
+// BatchGetByNames 通过名称批量获取角色
+//
+//	receiver dao *RoleDAO
+//	param db *gorm.DB
+//	param names []string
+//	param fields []string
+//	return roles *[]model.Role
+//	return err error
+//	author centonhuang
+//	update 2025-11-11 17:05:24
+func (dao *RoleDAO) BatchGetByNames(db *gorm.DB, names []string, fields []string) (roles *[]model.Role, err error) {
+	err = db.Select(fields).Where("name IN ?", names).Find(&roles).Error
+	return
+}
+
+// List 按名称顺序列出全部角色
+//
+//	receiver dao *RoleDAO
+//	param db *gorm.DB
+//	param fields []string
+//	return roles *[]model.Role
+//	return err error
+//	author centonhuang
+//	update 2025-11-11 17:05:24
+func (dao *RoleDAO) List(db *gorm.DB, fields []string) (roles *[]model.Role, err error) {
+	err = db.Select(fields).Order("built_in DESC, name").Find(&roles).Error
+	return
+}
+
+// EnsureBuiltinRoles 创建缺失的内置角色，已存在的角色保持管理员修改后的权限项
+//
+//	receiver dao *RoleDAO
+//	param db *gorm.DB
+//	return err error
+//	author centonhuang
+//	update 2025-11-11 17:05:24
+func (dao *RoleDAO) EnsureBuiltinRoles(db *gorm.DB) (err error) {
+	for permission, scopes := range model.BuiltinRoleScopes {
+		_, err = dao.GetByName(db, string(permission), []string{"id"}, []string{})
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return
+		}
+		if err = dao.Create(db, &model.Role{
+			Name:        string(permission),
+			Description: fmt.Sprintf("Builtin role migrated from the %s permission level", permission),
+			Scopes:      scopes,
+			BuiltIn:     true,
+		}); err != nil {
+			return
+		}
+	}
+	return nil
+}
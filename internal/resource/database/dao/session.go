@@ -0,0 +1,90 @@
+package dao
+
+import (
+	"time"
+
+	"github.com/hcd233/aris-blog-api/internal/resource/database/model"
+	"gorm.io/gorm"
+)
+
+// SessionDAO 登录会话DAO
+//
+//	author centonhuang
+//	update 2025-11-11 20:12:40
+type SessionDAO struct {
+	baseDAO[model.Session]
+}
+
+// Rotate 轮换会话的刷新令牌，仅当当前令牌ID仍为oldTokenID且会话未吊销时生效
+//
+//	receiver dao *SessionDAO
+//	param db *gorm.DB
+//	param session *model.Session
+//	param oldTokenID string
+//	param info map[string]interface{}
+//	return rotated bool
+//	return err error
+//	author centonhuang
+//	update 2025-11-11 20:12:40
+func (dao *SessionDAO) Rotate(db *gorm.DB, session *model.Session, oldTokenID string, info map[string]interface{}) (rotated bool, err error) {
+	info["updated_at"] = time.Now().UTC()
+	result := db.Model(session).Where("token_id = ? AND revoked_at IS NULL", oldTokenID).Updates(info)
+	return result.RowsAffected > 0, result.Error
+}
+
+// Revoke 吊销会话，已吊销的会话保持原吊销原因
+//
+//	receiver dao *SessionDAO
+//	param db *gorm.DB
+//	param session *model.Session
+//	param reason model.SessionRevokeReason
+//	return err error
+//	author centonhuang
+//	update 2025-11-11 20:12:40
+func (dao *SessionDAO) Revoke(db *gorm.DB, session *model.Session, reason model.SessionRevokeReason) (err error) {
+	now := time.Now().UTC()
+	err = db.Model(session).Where("revoked_at IS NULL").Updates(map[string]interface{}{
+		"revoked_at":    now,
+		"revoke_reason": reason,
+		"updated_at":    now,
+	}).Error
+	return
+}
+
+// RevokeByUserID 吊销用户的全部有效会话
+//
+//	receiver dao *SessionDAO
+//	param db *gorm.DB
+//	param userID uint
+//	param reason model.SessionRevokeReason
+//	return revoked int64
+//	return err error
+//	author centonhuang
+//	update 2025-11-11 20:12:40
+func (dao *SessionDAO) RevokeByUserID(db *gorm.DB, userID uint, reason model.SessionRevokeReason) (revoked int64, err error) {
+	now := time.Now().UTC()
+	result := db.Model(&model.Session{}).Where("user_id = ? AND revoked_at IS NULL", userID).Updates(map[string]interface{}{
+		"revoked_at":    now,
+		"revoke_reason": reason,
+		"updated_at":    now,
+	})
+	return result.RowsAffected, result.Error
+}
+
+// ListActiveByUserID 按最近使用时间倒序列出用户未吊销且未过期的会话
+//
+//	receiver dao *SessionDAO
+//	param db *gorm.DB
+//	param userID uint
+//	param fields []string
+//	return sessions *[]model.Session
+//	return err error
+//	author centonhuang
+//	update 2025-11-11 20:12:40
+func (dao *SessionDAO) ListActiveByUserID(db *gorm.DB, userID uint, fields []string) (sessions *[]model.Session, err error) {
+	err = db.Select(fields).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now().UTC()).
+		Order("last_used_at DESC").
+		Find(&sessions).Error
+	return
+}
@@ -5,25 +5,49 @@ import (
 )
 
 var (
-	categoryDAOSingleton       *CategoryDAO
-	userDAOSingleton           *UserDAO
-	tagDAOSingleton            *TagDAO
-	articleDAOSingleton        *ArticleDAO
-	articleVersionDAOSingleton *ArticleVersionDAO
-	commentDAOSingleton        *CommentDAO
-	userLikeDAOSingleton       *UserLikeDAO
-	userViewDAOSingleton       *UserViewDAO
-	promptDAOSingleton         *PromptDAO
-
-	categoryOnce       sync.Once
-	userOnce           sync.Once
-	tagOnce            sync.Once
-	articleOnce        sync.Once
-	articleVersionOnce sync.Once
-	commentOnce        sync.Once
-	userLikeOnce       sync.Once
-	userViewOnce       sync.Once
-	promptOnce         sync.Once
+	categoryDAOSingleton            *CategoryDAO
+	userDAOSingleton                *UserDAO
+	tagDAOSingleton                 *TagDAO
+	articleDAOSingleton             *ArticleDAO
+	articleVersionDAOSingleton      *ArticleVersionDAO
+	commentDAOSingleton             *CommentDAO
+	userLikeDAOSingleton            *UserLikeDAO
+	userViewDAOSingleton            *UserViewDAO
+	promptDAOSingleton              *PromptDAO
+	articleSuggestionDAOSingleton   *ArticleSuggestionDAO
+	auditEventDAOSingleton          *AuditEventDAO
+	creatorApplicationDAOSingleton  *CreatorApplicationDAO
+	roleDAOSingleton                *RoleDAO
+	sessionDAOSingleton             *SessionDAO
+	jwtKeyDAOSingleton              *JwtKeyDAO
+	personalAccessTokenDAOSingleton *PersonalAccessTokenDAO
+	oidcIdentityDAOSingleton        *OidcIdentityDAO
+	dataExportDAOSingleton          *DataExportDAO
+	accountDeletionDAOSingleton     *AccountDeletionDAO
+	assetDAOSingleton               *AssetDAO
+	attachmentDAOSingleton          *AttachmentDAO
+
+	categoryOnce            sync.Once
+	userOnce                sync.Once
+	tagOnce                 sync.Once
+	articleOnce             sync.Once
+	articleVersionOnce      sync.Once
+	commentOnce             sync.Once
+	userLikeOnce            sync.Once
+	userViewOnce            sync.Once
+	promptOnce              sync.Once
+	articleSuggestionOnce   sync.Once
+	auditEventOnce          sync.Once
+	creatorApplicationOnce  sync.Once
+	roleOnce                sync.Once
+	sessionOnce             sync.Once
+	jwtKeyOnce              sync.Once
+	personalAccessTokenOnce sync.Once
+	oidcIdentityOnce        sync.Once
+	dataExportOnce          sync.Once
+	accountDeletionOnce     sync.Once
+	assetOnce               sync.Once
+	attachmentOnce          sync.Once
 )
 
 // GetCategoryDAO 获取类别DAO
@@ -133,3 +157,147 @@ func GetPromptDAO() *PromptDAO {
 	})
 	return promptDAOSingleton
 }
+
+// GetArticleSuggestionDAO 获取文章AI建议DAO
+//
+//	return *ArticleSuggestionDAO
+//	author centonhuang
+//	update 2025-11-09 16:40:12
+func GetArticleSuggestionDAO() *ArticleSuggestionDAO {
+	articleSuggestionOnce.Do(func() {
+		articleSuggestionDAOSingleton = &ArticleSuggestionDAO{}
+	})
+	return articleSuggestionDAOSingleton
+}
+
+// GetAuditEventDAO 获取审计事件DAO
+//
+//	return *AuditEventDAO
+//	author centonhuang
+//	update 2025-11-10 15:32:08
+func GetAuditEventDAO() *AuditEventDAO {
+	auditEventOnce.Do(func() {
+		auditEventDAOSingleton = &AuditEventDAO{}
+	})
+	return auditEventDAOSingleton
+}
+
+// GetCreatorApplicationDAO 获取创作者申请DAO
+//
+//	return *CreatorApplicationDAO
+//	author centonhuang
+//	update 2025-11-11 10:20:36
+func GetCreatorApplicationDAO() *CreatorApplicationDAO {
+	creatorApplicationOnce.Do(func() {
+		creatorApplicationDAOSingleton = &CreatorApplicationDAO{}
+	})
+	return creatorApplicationDAOSingleton
+}
+
+// GetRoleDAO 获取角色DAO
+//
+//	return *RoleDAO
+//	author centonhuang
+//	update 2025-11-11 17:05:24
+func GetRoleDAO() *RoleDAO {
+	roleOnce.Do(func() {
+		roleDAOSingleton = &RoleDAO{}
+	})
+	return roleDAOSingleton
+}
+
+// GetSessionDAO 获取登录会话DAO
+//
+//	return *SessionDAO
+//	author centonhuang
+//	update 2025-11-11 20:12:40
+func GetSessionDAO() *SessionDAO {
+	sessionOnce.Do(func() {
+		sessionDAOSingleton = &SessionDAO{}
+	})
+	return sessionDAOSingleton
+}
+
+// GetJwtKeyDAO 获取JWT签名密钥DAO
+//
+//	return *JwtKeyDAO
+//	author centonhuang
+//	update 2025-11-12 10:24:06
+func GetJwtKeyDAO() *JwtKeyDAO {
+	jwtKeyOnce.Do(func() {
+		jwtKeyDAOSingleton = &JwtKeyDAO{}
+	})
+	return jwtKeyDAOSingleton
+}
+
+// GetPersonalAccessTokenDAO 获取个人访问令牌DAO
+//
+//	return *PersonalAccessTokenDAO
+//	author centonhuang
+//	update 2025-11-12 14:02:51
+func GetPersonalAccessTokenDAO() *PersonalAccessTokenDAO {
+	personalAccessTokenOnce.Do(func() {
+		personalAccessTokenDAOSingleton = &PersonalAccessTokenDAO{}
+	})
+	return personalAccessTokenDAOSingleton
+}
+
+// GetOidcIdentityDAO 获取OIDC身份DAO
+//
+//	return *OidcIdentityDAO
+//	author centonhuang
+//	update 2025-11-13 19:26:03
+func GetOidcIdentityDAO() *OidcIdentityDAO {
+	oidcIdentityOnce.Do(func() {
+		oidcIdentityDAOSingleton = &OidcIdentityDAO{}
+	})
+	return oidcIdentityDAOSingleton
+}
+
+// GetDataExportDAO 获取用户数据导出DAO
+//
+//	return *DataExportDAO
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+func GetDataExportDAO() *DataExportDAO {
+	dataExportOnce.Do(func() {
+		dataExportDAOSingleton = &DataExportDAO{}
+	})
+	return dataExportDAOSingleton
+}
+
+// GetAccountDeletionDAO 获取账号注销申请DAO
+//
+//	return *AccountDeletionDAO
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+func GetAccountDeletionDAO() *AccountDeletionDAO {
+	accountDeletionOnce.Do(func() {
+		accountDeletionDAOSingleton = &AccountDeletionDAO{}
+	})
+	return accountDeletionDAOSingleton
+}
+
+// GetAssetDAO 获取图片资产DAO
+//
+//	return *AssetDAO
+//	author centonhuang
+//	update 2025-11-16 16:38:25
+func GetAssetDAO() *AssetDAO {
+	assetOnce.Do(func() {
+		assetDAOSingleton = &AssetDAO{}
+	})
+	return assetDAOSingleton
+}
+
+// GetAttachmentDAO 获取文章附件DAO
+//
+//	return *AttachmentDAO
+//	author centonhuang
+//	update 2025-11-18 10:21:37
+func GetAttachmentDAO() *AttachmentDAO {
+	attachmentOnce.Do(func() {
+		attachmentDAOSingleton = &AttachmentDAO{}
+	})
+	return attachmentDAOSingleton
+}
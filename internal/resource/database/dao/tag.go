@@ -104,3 +104,18 @@ func (dao *TagDAO) PaginateByUserID(db *gorm.DB, userID uint, fields, preloads [
 	err = db.Model(&tags).Where(model.Tag{UserID: userID}).Count(&pageInfo.Total).Error
 	return
 }
+
+// ListPopular 按点赞数获取热门标签
+//
+//	receiver dao *TagDAO
+//	param db *gorm.DB
+//	param limit int
+//	param fields []string
+//	return tags *[]model.Tag
+//	return err error
+//	author centonhuang
+//	update 2025-11-09 16:40:12
+func (dao *TagDAO) ListPopular(db *gorm.DB, limit int, fields []string) (tags *[]model.Tag, err error) {
+	err = db.Select(fields).Order("likes DESC").Order("id").Limit(limit).Find(&tags).Error
+	return
+}
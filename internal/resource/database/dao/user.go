@@ -3,6 +3,7 @@ package dao
 import (
 	"github.com/hcd233/aris-blog-api/internal/resource/database/model"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // UserDAO 用户DAO
@@ -32,6 +33,27 @@ func (dao *UserDAO) GetByEmail(db *gorm.DB, email string, fields, preloads []str
 	return
 }
 
+// GetByBindID 通过第三方平台绑定ID获取用户
+//
+//	receiver dao *UserDAO
+//	param db *gorm.DB
+//	param bindField string
+//	param bindID string
+//	param fields []string
+//	param preloads []string
+//	return user *model.User
+//	return err error
+//	author centonhuang
+//	update 2025-11-13 10:12:37
+func (dao *UserDAO) GetByBindID(db *gorm.DB, bindField, bindID string, fields, preloads []string) (user *model.User, err error) {
+	sql := db.Select(fields)
+	for _, preload := range preloads {
+		sql = sql.Preload(preload)
+	}
+	err = sql.Where(clause.Eq{Column: clause.Column{Name: bindField}, Value: bindID}).First(&user).Error
+	return
+}
+
 // GetByName 通过用户名获取用户
 //
 //	receiver dao *UserDAO
@@ -78,3 +100,172 @@ func (dao *UserDAO) DeliverLLMQuota(db *gorm.DB, userID uint, quota model.Quota)
 func (dao *UserDAO) BatchDeliverLLMQuota(db *gorm.DB, userIDs []uint, quota model.Quota) error {
 	return db.Model(&model.User{}).Where("id IN ?", userIDs).Update("llm_quota", quota).Error
 }
+
+// RefundLLMQuota 按增量退还LLM配额，用于已扣费但生成结果未被采用的请求
+//
+//	receiver dao *UserDAO
+//	param db *gorm.DB
+//	param userID uint
+//	param quota model.Quota
+//	return err error
+//	author centonhuang
+//	update 2025-11-19 10:12:26
+func (dao *UserDAO) RefundLLMQuota(db *gorm.DB, userID uint, quota model.Quota) error {
+	return db.Model(&model.User{}).Where("id = ?", userID).UpdateColumn("llm_quota", gorm.Expr("llm_quota + ?", quota)).Error
+}
+
+// PaginateByFilter 按权限与账号状态过滤分页查询用户，空值表示不过滤
+//
+//	receiver dao *UserDAO
+//	param db *gorm.DB
+//	param permission model.Permission
+//	param status model.UserStatus
+//	param fields []string
+//	param preloads []string
+//	param param *CommonParam
+//	return users *[]model.User
+//	return pageInfo *PageInfo
+//	return err error
+//	author centonhuang
+//	update 2025-11-10 15:32:08
+func (dao *UserDAO) PaginateByFilter(db *gorm.DB, permission model.Permission, status model.UserStatus, fields, preloads []string, param *CommonParam) (users *[]model.User, pageInfo *PageInfo, err error) {
+	limit, offset := param.PageSize, (param.Page-1)*param.PageSize
+
+	sql := db.Select(fields)
+	for _, preload := range preloads {
+		sql = sql.Preload(preload)
+	}
+
+	filter := func(tx *gorm.DB) *gorm.DB {
+		tx = tx.Where(&model.User{Permission: permission, Status: status})
+		if param.Query != "" && len(param.QueryFields) > 0 {
+			like := "%" + param.Query + "%"
+			expressions := make([]clause.Expression, 0, len(param.QueryFields))
+			for _, field := range param.QueryFields {
+				expressions = append(expressions, clause.Like{Column: clause.Column{Name: field}, Value: like})
+			}
+			tx = tx.Where(clause.Or(expressions...))
+		}
+		return tx
+	}
+
+	err = sql.Scopes(filter).Order("id").Limit(limit).Offset(offset).Find(&users).Error
+	if err != nil {
+		return
+	}
+
+	pageInfo = &PageInfo{
+		Page:     param.Page,
+		PageSize: param.PageSize,
+	}
+
+	err = db.Model(&model.User{}).Scopes(filter).Count(&pageInfo.Total).Error
+	return
+}
+
+// ReplaceRoles 替换用户的全部角色
+//
+//	receiver dao *UserDAO
+//	param db *gorm.DB
+//	param user *model.User
+//	param roles []model.Role
+//	return err error
+//	author centonhuang
+//	update 2025-11-11 17:05:24
+func (dao *UserDAO) ReplaceRoles(db *gorm.DB, user *model.User, roles []model.Role) error {
+	return db.Model(user).Association("Roles").Replace(roles)
+}
+
+// AssignBuiltinRolesByPermission 为尚未分配任何角色的用户授予与其权限等级同名的内置角色
+//
+//	receiver dao *UserDAO
+//	param db *gorm.DB
+//	return assigned int64
+//	return err error
+//	author centonhuang
+//	update 2025-11-11 17:05:24
+func (dao *UserDAO) AssignBuiltinRolesByPermission(db *gorm.DB) (assigned int64, err error) {
+	result := db.Exec(`INSERT INTO user_roles (user_id, role_id)
+SELECT users.id, roles.id FROM users
+JOIN roles ON roles.name = users.permission AND roles.built_in AND roles.deleted_at IS NULL
+WHERE users.deleted_at IS NULL
+AND NOT EXISTS (SELECT 1 FROM user_roles WHERE user_roles.user_id = users.id)`)
+	return result.RowsAffected, result.Error
+}
+
+// NullifyEmptyBindIDs 将空字符串绑定ID置为NULL，避免多个未绑定用户触发唯一索引冲突
+//
+//	receiver dao *UserDAO
+//	param db *gorm.DB
+//	return err error
+//	author centonhuang
+//	update 2025-11-13 10:12:37
+func (dao *UserDAO) NullifyEmptyBindIDs(db *gorm.DB) error {
+	for _, bindField := range []string{"github_bind_id", "qq_bind_id", "google_bind_id"} {
+		if err := db.Model(&model.User{}).Where(clause.Eq{Column: clause.Column{Name: bindField}, Value: ""}).Update(bindField, nil).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListIDs 列出全部未注销用户的ID
+//
+//	receiver dao *UserDAO
+//	param db *gorm.DB
+//	return ids []uint
+//	return err error
+//	author centonhuang
+//	update 2025-11-16 16:38:25
+func (dao *UserDAO) ListIDs(db *gorm.DB) (ids []uint, err error) {
+	err = db.Model(&model.User{}).Order("id").Pluck("id", &ids).Error
+	return
+}
+
+// ReserveStorage 在不超过配额的前提下为用户预占存储用量，配额不足时reserved为false
+//
+//	receiver dao *UserDAO
+//	param db *gorm.DB
+//	param userID uint
+//	param size int64
+//	param quota int64
+//	return reserved bool
+//	return err error
+//	author centonhuang
+//	update 2025-11-17 10:26:42
+func (dao *UserDAO) ReserveStorage(db *gorm.DB, userID uint, size, quota int64) (reserved bool, err error) {
+	result := db.Model(&model.User{}).
+		Where("id = ? AND storage_usage + ? <= ?", userID, size, quota).
+		UpdateColumn("storage_usage", gorm.Expr("storage_usage + ?", size))
+	return result.RowsAffected > 0, result.Error
+}
+
+// AdjustStorageUsage 按增量调整用户的存储用量，不检查配额且不低于0
+//
+//	receiver dao *UserDAO
+//	param db *gorm.DB
+//	param userID uint
+//	param delta int64
+//	return err error
+//	author centonhuang
+//	update 2025-11-17 10:26:42
+func (dao *UserDAO) AdjustStorageUsage(db *gorm.DB, userID uint, delta int64) (err error) {
+	err = db.Model(&model.User{}).
+		Where("id = ?", userID).
+		UpdateColumn("storage_usage", gorm.Expr("GREATEST(storage_usage + ?, 0)", delta)).Error
+	return
+}
+
+// SetStorageUsage 覆盖用户的存储用量，用于按对象存储实际用量校正
+//
+//	receiver dao *UserDAO
+//	param db *gorm.DB
+//	param userID uint
+//	param usage int64
+//	return err error
+//	author centonhuang
+//	update 2025-11-17 10:26:42
+func (dao *UserDAO) SetStorageUsage(db *gorm.DB, userID uint, usage int64) (err error) {
+	err = db.Model(&model.User{}).Where("id = ?", userID).UpdateColumn("storage_usage", usage).Error
+	return
+}
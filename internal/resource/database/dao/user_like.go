@@ -107,3 +107,43 @@ func (dao *UserLikeDAO) PaginateByUserIDAndObjectType(db *gorm.DB, userID uint,
 
 	return
 }
+
+// ListByUserID 列出用户的全部点赞
+//
+//	receiver dao *UserLikeDAO
+//	param db *gorm.DB
+//	param userID uint
+//	param fields []string
+//	return userLikes *[]model.UserLike
+//	return err error
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+func (dao *UserLikeDAO) ListByUserID(db *gorm.DB, userID uint, fields []string) (userLikes *[]model.UserLike, err error) {
+	err = db.Select(fields).Where(&model.UserLike{UserID: userID}).Order("id").Find(&userLikes).Error
+	return
+}
+
+// DeleteByUserID 撤销用户的全部点赞，并扣减被点赞对象的点赞数
+//
+//	receiver dao *UserLikeDAO
+//	param db *gorm.DB
+//	param userID uint
+//	return err error
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+func (dao *UserLikeDAO) DeleteByUserID(db *gorm.DB, userID uint) (err error) {
+	tables := map[model.LikeObjectType]string{
+		model.LikeObjectTypeArticle: "articles",
+		model.LikeObjectTypeComment: "comments",
+		model.LikeObjectTypeTag:     "tags",
+	}
+	for objectType, table := range tables {
+		objectIDs := db.Model(&model.UserLike{}).Select("object_id").Where(&model.UserLike{UserID: userID, ObjectType: objectType})
+		if err = db.Table(table).Where("id IN (?)", objectIDs).Where("likes > 0").
+			UpdateColumn("likes", gorm.Expr("likes - 1")).Error; err != nil {
+			return
+		}
+	}
+	err = db.Unscoped().Where(&model.UserLike{UserID: userID}).Delete(&model.UserLike{}).Error
+	return
+}
@@ -88,3 +88,31 @@ func (dao *UserViewDAO) PaginateByUserID(db *gorm.DB, userID uint, fields, prelo
 	err = db.Model(&userViews).Where(model.UserView{UserID: userID}).Count(&pageInfo.Total).Error
 	return
 }
+
+// ListByUserID 列出用户的全部浏览记录
+//
+//	receiver dao *UserViewDAO
+//	param db *gorm.DB
+//	param userID uint
+//	param fields []string
+//	return userViews *[]model.UserView
+//	return err error
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+func (dao *UserViewDAO) ListByUserID(db *gorm.DB, userID uint, fields []string) (userViews *[]model.UserView, err error) {
+	err = db.Select(fields).Where(&model.UserView{UserID: userID}).Order("id").Find(&userViews).Error
+	return
+}
+
+// DeleteByUserID 删除用户的全部浏览记录
+//
+//	receiver dao *UserViewDAO
+//	param db *gorm.DB
+//	param userID uint
+//	return err error
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+func (dao *UserViewDAO) DeleteByUserID(db *gorm.DB, userID uint) (err error) {
+	err = db.Unscoped().Where(&model.UserView{UserID: userID}).Delete(&model.UserView{}).Error
+	return
+}
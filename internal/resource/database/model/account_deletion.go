@@ -0,0 +1,63 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AccountDeletionStatus 注销申请状态
+//
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+type AccountDeletionStatus string
+
+const (
+
+	// AccountDeletionStatusPending AccountDeletionStatus 冷静期中，等待执行
+	//	update 2025-11-14 10:35:21
+	AccountDeletionStatusPending AccountDeletionStatus = "pending"
+
+	// AccountDeletionStatusCancelled AccountDeletionStatus 用户在冷静期内撤销
+	//	update 2025-11-14 10:35:21
+	AccountDeletionStatusCancelled AccountDeletionStatus = "cancelled"
+
+	// AccountDeletionStatusCompleted AccountDeletionStatus 已注销
+	//	update 2025-11-14 10:35:21
+	AccountDeletionStatusCompleted AccountDeletionStatus = "completed"
+)
+
+// ArticleDisposition 注销时对用户文章的处理方式
+//
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+type ArticleDisposition string
+
+const (
+
+	// ArticleDispositionDelete ArticleDisposition 删除全部文章
+	//	update 2025-11-14 10:35:21
+	ArticleDispositionDelete ArticleDisposition = "delete"
+
+	// ArticleDispositionTransfer ArticleDisposition 将全部文章转让给其他用户
+	//	update 2025-11-14 10:35:21
+	ArticleDispositionTransfer ArticleDisposition = "transfer"
+)
+
+// AccountDeletion 账号注销申请，冷静期结束后由定时任务执行
+//
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+type AccountDeletion struct {
+	gorm.Model
+	ID                 uint                  `json:"id" gorm:"column:id;primary_key;auto_increment;comment:注销申请ID"`
+	UserID             uint                  `json:"user_id" gorm:"column:user_id;not null;index;comment:用户ID"`
+	Status             AccountDeletionStatus `json:"status" gorm:"column:status;not null;default:'pending';index;comment:注销状态"`
+	ArticleDisposition ArticleDisposition    `json:"article_disposition" gorm:"column:article_disposition;not null;default:'delete';comment:文章处理方式"`
+	TransferToUserID   uint                  `json:"transfer_to_user_id" gorm:"column:transfer_to_user_id;default:NULL;comment:文章受让用户ID"`
+	Reason             string                `json:"reason" gorm:"column:reason;comment:注销原因"`
+	ScheduledAt        time.Time             `json:"scheduled_at" gorm:"column:scheduled_at;not null;index;comment:计划执行时间"`
+	Retries            uint                  `json:"retries" gorm:"column:retries;default:0;comment:重试次数"`
+	LastError          string                `json:"last_error" gorm:"column:last_error;comment:最近一次错误"`
+	CompletedAt        time.Time             `json:"completed_at" gorm:"column:completed_at;default:NULL;comment:注销完成时间"`
+}
@@ -0,0 +1,54 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ArticleSuggestionStatus 文章AI建议状态
+//
+//	author centonhuang
+//	update 2025-11-09 16:40:12
+type ArticleSuggestionStatus string
+
+const (
+
+	// ArticleSuggestionStatusPending ArticleSuggestionStatus 等待生成
+	//	update 2025-11-09 16:40:12
+	ArticleSuggestionStatusPending ArticleSuggestionStatus = "pending"
+
+	// ArticleSuggestionStatusFailed ArticleSuggestionStatus 生成失败，等待重试
+	//	update 2025-11-09 16:40:12
+	ArticleSuggestionStatusFailed ArticleSuggestionStatus = "failed"
+
+	// ArticleSuggestionStatusGenerated ArticleSuggestionStatus 已生成，等待作者确认
+	//	update 2025-11-09 16:40:12
+	ArticleSuggestionStatusGenerated ArticleSuggestionStatus = "generated"
+
+	// ArticleSuggestionStatusAccepted ArticleSuggestionStatus 作者已采纳
+	//	update 2025-11-09 16:40:12
+	ArticleSuggestionStatusAccepted ArticleSuggestionStatus = "accepted"
+
+	// ArticleSuggestionStatusRejected ArticleSuggestionStatus 作者已拒绝
+	//	update 2025-11-09 16:40:12
+	ArticleSuggestionStatusRejected ArticleSuggestionStatus = "rejected"
+)
+
+// ArticleSuggestion 文章发布后的AI摘要与标签建议
+//
+//	author centonhuang
+//	update 2025-11-09 16:40:12
+type ArticleSuggestion struct {
+	gorm.Model
+	ID               uint                    `json:"id" gorm:"column:id;primary_key;auto_increment;comment:建议ID"`
+	ArticleID        uint                    `json:"article_id" gorm:"column:article_id;not null;index;comment:文章ID"`
+	ArticleVersionID uint                    `json:"article_version_id" gorm:"column:article_version_id;not null;comment:文章版本ID"`
+	UserID           uint                    `json:"user_id" gorm:"column:user_id;not null;comment:作者ID"`
+	Status           ArticleSuggestionStatus `json:"status" gorm:"column:status;not null;default:'pending';index;comment:建议状态"`
+	Summary          string                  `json:"summary" gorm:"column:summary;type:TEXT;comment:AI摘要"`
+	TagIDs           []uint                  `json:"tag_ids" gorm:"column:tag_ids;type:json;serializer:json;comment:建议标签ID"`
+	Retries          uint                    `json:"retries" gorm:"column:retries;default:0;comment:重试次数"`
+	LastError        string                  `json:"last_error" gorm:"column:last_error;comment:最近一次错误"`
+	NextRetryAt      time.Time               `json:"next_retry_at" gorm:"column:next_retry_at;index;comment:下次执行时间"`
+}
@@ -4,16 +4,77 @@ import (
 	"gorm.io/gorm"
 )
 
+// Language 文章语言
+//
+//	author centonhuang
+//	update 2025-11-08 15:20:11
+type Language string
+
+const (
+
+	// LanguageZh Language 中文
+	//	update 2025-11-08 15:20:11
+	LanguageZh Language = "zh"
+
+	// LanguageEn Language 英文
+	//	update 2025-11-08 15:20:11
+	LanguageEn Language = "en"
+
+	// LanguageJa Language 日文
+	//	update 2025-11-08 15:20:11
+	LanguageJa Language = "ja"
+
+	// LanguageKo Language 韩文
+	//	update 2025-11-08 15:20:11
+	LanguageKo Language = "ko"
+
+	// LanguageFr Language 法文
+	//	update 2025-11-08 15:20:11
+	LanguageFr Language = "fr"
+
+	// LanguageDe Language 德文
+	//	update 2025-11-08 15:20:11
+	LanguageDe Language = "de"
+
+	// LanguageEs Language 西班牙文
+	//	update 2025-11-08 15:20:11
+	LanguageEs Language = "es"
+
+	// LanguageRu Language 俄文
+	//	update 2025-11-08 15:20:11
+	LanguageRu Language = "ru"
+
+	// LanguageDefault Language 文章原文默认语言
+	//	update 2025-11-08 15:20:11
+	LanguageDefault = LanguageZh
+)
+
+// LanguageNameMapping 语言名称映射，用于提示词
+//
+//	update 2025-11-08 15:20:11
+var LanguageNameMapping = map[Language]string{
+	LanguageZh: "Simplified Chinese",
+	LanguageEn: "English",
+	LanguageJa: "Japanese",
+	LanguageKo: "Korean",
+	LanguageFr: "French",
+	LanguageDe: "German",
+	LanguageEs: "Spanish",
+	LanguageRu: "Russian",
+}
+
 // ArticleVersion 文章版本
 //
 //	author centonhuang
 //	update 2024-09-21 06:47:31
 type ArticleVersion struct {
 	gorm.Model
-	ArticleID   uint     `json:"article_id" gorm:"column:article_id;uniqueIndex:idx_article_version;comment:文章ID"`
-	Article     *Article `json:"article" gorm:"foreignKey:ArticleID"`
-	Version     uint     `json:"version" gorm:"column:version;uniqueIndex:idx_article_version;comment:版本号"`
-	Content     string   `json:"content" gorm:"column:content;type:TEXT;comment:文章内容"`
-	Description string   `json:"description" gorm:"column:description;comment:版本描述"`
-	Summary     string   `json:"summary" gorm:"column:summary;type:TEXT;comment:版本摘要"`
+	ArticleID       uint     `json:"article_id" gorm:"column:article_id;uniqueIndex:idx_article_language_version;comment:文章ID"`
+	Article         *Article `json:"article" gorm:"foreignKey:ArticleID"`
+	Language        Language `json:"language" gorm:"column:language;not null;default:'zh';uniqueIndex:idx_article_language_version;comment:语言"`
+	Version         uint     `json:"version" gorm:"column:version;uniqueIndex:idx_article_language_version;comment:版本号"`
+	Content         string   `json:"content" gorm:"column:content;type:TEXT;comment:文章内容"`
+	Description     string   `json:"description" gorm:"column:description;comment:版本描述"`
+	Summary         string   `json:"summary" gorm:"column:summary;type:TEXT;comment:版本摘要"`
+	SourceVersionID uint     `json:"source_version_id" gorm:"column:source_version_id;default:NULL;comment:翻译来源版本ID"`
 }
@@ -0,0 +1,24 @@
+package model
+
+import (
+	"gorm.io/gorm"
+)
+
+// Asset 用户图片资产，对象按内容摘要存储，同一用户内容相同的图片共用一个对象
+//
+//	author centonhuang
+//	update 2025-11-16 16:38:25
+type Asset struct {
+	gorm.Model
+	ID            uint   `json:"id" gorm:"column:id;primary_key;auto_increment;comment:资产ID"`
+	UserID        uint   `json:"user_id" gorm:"column:user_id;not null;uniqueIndex:idx_asset_user_object;index:idx_asset_user_hash;comment:用户ID"`
+	ObjectName    string `json:"object_name" gorm:"column:object_name;not null;uniqueIndex:idx_asset_user_object;comment:图片名称"`
+	StorageName   string `json:"storage_name" gorm:"column:storage_name;not null;comment:对象存储中的对象名，由内容摘要与扩展名组成"`
+	ContentHash   string `json:"content_hash" gorm:"column:content_hash;not null;index:idx_asset_user_hash;comment:去除元数据后内容的SHA-256摘要"`
+	ContentType   string `json:"content_type" gorm:"column:content_type;not null;comment:内容类型"`
+	Size          int64  `json:"size" gorm:"column:size;not null;comment:字节数"`
+	Width         int    `json:"width" gorm:"column:width;not null;comment:宽度像素"`
+	Height        int    `json:"height" gorm:"column:height;not null;comment:高度像素"`
+	BlurHash      string `json:"blur_hash" gorm:"column:blur_hash;comment:BlurHash占位图"`
+	DominantColor string `json:"dominant_color" gorm:"column:dominant_color;comment:主色调，#rrggbb"`
+}
@@ -0,0 +1,77 @@
+package model
+
+import (
+	"gorm.io/gorm"
+)
+
+// AttachmentKind 附件类别
+//
+//	author centonhuang
+//	update 2025-11-18 10:21:37
+type AttachmentKind string
+
+const (
+
+	// AttachmentKindDocument AttachmentKind 文档，如PDF与幻灯片
+	//	update 2025-11-18 10:21:37
+	AttachmentKindDocument AttachmentKind = "document"
+
+	// AttachmentKindAudio AttachmentKind 音频，如播客
+	//	update 2025-11-18 10:21:37
+	AttachmentKindAudio AttachmentKind = "audio"
+
+	// AttachmentKindVideo AttachmentKind 视频
+	//	update 2025-11-18 10:21:37
+	AttachmentKindVideo AttachmentKind = "video"
+
+	// AttachmentKindArchive AttachmentKind 压缩包，如示例代码
+	//	update 2025-11-18 10:21:37
+	AttachmentKindArchive AttachmentKind = "archive"
+)
+
+const (
+
+	// AttachmentMaxSizeDocument int64 文档附件大小上限
+	//	update 2025-11-18 10:21:37
+	AttachmentMaxSizeDocument int64 = 50 << 20
+
+	// AttachmentMaxSizeAudio int64 音频附件大小上限
+	//	update 2025-11-18 10:21:37
+	AttachmentMaxSizeAudio int64 = 200 << 20
+
+	// AttachmentMaxSizeVideo int64 视频附件大小上限
+	//	update 2025-11-18 10:21:37
+	AttachmentMaxSizeVideo int64 = 500 << 20
+
+	// AttachmentMaxSizeArchive int64 压缩包附件大小上限
+	//	update 2025-11-18 10:21:37
+	AttachmentMaxSizeArchive int64 = 100 << 20
+)
+
+// AttachmentKindMaxSizeMapping 附件类别与大小上限的映射
+//
+//	update 2025-11-18 10:21:37
+var AttachmentKindMaxSizeMapping = map[AttachmentKind]int64{
+	AttachmentKindDocument: AttachmentMaxSizeDocument,
+	AttachmentKindAudio:    AttachmentMaxSizeAudio,
+	AttachmentKindVideo:    AttachmentMaxSizeVideo,
+	AttachmentKindArchive:  AttachmentMaxSizeArchive,
+}
+
+// Attachment 文章附件，对象由浏览器直传，确认时按文件头识别真实类型
+//
+//	author centonhuang
+//	update 2025-11-18 10:21:37
+type Attachment struct {
+	gorm.Model
+	ID          uint           `json:"id" gorm:"column:id;primary_key;auto_increment;comment:附件ID"`
+	UserID      uint           `json:"user_id" gorm:"column:user_id;not null;index;comment:用户ID"`
+	ArticleID   uint           `json:"article_id" gorm:"column:article_id;not null;uniqueIndex:idx_attachment_article_file;comment:文章ID"`
+	FileName    string         `json:"file_name" gorm:"column:file_name;not null;uniqueIndex:idx_attachment_article_file;comment:文件名"`
+	StorageName string         `json:"storage_name" gorm:"column:storage_name;not null;comment:对象存储中的对象名"`
+	Kind        AttachmentKind `json:"kind" gorm:"column:kind;not null;comment:附件类别"`
+	ContentType string         `json:"content_type" gorm:"column:content_type;not null;comment:按文件头识别的内容类型"`
+	Size        int64          `json:"size" gorm:"column:size;not null;comment:字节数"`
+	PreviewName string         `json:"preview_name" gorm:"column:preview_name;comment:预览图对象名，无法生成时为空"`
+	PreviewSize int64          `json:"preview_size" gorm:"column:preview_size;default:0;comment:预览图字节数"`
+}
@@ -0,0 +1,198 @@
+package model
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// AuditAction 审计动作
+//
+//	author centonhuang
+//	update 2025-11-10 15:32:08
+type AuditAction string
+
+const (
+
+	// AuditActionUserPermissionUpdate AuditAction 修改用户权限
+	//	update 2025-11-10 15:32:08
+	AuditActionUserPermissionUpdate AuditAction = "user.permission.update"
+
+	// AuditActionUserQuotaUpdate AuditAction 调整用户LLM配额
+	//	update 2025-11-10 15:32:08
+	AuditActionUserQuotaUpdate AuditAction = "user.quota.update"
+
+	// AuditActionUserStatusUpdate AuditAction 修改用户账号状态
+	//	update 2025-11-10 15:32:08
+	AuditActionUserStatusUpdate AuditAction = "user.status.update"
+
+	// AuditActionCreatorApplicationApprove AuditAction 通过创作者申请
+	//	update 2025-11-11 10:20:36
+	AuditActionCreatorApplicationApprove AuditAction = "creator_application.approve"
+
+	// AuditActionCreatorApplicationReject AuditAction 拒绝创作者申请
+	//	update 2025-11-11 10:20:36
+	AuditActionCreatorApplicationReject AuditAction = "creator_application.reject"
+
+	// AuditActionUserLogin AuditAction 用户登录
+	//	update 2025-11-11 14:48:02
+	AuditActionUserLogin AuditAction = "user.login"
+
+	// AuditActionArticleDelete AuditAction 删除文章
+	//	update 2025-11-11 14:48:02
+	AuditActionArticleDelete AuditAction = "article.delete"
+
+	// AuditActionTagCreate AuditAction 创建标签
+	//	update 2025-11-11 14:48:02
+	AuditActionTagCreate AuditAction = "tag.create"
+
+	// AuditActionTagUpdate AuditAction 修改标签
+	//	update 2025-11-11 14:48:02
+	AuditActionTagUpdate AuditAction = "tag.update"
+
+	// AuditActionTagDelete AuditAction 删除标签
+	//	update 2025-11-11 14:48:02
+	AuditActionTagDelete AuditAction = "tag.delete"
+
+	// AuditActionPromptCreate AuditAction 创建提示词
+	//	update 2025-11-11 14:48:02
+	AuditActionPromptCreate AuditAction = "prompt.create"
+
+	// AuditActionUserRolesUpdate AuditAction 修改用户角色
+	//	update 2025-11-11 17:05:24
+	AuditActionUserRolesUpdate AuditAction = "user.roles.update"
+
+	// AuditActionRoleCreate AuditAction 创建角色
+	//	update 2025-11-11 17:05:24
+	AuditActionRoleCreate AuditAction = "role.create"
+
+	// AuditActionRoleUpdate AuditAction 修改角色
+	//	update 2025-11-11 17:05:24
+	AuditActionRoleUpdate AuditAction = "role.update"
+
+	// AuditActionRoleDelete AuditAction 删除角色
+	//	update 2025-11-11 17:05:24
+	AuditActionRoleDelete AuditAction = "role.delete"
+
+	// AuditActionSessionReuseDetected AuditAction 检测到刷新令牌重放并吊销会话
+	//	update 2025-11-11 20:12:40
+	AuditActionSessionReuseDetected AuditAction = "session.reuse_detected"
+
+	// AuditActionPersonalAccessTokenCreate AuditAction 创建个人访问令牌
+	//	update 2025-11-12 14:02:51
+	AuditActionPersonalAccessTokenCreate AuditAction = "personal_access_token.create"
+
+	// AuditActionPersonalAccessTokenRevoke AuditAction 吊销个人访问令牌
+	//	update 2025-11-12 14:02:51
+	AuditActionPersonalAccessTokenRevoke AuditAction = "personal_access_token.revoke"
+
+	// AuditActionUserOauth2Link AuditAction 绑定第三方登录
+	//	update 2025-11-13 10:12:37
+	AuditActionUserOauth2Link AuditAction = "user.oauth2.link"
+
+	// AuditActionUserOauth2Unlink AuditAction 解绑第三方登录
+	//	update 2025-11-13 10:12:37
+	AuditActionUserOauth2Unlink AuditAction = "user.oauth2.unlink"
+
+	// AuditActionUserDataExport AuditAction 申请导出个人数据
+	//	update 2025-11-14 10:35:21
+	AuditActionUserDataExport AuditAction = "user.data_export"
+
+	// AuditActionUserDeletionRequest AuditAction 申请注销账号
+	//	update 2025-11-14 10:35:21
+	AuditActionUserDeletionRequest AuditAction = "user.deletion.request"
+
+	// AuditActionUserDeletionCancel AuditAction 撤销注销申请
+	//	update 2025-11-14 10:35:21
+	AuditActionUserDeletionCancel AuditAction = "user.deletion.cancel"
+
+	// AuditActionUserDeletionComplete AuditAction 冷静期结束后注销账号
+	//	update 2025-11-14 10:35:21
+	AuditActionUserDeletionComplete AuditAction = "user.deletion.complete"
+)
+
+// AuditTargetType 审计对象类型
+//
+//	author centonhuang
+//	update 2025-11-10 15:32:08
+type AuditTargetType string
+
+const (
+
+	// AuditTargetTypeUser AuditTargetType 用户
+	//	update 2025-11-10 15:32:08
+	AuditTargetTypeUser AuditTargetType = "user"
+
+	// AuditTargetTypeCreatorApplication AuditTargetType 创作者申请
+	//	update 2025-11-11 10:20:36
+	AuditTargetTypeCreatorApplication AuditTargetType = "creator_application"
+
+	// AuditTargetTypeArticle AuditTargetType 文章
+	//	update 2025-11-11 14:48:02
+	AuditTargetTypeArticle AuditTargetType = "article"
+
+	// AuditTargetTypeTag AuditTargetType 标签
+	//	update 2025-11-11 14:48:02
+	AuditTargetTypeTag AuditTargetType = "tag"
+
+	// AuditTargetTypePrompt AuditTargetType 提示词
+	//	update 2025-11-11 14:48:02
+	AuditTargetTypePrompt AuditTargetType = "prompt"
+
+	// AuditTargetTypeRole AuditTargetType 角色
+	//	update 2025-11-11 17:05:24
+	AuditTargetTypeRole AuditTargetType = "role"
+
+	// AuditTargetTypeSession AuditTargetType 登录会话
+	//	update 2025-11-11 20:12:40
+	AuditTargetTypeSession AuditTargetType = "session"
+
+	// AuditTargetTypePersonalAccessToken AuditTargetType 个人访问令牌
+	//	update 2025-11-12 14:02:51
+	AuditTargetTypePersonalAccessToken AuditTargetType = "personal_access_token"
+)
+
+// ErrAuditEventImmutable 审计事件只允许追加
+//
+//	update 2025-11-11 14:48:02
+var ErrAuditEventImmutable = errors.New("audit event is append-only")
+
+// AuditEvent 审计事件，只允许追加，不允许修改与删除
+//
+//	author centonhuang
+//	update 2025-11-11 14:48:02
+type AuditEvent struct {
+	gorm.Model
+	ID         uint            `json:"id" gorm:"column:id;primary_key;auto_increment;comment:审计事件ID"`
+	ActorID    uint            `json:"actor_id" gorm:"column:actor_id;not null;index;comment:操作者ID"`
+	Action     AuditAction     `json:"action" gorm:"column:action;not null;index;comment:审计动作"`
+	TargetType AuditTargetType `json:"target_type" gorm:"column:target_type;not null;index:idx_audit_target;comment:对象类型"`
+	TargetID   uint            `json:"target_id" gorm:"column:target_id;not null;index:idx_audit_target;comment:对象ID"`
+	Before     map[string]any  `json:"before" gorm:"column:before;type:json;serializer:json;comment:变更前字段"`
+	After      map[string]any  `json:"after" gorm:"column:after;type:json;serializer:json;comment:变更后字段"`
+	IP         string          `json:"ip" gorm:"column:ip;comment:客户端IP"`
+	UserAgent  string          `json:"user_agent" gorm:"column:user_agent;comment:客户端UA"`
+	TraceID    string          `json:"trace_id" gorm:"column:trace_id;index;comment:追踪ID"`
+}
+
+// BeforeUpdate 禁止修改审计事件
+//
+//	receiver e *AuditEvent
+//	param _ *gorm.DB
+//	return error
+//	author centonhuang
+//	update 2025-11-11 14:48:02
+func (e *AuditEvent) BeforeUpdate(_ *gorm.DB) error {
+	return ErrAuditEventImmutable
+}
+
+// BeforeDelete 禁止删除审计事件
+//
+//	receiver e *AuditEvent
+//	param _ *gorm.DB
+//	return error
+//	author centonhuang
+//	update 2025-11-11 14:48:02
+func (e *AuditEvent) BeforeDelete(_ *gorm.DB) error {
+	return ErrAuditEventImmutable
+}
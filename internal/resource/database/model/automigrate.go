@@ -13,4 +13,16 @@ var Models = []interface{}{
 	&UserLike{},
 	&UserView{},
 	&Prompt{},
+	&ArticleSuggestion{},
+	&AuditEvent{},
+	&CreatorApplication{},
+	&Role{},
+	&Session{},
+	&JwtKey{},
+	&PersonalAccessToken{},
+	&OidcIdentity{},
+	&DataExport{},
+	&AccountDeletion{},
+	&Asset{},
+	&Attachment{},
 }
@@ -10,7 +10,7 @@ type Comment struct {
 	gorm.Model
 	ID        uint      `json:"id" gorm:"column:id;primary_key;auto_increment;comment:'评论ID'"`
 	ArticleID uint      `json:"article_id" gorm:"column:article_id;not null;comment:'文章ID'"`
-	UserID    uint      `json:"user_id" gorm:"column:user_id;not null;comment:'用户ID'"`
+	UserID    uint      `json:"user_id" gorm:"column:user_id;default:NULL;comment:'用户ID，作者注销后为空'"`
 	Content   string    `json:"content" gorm:"column:content;not null;comment:'评论内容'"`
 	ParentID  uint      `json:"parent_id" gorm:"column:parent_id;default:NULL;comment:'父评论ID'"`
 	Likes     uint      `json:"likes" gorm:"column:likes;default:0;comment:'点赞数'"`
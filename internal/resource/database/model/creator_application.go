@@ -0,0 +1,45 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CreatorApplicationStatus 创作者申请状态
+//
+//	author centonhuang
+//	update 2025-11-11 10:20:36
+type CreatorApplicationStatus string
+
+const (
+
+	// CreatorApplicationStatusPending CreatorApplicationStatus 待审核
+	//	update 2025-11-11 10:20:36
+	CreatorApplicationStatusPending CreatorApplicationStatus = "pending"
+
+	// CreatorApplicationStatusApproved CreatorApplicationStatus 已通过
+	//	update 2025-11-11 10:20:36
+	CreatorApplicationStatusApproved CreatorApplicationStatus = "approved"
+
+	// CreatorApplicationStatusRejected CreatorApplicationStatus 已拒绝
+	//	update 2025-11-11 10:20:36
+	CreatorApplicationStatusRejected CreatorApplicationStatus = "rejected"
+)
+
+// CreatorApplication 创作者申请
+//
+//	author centonhuang
+//	update 2025-11-11 10:20:36
+type CreatorApplication struct {
+	gorm.Model
+	ID            uint                     `json:"id" gorm:"column:id;primary_key;auto_increment;comment:申请ID"`
+	UserID        uint                     `json:"user_id" gorm:"column:user_id;not null;index;comment:申请人ID"`
+	User          *User                    `json:"user" gorm:"foreignKey:UserID"`
+	Reason        string                   `json:"reason" gorm:"column:reason;type:TEXT;not null;comment:申请理由"`
+	Links         []string                 `json:"links" gorm:"column:links;type:json;serializer:json;comment:作品链接"`
+	Status        CreatorApplicationStatus `json:"status" gorm:"column:status;not null;default:'pending';index;comment:申请状态"`
+	ReviewerID    uint                     `json:"reviewer_id" gorm:"column:reviewer_id;comment:审核人ID"`
+	ReviewComment string                   `json:"review_comment" gorm:"column:review_comment;comment:审核意见"`
+	ReviewedAt    time.Time                `json:"reviewed_at" gorm:"column:reviewed_at;default:NULL;comment:审核时间"`
+}
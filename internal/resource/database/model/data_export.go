@@ -0,0 +1,50 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DataExportStatus 数据导出状态
+//
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+type DataExportStatus string
+
+const (
+
+	// DataExportStatusPending DataExportStatus 等待打包
+	//	update 2025-11-14 10:35:21
+	DataExportStatusPending DataExportStatus = "pending"
+
+	// DataExportStatusFailed DataExportStatus 打包失败，等待重试
+	//	update 2025-11-14 10:35:21
+	DataExportStatusFailed DataExportStatus = "failed"
+
+	// DataExportStatusReady DataExportStatus 已打包，可下载
+	//	update 2025-11-14 10:35:21
+	DataExportStatusReady DataExportStatus = "ready"
+
+	// DataExportStatusExpired DataExportStatus 已过期，归档已删除
+	//	update 2025-11-14 10:35:21
+	DataExportStatusExpired DataExportStatus = "expired"
+)
+
+// DataExport 用户数据导出，归档打包完成后保存在用户的export目录中
+//
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+type DataExport struct {
+	gorm.Model
+	ID          uint             `json:"id" gorm:"column:id;primary_key;auto_increment;comment:导出ID"`
+	UserID      uint             `json:"user_id" gorm:"column:user_id;not null;index;comment:用户ID"`
+	Status      DataExportStatus `json:"status" gorm:"column:status;not null;default:'pending';index;comment:导出状态"`
+	ObjectName  string           `json:"object_name" gorm:"column:object_name;comment:归档对象名"`
+	Size        int64            `json:"size" gorm:"column:size;default:0;comment:归档大小"`
+	Retries     uint             `json:"retries" gorm:"column:retries;default:0;comment:重试次数"`
+	LastError   string           `json:"last_error" gorm:"column:last_error;comment:最近一次错误"`
+	NextRetryAt time.Time        `json:"next_retry_at" gorm:"column:next_retry_at;index;comment:下次执行时间"`
+	ReadyAt     time.Time        `json:"ready_at" gorm:"column:ready_at;default:NULL;comment:打包完成时间"`
+	ExpiresAt   time.Time        `json:"expires_at" gorm:"column:expires_at;default:NULL;index;comment:归档过期时间"`
+}
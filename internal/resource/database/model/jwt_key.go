@@ -0,0 +1,23 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// JwtKey JWT非对称签名密钥
+//
+// 最新创建且未设置过期时间的密钥用于签名；轮换后旧密钥仅用于验签，直到其签发的令牌全部过期
+//
+//	author centonhuang
+//	update 2025-11-12 10:24:06
+type JwtKey struct {
+	gorm.Model
+	ID         uint      `json:"id" gorm:"column:id;primary_key;auto_increment;comment:密钥ID"`
+	KID        string    `json:"kid" gorm:"column:kid;not null;uniqueIndex;comment:JWK密钥标识"`
+	Algorithm  string    `json:"algorithm" gorm:"column:algorithm;not null;comment:签名算法"`
+	PrivateKey string    `json:"-" gorm:"column:private_key;type:TEXT;not null;comment:PKCS8私钥PEM"`
+	PublicKey  string    `json:"public_key" gorm:"column:public_key;type:TEXT;not null;comment:PKIX公钥PEM"`
+	ExpiresAt  time.Time `json:"expires_at" gorm:"column:expires_at;default:NULL;index;comment:停止验签时间"`
+}
@@ -0,0 +1,15 @@
+package model
+
+import "gorm.io/gorm"
+
+// OidcIdentity 用户在通用OIDC提供商的身份，内置提供商的绑定ID仍保存在用户表中
+//
+//	author centonhuang
+//	update 2025-11-13 19:26:03
+type OidcIdentity struct {
+	gorm.Model
+	ID       uint   `json:"id" gorm:"column:id;primary_key;auto_increment;comment:身份ID"`
+	UserID   uint   `json:"user_id" gorm:"column:user_id;not null;uniqueIndex:idx_oidc_identity_user;comment:用户ID"`
+	Provider string `json:"provider" gorm:"column:provider;not null;uniqueIndex:idx_oidc_identity_subject;uniqueIndex:idx_oidc_identity_user;comment:OIDC提供商名称"`
+	Subject  string `json:"subject" gorm:"column:subject;not null;uniqueIndex:idx_oidc_identity_subject;comment:ID Token中的sub"`
+}
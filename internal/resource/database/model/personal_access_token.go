@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PersonalAccessToken 个人访问令牌，用于CI等自动化场景调用API
+//
+//	author centonhuang
+//	update 2025-11-12 14:02:51
+type PersonalAccessToken struct {
+	gorm.Model
+	ID         uint      `json:"id" gorm:"column:id;primary_key;auto_increment;comment:令牌ID"`
+	UserID     uint      `json:"user_id" gorm:"column:user_id;not null;index;comment:用户ID"`
+	Name       string    `json:"name" gorm:"column:name;not null;comment:令牌名称"`
+	TokenHash  string    `json:"-" gorm:"column:token_hash;not null;uniqueIndex;comment:令牌SHA-256摘要"`
+	Prefix     string    `json:"prefix" gorm:"column:prefix;not null;comment:令牌明文前缀，用于识别"`
+	Scopes     []Scope   `json:"scopes" gorm:"column:scopes;type:json;serializer:json;comment:权限项"`
+	ExpiresAt  time.Time `json:"expires_at" gorm:"column:expires_at;not null;comment:过期时间"`
+	LastUsedAt time.Time `json:"last_used_at" gorm:"column:last_used_at;default:NULL;comment:最近使用时间"`
+	LastUsedIP string    `json:"last_used_ip" gorm:"column:last_used_ip;comment:最近使用的客户端IP"`
+	RevokedAt  time.Time `json:"revoked_at" gorm:"column:revoked_at;default:NULL;comment:吊销时间"`
+}
+
+// IsActive 令牌是否未吊销且未过期
+//
+//	receiver t *PersonalAccessToken
+//	param now time.Time
+//	return bool
+//	author centonhuang
+//	update 2025-11-12 14:02:51
+func (t *PersonalAccessToken) IsActive(now time.Time) bool {
+	return t.RevokedAt.IsZero() && now.Before(t.ExpiresAt)
+}
@@ -40,6 +40,10 @@ const (
 	// TaskTermExplaination Task 术语解释
 	//	update 2024-12-09 16:13:42
 	TaskTermExplaination Task = "termExplaination"
+
+	// TaskArticleTagSuggestion Task 文章标签建议
+	//	update 2025-11-09 16:40:12
+	TaskArticleTagSuggestion Task = "articleTagSuggestion"
 )
 
 // Prompt 提示词
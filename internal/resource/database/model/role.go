@@ -0,0 +1,128 @@
+package model
+
+import (
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+)
+
+// Scope 权限项，格式为 资源:动作
+//
+//	author centonhuang
+//	update 2025-11-11 17:05:24
+type Scope string
+
+const (
+
+	// ScopeAll Scope 全部权限
+	//	update 2025-11-11 17:05:24
+	ScopeAll Scope = "*"
+
+	// ScopeArticleWrite Scope 撰写与管理自己的文章
+	//	update 2025-11-11 17:05:24
+	ScopeArticleWrite Scope = "article:write"
+
+	// ScopeAssetWrite Scope 上传与管理图片
+	//	update 2025-11-11 17:05:24
+	ScopeAssetWrite Scope = "asset:write"
+
+	// ScopeTagManage Scope 创建与管理标签
+	//	update 2025-11-11 17:05:24
+	ScopeTagManage Scope = "tag:manage"
+
+	// ScopeCommentModerate Scope 删除任意评论
+	//	update 2025-11-11 17:05:24
+	ScopeCommentModerate Scope = "comment:moderate"
+
+	// ScopePromptRead Scope 查看提示词
+	//	update 2025-11-11 17:05:24
+	ScopePromptRead Scope = "prompt:read"
+
+	// ScopePromptWrite Scope 创建提示词版本
+	//	update 2025-11-11 17:05:24
+	ScopePromptWrite Scope = "prompt:write"
+
+	// ScopeUserManage Scope 管理用户权限、配额与账号状态
+	//	update 2025-11-11 17:05:24
+	ScopeUserManage Scope = "user:manage"
+
+	// ScopeRoleManage Scope 管理角色及用户角色
+	//	update 2025-11-11 17:05:24
+	ScopeRoleManage Scope = "role:manage"
+
+	// ScopeCreatorReview Scope 审核创作者申请
+	//	update 2025-11-11 17:05:24
+	ScopeCreatorReview Scope = "creator:review"
+
+	// ScopeAuditRead Scope 查看与导出审计日志
+	//	update 2025-11-11 17:05:24
+	ScopeAuditRead Scope = "audit:read"
+)
+
+var (
+
+	// Scopes 全部可分配的权限项
+	//	update 2025-11-11 17:05:24
+	Scopes = []Scope{
+		ScopeAll,
+		ScopeArticleWrite,
+		ScopeAssetWrite,
+		ScopeTagManage,
+		ScopeCommentModerate,
+		ScopePromptRead,
+		ScopePromptWrite,
+		ScopeUserManage,
+		ScopeRoleManage,
+		ScopeCreatorReview,
+		ScopeAuditRead,
+	}
+
+	// BuiltinRoleScopes 内置角色及其初始权限项，角色名与旧的权限等级一致
+	//	update 2025-11-11 17:05:24
+	BuiltinRoleScopes = map[Permission][]Scope{
+		PermissionReader:  {},
+		PermissionCreator: {ScopeArticleWrite, ScopeAssetWrite, ScopeTagManage},
+		PermissionAdmin:   {ScopeAll},
+	}
+)
+
+// HasScope 判断权限项集合是否包含所需权限
+//
+//	param scopes []Scope
+//	param required Scope
+//	return bool
+//	author centonhuang
+//	update 2025-11-11 17:05:24
+func HasScope(scopes []Scope, required Scope) bool {
+	return lo.Contains(scopes, ScopeAll) || lo.Contains(scopes, required)
+}
+
+// IntersectScopes 计算两个权限项集合的交集，其中*表示对方的全部权限项
+//
+//	param a []Scope
+//	param b []Scope
+//	return []Scope
+//	author centonhuang
+//	update 2025-11-12 14:02:51
+func IntersectScopes(a, b []Scope) []Scope {
+	if lo.Contains(a, ScopeAll) {
+		return b
+	}
+	if lo.Contains(b, ScopeAll) {
+		return a
+	}
+	return lo.Intersect(a, b)
+}
+
+// Role 角色
+//
+//	author centonhuang
+//	update 2025-11-11 17:05:24
+type Role struct {
+	gorm.Model
+	ID          uint    `json:"id" gorm:"column:id;primary_key;auto_increment;comment:角色ID"`
+	Name        string  `json:"name" gorm:"column:name;uniqueIndex;not null;comment:角色名"`
+	Description string  `json:"description" gorm:"column:description;comment:角色描述"`
+	Scopes      []Scope `json:"scopes" gorm:"column:scopes;type:json;serializer:json;comment:权限项"`
+	BuiltIn     bool    `json:"built_in" gorm:"column:built_in;not null;default:false;comment:是否内置角色"`
+	Users       []User  `json:"users" gorm:"many2many:user_roles"`
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SessionRevokeReason 会话吊销原因
+//
+//	author centonhuang
+//	update 2025-11-11 20:12:40
+type SessionRevokeReason string
+
+const (
+
+	// SessionRevokeReasonLogout SessionRevokeReason 用户登出
+	//	update 2025-11-11 20:12:40
+	SessionRevokeReasonLogout SessionRevokeReason = "logout"
+
+	// SessionRevokeReasonRevoked SessionRevokeReason 用户主动吊销
+	//	update 2025-11-11 20:12:40
+	SessionRevokeReasonRevoked SessionRevokeReason = "revoked"
+
+	// SessionRevokeReasonReuse SessionRevokeReason 检测到刷新令牌被重复使用
+	//	update 2025-11-11 20:12:40
+	SessionRevokeReasonReuse SessionRevokeReason = "reuse_detected"
+
+	// SessionRevokeReasonAccountDeleted SessionRevokeReason 账号已注销
+	//	update 2025-11-14 10:35:21
+	SessionRevokeReasonAccountDeleted SessionRevokeReason = "account_deleted"
+)
+
+// Session 登录会话，一个会话对应一条刷新令牌轮换链
+//
+//	author centonhuang
+//	update 2025-11-11 20:12:40
+type Session struct {
+	gorm.Model
+	ID           uint                `json:"id" gorm:"column:id;primary_key;auto_increment;comment:会话ID"`
+	UserID       uint                `json:"user_id" gorm:"column:user_id;not null;index;comment:用户ID"`
+	TokenID      string              `json:"token_id" gorm:"column:token_id;not null;uniqueIndex;comment:当前有效的刷新令牌ID"`
+	IP           string              `json:"ip" gorm:"column:ip;comment:最近使用的客户端IP"`
+	UserAgent    string              `json:"user_agent" gorm:"column:user_agent;comment:最近使用的客户端UA"`
+	LastUsedAt   time.Time           `json:"last_used_at" gorm:"column:last_used_at;comment:最近使用时间"`
+	ExpiresAt    time.Time           `json:"expires_at" gorm:"column:expires_at;not null;index;comment:过期时间"`
+	RevokedAt    time.Time           `json:"revoked_at" gorm:"column:revoked_at;default:NULL;comment:吊销时间"`
+	RevokeReason SessionRevokeReason `json:"revoke_reason" gorm:"column:revoke_reason;comment:吊销原因"`
+}
+
+// IsActive 会话是否未吊销且未过期
+//
+//	receiver s *Session
+//	param now time.Time
+//	return bool
+//	author centonhuang
+//	update 2025-11-11 20:12:40
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt.IsZero() && now.Before(s.ExpiresAt)
+}
@@ -6,6 +6,7 @@ package model
 import (
 	"time"
 
+	"github.com/samber/lo"
 	"gorm.io/gorm"
 )
 
@@ -25,6 +26,10 @@ type (
 	// Platform string 平台
 	//	update 2024-09-21 01:34:12
 	Platform string
+
+	// UserStatus string 账号状态
+	//	update 2025-11-10 15:32:08
+	UserStatus string
 )
 
 const (
@@ -62,6 +67,30 @@ const (
 	// QuotaAdmin Quota 管理员配额
 	//	update 2024-12-09 16:13:24
 	QuotaAdmin Quota = 120
+
+	// StorageQuotaReader int64 读者的存储配额，单位字节
+	//	update 2025-11-17 10:26:42
+	StorageQuotaReader int64 = 100 << 20
+
+	// StorageQuotaCreator int64 创作者的存储配额，单位字节
+	//	update 2025-11-17 10:26:42
+	StorageQuotaCreator int64 = 2 << 30
+
+	// StorageQuotaAdmin int64 管理员的存储配额，单位字节
+	//	update 2025-11-17 10:26:42
+	StorageQuotaAdmin int64 = 20 << 30
+
+	// UserStatusActive UserStatus 正常
+	//	update 2025-11-10 15:32:08
+	UserStatusActive UserStatus = "active"
+
+	// UserStatusSuspended UserStatus 暂停，到期后自动恢复
+	//	update 2025-11-10 15:32:08
+	UserStatusSuspended UserStatus = "suspended"
+
+	// UserStatusBanned UserStatus 封禁
+	//	update 2025-11-10 15:32:08
+	UserStatusBanned UserStatus = "banned"
 )
 
 // PermissionLevelMapping 权限等级映射
@@ -79,25 +108,87 @@ var (
 		PermissionCreator: QuotaCreator,
 		PermissionAdmin:   QuotaAdmin,
 	}
+
+	PermissionStorageQuotaMapping = map[Permission]int64{
+		PermissionReader:  StorageQuotaReader,
+		PermissionCreator: StorageQuotaCreator,
+		PermissionAdmin:   StorageQuotaAdmin,
+	}
 )
 
 // User 用户数据库模型
 //
 //	author centonhuang
-//	update 2024-06-22 09:36:22
+//	update 2025-11-17 10:26:42
 type User struct {
 	gorm.Model
-	ID           uint       `json:"id" gorm:"column:id;primary_key;auto_increment;comment:用户ID"`
-	Name         string     `json:"name" gorm:"column:name;unique;not null;comment:用户名"`
-	Email        string     `json:"email" gorm:"column:email;unique;not null;comment:邮箱"`
-	Avatar       string     `json:"avatar" gorm:"column:avatar;not null;comment:头像"`
-	Permission   Permission `json:"permission" gorm:"column:permission;not null;default:'reader';comment:权限"`
-	LastLogin    time.Time  `json:"last_login" gorm:"column:last_login;comment:最后登录时间"`
-	GithubBindID string     `json:"-" gorm:"unique;comment:Github绑定ID"`
-	QQBindID     string     `json:"-" gorm:"unique;comment:QQ绑定ID"`
-	GoogleBindID string     `json:"-" gorm:"unique;comment:Google绑定ID"`
-	LLMQuota     Quota      `json:"llm_quota" gorm:"column:llm_quota;not null;default:0;comment:LLM配额"`
-	Articles     []Article  `json:"articles" gorm:"foreignKey:UserID"`
-	Categories   []Category `json:"categories" gorm:"foreignKey:UserID"`
-	Tags         []Tag      `json:"tags" gorm:"foreignKey:UserID"`
+	ID             uint           `json:"id" gorm:"column:id;primary_key;auto_increment;comment:用户ID"`
+	Name           string         `json:"name" gorm:"column:name;unique;not null;comment:用户名"`
+	Email          string         `json:"email" gorm:"column:email;unique;not null;comment:邮箱"`
+	Avatar         string         `json:"avatar" gorm:"column:avatar;not null;comment:头像"`
+	Permission     Permission     `json:"permission" gorm:"column:permission;not null;default:'reader';comment:权限"`
+	LastLogin      time.Time      `json:"last_login" gorm:"column:last_login;comment:最后登录时间"`
+	GithubBindID   string         `json:"-" gorm:"column:github_bind_id;unique;default:NULL;comment:Github绑定ID"`
+	QQBindID       string         `json:"-" gorm:"column:qq_bind_id;unique;default:NULL;comment:QQ绑定ID"`
+	GoogleBindID   string         `json:"-" gorm:"column:google_bind_id;unique;default:NULL;comment:Google绑定ID"`
+	LLMQuota       Quota          `json:"llm_quota" gorm:"column:llm_quota;not null;default:0;comment:LLM配额"`
+	StorageUsage   int64          `json:"storage_usage" gorm:"column:storage_usage;not null;default:0;comment:图片与缩略图占用的存储字节数"`
+	Status         UserStatus     `json:"status" gorm:"column:status;not null;default:'active';comment:账号状态"`
+	StatusReason   string         `json:"status_reason" gorm:"column:status_reason;comment:账号状态变更原因"`
+	SuspendedUntil time.Time      `json:"suspended_until" gorm:"column:suspended_until;default:NULL;comment:暂停截止时间"`
+	Articles       []Article      `json:"articles" gorm:"foreignKey:UserID"`
+	Categories     []Category     `json:"categories" gorm:"foreignKey:UserID"`
+	Tags           []Tag          `json:"tags" gorm:"foreignKey:UserID"`
+	Roles          []Role         `json:"roles" gorm:"many2many:user_roles"`
+	OidcIdentities []OidcIdentity `json:"oidc_identities" gorm:"foreignKey:UserID"`
+}
+
+// GetScopes 汇总用户全部角色的权限项，需预加载Roles
+//
+//	receiver u *User
+//	return []Scope
+//	author centonhuang
+//	update 2025-11-11 17:05:24
+func (u *User) GetScopes() []Scope {
+	return lo.Uniq(lo.FlatMap(u.Roles, func(role Role, _ int) []Scope {
+		return role.Scopes
+	}))
+}
+
+// GetBindIDs 获取用户已绑定的第三方平台及其绑定ID，需查询各绑定字段并预加载OidcIdentities，OIDC提供商以其名称作为平台
+//
+//	receiver u *User
+//	return map[Platform]string
+//	author centonhuang
+//	update 2025-11-13 19:26:03
+func (u *User) GetBindIDs() map[Platform]string {
+	bindIDs := lo.PickBy(map[Platform]string{
+		PlatformGithub: u.GithubBindID,
+		PlatformQQ:     u.QQBindID,
+		PlatformGoogle: u.GoogleBindID,
+	}, func(_ Platform, bindID string) bool {
+		return bindID != ""
+	})
+	for _, identity := range u.OidcIdentities {
+		bindIDs[Platform(identity.Provider)] = identity.Subject
+	}
+	return bindIDs
+}
+
+// IsBlocked 判断账号当前是否被暂停或封禁
+//
+//	receiver u *User
+//	param now time.Time
+//	return bool
+//	author centonhuang
+//	update 2025-11-10 15:32:08
+func (u *User) IsBlocked(now time.Time) bool {
+	switch u.Status {
+	case UserStatusBanned:
+		return true
+	case UserStatusSuspended:
+		return now.Before(u.SuspendedUntil)
+	default:
+		return false
+	}
 }
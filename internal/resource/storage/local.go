@@ -0,0 +1,20 @@
+package storage
+
+import (
+	"os"
+
+	"github.com/hcd233/aris-blog-api/internal/config"
+	"github.com/hcd233/aris-blog-api/internal/logger"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+func initLocalStorage() {
+	if config.LocalStorageSecret == "" {
+		panic("local.storage.secret is required for local object storage")
+	}
+
+	lo.Must0(os.MkdirAll(config.LocalStorageDir, 0o750))
+
+	logger.Logger().Info("[Object Storage] Using local storage", zap.String("dir", config.LocalStorageDir))
+}
@@ -3,25 +3,36 @@ package objdao
 
 import (
 	"context"
+	"errors"
 	"io"
+	"net/http"
 	"net/url"
 	"time"
 )
 
+// ErrObjectNotFound 对象不存在
+//
+//	update 2025-11-15 15:21:06
+var ErrObjectNotFound = errors.New("object not found")
+
 // ObjDAO 对象存储DAO接口
 //
 //	author centonhuang
-//	update 2025-01-05 22:45:30
+//	update 2025-11-15 15:21:06
 type ObjDAO interface {
 	GetBucketName(ctx context.Context) string
 	CreateBucket(ctx context.Context) (err error)
 	CreateDir(ctx context.Context, userID uint) (objectInfo *ObjectInfo, err error)
 	ListObjects(ctx context.Context, userID uint) (objectInfos []ObjectInfo, err error)
+	ListDirObjects(ctx context.Context, userID uint, subDir string) (objectInfos []ObjectInfo, err error)
 	CheckObjectExists(ctx context.Context, userID uint, objectName string) (exists bool, err error)
+	StatObject(ctx context.Context, userID uint, objectName string) (objectInfo *ObjectInfo, err error)
 	UploadObject(ctx context.Context, userID uint, objectName string, size int64, reader io.Reader) (err error)
 	DownloadObject(ctx context.Context, userID uint, objectName string, writer io.Writer) (objectInfo *ObjectInfo, err error)
 	PresignObject(ctx context.Context, userID uint, objectName string) (presignedURL *url.URL, err error)
+	PresignUpload(ctx context.Context, userID uint, objectName string, constraint *UploadConstraint) (upload *PresignedUpload, err error)
 	DeleteObject(ctx context.Context, userID uint, objectName string) (err error)
+	DeleteDir(ctx context.Context, userID uint) (err error)
 }
 
 // ObjectType 对象类型
@@ -39,15 +50,34 @@ const (
 	//	update 2025-01-05 17:36:05
 	ObjectTypeThumbnail ObjectType = "thumbnail"
 
+	// ObjectTypeExport ObjectType 用户数据导出归档
+	//	update 2025-11-14 10:35:21
+	ObjectTypeExport ObjectType = "export"
+
+	// ObjectTypeVariant ObjectType 图片的响应式变体，按原图名分目录存放
+	//	update 2025-11-16 10:12:48
+	ObjectTypeVariant ObjectType = "variant"
+
+	// ObjectTypeAttachment ObjectType 文章附件
+	//	update 2025-11-18 10:21:37
+	ObjectTypeAttachment ObjectType = "attachment"
+
+	// ObjectTypeAttachmentPreview ObjectType 附件的预览图，如PDF首页与音视频封面
+	//	update 2025-11-18 10:21:37
+	ObjectTypeAttachmentPreview ObjectType = "attachment-preview"
+
 	createBucketTimeout      = 10 * time.Second
 	listObjectsTimeout       = 10 * time.Second
 	uploadObjectTimeout      = 30 * time.Second
 	downloadObjectTimeout    = 30 * time.Second
 	deleteObjectTimeout      = 10 * time.Second
+	deleteDirTimeout         = 60 * time.Second
 	presignObjectTimeout     = 10 * time.Second
 	checkObjectExistsTimeout = 10 * time.Second
+	statObjectTimeout        = 10 * time.Second
 
 	presignObjectExpire = 5 * time.Minute
+	presignUploadExpire = 15 * time.Minute
 )
 
 // ObjectInfo 对象信息
@@ -62,3 +92,35 @@ type ObjectInfo struct {
 	Expires      time.Time `json:"expires"`
 	ETag         string    `json:"etag"`
 }
+
+// UploadConstraint 直传对象的约束，内容类型与大小会签入预签名链接，由存储服务校验
+//
+//	author centonhuang
+//	update 2025-11-15 15:21:06
+type UploadConstraint struct {
+	ContentType string
+	Size        int64
+}
+
+// PresignedUpload 直传对象的预签名信息，客户端需以Method请求URL并原样携带Headers
+//
+//	author centonhuang
+//	update 2025-11-15 15:21:06
+type PresignedUpload struct {
+	Method    string
+	URL       string
+	Headers   map[string]string
+	ExpiresAt time.Time
+}
+
+// flattenUploadHeader 转为客户端需携带的请求头，Content-Length由客户端按请求体自动设置
+func flattenUploadHeader(header http.Header) map[string]string {
+	headers := make(map[string]string, len(header))
+	for key := range header {
+		if key == "Content-Length" {
+			continue
+		}
+		headers[key] = header.Get(key)
+	}
+	return headers
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/url"
 	"path"
+	"strconv"
 	"strings"
 	"time"
 
@@ -119,6 +120,56 @@ func (dao *CosObjDAO) ListObjects(ctx context.Context, userID uint) (objectInfos
 	return
 }
 
+// ListDirObjects 递归列出用户目录下子目录中的对象，对象名包含子目录前缀，可直接用于删除
+//
+//	receiver dao *CosObjDAO
+//	param userID uint
+//	param subDir string
+//	return objectInfos []ObjectInfo
+//	return err error
+//	author centonhuang
+//	update 2025-11-20 15:31:44
+func (dao *CosObjDAO) ListDirObjects(ctx context.Context, userID uint, subDir string) (objectInfos []ObjectInfo, err error) {
+	dirName := dao.composeDirName(userID)
+	dirName += "/"
+
+	ctx, cancel := context.WithTimeout(ctx, listObjectsTimeout)
+	defer cancel()
+
+	opt := &cos.BucketGetOptions{
+		Prefix:  dirName + strings.Trim(subDir, "/") + "/",
+		MaxKeys: 1000,
+	}
+	for {
+		result, _, err := dao.client.Bucket.Get(ctx, opt)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, object := range result.Contents {
+			if strings.HasSuffix(object.Key, "/") {
+				continue
+			}
+
+			lastModified := lo.Must1(time.ParseInLocation(time.RFC3339, object.LastModified, time.UTC))
+
+			objectInfos = append(objectInfos, ObjectInfo{
+				ObjectName:   strings.TrimPrefix(object.Key, dirName),
+				ContentType:  "",
+				Size:         object.Size,
+				LastModified: lastModified,
+				Expires:      time.Time{},
+				ETag:         strings.Trim(object.ETag, "\""),
+			})
+		}
+
+		if !result.IsTruncated {
+			return objectInfos, nil
+		}
+		opt.Marker = result.NextMarker
+	}
+}
+
 // UploadObject 上传对象
 func (dao *CosObjDAO) UploadObject(ctx context.Context, userID uint, objectName string, _ int64, reader io.Reader) (err error) {
 	dirName := dao.composeDirName(userID)
@@ -221,3 +272,125 @@ func (dao *CosObjDAO) CheckObjectExists(ctx context.Context, userID uint, object
 	exists = true
 	return
 }
+
+// DeleteDir 删除用户目录下的全部对象，包括目录本身
+//
+//	receiver dao *CosObjDAO
+//	param userID uint
+//	return err error
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+func (dao *CosObjDAO) DeleteDir(ctx context.Context, userID uint) (err error) {
+	dirName := dao.composeDirName(userID)
+	dirName += "/"
+
+	ctx, cancel := context.WithTimeout(ctx, deleteDirTimeout)
+	defer cancel()
+
+	opt := &cos.BucketGetOptions{
+		Prefix:  dirName,
+		MaxKeys: 1000,
+	}
+	for {
+		result, _, err := dao.client.Bucket.Get(ctx, opt)
+		if err != nil {
+			return err
+		}
+		if len(result.Contents) > 0 {
+			_, _, err = dao.client.Object.DeleteMulti(ctx, &cos.ObjectDeleteMultiOptions{
+				Quiet: true,
+				Objects: lo.Map(result.Contents, func(object cos.Object, _ int) cos.Object {
+					return cos.Object{Key: object.Key}
+				}),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if !result.IsTruncated {
+			return nil
+		}
+		opt.Marker = result.NextMarker
+	}
+}
+
+// StatObject 获取对象信息，对象不存在时返回ErrObjectNotFound
+//
+//	receiver dao *CosObjDAO
+//	param userID uint
+//	param objectName string
+//	return objectInfo *ObjectInfo
+//	return err error
+//	author centonhuang
+//	update 2025-11-15 15:21:06
+func (dao *CosObjDAO) StatObject(ctx context.Context, userID uint, objectName string) (objectInfo *ObjectInfo, err error) {
+	dirName := dao.composeDirName(userID)
+	key := path.Join(dirName, objectName)
+
+	ctx, cancel := context.WithTimeout(ctx, statObjectTimeout)
+	defer cancel()
+
+	head, err := dao.client.Object.Head(ctx, key, nil)
+	if err != nil {
+		if cos.IsNotFoundError(err) {
+			err = ErrObjectNotFound
+		}
+		return
+	}
+
+	lastModified, _ := http.ParseTime(head.Header.Get("Last-Modified"))
+
+	objectInfo = &ObjectInfo{
+		ObjectName:   objectName,
+		ContentType:  head.Header.Get("Content-Type"),
+		Size:         head.ContentLength,
+		LastModified: lastModified,
+		Expires:      time.Time{},
+		ETag:         strings.Trim(head.Header.Get("ETag"), "\""),
+	}
+	return
+}
+
+// PresignUpload 生成浏览器直传对象的预签名PUT链接，内容类型与大小均参与签名
+//
+//	receiver dao *CosObjDAO
+//	param userID uint
+//	param objectName string
+//	param constraint *UploadConstraint
+//	return upload *PresignedUpload
+//	return err error
+//	author centonhuang
+//	update 2025-11-15 15:21:06
+func (dao *CosObjDAO) PresignUpload(ctx context.Context, userID uint, objectName string, constraint *UploadConstraint) (upload *PresignedUpload, err error) {
+	dirName := dao.composeDirName(userID)
+	objectName = path.Join(dirName, objectName)
+
+	ctx, cancel := context.WithTimeout(ctx, presignObjectTimeout)
+	defer cancel()
+
+	header := http.Header{}
+	header.Set("Content-Type", constraint.ContentType)
+	header.Set("Content-Length", strconv.FormatInt(constraint.Size, 10))
+
+	expiresAt := time.Now().Add(presignUploadExpire)
+
+	presignedURL, err := dao.client.Object.GetPresignedURL(ctx,
+		http.MethodPut,
+		objectName,
+		dao.client.GetCredential().SecretID,
+		dao.client.GetCredential().SecretKey,
+		presignUploadExpire,
+		&cos.PresignedURLOptions{Header: &header},
+	)
+	if err != nil {
+		return
+	}
+
+	upload = &PresignedUpload{
+		Method:    http.MethodPut,
+		URL:       presignedURL.String(),
+		Headers:   flattenUploadHeader(header),
+		ExpiresAt: expiresAt,
+	}
+	return
+}
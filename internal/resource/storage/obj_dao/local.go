@@ -0,0 +1,518 @@
+package objdao
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	localDirPerm         = 0o750
+	localTempFilePattern = ".upload-*"
+
+	// LocalObjectRoutePrefix string 本地对象存储签名下载与直传链接的路由前缀
+	//	update 2025-11-15 15:21:06
+	LocalObjectRoutePrefix = "/v1/storage/local"
+)
+
+var (
+	// ErrInvalidObjectName 对象名为空或试图访问用户目录之外的路径
+	//	update 2025-11-14 16:08:45
+	ErrInvalidObjectName = errors.New("invalid object name")
+
+	// ErrInvalidSignature 签名链接的签名错误或已过期
+	//	update 2025-11-15 15:21:06
+	ErrInvalidSignature = errors.New("invalid or expired signature")
+)
+
+// LocalObjDAO 本地文件系统对象存储DAO，目录结构与桶中保持一致
+//
+//	author centonhuang
+//	update 2025-11-14 16:08:45
+type LocalObjDAO struct {
+	ObjectType ObjectType
+	RootDir    string
+	BaseURL    string
+	secret     []byte
+}
+
+func (dao *LocalObjDAO) composeDirName(userID uint) string {
+	return fmt.Sprintf("user-%d-%s", userID, dao.ObjectType)
+}
+
+func (dao *LocalObjDAO) composeDirPath(userID uint) string {
+	return filepath.Join(dao.RootDir, dao.composeDirName(userID))
+}
+
+// composeObjectPath 对象名按URL路径清理后拼接，结果总在用户目录之内
+func (dao *LocalObjDAO) composeObjectPath(userID uint, objectName string) (string, error) {
+	cleaned := strings.TrimPrefix(path.Clean("/"+objectName), "/")
+	if cleaned == "" || strings.ContainsRune(objectName, '\\') {
+		return "", ErrInvalidObjectName
+	}
+	return filepath.Join(dao.composeDirPath(userID), filepath.FromSlash(cleaned)), nil
+}
+
+// GetBucketName 获取桶名，本地存储以根目录作为桶
+//
+//	receiver dao *LocalObjDAO
+//	return bucketName string
+//	author centonhuang
+//	update 2025-11-14 16:08:45
+func (dao *LocalObjDAO) GetBucketName(_ context.Context) string {
+	return dao.RootDir
+}
+
+// CreateBucket 创建根目录
+//
+//	receiver dao *LocalObjDAO
+//	return err error
+//	author centonhuang
+//	update 2025-11-14 16:08:45
+func (dao *LocalObjDAO) CreateBucket(_ context.Context) (err error) {
+	return os.MkdirAll(dao.RootDir, localDirPerm)
+}
+
+// CreateDir 创建用户目录
+//
+//	receiver dao *LocalObjDAO
+//	param userID uint
+//	return objectInfo *ObjectInfo
+//	return err error
+//	author centonhuang
+//	update 2025-11-14 16:08:45
+func (dao *LocalObjDAO) CreateDir(_ context.Context, userID uint) (objectInfo *ObjectInfo, err error) {
+	dirPath := dao.composeDirPath(userID)
+	if err = os.MkdirAll(dirPath, localDirPerm); err != nil {
+		return
+	}
+
+	stat, err := os.Stat(dirPath)
+	if err != nil {
+		return
+	}
+
+	objectInfo = &ObjectInfo{
+		ObjectName:   dao.composeDirName(userID) + "/",
+		ContentType:  "",
+		Size:         0,
+		LastModified: stat.ModTime().UTC(),
+		Expires:      time.Time{},
+		ETag:         "",
+	}
+	return
+}
+
+// ListObjects 列出用户目录下的对象
+//
+//	receiver dao *LocalObjDAO
+//	param userID uint
+//	return objectInfos []ObjectInfo
+//	return err error
+//	author centonhuang
+//	update 2025-11-14 16:08:45
+func (dao *LocalObjDAO) ListObjects(_ context.Context, userID uint) (objectInfos []ObjectInfo, err error) {
+	entries, err := os.ReadDir(dao.composeDirPath(userID))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return
+	}
+
+	for _, entry := range entries {
+		// 跳过子目录与上传中的临时文件
+		if !entry.Type().IsRegular() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+
+		objectInfos = append(objectInfos, *buildLocalObjectInfo(entry.Name(), info))
+	}
+	return
+}
+
+// ListDirObjects 递归列出用户目录下子目录中的对象，对象名包含子目录前缀，可直接用于删除
+//
+//	receiver dao *LocalObjDAO
+//	param userID uint
+//	param subDir string
+//	return objectInfos []ObjectInfo
+//	return err error
+//	author centonhuang
+//	update 2025-11-20 15:31:44
+func (dao *LocalObjDAO) ListDirObjects(_ context.Context, userID uint, subDir string) (objectInfos []ObjectInfo, err error) {
+	dirPath, err := dao.composeObjectPath(userID, subDir)
+	if err != nil {
+		return
+	}
+	userDirPath := dao.composeDirPath(userID)
+
+	err = filepath.WalkDir(dirPath, func(objectPath string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			if errors.Is(walkErr, fs.ErrNotExist) {
+				return nil
+			}
+			return walkErr
+		}
+		// 跳过目录与上传中的临时文件
+		if !entry.Type().IsRegular() || strings.HasPrefix(entry.Name(), ".") {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+
+		relPath, err := filepath.Rel(userDirPath, objectPath)
+		if err != nil {
+			return err
+		}
+		objectInfos = append(objectInfos, *buildLocalObjectInfo(filepath.ToSlash(relPath), info))
+		return nil
+	})
+	return
+}
+
+// UploadObject 上传对象，先写入临时文件再重命名，读取方不会看到写了一半的对象
+//
+//	receiver dao *LocalObjDAO
+//	param userID uint
+//	param objectName string
+//	param size int64
+//	param reader io.Reader
+//	return err error
+//	author centonhuang
+//	update 2025-11-14 16:08:45
+func (dao *LocalObjDAO) UploadObject(_ context.Context, userID uint, objectName string, _ int64, reader io.Reader) (err error) {
+	objectPath, err := dao.composeObjectPath(userID, objectName)
+	if err != nil {
+		return
+	}
+
+	dirPath := filepath.Dir(objectPath)
+	if err = os.MkdirAll(dirPath, localDirPerm); err != nil {
+		return
+	}
+
+	tmp, err := os.CreateTemp(dirPath, localTempFilePattern)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err = io.Copy(tmp, reader); err != nil {
+		_ = tmp.Close()
+		return
+	}
+	if err = tmp.Close(); err != nil {
+		return
+	}
+
+	err = os.Rename(tmp.Name(), objectPath)
+	return
+}
+
+// DownloadObject 下载对象
+//
+//	receiver dao *LocalObjDAO
+//	param userID uint
+//	param objectName string
+//	param writer io.Writer
+//	return objectInfo *ObjectInfo
+//	return err error
+//	author centonhuang
+//	update 2025-11-14 16:08:45
+func (dao *LocalObjDAO) DownloadObject(_ context.Context, userID uint, objectName string, writer io.Writer) (objectInfo *ObjectInfo, err error) {
+	objectPath, err := dao.composeObjectPath(userID, objectName)
+	if err != nil {
+		return
+	}
+
+	file, err := os.Open(objectPath)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return
+	}
+
+	objectInfo = buildLocalObjectInfo(path.Join(dao.composeDirName(userID), objectName), stat)
+
+	_, err = io.Copy(writer, file)
+	return
+}
+
+// PresignObject 生成带HMAC签名的限时下载链接，由本地对象存储路由校验后返回文件
+//
+//	receiver dao *LocalObjDAO
+//	param userID uint
+//	param objectName string
+//	return presignedURL *url.URL
+//	return err error
+//	author centonhuang
+//	update 2025-11-14 16:08:45
+func (dao *LocalObjDAO) PresignObject(_ context.Context, userID uint, objectName string) (presignedURL *url.URL, err error) {
+	if _, err = dao.composeObjectPath(userID, objectName); err != nil {
+		return
+	}
+
+	expires := time.Now().Add(presignObjectExpire).Unix()
+
+	presignedURL, err = url.Parse(dao.BaseURL)
+	if err != nil {
+		return
+	}
+	presignedURL = presignedURL.JoinPath(LocalObjectRoutePrefix, string(dao.ObjectType), strconv.FormatUint(uint64(userID), 10), objectName)
+	presignedURL.RawQuery = url.Values{
+		"expires":   {strconv.FormatInt(expires, 10)},
+		"signature": {dao.sign(http.MethodGet, userID, objectName, expires)},
+	}.Encode()
+	return
+}
+
+// OpenSignedObject 校验下载链接的签名与有效期，通过后打开对象文件
+//
+//	receiver dao *LocalObjDAO
+//	param userID uint
+//	param objectName string
+//	param expires int64
+//	param signature string
+//	return file *os.File
+//	return objectInfo *ObjectInfo
+//	return err error
+//	author centonhuang
+//	update 2025-11-14 16:08:45
+func (dao *LocalObjDAO) OpenSignedObject(userID uint, objectName string, expires int64, signature string) (file *os.File, objectInfo *ObjectInfo, err error) {
+	if time.Now().Unix() > expires || !hmac.Equal([]byte(signature), []byte(dao.sign(http.MethodGet, userID, objectName, expires))) {
+		return nil, nil, ErrInvalidSignature
+	}
+
+	objectPath, err := dao.composeObjectPath(userID, objectName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file, err = os.Open(objectPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stat, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+	if !stat.Mode().IsRegular() {
+		file.Close()
+		return nil, nil, fs.ErrNotExist
+	}
+
+	return file, buildLocalObjectInfo(objectName, stat), nil
+}
+
+// DeleteObject 删除对象，对象不存在时视为成功
+//
+//	receiver dao *LocalObjDAO
+//	param userID uint
+//	param objectName string
+//	return err error
+//	author centonhuang
+//	update 2025-11-14 16:08:45
+func (dao *LocalObjDAO) DeleteObject(_ context.Context, userID uint, objectName string) (err error) {
+	objectPath, err := dao.composeObjectPath(userID, objectName)
+	if err != nil {
+		return
+	}
+
+	if err = os.Remove(objectPath); errors.Is(err, fs.ErrNotExist) {
+		err = nil
+	}
+	return
+}
+
+// DeleteDir 删除用户目录下的全部对象，包括目录本身
+//
+//	receiver dao *LocalObjDAO
+//	param userID uint
+//	return err error
+//	author centonhuang
+//	update 2025-11-14 16:08:45
+func (dao *LocalObjDAO) DeleteDir(_ context.Context, userID uint) (err error) {
+	return os.RemoveAll(dao.composeDirPath(userID))
+}
+
+// CheckObjectExists 检查对象是否存在
+//
+//	receiver dao *LocalObjDAO
+//	param userID uint
+//	param objectName string
+//	return exists bool
+//	return err error
+//	author centonhuang
+//	update 2025-11-14 16:08:45
+func (dao *LocalObjDAO) CheckObjectExists(_ context.Context, userID uint, objectName string) (exists bool, err error) {
+	objectPath, err := dao.composeObjectPath(userID, objectName)
+	if err != nil {
+		return
+	}
+
+	stat, err := os.Stat(objectPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+		return
+	}
+
+	exists = stat.Mode().IsRegular()
+	return
+}
+
+// StatObject 获取对象信息，对象不存在时返回ErrObjectNotFound
+//
+//	receiver dao *LocalObjDAO
+//	param userID uint
+//	param objectName string
+//	return objectInfo *ObjectInfo
+//	return err error
+//	author centonhuang
+//	update 2025-11-15 15:21:06
+func (dao *LocalObjDAO) StatObject(_ context.Context, userID uint, objectName string) (objectInfo *ObjectInfo, err error) {
+	objectPath, err := dao.composeObjectPath(userID, objectName)
+	if err != nil {
+		return
+	}
+
+	stat, err := os.Stat(objectPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			err = ErrObjectNotFound
+		}
+		return
+	}
+	if !stat.Mode().IsRegular() {
+		return nil, ErrObjectNotFound
+	}
+
+	objectInfo = buildLocalObjectInfo(objectName, stat)
+	return
+}
+
+// PresignUpload 生成直传对象的签名PUT链接，由本地对象存储路由校验后写入文件
+//
+//	本地存储的请求体受服务端请求体大小上限约束，仅适用于开发与测试环境
+//	receiver dao *LocalObjDAO
+//	param userID uint
+//	param objectName string
+//	param constraint *UploadConstraint
+//	return upload *PresignedUpload
+//	return err error
+//	author centonhuang
+//	update 2025-11-15 15:21:06
+func (dao *LocalObjDAO) PresignUpload(_ context.Context, userID uint, objectName string, constraint *UploadConstraint) (upload *PresignedUpload, err error) {
+	if _, err = dao.composeObjectPath(userID, objectName); err != nil {
+		return
+	}
+
+	expiresAt := time.Now().Add(presignUploadExpire)
+	expires := expiresAt.Unix()
+
+	presignedURL, err := url.Parse(dao.BaseURL)
+	if err != nil {
+		return
+	}
+	presignedURL = presignedURL.JoinPath(LocalObjectRoutePrefix, string(dao.ObjectType), strconv.FormatUint(uint64(userID), 10), objectName)
+	presignedURL.RawQuery = url.Values{
+		"expires":   {strconv.FormatInt(expires, 10)},
+		"signature": {dao.sign(http.MethodPut, userID, objectName, expires, constraint.ContentType, strconv.FormatInt(constraint.Size, 10))},
+	}.Encode()
+
+	upload = &PresignedUpload{
+		Method:    http.MethodPut,
+		URL:       presignedURL.String(),
+		Headers:   map[string]string{"Content-Type": constraint.ContentType},
+		ExpiresAt: expiresAt,
+	}
+	return
+}
+
+// VerifySignedUpload 校验直传链接的签名与有效期，内容类型与大小需与签名时一致
+//
+//	receiver dao *LocalObjDAO
+//	param userID uint
+//	param objectName string
+//	param contentType string
+//	param size int64
+//	param expires int64
+//	param signature string
+//	return err error
+//	author centonhuang
+//	update 2025-11-15 15:21:06
+func (dao *LocalObjDAO) VerifySignedUpload(userID uint, objectName, contentType string, size, expires int64, signature string) (err error) {
+	expected := dao.sign(http.MethodPut, userID, objectName, expires, contentType, strconv.FormatInt(size, 10))
+	if time.Now().Unix() > expires || !hmac.Equal([]byte(signature), []byte(expected)) {
+		return ErrInvalidSignature
+	}
+
+	_, err = dao.composeObjectPath(userID, objectName)
+	return
+}
+
+// sign 签名覆盖请求方法、对象位置与有效期，上传时还包括内容类型与大小
+func (dao *LocalObjDAO) sign(method string, userID uint, objectName string, expires int64, extras ...string) string {
+	mac := hmac.New(sha256.New, dao.secret)
+	fmt.Fprintf(mac, "%s\n%s\n%d\n%s\n%d", method, dao.ObjectType, userID, objectName, expires)
+	for _, extra := range extras {
+		fmt.Fprintf(mac, "\n%s", extra)
+	}
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func buildLocalObjectInfo(objectName string, info fs.FileInfo) *ObjectInfo {
+	contentType := "application/octet-stream"
+	if mimeType := mime.TypeByExtension(filepath.Ext(objectName)); mimeType != "" {
+		contentType = mimeType
+	}
+
+	return &ObjectInfo{
+		ObjectName:   objectName,
+		ContentType:  contentType,
+		Size:         info.Size(),
+		LastModified: info.ModTime().UTC(),
+		Expires:      time.Time{},
+		ETag:         fmt.Sprintf("%x-%x", info.ModTime().UnixNano(), info.Size()),
+	}
+}
@@ -5,24 +5,28 @@ import (
 	"fmt"
 	"io"
 	"mime"
+	"net/http"
 	"net/url"
 	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
 	"github.com/samber/lo"
 )
 
-// MinioObjDAO 基础Minio对象存储DAO
+// MinioObjDAO 基础Minio对象存储DAO，同时用于通用S3兼容存储
 //
 //	author centonhuang
-//	update 2025-01-05 22:45:43
+//	update 2025-11-15 09:42:17
 type MinioObjDAO struct {
 	ObjectType ObjectType
 	BucketName string
 	client     *minio.Client
+	sse        encrypt.ServerSide
 }
 
 func (dao *MinioObjDAO) composeDirName(userID uint) string {
@@ -72,7 +76,7 @@ func (dao *MinioObjDAO) CreateDir(ctx context.Context, userID uint) (objectInfo
 	defer cancel()
 
 	// 创建一个空的目录对象
-	object, err := dao.client.PutObject(ctx, dao.BucketName, dirName+"/", nil, 0, minio.PutObjectOptions{})
+	object, err := dao.client.PutObject(ctx, dao.BucketName, dirName+"/", nil, 0, minio.PutObjectOptions{ServerSideEncryption: dao.sse})
 	if err != nil {
 		return
 	}
@@ -115,8 +119,50 @@ func (dao *MinioObjDAO) ListObjects(ctx context.Context, userID uint) (objectInf
 			return
 		}
 
-		// 跳过目录本身
-		if object.Key == dirName {
+		// 跳过目录本身与子目录（如直传暂存区），与其它存储只列出顶层对象保持一致
+		if object.Key == dirName || strings.HasSuffix(object.Key, "/") {
+			continue
+		}
+
+		objectInfos = append(objectInfos, ObjectInfo{
+			ObjectName:   strings.TrimPrefix(object.Key, dirName),
+			ContentType:  object.ContentType,
+			Size:         object.Size,
+			LastModified: object.LastModified,
+			Expires:      object.Expires,
+			ETag:         object.ETag,
+		})
+	}
+	return
+}
+
+// ListDirObjects 递归列出用户目录下子目录中的对象，对象名包含子目录前缀，可直接用于删除
+//
+//	receiver dao *MinioObjDAO
+//	param userID uint
+//	param subDir string
+//	return objectInfos []ObjectInfo
+//	return err error
+//	author centonhuang
+//	update 2025-11-20 15:31:44
+func (dao *MinioObjDAO) ListDirObjects(ctx context.Context, userID uint, subDir string) (objectInfos []ObjectInfo, err error) {
+	dirName := dao.composeDirName(userID)
+	dirName += "/"
+
+	ctx, cancel := context.WithTimeout(ctx, listObjectsTimeout)
+	defer cancel()
+
+	objectCh := dao.client.ListObjects(ctx, dao.BucketName, minio.ListObjectsOptions{
+		Prefix:    dirName + strings.Trim(subDir, "/") + "/",
+		Recursive: true,
+	})
+
+	for object := range objectCh {
+		if object.Err != nil {
+			err = object.Err
+			return
+		}
+		if strings.HasSuffix(object.Key, "/") {
 			continue
 		}
 
@@ -149,7 +195,7 @@ func (dao *MinioObjDAO) UploadObject(ctx context.Context, userID uint, objectNam
 	ctx, cancel := context.WithTimeout(ctx, uploadObjectTimeout)
 	defer cancel()
 
-	_, err = dao.client.PutObject(ctx, dao.BucketName, objectName, reader, size, minio.PutObjectOptions{})
+	_, err = dao.client.PutObject(ctx, dao.BucketName, objectName, reader, size, minio.PutObjectOptions{ServerSideEncryption: dao.sse})
 	return
 }
 
@@ -269,3 +315,105 @@ func (dao *MinioObjDAO) CheckObjectExists(ctx context.Context, userID uint, obje
 	exists = true
 	return
 }
+
+// DeleteDir 删除用户目录下的全部对象，包括目录本身
+//
+//	receiver dao *MinioObjDAO
+//	param userID uint
+//	return err error
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+func (dao *MinioObjDAO) DeleteDir(ctx context.Context, userID uint) (err error) {
+	dirName := dao.composeDirName(userID)
+	dirName += "/"
+
+	ctx, cancel := context.WithTimeout(ctx, deleteDirTimeout)
+	defer cancel()
+
+	objectCh := dao.client.ListObjects(ctx, dao.BucketName, minio.ListObjectsOptions{
+		Prefix:    dirName,
+		Recursive: true,
+	})
+
+	for removeErr := range dao.client.RemoveObjects(ctx, dao.BucketName, objectCh, minio.RemoveObjectsOptions{}) {
+		if removeErr.Err != nil {
+			return removeErr.Err
+		}
+	}
+	return
+}
+
+// StatObject 获取对象信息，对象不存在时返回ErrObjectNotFound
+//
+//	receiver dao *MinioObjDAO
+//	param userID uint
+//	param objectName string
+//	return objectInfo *ObjectInfo
+//	return err error
+//	author centonhuang
+//	update 2025-11-15 15:21:06
+func (dao *MinioObjDAO) StatObject(ctx context.Context, userID uint, objectName string) (objectInfo *ObjectInfo, err error) {
+	dirName := dao.composeDirName(userID)
+	objectName = path.Join(dirName, objectName)
+
+	ctx, cancel := context.WithTimeout(ctx, statObjectTimeout)
+	defer cancel()
+
+	stat, err := dao.client.StatObject(ctx, dao.BucketName, objectName, minio.StatObjectOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).StatusCode == http.StatusNotFound {
+			err = ErrObjectNotFound
+		}
+		return
+	}
+
+	objectInfo = &ObjectInfo{
+		ObjectName:   strings.TrimPrefix(stat.Key, dirName+"/"),
+		ContentType:  stat.ContentType,
+		Size:         stat.Size,
+		LastModified: stat.LastModified,
+		Expires:      stat.Expires,
+		ETag:         stat.ETag,
+	}
+	return
+}
+
+// PresignUpload 生成浏览器直传对象的预签名PUT链接，内容类型、大小与服务端加密请求头均参与签名
+//
+//	receiver dao *MinioObjDAO
+//	param userID uint
+//	param objectName string
+//	param constraint *UploadConstraint
+//	return upload *PresignedUpload
+//	return err error
+//	author centonhuang
+//	update 2025-11-15 15:21:06
+func (dao *MinioObjDAO) PresignUpload(ctx context.Context, userID uint, objectName string, constraint *UploadConstraint) (upload *PresignedUpload, err error) {
+	dirName := dao.composeDirName(userID)
+	objectName = path.Join(dirName, objectName)
+
+	ctx, cancel := context.WithTimeout(ctx, presignObjectTimeout)
+	defer cancel()
+
+	header := http.Header{}
+	header.Set("Content-Type", constraint.ContentType)
+	header.Set("Content-Length", strconv.FormatInt(constraint.Size, 10))
+	if dao.sse != nil {
+		dao.sse.Marshal(header)
+	}
+
+	expiresAt := time.Now().Add(presignUploadExpire)
+
+	presignedURL, err := dao.client.PresignHeader(ctx, http.MethodPut, dao.BucketName, objectName, presignUploadExpire, nil, header)
+	if err != nil {
+		return
+	}
+
+	upload = &PresignedUpload{
+		Method:    http.MethodPut,
+		URL:       presignedURL.String(),
+		Headers:   flattenUploadHeader(header),
+		ExpiresAt: expiresAt,
+	}
+	return
+}
@@ -16,13 +16,40 @@ var (
 	//	update 2025-01-05 22:45:54
 	ThumbnailObjDAOSingleton ObjDAO
 
+	// ExportObjDAOSingleton 数据导出对象DAO单例
+	//	update 2025-11-14 10:35:21
+	ExportObjDAOSingleton ObjDAO
+
+	// VariantObjDAOSingleton 图片变体对象DAO单例
+	//	update 2025-11-16 10:12:48
+	VariantObjDAOSingleton ObjDAO
+
+	// AttachmentObjDAOSingleton 文章附件对象DAO单例
+	//	update 2025-11-18 10:21:37
+	AttachmentObjDAOSingleton ObjDAO
+
+	// AttachmentPreviewObjDAOSingleton 附件预览图对象DAO单例
+	//	update 2025-11-18 10:21:37
+	AttachmentPreviewObjDAOSingleton ObjDAO
+
 	imageObjOnce     sync.Once
 	thumbnailObjOnce sync.Once
+	exportObjOnce    sync.Once
+	variantObjOnce   sync.Once
+
+	attachmentObjOnce        sync.Once
+	attachmentPreviewObjOnce sync.Once
 )
 
-// createObjectStorageDAO 创建对象存储DAO
-func createObjectStorageDAO(objectType ObjectType) ObjDAO {
-	switch storage.GetProvider() {
+// NewObjDAO 创建指定存储提供商的对象存储DAO，提供商的客户端须已初始化
+//
+//	param provider storage.Provider
+//	param objectType ObjectType
+//	return ObjDAO
+//	author centonhuang
+//	update 2025-11-17 19:42:16
+func NewObjDAO(provider storage.Provider, objectType ObjectType) ObjDAO {
+	switch provider {
 	case storage.ProviderMinio:
 		return &MinioObjDAO{
 			ObjectType: objectType,
@@ -35,6 +62,20 @@ func createObjectStorageDAO(objectType ObjectType) ObjDAO {
 			BucketName: config.CosBucketName,
 			client:     storage.GetCosClient(),
 		}
+	case storage.ProviderS3:
+		return &MinioObjDAO{
+			ObjectType: objectType,
+			BucketName: config.S3BucketName,
+			client:     storage.GetS3Client(),
+			sse:        storage.GetS3ServerSideEncryption(),
+		}
+	case storage.ProviderLocal:
+		return &LocalObjDAO{
+			ObjectType: objectType,
+			RootDir:    config.LocalStorageDir,
+			BaseURL:    config.LocalStorageBaseURL,
+			secret:     []byte(config.LocalStorageSecret),
+		}
 	default:
 		panic("unsupported storage type")
 	}
@@ -47,7 +88,7 @@ func createObjectStorageDAO(objectType ObjectType) ObjDAO {
 //	update 2024-10-18 01:10:28
 func GetImageObjDAO() ObjDAO {
 	imageObjOnce.Do(func() {
-		ImageObjDAOSingleton = createObjectStorageDAO(ObjectTypeImage)
+		ImageObjDAOSingleton = NewObjDAO(storage.GetProvider(), ObjectTypeImage)
 	})
 	return ImageObjDAOSingleton
 }
@@ -59,7 +100,85 @@ func GetImageObjDAO() ObjDAO {
 //	update 2024-10-18 01:09:59
 func GetThumbnailObjDAO() ObjDAO {
 	thumbnailObjOnce.Do(func() {
-		ThumbnailObjDAOSingleton = createObjectStorageDAO(ObjectTypeThumbnail)
+		ThumbnailObjDAOSingleton = NewObjDAO(storage.GetProvider(), ObjectTypeThumbnail)
 	})
 	return ThumbnailObjDAOSingleton
 }
+
+// GetExportObjDAO 获取数据导出对象DAO单例
+//
+//	return ObjDAO
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+func GetExportObjDAO() ObjDAO {
+	exportObjOnce.Do(func() {
+		ExportObjDAOSingleton = NewObjDAO(storage.GetProvider(), ObjectTypeExport)
+	})
+	return ExportObjDAOSingleton
+}
+
+// GetVariantObjDAO 获取图片变体对象DAO单例
+//
+//	return ObjDAO
+//	author centonhuang
+//	update 2025-11-16 10:12:48
+func GetVariantObjDAO() ObjDAO {
+	variantObjOnce.Do(func() {
+		VariantObjDAOSingleton = NewObjDAO(storage.GetProvider(), ObjectTypeVariant)
+	})
+	return VariantObjDAOSingleton
+}
+
+// GetAttachmentObjDAO 获取文章附件对象DAO单例
+//
+//	return ObjDAO
+//	author centonhuang
+//	update 2025-11-18 10:21:37
+func GetAttachmentObjDAO() ObjDAO {
+	attachmentObjOnce.Do(func() {
+		AttachmentObjDAOSingleton = NewObjDAO(storage.GetProvider(), ObjectTypeAttachment)
+	})
+	return AttachmentObjDAOSingleton
+}
+
+// GetAttachmentPreviewObjDAO 获取附件预览图对象DAO单例
+//
+//	return ObjDAO
+//	author centonhuang
+//	update 2025-11-18 10:21:37
+func GetAttachmentPreviewObjDAO() ObjDAO {
+	attachmentPreviewObjOnce.Do(func() {
+		AttachmentPreviewObjDAOSingleton = NewObjDAO(storage.GetProvider(), ObjectTypeAttachmentPreview)
+	})
+	return AttachmentPreviewObjDAOSingleton
+}
+
+// GetLocalObjDAOByType 按对象类型获取本地对象存储DAO，仅在使用本地存储时返回成功
+//
+//	param objectType ObjectType
+//	return *LocalObjDAO
+//	return bool
+//	author centonhuang
+//	update 2025-11-14 16:08:45
+func GetLocalObjDAOByType(objectType ObjectType) (*LocalObjDAO, bool) {
+	var dao ObjDAO
+	switch objectType {
+	case ObjectTypeImage:
+		dao = GetImageObjDAO()
+	case ObjectTypeThumbnail:
+		dao = GetThumbnailObjDAO()
+	case ObjectTypeExport:
+		dao = GetExportObjDAO()
+	case ObjectTypeVariant:
+		dao = GetVariantObjDAO()
+	case ObjectTypeAttachment:
+		dao = GetAttachmentObjDAO()
+	case ObjectTypeAttachmentPreview:
+		dao = GetAttachmentPreviewObjDAO()
+	default:
+		return nil, false
+	}
+
+	localDAO, ok := dao.(*LocalObjDAO)
+	return localDAO, ok
+}
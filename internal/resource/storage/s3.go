@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hcd233/aris-blog-api/internal/config"
+	"github.com/hcd233/aris-blog-api/internal/logger"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+const (
+	s3SSEAES256 = "AES256"
+	s3SSEKMS    = "aws:kms"
+)
+
+var (
+	s3Client *minio.Client
+	s3SSE    encrypt.ServerSide
+)
+
+func initS3Client() {
+	if config.S3BucketName == "" {
+		panic("s3.bucket.name is required for s3 object storage")
+	}
+
+	bucketLookup := minio.BucketLookupDNS
+	if config.S3PathStyle {
+		bucketLookup = minio.BucketLookupPath
+	}
+
+	s3Client = lo.Must1(minio.New(config.S3Endpoint, &minio.Options{
+		Creds:        credentials.NewStaticV4(config.S3AccessID, config.S3AccessKey, ""),
+		Secure:       config.S3TLS,
+		Region:       config.S3Region,
+		BucketLookup: bucketLookup,
+	}))
+
+	switch config.S3SSE {
+	case "":
+	case s3SSEAES256:
+		s3SSE = encrypt.NewSSE()
+	case s3SSEKMS:
+		s3SSE = lo.Must1(encrypt.NewSSEKMS(config.S3SSEKMSKeyID, nil))
+	default:
+		panic(fmt.Sprintf("unsupported s3 server side encryption %q", config.S3SSE))
+	}
+
+	// R2等服务的令牌通常只授权单个桶，不能用ListBuckets检查连通性
+	if !lo.Must1(s3Client.BucketExists(context.Background(), config.S3BucketName)) {
+		panic(fmt.Sprintf("s3 bucket %q does not exist", config.S3BucketName))
+	}
+
+	logger.Logger().Info("[Object Storage] Connected to S3",
+		zap.String("endpoint", config.S3Endpoint),
+		zap.String("bucket", config.S3BucketName),
+		zap.Bool("pathStyle", config.S3PathStyle),
+		zap.String("sse", config.S3SSE))
+}
+
+// GetS3Client 获取S3兼容存储客户端
+//
+//	return *minio.Client
+//	author centonhuang
+//	update 2025-11-15 09:42:17
+func GetS3Client() *minio.Client {
+	return s3Client
+}
+
+// GetS3ServerSideEncryption 获取上传对象时使用的服务端加密配置，未启用时为nil
+//
+//	return encrypt.ServerSide
+//	author centonhuang
+//	update 2025-11-15 09:42:17
+func GetS3ServerSideEncryption() encrypt.ServerSide {
+	return s3SSE
+}
@@ -1,6 +1,8 @@
 package storage
 
 import (
+	"fmt"
+
 	"github.com/hcd233/aris-blog-api/internal/config"
 )
 
@@ -12,6 +14,10 @@ const (
 	ProviderMinio Provider = "minio"
 	// ProviderCOS 腾讯云COS存储
 	ProviderCOS Provider = "cos"
+	// ProviderS3 通用S3兼容存储，如AWS S3、Cloudflare R2、Ceph
+	ProviderS3 Provider = "s3"
+	// ProviderLocal 本地文件系统存储
+	ProviderLocal Provider = "local"
 )
 
 var provider Provider
@@ -23,28 +29,52 @@ var provider Provider
 func InitObjectStorage() {
 	provider = GetProvider()
 
-	switch provider {
+	InitProvider(provider)
+}
+
+// InitProvider 初始化指定存储提供商的客户端，迁移对象时可同时初始化多个提供商
+//
+//	param p Provider
+//	author centonhuang
+//	update 2025-11-17 19:42:16
+func InitProvider(p Provider) {
+	switch p {
 	case ProviderMinio:
 		initMinioClient()
 	case ProviderCOS:
 		initCosClient()
+	case ProviderS3:
+		initS3Client()
+	case ProviderLocal:
+		initLocalStorage()
 	}
 }
 
-// GetProvider 获取存储提供商
+// ParseProvider 解析存储提供商名称
 //
+//	param name string
 //	return Provider
+//	return error
 //	author centonhuang
-//	update 2025-01-19 14:13:22
-func GetProvider() Provider {
-	// 优先使用 COS
-	if config.CosAppID != "" {
-		return ProviderCOS
+//	update 2025-11-17 19:42:16
+func ParseProvider(name string) (Provider, error) {
+	switch p := Provider(name); p {
+	case ProviderMinio, ProviderCOS, ProviderS3, ProviderLocal:
+		return p, nil
+	default:
+		return "", fmt.Errorf("unsupported object storage provider %q, choose one of minio, cos, s3, local", name)
 	}
+}
 
-	if config.MinioEndpoint != "" {
-		return ProviderMinio
+// GetProvider 获取存储提供商，由object.storage.provider显式指定
+//
+//	return Provider
+//	author centonhuang
+//	update 2025-11-20 17:20:31
+func GetProvider() Provider {
+	p, err := ParseProvider(config.ObjectStorageProvider)
+	if err != nil {
+		panic(err)
 	}
-
-	panic("no object storage configured")
+	return p
 }
@@ -0,0 +1,201 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/hcd233/aris-blog-api/internal/handler"
+	"github.com/hcd233/aris-blog-api/internal/middleware"
+	"github.com/hcd233/aris-blog-api/internal/resource/database/model"
+)
+
+func initAdminRouter(adminGroup *huma.Group) {
+	adminHandler := handler.NewAdminHandler()
+	creatorApplicationHandler := handler.NewCreatorApplicationHandler()
+	auditHandler := handler.NewAuditHandler()
+	roleHandler := handler.NewRoleHandler()
+
+	adminGroup.UseMiddleware(middleware.JwtMiddleware())
+
+	userGroup := huma.NewGroup(adminGroup, "/user")
+	middleware.RequireScope(userGroup, model.ScopeUserManage)
+
+	huma.Register(userGroup, huma.Operation{
+		OperationID: "adminListUsers",
+		Method:      http.MethodGet,
+		Path:        "/list",
+		Summary:     "AdminListUsers",
+		Description: "List and search users by name or email, optionally filtered by permission and account status",
+		Tags:        []string{"admin"},
+		Security:    []map[string][]string{{"jwtAuth": {}}},
+	}, adminHandler.HandleListUsers)
+
+	huma.Register(userGroup, huma.Operation{
+		OperationID: "adminGetUser",
+		Method:      http.MethodGet,
+		Path:        "/{userID}",
+		Summary:     "AdminGetUser",
+		Description: "Get user details with article count, comment count and storage usage",
+		Tags:        []string{"admin"},
+		Security:    []map[string][]string{{"jwtAuth": {}}},
+	}, adminHandler.HandleGetUser)
+
+	huma.Register(userGroup, huma.Operation{
+		OperationID: "adminUpdateUserPermission",
+		Method:      http.MethodPut,
+		Path:        "/{userID}/permission",
+		Summary:     "AdminUpdateUserPermission",
+		Description: "Change the permission of a user and its builtin role. Granting or revoking scopes the operator does not hold requires role:manage",
+		Tags:        []string{"admin"},
+		Security:    []map[string][]string{{"jwtAuth": {}}},
+	}, adminHandler.HandleUpdateUserPermission)
+
+	huma.Register(userGroup, huma.Operation{
+		OperationID: "adminUpdateUserQuota",
+		Method:      http.MethodPut,
+		Path:        "/{userID}/quota",
+		Summary:     "AdminUpdateUserQuota",
+		Description: "Adjust the LLM quota of a user. Operators cannot target themselves or users holding scopes they lack",
+		Tags:        []string{"admin"},
+		Security:    []map[string][]string{{"jwtAuth": {}}},
+	}, adminHandler.HandleUpdateUserQuota)
+
+	huma.Register(userGroup, huma.Operation{
+		OperationID: "adminUpdateUserStatus",
+		Method:      http.MethodPut,
+		Path:        "/{userID}/status",
+		Summary:     "AdminUpdateUserStatus",
+		Description: "Suspend, ban or reactivate a user account. Operators cannot target themselves or users holding scopes they lack",
+		Tags:        []string{"admin"},
+		Security:    []map[string][]string{{"jwtAuth": {}}},
+	}, adminHandler.HandleUpdateUserStatus)
+
+	huma.Register(userGroup, huma.Operation{
+		OperationID: "adminListUserArticles",
+		Method:      http.MethodGet,
+		Path:        "/{userID}/articles",
+		Summary:     "AdminListUserArticles",
+		Description: "List all articles of a user regardless of status",
+		Tags:        []string{"admin"},
+		Security:    []map[string][]string{{"jwtAuth": {}}},
+	}, adminHandler.HandleListUserArticles)
+
+	huma.Register(userGroup, huma.Operation{
+		OperationID: "adminListUserComments",
+		Method:      http.MethodGet,
+		Path:        "/{userID}/comments",
+		Summary:     "AdminListUserComments",
+		Description: "List comments posted by a user",
+		Tags:        []string{"admin"},
+		Security:    []map[string][]string{{"jwtAuth": {}}},
+	}, adminHandler.HandleListUserComments)
+
+	creatorApplicationGroup := huma.NewGroup(adminGroup, "/creatorApplication")
+	middleware.RequireScope(creatorApplicationGroup, model.ScopeCreatorReview)
+
+	huma.Register(creatorApplicationGroup, huma.Operation{
+		OperationID: "adminListCreatorApplications",
+		Method:      http.MethodGet,
+		Path:        "/list",
+		Summary:     "AdminListCreatorApplications",
+		Description: "List creator applications, optionally filtered by status",
+		Tags:        []string{"admin"},
+		Security:    []map[string][]string{{"jwtAuth": {}}},
+	}, creatorApplicationHandler.HandleListCreatorApplications)
+
+	huma.Register(creatorApplicationGroup, huma.Operation{
+		OperationID: "adminReviewCreatorApplication",
+		Method:      http.MethodPost,
+		Path:        "/{applicationID}/review",
+		Summary:     "AdminReviewCreatorApplication",
+		Description: "Approve or reject a pending creator application. Approval upgrades the applicant to creator and tops up the LLM quota",
+		Tags:        []string{"admin"},
+		Security:    []map[string][]string{{"jwtAuth": {}}},
+	}, creatorApplicationHandler.HandleReviewCreatorApplication)
+
+	auditGroup := huma.NewGroup(adminGroup, "")
+	middleware.RequireScope(auditGroup, model.ScopeAuditRead)
+
+	huma.Register(auditGroup, huma.Operation{
+		OperationID: "adminListAuditEvents",
+		Method:      http.MethodGet,
+		Path:        "/audit",
+		Summary:     "AdminListAuditEvents",
+		Description: "List audit events newest first, filtered by actor, action, target and time range",
+		Tags:        []string{"admin"},
+		Security:    []map[string][]string{{"jwtAuth": {}}},
+	}, auditHandler.HandleListAuditEvents)
+
+	huma.Register(auditGroup, huma.Operation{
+		OperationID: "adminExportAuditEvents",
+		Method:      http.MethodGet,
+		Path:        "/audit/export",
+		Summary:     "AdminExportAuditEvents",
+		Description: "Export audit events matching the filters as NDJSON, one event per line in ascending order",
+		Tags:        []string{"admin"},
+		Security:    []map[string][]string{{"jwtAuth": {}}},
+	}, auditHandler.HandleExportAuditEvents)
+
+	roleGroup := huma.NewGroup(adminGroup, "")
+	middleware.RequireScope(roleGroup, model.ScopeRoleManage)
+
+	huma.Register(roleGroup, huma.Operation{
+		OperationID: "adminListRoles",
+		Method:      http.MethodGet,
+		Path:        "/role/list",
+		Summary:     "AdminListRoles",
+		Description: "List all roles with their permissions",
+		Tags:        []string{"admin"},
+		Security:    []map[string][]string{{"jwtAuth": {}}},
+	}, roleHandler.HandleListRoles)
+
+	huma.Register(roleGroup, huma.Operation{
+		OperationID: "adminListScopes",
+		Method:      http.MethodGet,
+		Path:        "/role/scopes",
+		Summary:     "AdminListScopes",
+		Description: "List all permissions that can be granted to a role",
+		Tags:        []string{"admin"},
+		Security:    []map[string][]string{{"jwtAuth": {}}},
+	}, roleHandler.HandleListScopes)
+
+	huma.Register(roleGroup, huma.Operation{
+		OperationID: "adminCreateRole",
+		Method:      http.MethodPost,
+		Path:        "/role",
+		Summary:     "AdminCreateRole",
+		Description: "Create a custom role made of named permissions",
+		Tags:        []string{"admin"},
+		Security:    []map[string][]string{{"jwtAuth": {}}},
+	}, roleHandler.HandleCreateRole)
+
+	huma.Register(roleGroup, huma.Operation{
+		OperationID: "adminUpdateRole",
+		Method:      http.MethodPatch,
+		Path:        "/role/{roleID}",
+		Summary:     "AdminUpdateRole",
+		Description: "Update the description or permissions of a role",
+		Tags:        []string{"admin"},
+		Security:    []map[string][]string{{"jwtAuth": {}}},
+	}, roleHandler.HandleUpdateRole)
+
+	huma.Register(roleGroup, huma.Operation{
+		OperationID: "adminDeleteRole",
+		Method:      http.MethodDelete,
+		Path:        "/role/{roleID}",
+		Summary:     "AdminDeleteRole",
+		Description: "Delete a custom role. Builtin roles cannot be deleted",
+		Tags:        []string{"admin"},
+		Security:    []map[string][]string{{"jwtAuth": {}}},
+	}, roleHandler.HandleDeleteRole)
+
+	huma.Register(roleGroup, huma.Operation{
+		OperationID: "adminUpdateUserRoles",
+		Method:      http.MethodPut,
+		Path:        "/user/{userID}/roles",
+		Summary:     "AdminUpdateUserRoles",
+		Description: "Replace the roles held by a user",
+		Tags:        []string{"admin"},
+		Security:    []map[string][]string{{"jwtAuth": {}}},
+	}, adminHandler.HandleUpdateUserRoles)
+}
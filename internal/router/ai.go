@@ -19,9 +19,14 @@ func initAIRouter(aiGroup *huma.Group) {
 	aiGroup.UseMiddleware(middleware.JwtMiddleware())
 
 	promptGroup := huma.NewGroup(aiGroup, "/prompt")
-	promptGroup.UseMiddleware(middleware.LimitUserPermissionMiddleware("promptService", model.PermissionAdmin))
 
-	huma.Register(promptGroup, huma.Operation{
+	promptReadGroup := huma.NewGroup(promptGroup, "")
+	middleware.RequireScope(promptReadGroup, model.ScopePromptRead)
+
+	promptWriteGroup := huma.NewGroup(promptGroup, "")
+	middleware.RequireScope(promptWriteGroup, model.ScopePromptWrite)
+
+	huma.Register(promptReadGroup, huma.Operation{
 		OperationID: "getPrompt",
 		Method:      http.MethodGet,
 		Path:        "/{taskName}/v{version}",
@@ -31,7 +36,7 @@ func initAIRouter(aiGroup *huma.Group) {
 		Security:    []map[string][]string{{"jwtAuth": {}}},
 	}, aiHandler.HandleGetPrompt)
 
-	huma.Register(promptGroup, huma.Operation{
+	huma.Register(promptReadGroup, huma.Operation{
 		OperationID: "getLatestPrompt",
 		Method:      http.MethodGet,
 		Path:        "/{taskName}/latest",
@@ -41,7 +46,7 @@ func initAIRouter(aiGroup *huma.Group) {
 		Security:    []map[string][]string{{"jwtAuth": {}}},
 	}, aiHandler.HandleGetLatestPrompt)
 
-	huma.Register(promptGroup, huma.Operation{
+	huma.Register(promptReadGroup, huma.Operation{
 		OperationID: "listPrompts",
 		Method:      http.MethodGet,
 		Path:        "/{taskName}",
@@ -51,7 +56,7 @@ func initAIRouter(aiGroup *huma.Group) {
 		Security:    []map[string][]string{{"jwtAuth": {}}},
 	}, aiHandler.HandleListPrompt)
 
-	huma.Register(promptGroup, huma.Operation{
+	huma.Register(promptWriteGroup, huma.Operation{
 		OperationID: "createPrompt",
 		Method:      http.MethodPost,
 		Path:        "/{taskName}",
@@ -98,6 +103,22 @@ func initAIRouter(aiGroup *huma.Group) {
 			"SSEResponse": protocol.SSEResponse{},
 		}, aiHandler.HandleGenerateArticleSummary)
 
+	articleTranslationGroup := huma.NewGroup(creatorGroup, "")
+	articleTranslationGroup.UseMiddleware(middleware.RedisLockMiddleware("articleTranslation", constant.CtxKeyUserID, 30*time.Second))
+
+	sse.Register(articleTranslationGroup, huma.Operation{
+		OperationID: "generateArticleTranslation",
+		Method:      http.MethodPost,
+		Path:        "/articleTranslation",
+		Summary:     "GenerateArticleTranslation",
+		Description: "Translate the latest article version into the target language using AI and save it as a language variant",
+		Tags:        []string{"ai"},
+		Security:    []map[string][]string{{"jwtAuth": {}}},
+	},
+		map[string]any{
+			"SSEResponse": protocol.SSEResponse{},
+		}, aiHandler.HandleGenerateArticleTranslation)
+
 	readerGroup := huma.NewGroup(appGroup, "/reader")
 
 	articleQAGroup := huma.NewGroup(readerGroup, "")
@@ -116,15 +137,19 @@ func initAIRouter(aiGroup *huma.Group) {
 			"SSEResponse": protocol.SSEResponse{},
 		}, aiHandler.HandleGenerateArticleQA)
 
-	//
-	// termExplainationGroup := huma.NewGroup(readerGroup, "")
-	// huma.Register(termExplainationGroup, huma.Operation{
-	// 	OperationID: "generateTermExplaination",
-	// 	Method:      http.MethodPost,
-	// 	Path:        "/termExplaination",
-	// 	Summary:     "GenerateTermExplaination",
-	// 	Description: "Generate term explanation using AI",
-	// 	Tags:        []string{"ai"},
-	// 	Security:    []map[string][]string{{"jwtAuth": {}}},
-	// }, aiHandler.HandleGenerateTermExplaination)
+	termExplainationGroup := huma.NewGroup(readerGroup, "")
+	termExplainationGroup.UseMiddleware(middleware.RedisLockMiddleware("termExplaination", constant.CtxKeyUserID, 30*time.Second))
+
+	sse.Register(termExplainationGroup, huma.Operation{
+		OperationID: "generateTermExplaination",
+		Method:      http.MethodPost,
+		Path:        "/termExplaination",
+		Summary:     "GenerateTermExplaination",
+		Description: "Generate term explanation using AI",
+		Tags:        []string{"ai"},
+		Security:    []map[string][]string{{"jwtAuth": {}}},
+	},
+		map[string]any{
+			"SSEResponse": protocol.SSEResponse{},
+		}, aiHandler.HandleGenerateTermExplaination)
 }
@@ -46,7 +46,7 @@ func initArticleRouter(articleGroup *huma.Group) {
 	}, articleHandler.HandleGetArticleInfo)
 
 	creatorArticleGroup := huma.NewGroup(articleGroup, "")
-	creatorArticleGroup.UseMiddleware(middleware.LimitUserPermissionMiddleware("articleService", model.PermissionCreator))
+	middleware.RequireScope(creatorArticleGroup, model.ScopeArticleWrite)
 
 	huma.Register(creatorArticleGroup, huma.Operation{
 		OperationID: "createArticle",
@@ -88,8 +88,33 @@ func initArticleRouter(articleGroup *huma.Group) {
 		Security:    []map[string][]string{{"jwtAuth": {}}},
 	}, articleHandler.HandleUpdateArticleStatus)
 
+	suggestionHandler := handler.NewArticleSuggestionHandler()
+
+	huma.Register(creatorArticleGroup, huma.Operation{
+		OperationID: "getArticleSuggestion",
+		Method:      http.MethodGet,
+		Path:        "/{articleID}/suggestion",
+		Summary:     "GetArticleSuggestion",
+		Description: "Get the latest AI summary and tag suggestion generated on publish",
+		Tags:        []string{"article"},
+		Security:    []map[string][]string{{"jwtAuth": {}}},
+	}, suggestionHandler.HandleGetArticleSuggestion)
+
+	huma.Register(creatorArticleGroup, huma.Operation{
+		OperationID: "reviewArticleSuggestion",
+		Method:      http.MethodPost,
+		Path:        "/{articleID}/suggestion/review",
+		Summary:     "ReviewArticleSuggestion",
+		Description: "Accept or reject the latest AI suggestion of the article",
+		Tags:        []string{"article"},
+		Security:    []map[string][]string{{"jwtAuth": {}}},
+	}, suggestionHandler.HandleReviewArticleSuggestion)
+
 	articleVersionGroup := huma.NewGroup(articleGroup, "/{articleID}/version")
 	initArticleVersionRouter(articleVersionGroup)
+
+	articleAttachmentGroup := huma.NewGroup(articleGroup, "/{articleID}/attachment")
+	initArticleAttachmentRouter(articleAttachmentGroup)
 }
 
 func initArticleVersionRouter(articleVersionGroup *huma.Group) {
@@ -106,7 +131,7 @@ func initArticleVersionRouter(articleVersionGroup *huma.Group) {
 	}, versionHandler.HandleGetLatestArticleVersionInfo)
 
 	creatorArticleVersionGroup := huma.NewGroup(articleVersionGroup, "")
-	creatorArticleVersionGroup.UseMiddleware(middleware.LimitUserPermissionMiddleware("articleVersionService", model.PermissionCreator))
+	middleware.RequireScope(creatorArticleVersionGroup, model.ScopeArticleWrite)
 
 	huma.Register(creatorArticleVersionGroup, huma.Operation{
 		OperationID: "listArticleVersions",
@@ -138,4 +163,78 @@ func initArticleVersionRouter(articleVersionGroup *huma.Group) {
 		Tags:        []string{"articleVersion"},
 		Security:    []map[string][]string{{"jwtAuth": {}}},
 	}, versionHandler.HandleGetArticleVersionInfo)
+
+	assetHandler := handler.NewAssetHandler()
+
+	huma.Register(creatorArticleVersionGroup, huma.Operation{
+		OperationID: "importArticleVersionImages",
+		Method:      http.MethodPost,
+		Path:        "/v{version}/images/import",
+		Summary:     "ImportArticleVersionImages",
+		Description: "Import the external images referenced by the version content into the author's storage and create a new version with the links rewritten; links that fail to import are left unchanged",
+		Tags:        []string{"articleVersion"},
+		Security:    []map[string][]string{{"jwtAuth": {}}},
+		Middlewares: huma.Middlewares{
+			middleware.RequirePermission(model.ScopeAssetWrite),
+			middleware.RateLimiterMiddleware("importArticleVersionImages", constant.CtxKeyUserID, time.Minute, 1),
+		},
+	}, assetHandler.HandleImportArticleVersionImages)
+}
+
+func initArticleAttachmentRouter(articleAttachmentGroup *huma.Group) {
+	attachmentHandler := handler.NewAttachmentHandler()
+
+	huma.Register(articleAttachmentGroup, huma.Operation{
+		OperationID: "listArticleAttachments",
+		Method:      http.MethodGet,
+		Path:        "/list",
+		Summary:     "ListArticleAttachments",
+		Description: "List attachments of the article, drafts are only visible to the author",
+		Tags:        []string{"articleAttachment"},
+		Security:    []map[string][]string{{"jwtAuth": {}}},
+	}, attachmentHandler.HandleListArticleAttachments)
+
+	huma.Register(articleAttachmentGroup, huma.Operation{
+		OperationID: "getArticleAttachment",
+		Method:      http.MethodGet,
+		Path:        "/{attachmentID}",
+		Summary:     "GetArticleAttachment",
+		Description: "Get a presigned URL of the attachment, or of its preview image when preview is set",
+		Tags:        []string{"articleAttachment"},
+		Security:    []map[string][]string{{"jwtAuth": {}}},
+	}, attachmentHandler.HandleGetAttachment)
+
+	creatorArticleAttachmentGroup := huma.NewGroup(articleAttachmentGroup, "")
+	middleware.RequireScope(creatorArticleAttachmentGroup, model.ScopeArticleWrite)
+
+	huma.Register(creatorArticleAttachmentGroup, huma.Operation{
+		OperationID: "presignArticleAttachmentUpload",
+		Method:      http.MethodPost,
+		Path:        "/presign",
+		Summary:     "PresignArticleAttachmentUpload",
+		Description: "Get a presigned request to upload a document, audio, video or archive straight to storage, then call confirmArticleAttachmentUpload with the returned upload ID",
+		Tags:        []string{"articleAttachment"},
+		Security:    []map[string][]string{{"jwtAuth": {}}},
+		Middlewares: huma.Middlewares{middleware.RateLimiterMiddleware("presignArticleAttachmentUpload", constant.CtxKeyUserID, 10*time.Second, 1)},
+	}, attachmentHandler.HandlePresignAttachmentUpload)
+
+	huma.Register(creatorArticleAttachmentGroup, huma.Operation{
+		OperationID: "confirmArticleAttachmentUpload",
+		Method:      http.MethodPost,
+		Path:        "/confirm",
+		Summary:     "ConfirmArticleAttachmentUpload",
+		Description: "Check the uploaded file content against its extension and size limit, then attach it to the article",
+		Tags:        []string{"articleAttachment"},
+		Security:    []map[string][]string{{"jwtAuth": {}}},
+	}, attachmentHandler.HandleConfirmAttachmentUpload)
+
+	huma.Register(creatorArticleAttachmentGroup, huma.Operation{
+		OperationID: "deleteArticleAttachment",
+		Method:      http.MethodDelete,
+		Path:        "/{attachmentID}",
+		Summary:     "DeleteArticleAttachment",
+		Description: "Delete the attachment and its preview image",
+		Tags:        []string{"articleAttachment"},
+		Security:    []map[string][]string{{"jwtAuth": {}}},
+	}, attachmentHandler.HandleDeleteAttachment)
 }
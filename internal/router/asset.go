@@ -70,8 +70,21 @@ func initAssetRouter(assetGroup *huma.Group) {
 		Security:    []map[string][]string{{"jwtAuth": {}}},
 	}, assetHandler.HandleDeleteUserView)
 
+	variantGroup := huma.NewGroup(assetGroup, "/image")
+	middleware.RequireScope(variantGroup, model.ScopeAssetWrite)
+
+	huma.Register(variantGroup, huma.Operation{
+		OperationID: "getImageVariant",
+		Method:      http.MethodGet,
+		Path:        "/{objectName}/variant",
+		Summary:     "GetImageVariant",
+		Description: "Redirect to a resized variant of an image, rendering and caching it on first request",
+		Tags:        []string{"asset"},
+		Security:    []map[string][]string{{"jwtAuth": {}}},
+	}, assetHandler.HandleGetImageVariant)
+
 	objectGroup := huma.NewGroup(assetGroup, "/object")
-	objectGroup.UseMiddleware(middleware.LimitUserPermissionMiddleware("objectService", model.PermissionCreator))
+	middleware.RequireScope(objectGroup, model.ScopeAssetWrite)
 
 	huma.Register(objectGroup, huma.Operation{
 		OperationID: "listImages",
@@ -93,11 +106,47 @@ func initAssetRouter(assetGroup *huma.Group) {
 		Method:      http.MethodPost,
 		Path:        "/",
 		Summary:     "UploadImage",
-		Description: "Upload an image",
+		Description: "Upload an image. EXIF/XMP metadata is stripped, identical content is stored once, and a name already used by different content is rejected. New content counts against the storage quota of the permission level (StorageQuotaExceeded)",
 		Tags:        []string{"asset"},
 		Security:    []map[string][]string{{"jwtAuth": {}}},
 	}, assetHandler.HandleUploadImage)
 
+	presignImageGroup := huma.NewGroup(imageGroup, "")
+	presignImageGroup.UseMiddleware(middleware.RateLimiterMiddleware("presignImageUpload", constant.CtxKeyUserID, 10*time.Second, 1))
+
+	huma.Register(presignImageGroup, huma.Operation{
+		OperationID: "presignImageUpload",
+		Method:      http.MethodPost,
+		Path:        "/presign",
+		Summary:     "PresignImageUpload",
+		Description: "Get a presigned request to upload an image straight to a staging area, then call confirmImageUpload with the returned upload ID",
+		Tags:        []string{"asset"},
+		Security:    []map[string][]string{{"jwtAuth": {}}},
+	}, assetHandler.HandlePresignImageUpload)
+
+	importImageGroup := huma.NewGroup(imageGroup, "")
+	importImageGroup.UseMiddleware(middleware.RateLimiterMiddleware("importImage", constant.CtxKeyUserID, 10*time.Second, 1))
+
+	huma.Register(importImageGroup, huma.Operation{
+		OperationID: "importImage",
+		Method:      http.MethodPost,
+		Path:        "/import",
+		Summary:     "ImportImage",
+		Description: "Fetch an image from a public http or https URL and store it like a regular upload. Private, loopback and link-local addresses are refused, and the download is bounded by IMAGE_IMPORT_TIMEOUT and IMAGE_IMPORT_MAX_SIZE",
+		Tags:        []string{"asset"},
+		Security:    []map[string][]string{{"jwtAuth": {}}},
+	}, assetHandler.HandleImportImage)
+
+	huma.Register(imageGroup, huma.Operation{
+		OperationID: "confirmImageUpload",
+		Method:      http.MethodPost,
+		Path:        "/{objectName}/confirm",
+		Summary:     "ConfirmImageUpload",
+		Description: "Confirm a direct upload, strip its metadata and store it like a regular upload; the thumbnail is generated in the background",
+		Tags:        []string{"asset"},
+		Security:    []map[string][]string{{"jwtAuth": {}}},
+	}, assetHandler.HandleConfirmImageUpload)
+
 	huma.Register(imageGroup, huma.Operation{
 		OperationID: "getImage",
 		Method:      http.MethodGet,
@@ -16,7 +16,7 @@ func initOauth2Router(oauth2Group *huma.Group) {
 		Method:      http.MethodGet,
 		Path:        "/{provider}/login",
 		Summary:     "OAuth2Login",
-		Description: "Get OAuth2 authorization URL for the specified provider (github/google/qq)",
+		Description: "Get OAuth2 authorization URL for the specified provider (github/google/qq or a configured OIDC provider)",
 		Tags:        []string{"oauth2"},
 	}, oauth2Handler.HandleLogin)
 
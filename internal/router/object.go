@@ -0,0 +1,24 @@
+package router
+
+import (
+	"github.com/hcd233/aris-blog-api/internal/api"
+	"github.com/hcd233/aris-blog-api/internal/handler"
+	"github.com/hcd233/aris-blog-api/internal/resource/storage"
+	objdao "github.com/hcd233/aris-blog-api/internal/resource/storage/obj_dao"
+)
+
+// RegisterLocalObjectRouter 注册本地对象存储的签名下载与直传路由，仅在使用本地存储时生效
+//
+//	author centonhuang
+//	update 2025-11-15 15:21:06
+func RegisterLocalObjectRouter() {
+	if storage.GetProvider() != storage.ProviderLocal {
+		return
+	}
+
+	localObjectHandler := handler.NewLocalObjectHandler()
+
+	app := api.GetFiberApp()
+	app.Get(objdao.LocalObjectRoutePrefix+"/:objectType/:userID/*", localObjectHandler.HandleDownloadLocalObject)
+	app.Put(objdao.LocalObjectRoutePrefix+"/:objectType/:userID/*", localObjectHandler.HandleUploadLocalObject)
+}
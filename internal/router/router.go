@@ -45,6 +45,7 @@ func RegisterDocsRouter() {
 //	update 2025-01-04 15:32:40
 func RegisterAPIRouter() {
 	pingService := handler.NewPingHandler()
+	jwksHandler := handler.NewJWKSHandler()
 
 	api := api.GetHumaAPI()
 
@@ -76,6 +77,18 @@ func RegisterAPIRouter() {
 	aiGroup := huma.NewGroup(v1Group, "/ai")
 	initAIRouter(aiGroup)
 
+	adminGroup := huma.NewGroup(v1Group, "/admin")
+	initAdminRouter(adminGroup)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "getJWKS",
+		Method:      http.MethodGet,
+		Path:        "/.well-known/jwks.json",
+		Summary:     "GetJWKS",
+		Description: "Public keys for verifying tokens issued by this service, including keys rotated out of signing but not yet retired",
+		Tags:        []string{"token"},
+	}, jwksHandler.HandleGetJWKS)
+
 	huma.Register(api, huma.Operation{
 		OperationID: "ping",
 		Method:      http.MethodGet,
@@ -36,7 +36,7 @@ func initTagRouter(v1Group *huma.Group) {
 	}, tagHandler.HandleGetTagInfo)
 
 	securedGroup := huma.NewGroup(tagGroup, "")
-	securedGroup.UseMiddleware(middleware.LimitUserPermissionMiddleware("tagService", model.PermissionCreator))
+	middleware.RequireScope(securedGroup, model.ScopeTagManage)
 
 	huma.Register(securedGroup, huma.Operation{
 		OperationID: "createTag",
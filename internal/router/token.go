@@ -5,6 +5,7 @@ import (
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/hcd233/aris-blog-api/internal/handler"
+	"github.com/hcd233/aris-blog-api/internal/middleware"
 )
 
 func initTokenRouter(tokenGroup *huma.Group) {
@@ -19,4 +20,18 @@ func initTokenRouter(tokenGroup *huma.Group) {
 		Description: "Refresh the access token using a refresh token",
 		Tags:        []string{"token"},
 	}, tokenHandler.HandleRefreshToken)
+
+	authedGroup := huma.NewGroup(tokenGroup, "")
+	authedGroup.UseMiddleware(middleware.JwtMiddleware())
+
+	// 登出
+	huma.Register(authedGroup, huma.Operation{
+		OperationID: "logout",
+		Method:      http.MethodPost,
+		Path:        "/logout",
+		Summary:     "Logout",
+		Description: "Revoke the current session so that its refresh token and access tokens stop working",
+		Tags:        []string{"token"},
+		Security:    []map[string][]string{{"jwtAuth": {}}},
+	}, tokenHandler.HandleLogout)
 }
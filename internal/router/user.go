@@ -10,6 +10,12 @@ import (
 
 func initUserRouter(userGroup *huma.Group) {
 	userHandler := handler.NewUserHandler()
+	creatorApplicationHandler := handler.NewCreatorApplicationHandler()
+	sessionHandler := handler.NewSessionHandler()
+	patHandler := handler.NewPersonalAccessTokenHandler()
+	oauth2Handler := handler.NewOauth2Handler()
+	dataExportHandler := handler.NewDataExportHandler()
+	accountDeletionHandler := handler.NewAccountDeletionHandler()
 
 	userGroup.UseMiddleware(middleware.JwtMiddleware())
 
@@ -51,4 +57,212 @@ func initUserRouter(userGroup *huma.Group) {
 			{"jwtAuth": {}},
 		},
 	}, userHandler.HandleGetUser)
+
+	// 获取当前用户的创作者申请
+	huma.Register(userGroup, huma.Operation{
+		OperationID: "getCurrentCreatorApplication",
+		Method:      http.MethodGet,
+		Path:        "/current/creatorApplication",
+		Summary:     "GetCurrentCreatorApplication",
+		Description: "Get the status of the current user's latest creator application, including when a rejected user can reapply",
+		Tags:        []string{"user"},
+		Security: []map[string][]string{
+			{"jwtAuth": {}},
+		},
+	}, creatorApplicationHandler.HandleGetCurrentCreatorApplication)
+
+	// 提交创作者申请
+	huma.Register(userGroup, huma.Operation{
+		OperationID: "submitCreatorApplication",
+		Method:      http.MethodPost,
+		Path:        "/current/creatorApplication",
+		Summary:     "SubmitCreatorApplication",
+		Description: "Apply for the creator permission with a reason and links to previous works",
+		Tags:        []string{"user"},
+		Security: []map[string][]string{
+			{"jwtAuth": {}},
+		},
+	}, creatorApplicationHandler.HandleSubmitCreatorApplication)
+
+	// 列出当前用户的登录会话
+	huma.Register(userGroup, huma.Operation{
+		OperationID: "listCurrentSessions",
+		Method:      http.MethodGet,
+		Path:        "/current/sessions",
+		Summary:     "ListCurrentSessions",
+		Description: "List active sessions of the current user with device and IP information",
+		Tags:        []string{"user"},
+		Security: []map[string][]string{
+			{"jwtAuth": {}},
+		},
+	}, sessionHandler.HandleListSessions)
+
+	// 吊销指定会话
+	huma.Register(userGroup, huma.Operation{
+		OperationID: "revokeCurrentSession",
+		Method:      http.MethodDelete,
+		Path:        "/current/sessions/{sessionID}",
+		Summary:     "RevokeSession",
+		Description: "Revoke one session of the current user",
+		Tags:        []string{"user"},
+		Security: []map[string][]string{
+			{"jwtAuth": {}},
+		},
+	}, sessionHandler.HandleRevokeSession)
+
+	// 吊销全部会话
+	huma.Register(userGroup, huma.Operation{
+		OperationID: "revokeAllCurrentSessions",
+		Method:      http.MethodDelete,
+		Path:        "/current/sessions",
+		Summary:     "RevokeAllSessions",
+		Description: "Revoke all sessions of the current user, including the one making this request",
+		Tags:        []string{"user"},
+		Security: []map[string][]string{
+			{"jwtAuth": {}},
+		},
+	}, sessionHandler.HandleRevokeAllSessions)
+
+	// 列出个人访问令牌
+	huma.Register(userGroup, huma.Operation{
+		OperationID: "listPersonalAccessTokens",
+		Method:      http.MethodGet,
+		Path:        "/current/tokens",
+		Summary:     "ListPersonalAccessTokens",
+		Description: "List personal access tokens of the current user with their scopes, expiry and last use",
+		Tags:        []string{"user"},
+		Security: []map[string][]string{
+			{"jwtAuth": {}},
+		},
+	}, patHandler.HandleListTokens)
+
+	// 创建个人访问令牌
+	huma.Register(userGroup, huma.Operation{
+		OperationID: "createPersonalAccessToken",
+		Method:      http.MethodPost,
+		Path:        "/current/tokens",
+		Summary:     "CreatePersonalAccessToken",
+		Description: "Create a personal access token for API automation. The token can only call endpoints that require a scope, within the intersection of its scopes and the user's current scopes. The token value is only returned in this response",
+		Tags:        []string{"user"},
+		Security: []map[string][]string{
+			{"jwtAuth": {}},
+		},
+	}, patHandler.HandleCreateToken)
+
+	// 吊销个人访问令牌
+	huma.Register(userGroup, huma.Operation{
+		OperationID: "revokePersonalAccessToken",
+		Method:      http.MethodDelete,
+		Path:        "/current/tokens/{tokenID}",
+		Summary:     "RevokePersonalAccessToken",
+		Description: "Revoke a personal access token of the current user",
+		Tags:        []string{"user"},
+		Security: []map[string][]string{
+			{"jwtAuth": {}},
+		},
+	}, patHandler.HandleRevokeToken)
+
+	// 发起绑定第三方账号的授权
+	huma.Register(userGroup, huma.Operation{
+		OperationID: "linkOauth2ProviderLogin",
+		Method:      http.MethodGet,
+		Path:        "/current/oauth2/{provider}/login",
+		Summary:     "LinkOauth2ProviderLogin",
+		Description: "Get the OAuth2 authorization URL for linking a provider to the current user. The returned state can only be used to link this user",
+		Tags:        []string{"user"},
+		Security: []map[string][]string{
+			{"jwtAuth": {}},
+		},
+	}, oauth2Handler.HandleLinkLogin)
+
+	// 绑定第三方账号
+	huma.Register(userGroup, huma.Operation{
+		OperationID: "linkOauth2Provider",
+		Method:      http.MethodPost,
+		Path:        "/current/oauth2/{provider}",
+		Summary:     "LinkOauth2Provider",
+		Description: "Link another OAuth2 provider account to the current user with the authorization code and state returned by the provider",
+		Tags:        []string{"user"},
+		Security: []map[string][]string{
+			{"jwtAuth": {}},
+		},
+	}, oauth2Handler.HandleLink)
+
+	// 解绑第三方账号
+	huma.Register(userGroup, huma.Operation{
+		OperationID: "unlinkOauth2Provider",
+		Method:      http.MethodDelete,
+		Path:        "/current/oauth2/{provider}",
+		Summary:     "UnlinkOauth2Provider",
+		Description: "Unlink an OAuth2 provider from the current user. The last linked provider cannot be unlinked",
+		Tags:        []string{"user"},
+		Security: []map[string][]string{
+			{"jwtAuth": {}},
+		},
+	}, oauth2Handler.HandleUnlink)
+
+	// 申请导出个人数据
+	huma.Register(userGroup, huma.Operation{
+		OperationID: "requestDataExport",
+		Method:      http.MethodPost,
+		Path:        "/current/export",
+		Summary:     "RequestDataExport",
+		Description: "Request an archive of the current user's profile, articles with all versions, comments, likes, views and uploaded images. The archive is built in the background",
+		Tags:        []string{"user"},
+		Security: []map[string][]string{
+			{"jwtAuth": {}},
+		},
+	}, dataExportHandler.HandleRequestDataExport)
+
+	// 获取个人数据导出状态
+	huma.Register(userGroup, huma.Operation{
+		OperationID: "getCurrentDataExport",
+		Method:      http.MethodGet,
+		Path:        "/current/export",
+		Summary:     "GetCurrentDataExport",
+		Description: "Get the status of the current user's latest data export, with a short-lived download URL once the archive is ready",
+		Tags:        []string{"user"},
+		Security: []map[string][]string{
+			{"jwtAuth": {}},
+		},
+	}, dataExportHandler.HandleGetCurrentDataExport)
+
+	// 申请注销账号
+	huma.Register(userGroup, huma.Operation{
+		OperationID: "requestAccountDeletion",
+		Method:      http.MethodPost,
+		Path:        "/current/deletion",
+		Summary:     "RequestAccountDeletion",
+		Description: "Schedule the deletion of the current account after a grace period. Articles are deleted or transferred to another creator, comments are kept anonymously and uploaded files are purged. Images and attachments of transferred articles are copied to the recipient and count against their storage quota; the deletion is retried later while they would exceed it",
+		Tags:        []string{"user"},
+		Security: []map[string][]string{
+			{"jwtAuth": {}},
+		},
+	}, accountDeletionHandler.HandleRequestAccountDeletion)
+
+	// 获取注销申请
+	huma.Register(userGroup, huma.Operation{
+		OperationID: "getCurrentAccountDeletion",
+		Method:      http.MethodGet,
+		Path:        "/current/deletion",
+		Summary:     "GetCurrentAccountDeletion",
+		Description: "Get the pending account deletion of the current user and when it will be executed",
+		Tags:        []string{"user"},
+		Security: []map[string][]string{
+			{"jwtAuth": {}},
+		},
+	}, accountDeletionHandler.HandleGetCurrentAccountDeletion)
+
+	// 撤销注销申请
+	huma.Register(userGroup, huma.Operation{
+		OperationID: "cancelAccountDeletion",
+		Method:      http.MethodDelete,
+		Path:        "/current/deletion",
+		Summary:     "CancelAccountDeletion",
+		Description: "Cancel the pending account deletion during the grace period",
+		Tags:        []string{"user"},
+		Security: []map[string][]string{
+			{"jwtAuth": {}},
+		},
+	}, accountDeletionHandler.HandleCancelAccountDeletion)
 }
@@ -0,0 +1,551 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hcd233/aris-blog-api/internal/config"
+	"github.com/hcd233/aris-blog-api/internal/constant"
+	"github.com/hcd233/aris-blog-api/internal/logger"
+	"github.com/hcd233/aris-blog-api/internal/protocol"
+	dto "github.com/hcd233/aris-blog-api/internal/protocol/dto"
+	"github.com/hcd233/aris-blog-api/internal/resource/database"
+	"github.com/hcd233/aris-blog-api/internal/resource/database/dao"
+	"github.com/hcd233/aris-blog-api/internal/resource/database/model"
+	objdao "github.com/hcd233/aris-blog-api/internal/resource/storage/obj_dao"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	accountDeletionRetryBaseDelay = 5 * time.Minute
+	accountDeletionLease          = 30 * time.Minute
+	accountDeletionBatchSize      = 10
+)
+
+var accountDeletionFields = []string{
+	"id", "user_id", "status", "article_disposition", "transfer_to_user_id",
+	"reason", "scheduled_at", "retries", "created_at",
+}
+
+var (
+	errAccountDeletionCancelled = errors.New("account deletion cancelled")
+	errInvalidTransferTarget    = errors.New("invalid article transfer target")
+)
+
+// AccountDeletionService 账号注销服务
+//
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+type AccountDeletionService interface {
+	RequestAccountDeletion(ctx context.Context, req *dto.RequestAccountDeletionRequest) (rsp *dto.RequestAccountDeletionResponse, err error)
+	GetCurrentAccountDeletion(ctx context.Context, req *dto.EmptyRequest) (rsp *dto.GetCurrentAccountDeletionResponse, err error)
+	CancelAccountDeletion(ctx context.Context, req *dto.EmptyRequest) (rsp *dto.EmptyResponse, err error)
+	ProcessDueAccountDeletions(ctx context.Context)
+}
+
+type accountDeletionService struct {
+	userDAO            *dao.UserDAO
+	articleDAO         *dao.ArticleDAO
+	articleVersionDAO  *dao.ArticleVersionDAO
+	categoryDAO        *dao.CategoryDAO
+	commentDAO         *dao.CommentDAO
+	userLikeDAO        *dao.UserLikeDAO
+	userViewDAO        *dao.UserViewDAO
+	sessionDAO         *dao.SessionDAO
+	patDAO             *dao.PersonalAccessTokenDAO
+	oidcIdentityDAO    *dao.OidcIdentityDAO
+	assetDAO           *dao.AssetDAO
+	attachmentDAO      *dao.AttachmentDAO
+	accountDeletionDAO *dao.AccountDeletionDAO
+	objDAOs            []objdao.ObjDAO
+
+	imageObjDAO             objdao.ObjDAO
+	thumbnailObjDAO         objdao.ObjDAO
+	attachmentObjDAO        objdao.ObjDAO
+	attachmentPreviewObjDAO objdao.ObjDAO
+}
+
+// NewAccountDeletionService 创建账号注销服务
+//
+//	return AccountDeletionService
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+func NewAccountDeletionService() AccountDeletionService {
+	return &accountDeletionService{
+		userDAO:            dao.GetUserDAO(),
+		articleDAO:         dao.GetArticleDAO(),
+		articleVersionDAO:  dao.GetArticleVersionDAO(),
+		categoryDAO:        dao.GetCategoryDAO(),
+		commentDAO:         dao.GetCommentDAO(),
+		userLikeDAO:        dao.GetUserLikeDAO(),
+		userViewDAO:        dao.GetUserViewDAO(),
+		sessionDAO:         dao.GetSessionDAO(),
+		patDAO:             dao.GetPersonalAccessTokenDAO(),
+		oidcIdentityDAO:    dao.GetOidcIdentityDAO(),
+		assetDAO:           dao.GetAssetDAO(),
+		attachmentDAO:      dao.GetAttachmentDAO(),
+		accountDeletionDAO: dao.GetAccountDeletionDAO(),
+		objDAOs: []objdao.ObjDAO{
+			objdao.GetImageObjDAO(), objdao.GetThumbnailObjDAO(), objdao.GetVariantObjDAO(), objdao.GetExportObjDAO(),
+			objdao.GetAttachmentObjDAO(), objdao.GetAttachmentPreviewObjDAO(),
+		},
+		imageObjDAO:             objdao.GetImageObjDAO(),
+		thumbnailObjDAO:         objdao.GetThumbnailObjDAO(),
+		attachmentObjDAO:        objdao.GetAttachmentObjDAO(),
+		attachmentPreviewObjDAO: objdao.GetAttachmentPreviewObjDAO(),
+	}
+}
+
+// RequestAccountDeletion 申请注销当前账号，冷静期结束后执行
+//
+//	receiver s *accountDeletionService
+//	param ctx context.Context
+//	param req *dto.RequestAccountDeletionRequest
+//	return rsp *dto.RequestAccountDeletionResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+func (s *accountDeletionService) RequestAccountDeletion(ctx context.Context, req *dto.RequestAccountDeletionRequest) (rsp *dto.RequestAccountDeletionResponse, err error) {
+	rsp = &dto.RequestAccountDeletionResponse{}
+
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	if err := requireSessionAuth(ctx); err != nil {
+		return nil, err
+	}
+
+	if req.Body == nil {
+		logger.Error("[AccountDeletionService] request body is nil")
+		return nil, protocol.ErrBadRequest
+	}
+
+	userID := ctx.Value(constant.CtxKeyUserID).(uint)
+	disposition := model.ArticleDisposition(req.Body.ArticleDisposition)
+
+	switch disposition {
+	case model.ArticleDispositionDelete:
+		if req.Body.TransferToUserID != 0 {
+			logger.Error("[AccountDeletionService] transfer target given for deleted articles", zap.Uint("transferToUserID", req.Body.TransferToUserID))
+			return nil, protocol.ErrBadRequest
+		}
+	case model.ArticleDispositionTransfer:
+		if err := s.checkTransferTarget(db, userID, req.Body.TransferToUserID); err != nil {
+			logger.Error("[AccountDeletionService] invalid transfer target", zap.Uint("transferToUserID", req.Body.TransferToUserID), zap.Error(err))
+			if errors.Is(err, errInvalidTransferTarget) {
+				return nil, protocol.ErrBadRequest
+			}
+			return nil, protocol.ErrInternalError
+		}
+	default:
+		logger.Error("[AccountDeletionService] invalid article disposition", zap.String("articleDisposition", req.Body.ArticleDisposition))
+		return nil, protocol.ErrBadRequest
+	}
+
+	pending, err := s.accountDeletionDAO.GetPendingByUserID(db, userID, []string{"id"})
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		logger.Error("[AccountDeletionService] failed to get pending deletion", zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+	if pending != nil && pending.ID != 0 {
+		logger.Error("[AccountDeletionService] deletion already pending", zap.Uint("deletionID", pending.ID))
+		return nil, protocol.ErrDataExists
+	}
+
+	deletion := &model.AccountDeletion{
+		UserID:             userID,
+		Status:             model.AccountDeletionStatusPending,
+		ArticleDisposition: disposition,
+		TransferToUserID:   req.Body.TransferToUserID,
+		Reason:             req.Body.Reason,
+		ScheduledAt:        time.Now().UTC().Add(config.AccountDeletionGracePeriod),
+	}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := s.accountDeletionDAO.Create(tx, deletion); err != nil {
+			return err
+		}
+		return recordAuditEvent(ctx, tx, &model.AuditEvent{
+			Action:     model.AuditActionUserDeletionRequest,
+			TargetType: model.AuditTargetTypeUser,
+			TargetID:   userID,
+			After: map[string]any{
+				"deletionID":         deletion.ID,
+				"articleDisposition": deletion.ArticleDisposition,
+				"transferToUserID":   deletion.TransferToUserID,
+				"scheduledAt":        deletion.ScheduledAt,
+			},
+		})
+	})
+	if err != nil {
+		logger.Error("[AccountDeletionService] failed to create deletion", zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	logger.Info("[AccountDeletionService] deletion requested",
+		zap.Uint("deletionID", deletion.ID),
+		zap.Time("scheduledAt", deletion.ScheduledAt))
+
+	rsp.Deletion = buildAccountDeletionDTO(deletion)
+	return rsp, nil
+}
+
+// GetCurrentAccountDeletion 获取当前用户冷静期中的注销申请
+//
+//	receiver s *accountDeletionService
+//	param ctx context.Context
+//	param _ *dto.EmptyRequest
+//	return rsp *dto.GetCurrentAccountDeletionResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+func (s *accountDeletionService) GetCurrentAccountDeletion(ctx context.Context, _ *dto.EmptyRequest) (rsp *dto.GetCurrentAccountDeletionResponse, err error) {
+	rsp = &dto.GetCurrentAccountDeletionResponse{}
+
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	userID := ctx.Value(constant.CtxKeyUserID).(uint)
+
+	deletion, err := s.accountDeletionDAO.GetPendingByUserID(db, userID, accountDeletionFields)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Info("[AccountDeletionService] pending deletion not found")
+			return nil, protocol.ErrDataNotExists
+		}
+		logger.Error("[AccountDeletionService] failed to get pending deletion", zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	rsp.Deletion = buildAccountDeletionDTO(deletion)
+	return rsp, nil
+}
+
+// CancelAccountDeletion 在冷静期内撤销注销申请
+//
+//	receiver s *accountDeletionService
+//	param ctx context.Context
+//	param _ *dto.EmptyRequest
+//	return rsp *dto.EmptyResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+func (s *accountDeletionService) CancelAccountDeletion(ctx context.Context, _ *dto.EmptyRequest) (rsp *dto.EmptyResponse, err error) {
+	rsp = &dto.EmptyResponse{}
+
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	if err := requireSessionAuth(ctx); err != nil {
+		return nil, err
+	}
+
+	userID := ctx.Value(constant.CtxKeyUserID).(uint)
+
+	deletion, err := s.accountDeletionDAO.GetPendingByUserID(db, userID, []string{"id", "status"})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Error("[AccountDeletionService] pending deletion not found")
+			return nil, protocol.ErrDataNotExists
+		}
+		logger.Error("[AccountDeletionService] failed to get pending deletion", zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		cancelled, err := s.accountDeletionDAO.Cancel(tx, deletion)
+		if err != nil {
+			return err
+		}
+		if !cancelled {
+			return errAccountDeletionCancelled
+		}
+		return recordAuditEvent(ctx, tx, &model.AuditEvent{
+			Action:     model.AuditActionUserDeletionCancel,
+			TargetType: model.AuditTargetTypeUser,
+			TargetID:   userID,
+			Before:     map[string]any{"status": model.AccountDeletionStatusPending},
+			After:      map[string]any{"status": model.AccountDeletionStatusCancelled},
+		})
+	})
+	if err != nil {
+		if errors.Is(err, errAccountDeletionCancelled) {
+			logger.Error("[AccountDeletionService] deletion is no longer pending", zap.Uint("deletionID", deletion.ID))
+			return nil, protocol.ErrDataNotExists
+		}
+		logger.Error("[AccountDeletionService] failed to cancel deletion", zap.Uint("deletionID", deletion.ID), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	logger.Info("[AccountDeletionService] deletion cancelled", zap.Uint("deletionID", deletion.ID))
+	return rsp, nil
+}
+
+// ProcessDueAccountDeletions 执行冷静期已结束的注销申请
+//
+//	receiver s *accountDeletionService
+//	param ctx context.Context
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+func (s *accountDeletionService) ProcessDueAccountDeletions(ctx context.Context) {
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	deletions, err := s.accountDeletionDAO.ListDue(db, time.Now().UTC(), accountDeletionBatchSize, accountDeletionFields)
+	if err != nil {
+		logger.Error("[AccountDeletionService] failed to list due deletions", zap.Error(err))
+		return
+	}
+
+	for _, deletion := range *deletions {
+		s.processAccountDeletion(ctx, &deletion)
+	}
+}
+
+func (s *accountDeletionService) processAccountDeletion(ctx context.Context, deletion *model.AccountDeletion) {
+	logger := logger.WithCtx(ctx).With(zap.Uint("deletionID", deletion.ID), zap.Uint("userID", deletion.UserID))
+	db := database.GetDBInstance(ctx)
+
+	now := time.Now().UTC()
+	claimed, err := s.accountDeletionDAO.Claim(db, deletion, now, now.Add(accountDeletionLease))
+	if err != nil {
+		logger.Error("[AccountDeletionService] failed to claim deletion", zap.Error(err))
+		return
+	}
+	if !claimed {
+		logger.Info("[AccountDeletionService] deletion claimed by others, skip")
+		return
+	}
+
+	// 转让文章引用的图片与附件须在清除对象存储前复制到受让用户目录
+	var renames map[string]string
+	if deletion.ArticleDisposition == model.ArticleDispositionTransfer {
+		if renames, err = s.copyTransferredObjects(ctx, deletion); err != nil {
+			s.failAccountDeletion(ctx, deletion, err)
+			return
+		}
+	}
+
+	articles, err := s.deleteAccount(ctx, deletion, renames)
+	if err != nil {
+		if errors.Is(err, errAccountDeletionCancelled) {
+			logger.Info("[AccountDeletionService] deletion cancelled during execution, skip")
+			return
+		}
+		s.failAccountDeletion(ctx, deletion, err)
+		return
+	}
+
+	// 数据库中的账号已注销，对象存储清理失败不影响注销结果
+	for _, objDAO := range s.objDAOs {
+		if err := objDAO.DeleteDir(ctx, deletion.UserID); err != nil {
+			logger.Error("[AccountDeletionService] failed to purge object storage",
+				zap.String("bucket", objDAO.GetBucketName(ctx)),
+				zap.Error(err))
+		}
+	}
+
+	logger.Info("[AccountDeletionService] account deleted",
+		zap.String("articleDisposition", string(deletion.ArticleDisposition)),
+		zap.Int("articles", articles))
+}
+
+// deleteAccount 在同一事务中处理文章、匿名化评论并注销用户
+func (s *accountDeletionService) deleteAccount(ctx context.Context, deletion *model.AccountDeletion, renames map[string]string) (articleCount int, err error) {
+	db := database.GetDBInstance(ctx)
+	userID := deletion.UserID
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		completed, err := s.accountDeletionDAO.Complete(tx, deletion, time.Now().UTC())
+		if err != nil {
+			return err
+		}
+		if !completed {
+			return errAccountDeletionCancelled
+		}
+
+		user, err := s.userDAO.GetByID(tx, userID, []string{"id", "name", "email", "avatar", "permission"}, []string{})
+		if err != nil {
+			return fmt.Errorf("get user: %w", err)
+		}
+
+		articles, err := s.articleDAO.ListByUserID(tx, userID, []string{"id", "slug", "user_id"}, []string{})
+		if err != nil {
+			return fmt.Errorf("list articles: %w", err)
+		}
+		articleCount = len(*articles)
+
+		switch deletion.ArticleDisposition {
+		case model.ArticleDispositionTransfer:
+			if err := s.transferArticles(tx, userID, deletion.TransferToUserID, *articles, renames); err != nil {
+				return err
+			}
+		default:
+			for _, article := range *articles {
+				if err := s.articleDAO.Delete(tx, &article); err != nil {
+					return fmt.Errorf("delete article %d: %w", article.ID, err)
+				}
+			}
+		}
+
+		if err := s.categoryDAO.DeleteByUserID(tx, userID); err != nil {
+			return fmt.Errorf("delete categories: %w", err)
+		}
+		if err := s.commentDAO.AnonymizeByUserID(tx, userID); err != nil {
+			return fmt.Errorf("anonymize comments: %w", err)
+		}
+		if err := s.userLikeDAO.DeleteByUserID(tx, userID); err != nil {
+			return fmt.Errorf("delete likes: %w", err)
+		}
+		if err := s.userViewDAO.DeleteByUserID(tx, userID); err != nil {
+			return fmt.Errorf("delete views: %w", err)
+		}
+		if err := s.assetDAO.DeleteByUserID(tx, userID); err != nil {
+			return fmt.Errorf("delete image assets: %w", err)
+		}
+		// 转让文章的附件已在事务中转给受让用户，此处只删除其余附件
+		if err := s.attachmentDAO.DeleteByUserID(tx, userID); err != nil {
+			return fmt.Errorf("delete attachments: %w", err)
+		}
+		if _, err := s.sessionDAO.RevokeByUserID(tx, userID, model.SessionRevokeReasonAccountDeleted); err != nil {
+			return fmt.Errorf("revoke sessions: %w", err)
+		}
+		if err := s.patDAO.RevokeByUserID(tx, userID); err != nil {
+			return fmt.Errorf("revoke personal access tokens: %w", err)
+		}
+		// provider为空时删除用户的全部OIDC身份
+		if err := s.oidcIdentityDAO.DeleteByUserID(tx, userID, ""); err != nil {
+			return fmt.Errorf("delete oidc identities: %w", err)
+		}
+		if err := s.userDAO.ReplaceRoles(tx, user, []model.Role{}); err != nil {
+			return fmt.Errorf("clear roles: %w", err)
+		}
+
+		// 保留用户行以维持外键，抹去全部个人信息后软删除
+		if err := s.userDAO.Update(tx, user, map[string]interface{}{
+			"name":           fmt.Sprintf("deleted-user-%d", userID),
+			"email":          fmt.Sprintf("deleted-user-%d@deleted.invalid", userID),
+			"avatar":         "",
+			"github_bind_id": gorm.Expr("NULL"),
+			"qq_bind_id":     gorm.Expr("NULL"),
+			"google_bind_id": gorm.Expr("NULL"),
+			"llm_quota":      0,
+			"storage_usage":  0,
+		}); err != nil {
+			return fmt.Errorf("anonymize user: %w", err)
+		}
+		if err := s.userDAO.Delete(tx, user); err != nil {
+			return fmt.Errorf("delete user: %w", err)
+		}
+
+		return recordAuditEvent(ctx, tx, &model.AuditEvent{
+			ActorID:    userID,
+			Action:     model.AuditActionUserDeletionComplete,
+			TargetType: model.AuditTargetTypeUser,
+			TargetID:   userID,
+			After: map[string]any{
+				"deletionID":         deletion.ID,
+				"articleDisposition": deletion.ArticleDisposition,
+				"transferToUserID":   deletion.TransferToUserID,
+				"articles":           articleCount,
+			},
+		})
+	})
+	return articleCount, err
+}
+
+// transferArticles 将文章及其附件转让给受让用户并归入其根类别，slug冲突时追加文章ID，改名图片的链接同步替换
+func (s *accountDeletionService) transferArticles(tx *gorm.DB, userID, targetID uint, articles []model.Article, renames map[string]string) error {
+	if err := s.checkTransferTarget(tx, userID, targetID); err != nil {
+		return err
+	}
+
+	rootCategory, err := s.categoryDAO.GetRootByUserID(tx, targetID, []string{"id"}, []string{})
+	if err != nil {
+		return fmt.Errorf("get root category of user %d: %w", targetID, err)
+	}
+
+	for _, article := range articles {
+		slug := article.Slug
+		if _, err := s.articleDAO.GetBySlugAndUserID(tx, slug, targetID, []string{"id"}, []string{}); err == nil {
+			slug = fmt.Sprintf("%s-%d", slug, article.ID)
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("check slug of article %d: %w", article.ID, err)
+		}
+
+		if err := s.articleDAO.Update(tx, &article, map[string]interface{}{
+			"user_id":     targetID,
+			"category_id": rootCategory.ID,
+			"slug":        slug,
+		}); err != nil {
+			return fmt.Errorf("transfer article %d: %w", article.ID, err)
+		}
+		if err := s.attachmentDAO.TransferByArticleID(tx, article.ID, targetID); err != nil {
+			return fmt.Errorf("transfer attachments of article %d: %w", article.ID, err)
+		}
+		if err := s.rewriteTransferredImageLinks(tx, article.ID, renames); err != nil {
+			return fmt.Errorf("rewrite image links of article %d: %w", article.ID, err)
+		}
+	}
+	return nil
+}
+
+// checkTransferTarget 受让用户必须是其他未被封禁、未申请注销且角色包含撰写文章权限的用户
+func (s *accountDeletionService) checkTransferTarget(db *gorm.DB, userID, targetID uint) error {
+	if targetID == 0 || targetID == userID {
+		return errInvalidTransferTarget
+	}
+
+	target, err := s.userDAO.GetByID(db, targetID, []string{"id", "status", "suspended_until"}, []string{"Roles"})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errInvalidTransferTarget
+		}
+		return err
+	}
+	if !model.HasScope(target.GetScopes(), model.ScopeArticleWrite) || target.IsBlocked(time.Now().UTC()) {
+		return errInvalidTransferTarget
+	}
+
+	pending, err := s.accountDeletionDAO.GetPendingByUserID(db, targetID, []string{"id"})
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if pending != nil && pending.ID != 0 {
+		return errInvalidTransferTarget
+	}
+	return nil
+}
+
+func (s *accountDeletionService) failAccountDeletion(ctx context.Context, deletion *model.AccountDeletion, cause error) {
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	retries := deletion.Retries + 1
+	logger.Error("[AccountDeletionService] failed to delete account",
+		zap.Uint("deletionID", deletion.ID),
+		zap.Uint("retries", retries),
+		zap.Error(cause))
+
+	if err := s.accountDeletionDAO.Update(db, deletion, map[string]interface{}{
+		"retries":      retries,
+		"last_error":   cause.Error(),
+		"scheduled_at": time.Now().UTC().Add(accountDeletionRetryBaseDelay << min(retries, 8)),
+	}); err != nil {
+		logger.Error("[AccountDeletionService] failed to update deletion", zap.Uint("deletionID", deletion.ID), zap.Error(err))
+	}
+}
+
+func buildAccountDeletionDTO(deletion *model.AccountDeletion) *dto.AccountDeletion {
+	return &dto.AccountDeletion{
+		DeletionID:         deletion.ID,
+		Status:             string(deletion.Status),
+		ArticleDisposition: string(deletion.ArticleDisposition),
+		TransferToUserID:   deletion.TransferToUserID,
+		Reason:             deletion.Reason,
+		CreatedAt:          deletion.CreatedAt.Format(time.DateTime),
+		ScheduledAt:        deletion.ScheduledAt.Format(time.DateTime),
+	}
+}
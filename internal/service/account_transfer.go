@@ -0,0 +1,290 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hcd233/aris-blog-api/internal/job"
+	"github.com/hcd233/aris-blog-api/internal/logger"
+	"github.com/hcd233/aris-blog-api/internal/resource/database"
+	"github.com/hcd233/aris-blog-api/internal/resource/database/model"
+	objdao "github.com/hcd233/aris-blog-api/internal/resource/storage/obj_dao"
+	"github.com/hcd233/aris-blog-api/internal/util"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// copyTransferredObjects 在清除注销用户的对象存储前，将转让文章引用的图片与附件复制到受让用户目录
+//
+//	复制计入受让用户的存储用量并校验配额，预估超出剩余配额时不复制任何对象；对象已存在时跳过，失败后重试可从中断处继续；
+//	受让用户已有同名但内容不同的图片时改名复制，返回原名到新名的映射
+//	receiver s *accountDeletionService
+//	param ctx context.Context
+//	param deletion *model.AccountDeletion
+//	return renames map[string]string
+//	return err error
+//	author centonhuang
+//	update 2025-11-20 16:32:14
+func (s *accountDeletionService) copyTransferredObjects(ctx context.Context, deletion *model.AccountDeletion) (renames map[string]string, err error) {
+	db := database.GetDBInstance(ctx)
+	userID, targetID := deletion.UserID, deletion.TransferToUserID
+
+	if err := s.checkTransferTarget(db, userID, targetID); err != nil {
+		return nil, err
+	}
+
+	contents, err := s.articleVersionDAO.ListContentsByUserID(db, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list article contents: %w", err)
+	}
+	references := newImageReferences(contents)
+
+	assets, err := s.assetDAO.ListByUserID(db, userID, assetFields)
+	if err != nil {
+		return nil, fmt.Errorf("list image assets: %w", err)
+	}
+
+	referencedAssets := lo.Filter(*assets, func(asset model.Asset, _ int) bool {
+		return references.contains(asset.ObjectName) || references.contains(asset.StorageName)
+	})
+
+	articles, err := s.articleDAO.ListByUserID(db, userID, []string{"id"}, []string{})
+	if err != nil {
+		return nil, fmt.Errorf("list articles: %w", err)
+	}
+	articleIDs := make(map[uint]struct{}, len(*articles))
+	for _, article := range *articles {
+		articleIDs[article.ID] = struct{}{}
+	}
+
+	attachments, err := s.attachmentDAO.ListByUserID(db, userID, attachmentFields)
+	if err != nil {
+		return nil, fmt.Errorf("list attachments: %w", err)
+	}
+	transferredAttachments := lo.Filter(*attachments, func(attachment model.Attachment, _ int) bool {
+		_, ok := articleIDs[attachment.ArticleID]
+		return ok
+	})
+
+	if err := s.checkTransferQuota(ctx, targetID, referencedAssets, transferredAttachments); err != nil {
+		return nil, err
+	}
+
+	renames = make(map[string]string)
+	for _, asset := range referencedAssets {
+		objectName, err := s.transferImage(ctx, &asset, targetID)
+		if err != nil {
+			return nil, fmt.Errorf("transfer image %s: %w", asset.ObjectName, err)
+		}
+		if objectName != asset.ObjectName {
+			renames[asset.ObjectName] = objectName
+		}
+	}
+
+	for _, attachment := range transferredAttachments {
+		if err := s.transferAttachmentObjects(ctx, &attachment, targetID); err != nil {
+			return nil, fmt.Errorf("transfer attachment %d: %w", attachment.ID, err)
+		}
+	}
+	return renames, nil
+}
+
+// checkTransferQuota 预估受让用户尚未持有的图片与附件大小，超出其剩余存储配额时拒绝转让；缩略图大小未知，在复制时逐个校验
+func (s *accountDeletionService) checkTransferQuota(ctx context.Context, targetID uint, assets []model.Asset, attachments []model.Attachment) error {
+	db := database.GetDBInstance(ctx)
+
+	var required int64
+	for _, asset := range assets {
+		if _, err := s.assetDAO.GetByContentHash(db, targetID, asset.ContentHash, []string{"id"}); err == nil {
+			continue
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		required += asset.Size
+	}
+	for _, attachment := range attachments {
+		exists, err := s.attachmentObjDAO.CheckObjectExists(ctx, targetID, attachment.StorageName)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			required += attachment.Size + attachment.PreviewSize
+		}
+	}
+
+	target, err := s.userDAO.GetByID(db, targetID, []string{"id", "permission", "storage_usage"}, []string{})
+	if err != nil {
+		return err
+	}
+	if quota := model.PermissionStorageQuotaMapping[target.Permission]; target.StorageUsage+required > quota {
+		return fmt.Errorf("transfer needs %d bytes, recipient uses %d of %d: %w", required, target.StorageUsage, quota, errStorageQuotaExceeded)
+	}
+	return nil
+}
+
+// transferImage 为受让用户建立图片资产，内容相同的对象复用受让用户已有的存储
+func (s *accountDeletionService) transferImage(ctx context.Context, asset *model.Asset, targetID uint) (objectName string, err error) {
+	logger := logger.WithCtx(ctx).With(zap.String("objectName", asset.ObjectName))
+	db := database.GetDBInstance(ctx)
+
+	objectName = asset.ObjectName
+	existing, err := s.assetDAO.GetByObjectName(db, targetID, objectName, []string{"id", "content_hash"})
+	switch {
+	case err == nil && existing.ContentHash == asset.ContentHash:
+		return objectName, nil
+	case err == nil:
+		ext := filepath.Ext(objectName)
+		objectName = fmt.Sprintf("%s-%s%s", strings.TrimSuffix(objectName, ext), asset.ContentHash[:8], ext)
+		existing, err = s.assetDAO.GetByObjectName(db, targetID, objectName, []string{"id", "content_hash"})
+		if err == nil {
+			if existing.ContentHash == asset.ContentHash {
+				return objectName, nil
+			}
+			return "", fmt.Errorf("image name %s already taken by recipient", objectName)
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", err
+		}
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return "", err
+	}
+
+	storageName := asset.StorageName
+	duplicate, err := s.assetDAO.GetByContentHash(db, targetID, asset.ContentHash, []string{"id", "storage_name"})
+	switch {
+	case err == nil:
+		storageName = duplicate.StorageName
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		if err := s.copyToRecipient(ctx, s.imageObjDAO, asset.UserID, targetID, storageName); err != nil {
+			return "", fmt.Errorf("copy image: %w", err)
+		}
+		err := s.copyToRecipient(ctx, s.thumbnailObjDAO, asset.UserID, targetID, storageName)
+		if errors.Is(err, objdao.ErrObjectNotFound) {
+			if _, err := job.Enqueue(ctx, job.TypeImageThumbnail, &job.ImageThumbnailPayload{UserID: targetID, ObjectName: storageName}); err != nil {
+				logger.Error("[AccountDeletionService] failed to enqueue thumbnail job", zap.Error(err))
+			}
+		} else if err != nil {
+			return "", fmt.Errorf("copy thumbnail: %w", err)
+		}
+	default:
+		return "", err
+	}
+
+	if err := s.assetDAO.Create(db, &model.Asset{
+		UserID:        targetID,
+		ObjectName:    objectName,
+		StorageName:   storageName,
+		ContentHash:   asset.ContentHash,
+		ContentType:   asset.ContentType,
+		Size:          asset.Size,
+		Width:         asset.Width,
+		Height:        asset.Height,
+		BlurHash:      asset.BlurHash,
+		DominantColor: asset.DominantColor,
+	}); err != nil {
+		return "", fmt.Errorf("create image asset: %w", err)
+	}
+	return objectName, nil
+}
+
+// transferAttachmentObjects 复制附件及其预览对象，附件记录在注销事务中转给受让用户
+func (s *accountDeletionService) transferAttachmentObjects(ctx context.Context, attachment *model.Attachment, targetID uint) error {
+	if err := s.copyToRecipient(ctx, s.attachmentObjDAO, attachment.UserID, targetID, attachment.StorageName); err != nil {
+		return fmt.Errorf("copy attachment: %w", err)
+	}
+	if attachment.PreviewName != "" {
+		err := s.copyToRecipient(ctx, s.attachmentPreviewObjDAO, attachment.UserID, targetID, attachment.PreviewName)
+		if err != nil && !errors.Is(err, objdao.ErrObjectNotFound) {
+			return fmt.Errorf("copy attachment preview: %w", err)
+		}
+	}
+	return nil
+}
+
+// rewriteTransferredImageLinks 将转让文章各版本中改名图片的链接替换为受让用户目录下的新名称
+func (s *accountDeletionService) rewriteTransferredImageLinks(tx *gorm.DB, articleID uint, renames map[string]string) error {
+	if len(renames) == 0 {
+		return nil
+	}
+
+	links := make(map[string]string, len(renames)*2)
+	for oldName, newName := range renames {
+		links[composeImageLink(oldName)] = composeImageLink(newName)
+		links[imageLinkPrefix+oldName] = composeImageLink(newName)
+	}
+
+	versions, err := s.articleVersionDAO.ListByArticleID(tx, articleID, []string{"id", "content"})
+	if err != nil {
+		return err
+	}
+	for _, version := range *versions {
+		rewritten := util.RewriteImageLinks(version.Content, func(link string) string {
+			if newLink, ok := links[link]; ok {
+				return newLink
+			}
+			return link
+		})
+		if rewritten == version.Content {
+			continue
+		}
+		if err := s.articleVersionDAO.Update(tx, &version, map[string]interface{}{"content": rewritten}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyToRecipient 经临时文件将对象复制到受让用户目录，目标已存在时跳过；复制前按对象大小预占受让用户的存储配额，复制失败时退还
+func (s *accountDeletionService) copyToRecipient(ctx context.Context, objDAO objdao.ObjDAO, srcUserID, targetID uint, objectName string) (err error) {
+	exists, err := objDAO.CheckObjectExists(ctx, targetID, objectName)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+
+	objectInfo, err := objDAO.StatObject(ctx, srcUserID, objectName)
+	if err != nil {
+		return err
+	}
+
+	if err = reserveUserStorage(ctx, s.userDAO, targetID, objectInfo.Size, true); err != nil {
+		return fmt.Errorf("reserve recipient storage: %w", err)
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		if releaseErr := releaseUserStorage(ctx, s.userDAO, targetID, objectInfo.Size); releaseErr != nil {
+			logger.WithCtx(ctx).Error("[AccountDeletionService] failed to release recipient storage",
+				zap.Uint("targetID", targetID), zap.Int64("size", objectInfo.Size), zap.Error(releaseErr))
+		}
+	}()
+
+	file, err := os.CreateTemp("", "account-transfer-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		file.Close()
+		os.Remove(file.Name())
+	}()
+
+	if _, err = objDAO.DownloadObject(ctx, srcUserID, objectName, file); err != nil {
+		return fmt.Errorf("download source: %w", err)
+	}
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	if err = objDAO.UploadObject(ctx, targetID, objectName, objectInfo.Size, file); err != nil {
+		return fmt.Errorf("upload destination: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,658 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hcd233/aris-blog-api/internal/constant"
+	"github.com/hcd233/aris-blog-api/internal/logger"
+	"github.com/hcd233/aris-blog-api/internal/protocol"
+	dto "github.com/hcd233/aris-blog-api/internal/protocol/dto"
+	"github.com/hcd233/aris-blog-api/internal/resource/database"
+	"github.com/hcd233/aris-blog-api/internal/resource/database/dao"
+	"github.com/hcd233/aris-blog-api/internal/resource/database/model"
+	objdao "github.com/hcd233/aris-blog-api/internal/resource/storage/obj_dao"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var adminUserFields = []string{
+	"id", "name", "email", "avatar", "permission", "llm_quota", "storage_usage",
+	"status", "status_reason", "suspended_until", "created_at", "last_login",
+}
+
+// AdminService 管理员服务
+//
+//	author centonhuang
+//	update 2025-11-10 15:32:08
+type AdminService interface {
+	ListUsers(ctx context.Context, req *dto.AdminListUsersRequest) (rsp *dto.AdminListUsersResponse, err error)
+	GetUser(ctx context.Context, req *dto.AdminGetUserRequest) (rsp *dto.AdminGetUserResponse, err error)
+	UpdateUserPermission(ctx context.Context, req *dto.AdminUpdateUserPermissionRequest) (rsp *dto.EmptyResponse, err error)
+	UpdateUserQuota(ctx context.Context, req *dto.AdminUpdateUserQuotaRequest) (rsp *dto.EmptyResponse, err error)
+	UpdateUserStatus(ctx context.Context, req *dto.AdminUpdateUserStatusRequest) (rsp *dto.EmptyResponse, err error)
+	ListUserArticles(ctx context.Context, req *dto.AdminListUserArticlesRequest) (rsp *dto.AdminListUserArticlesResponse, err error)
+	ListUserComments(ctx context.Context, req *dto.AdminListUserCommentsRequest) (rsp *dto.AdminListUserCommentsResponse, err error)
+	UpdateUserRoles(ctx context.Context, req *dto.AdminUpdateUserRolesRequest) (rsp *dto.EmptyResponse, err error)
+}
+
+type adminService struct {
+	userDAO           *dao.UserDAO
+	articleDAO        *dao.ArticleDAO
+	articleVersionDAO *dao.ArticleVersionDAO
+	commentDAO        *dao.CommentDAO
+	roleDAO           *dao.RoleDAO
+	imageObjDAO       objdao.ObjDAO
+	thumbnailObjDAO   objdao.ObjDAO
+}
+
+// NewAdminService 创建管理员服务
+//
+//	return AdminService
+//	author centonhuang
+//	update 2025-11-10 15:32:08
+func NewAdminService() AdminService {
+	return &adminService{
+		userDAO:           dao.GetUserDAO(),
+		articleDAO:        dao.GetArticleDAO(),
+		articleVersionDAO: dao.GetArticleVersionDAO(),
+		commentDAO:        dao.GetCommentDAO(),
+		roleDAO:           dao.GetRoleDAO(),
+		imageObjDAO:       objdao.GetImageObjDAO(),
+		thumbnailObjDAO:   objdao.GetThumbnailObjDAO(),
+	}
+}
+
+// ListUsers 列出用户
+//
+//	receiver s *adminService
+//	param ctx context.Context
+//	param req *dto.AdminListUsersRequest
+//	return rsp *dto.AdminListUsersResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-10 15:32:08
+func (s *adminService) ListUsers(ctx context.Context, req *dto.AdminListUsersRequest) (rsp *dto.AdminListUsersResponse, err error) {
+	rsp = &dto.AdminListUsersResponse{}
+
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	param := &dao.CommonParam{
+		PageParam: &dao.PageParam{
+			Page:     req.Page,
+			PageSize: req.PageSize,
+		},
+		QueryParam: &dao.QueryParam{
+			Query:       req.Query,
+			QueryFields: []string{"name", "email"},
+		},
+	}
+
+	users, pageInfo, err := s.userDAO.PaginateByFilter(db, model.Permission(req.Permission), model.UserStatus(req.Status), adminUserFields, []string{"Roles"}, param)
+	if err != nil {
+		logger.Error("[AdminService] failed to list users", zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	rsp.Users = lo.Map(*users, func(user model.User, _ int) *dto.AdminUser {
+		return buildAdminUserDTO(&user)
+	})
+	rsp.PageInfo = &dto.PageInfo{
+		Page:     pageInfo.Page,
+		PageSize: pageInfo.PageSize,
+		Total:    pageInfo.Total,
+	}
+
+	return rsp, nil
+}
+
+// GetUser 获取用户详情及内容、存储用量
+//
+//	receiver s *adminService
+//	param ctx context.Context
+//	param req *dto.AdminGetUserRequest
+//	return rsp *dto.AdminGetUserResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-17 10:26:42
+func (s *adminService) GetUser(ctx context.Context, req *dto.AdminGetUserRequest) (rsp *dto.AdminGetUserResponse, err error) {
+	rsp = &dto.AdminGetUserResponse{}
+
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	user, err := s.getUser(ctx, req.UserID, adminUserFields, []string{"Roles"})
+	if err != nil {
+		return nil, err
+	}
+
+	articles, err := s.articleDAO.CountByUserID(db, user.ID)
+	if err != nil {
+		logger.Error("[AdminService] failed to count articles", zap.Uint("userID", user.ID), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	comments, err := s.commentDAO.CountByUserID(db, user.ID)
+	if err != nil {
+		logger.Error("[AdminService] failed to count comments", zap.Uint("userID", user.ID), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	images, err := s.imageObjDAO.ListObjects(ctx, user.ID)
+	if err != nil {
+		logger.Error("[AdminService] failed to list images", zap.Uint("userID", user.ID), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	thumbnails, err := s.thumbnailObjDAO.ListObjects(ctx, user.ID)
+	if err != nil {
+		logger.Error("[AdminService] failed to list thumbnails", zap.Uint("userID", user.ID), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	sumSize := func(total int64, objectInfo objdao.ObjectInfo, _ int) int64 {
+		return total + objectInfo.Size
+	}
+
+	rsp.User = buildAdminUserDTO(user)
+	rsp.Articles = articles
+	rsp.Comments = comments
+	rsp.Storage = &dto.StorageUsage{
+		Images:         len(images),
+		ImageBytes:     lo.Reduce(images, sumSize, 0),
+		Thumbnails:     len(thumbnails),
+		ThumbnailBytes: lo.Reduce(thumbnails, sumSize, 0),
+		TrackedBytes:   user.StorageUsage,
+		QuotaBytes:     model.PermissionStorageQuotaMapping[user.Permission],
+	}
+
+	return rsp, nil
+}
+
+// UpdateUserPermission 修改用户权限
+//
+//	receiver s *adminService
+//	param ctx context.Context
+//	param req *dto.AdminUpdateUserPermissionRequest
+//	return rsp *dto.EmptyResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-19 14:26:50
+func (s *adminService) UpdateUserPermission(ctx context.Context, req *dto.AdminUpdateUserPermissionRequest) (rsp *dto.EmptyResponse, err error) {
+	rsp = &dto.EmptyResponse{}
+
+	logger := logger.WithCtx(ctx)
+
+	if req.Body == nil {
+		logger.Error("[AdminService] request body is nil")
+		return nil, protocol.ErrBadRequest
+	}
+
+	user, err := s.getTargetUser(ctx, req.UserID, []string{"id", "permission"}, []string{})
+	if err != nil {
+		return nil, err
+	}
+
+	permission := model.Permission(req.Body.Permission)
+	if user.Permission == permission {
+		return rsp, nil
+	}
+
+	// 修改权限等级会同步替换内置角色，需同时持有新旧内置角色的全部权限项，否则视为角色管理
+	if err = s.checkBuiltinRoleChange(ctx, user.Permission, permission); err != nil {
+		return nil, err
+	}
+
+	err = s.updateWithAudit(ctx, user, map[string]interface{}{"permission": permission},
+		model.AuditActionUserPermissionUpdate,
+		map[string]any{"permission": user.Permission},
+		map[string]any{"permission": permission})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("[AdminService] user permission updated",
+		zap.Uint("userID", user.ID),
+		zap.String("before", string(user.Permission)),
+		zap.String("after", string(permission)))
+
+	return rsp, nil
+}
+
+// UpdateUserQuota 调整用户LLM配额
+//
+//	receiver s *adminService
+//	param ctx context.Context
+//	param req *dto.AdminUpdateUserQuotaRequest
+//	return rsp *dto.EmptyResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-20 16:05:39
+func (s *adminService) UpdateUserQuota(ctx context.Context, req *dto.AdminUpdateUserQuotaRequest) (rsp *dto.EmptyResponse, err error) {
+	rsp = &dto.EmptyResponse{}
+
+	logger := logger.WithCtx(ctx)
+
+	if req.Body == nil {
+		logger.Error("[AdminService] request body is nil")
+		return nil, protocol.ErrBadRequest
+	}
+
+	user, err := s.getTargetUser(ctx, req.UserID, []string{"id", "llm_quota"}, []string{})
+	if err != nil {
+		return nil, err
+	}
+
+	quota := model.Quota(req.Body.LLMQuota)
+	err = s.updateWithAudit(ctx, user, map[string]interface{}{"llm_quota": quota},
+		model.AuditActionUserQuotaUpdate,
+		map[string]any{"llmQuota": user.LLMQuota},
+		map[string]any{"llmQuota": quota})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("[AdminService] user llm quota updated",
+		zap.Uint("userID", user.ID),
+		zap.Int8("before", int8(user.LLMQuota)),
+		zap.Int8("after", int8(quota)))
+
+	return rsp, nil
+}
+
+// UpdateUserStatus 暂停、封禁或恢复用户账号
+//
+//	receiver s *adminService
+//	param ctx context.Context
+//	param req *dto.AdminUpdateUserStatusRequest
+//	return rsp *dto.EmptyResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-20 16:05:39
+func (s *adminService) UpdateUserStatus(ctx context.Context, req *dto.AdminUpdateUserStatusRequest) (rsp *dto.EmptyResponse, err error) {
+	rsp = &dto.EmptyResponse{}
+
+	logger := logger.WithCtx(ctx)
+
+	if req.Body == nil {
+		logger.Error("[AdminService] request body is nil")
+		return nil, protocol.ErrBadRequest
+	}
+
+	status := model.UserStatus(req.Body.Status)
+	if status == model.UserStatusSuspended && req.Body.SuspendHours <= 0 {
+		logger.Error("[AdminService] suspend hours is required when suspending", zap.Uint("userID", req.UserID))
+		return nil, protocol.ErrBadRequest
+	}
+
+	user, err := s.getTargetUser(ctx, req.UserID, []string{"id", "status", "status_reason", "suspended_until"}, []string{})
+	if err != nil {
+		return nil, err
+	}
+
+	info := map[string]interface{}{
+		"status":          status,
+		"status_reason":   req.Body.Reason,
+		"suspended_until": nil,
+	}
+	after := map[string]any{"status": status, "reason": req.Body.Reason}
+	if status == model.UserStatusSuspended {
+		suspendedUntil := time.Now().UTC().Add(time.Duration(req.Body.SuspendHours) * time.Hour)
+		info["suspended_until"] = suspendedUntil
+		after["suspendedUntil"] = suspendedUntil.Format(time.DateTime)
+	}
+
+	before := map[string]any{"status": user.Status, "reason": user.StatusReason}
+	if user.Status == model.UserStatusSuspended {
+		before["suspendedUntil"] = user.SuspendedUntil.Format(time.DateTime)
+	}
+
+	err = s.updateWithAudit(ctx, user, info, model.AuditActionUserStatusUpdate, before, after)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("[AdminService] user status updated",
+		zap.Uint("userID", user.ID),
+		zap.String("before", string(user.Status)),
+		zap.String("after", string(status)),
+		zap.String("reason", req.Body.Reason))
+
+	return rsp, nil
+}
+
+// ListUserArticles 列出用户的全部文章
+//
+//	receiver s *adminService
+//	param ctx context.Context
+//	param req *dto.AdminListUserArticlesRequest
+//	return rsp *dto.AdminListUserArticlesResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-10 15:32:08
+func (s *adminService) ListUserArticles(ctx context.Context, req *dto.AdminListUserArticlesRequest) (rsp *dto.AdminListUserArticlesResponse, err error) {
+	rsp = &dto.AdminListUserArticlesResponse{}
+
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	user, err := s.getUser(ctx, req.UserID, []string{"id", "name", "avatar"}, []string{})
+	if err != nil {
+		return nil, err
+	}
+
+	param := &dao.CommonParam{
+		PageParam: &dao.PageParam{
+			Page:     req.Page,
+			PageSize: req.PageSize,
+		},
+		QueryParam: &dao.QueryParam{
+			Query:       req.Query,
+			QueryFields: []string{"title", "slug"},
+		},
+	}
+
+	articles, pageInfo, err := s.articleDAO.PaginateByUserID(db, user.ID,
+		[]string{
+			"id", "slug", "title", "status", "user_id",
+			"created_at", "updated_at", "published_at",
+			"likes", "views",
+		},
+		[]string{"Tags", "Comments"},
+		param,
+	)
+	if err != nil {
+		logger.Error("[AdminService] failed to list user articles", zap.Uint("userID", user.ID), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	articleIDs := lo.Map(*articles, func(article model.Article, _ int) uint {
+		return article.ID
+	})
+	languages, err := s.articleVersionDAO.ListLanguagesByArticleIDs(db, articleIDs)
+	if err != nil {
+		logger.Error("[AdminService] failed to list article languages", zap.Uints("articleIDs", articleIDs), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	rsp.Articles = lo.Map(*articles, func(article model.Article, _ int) *dto.Article {
+		return &dto.Article{
+			ArticleID: article.ID,
+			Title:     article.Title,
+			Slug:      article.Slug,
+			Status:    string(article.Status),
+			User: &dto.User{
+				UserID: user.ID,
+				Name:   user.Name,
+				Avatar: user.Avatar,
+			},
+			CreatedAt:   article.CreatedAt.Format(time.DateTime),
+			UpdatedAt:   article.UpdatedAt.Format(time.DateTime),
+			PublishedAt: article.PublishedAt.Format(time.DateTime),
+			Likes:       article.Likes,
+			Views:       article.Views,
+			Tags: lo.Map(article.Tags, func(tag model.Tag, _ int) *dto.Tag {
+				return &dto.Tag{
+					TagID: tag.ID,
+					Name:  tag.Name,
+					Slug:  tag.Slug,
+				}
+			}),
+			Comments: len(article.Comments),
+			Languages: lo.Map(languages[article.ID], func(language model.Language, _ int) string {
+				return string(language)
+			}),
+		}
+	})
+	rsp.PageInfo = &dto.PageInfo{
+		Page:     pageInfo.Page,
+		PageSize: pageInfo.PageSize,
+		Total:    pageInfo.Total,
+	}
+
+	return rsp, nil
+}
+
+// ListUserComments 列出用户发表的评论
+//
+//	receiver s *adminService
+//	param ctx context.Context
+//	param req *dto.AdminListUserCommentsRequest
+//	return rsp *dto.AdminListUserCommentsResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-10 15:32:08
+func (s *adminService) ListUserComments(ctx context.Context, req *dto.AdminListUserCommentsRequest) (rsp *dto.AdminListUserCommentsResponse, err error) {
+	rsp = &dto.AdminListUserCommentsResponse{}
+
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	user, err := s.getUser(ctx, req.UserID, []string{"id"}, []string{})
+	if err != nil {
+		return nil, err
+	}
+
+	param := &dao.CommonParam{
+		PageParam: &dao.PageParam{
+			Page:     req.Page,
+			PageSize: req.PageSize,
+		},
+		QueryParam: &dao.QueryParam{
+			Query: req.Query,
+		},
+	}
+
+	comments, pageInfo, err := s.commentDAO.PaginateByUserID(db, user.ID,
+		[]string{"id", "content", "user_id", "parent_id", "created_at", "likes"},
+		[]string{}, param)
+	if err != nil {
+		logger.Error("[AdminService] failed to list user comments", zap.Uint("userID", user.ID), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	rsp.Comments = lo.Map(*comments, func(comment model.Comment, _ int) *dto.Comment {
+		return &dto.Comment{
+			CommentID: comment.ID,
+			Content:   comment.Content,
+			UserID:    comment.UserID,
+			ReplyTo:   comment.ParentID,
+			CreatedAt: comment.CreatedAt.Format(time.DateTime),
+			Likes:     comment.Likes,
+		}
+	})
+	rsp.PageInfo = &dto.PageInfo{
+		Page:     pageInfo.Page,
+		PageSize: pageInfo.PageSize,
+		Total:    pageInfo.Total,
+	}
+
+	return rsp, nil
+}
+
+// UpdateUserRoles 设置用户持有的角色
+//
+//	receiver s *adminService
+//	param ctx context.Context
+//	param req *dto.AdminUpdateUserRolesRequest
+//	return rsp *dto.EmptyResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-11 17:05:24
+func (s *adminService) UpdateUserRoles(ctx context.Context, req *dto.AdminUpdateUserRolesRequest) (rsp *dto.EmptyResponse, err error) {
+	rsp = &dto.EmptyResponse{}
+
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	if req.Body == nil {
+		logger.Error("[AdminService] request body is nil")
+		return nil, protocol.ErrBadRequest
+	}
+
+	user, err := s.getTargetUser(ctx, req.UserID, []string{"id"}, []string{"Roles"})
+	if err != nil {
+		return nil, err
+	}
+
+	names := lo.Uniq(req.Body.Roles)
+	roles, err := s.roleDAO.BatchGetByNames(db, names, []string{"id", "name"})
+	if err != nil {
+		logger.Error("[AdminService] failed to get roles", zap.Strings("roles", names), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+	if len(*roles) != len(names) {
+		logger.Error("[AdminService] role not found", zap.Strings("roles", names))
+		return nil, protocol.ErrDataNotExists
+	}
+
+	before := lo.Map(user.Roles, func(role model.Role, _ int) string { return role.Name })
+	after := lo.Map(*roles, func(role model.Role, _ int) string { return role.Name })
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := s.userDAO.ReplaceRoles(tx, user, *roles); err != nil {
+			return err
+		}
+		return recordAuditEvent(ctx, tx, &model.AuditEvent{
+			Action:     model.AuditActionUserRolesUpdate,
+			TargetType: model.AuditTargetTypeUser,
+			TargetID:   user.ID,
+			Before:     map[string]any{"roles": before},
+			After:      map[string]any{"roles": after},
+		})
+	})
+	if err != nil {
+		logger.Error("[AdminService] failed to update user roles", zap.Uint("userID", user.ID), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	logger.Info("[AdminService] user roles updated",
+		zap.Uint("userID", user.ID),
+		zap.Strings("before", before),
+		zap.Strings("after", after))
+
+	return rsp, nil
+}
+
+func (s *adminService) getUser(ctx context.Context, userID uint, fields, preloads []string) (user *model.User, err error) {
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	user, err = s.userDAO.GetByID(db, userID, fields, preloads)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Error("[AdminService] user not found", zap.Uint("userID", userID))
+			return nil, protocol.ErrDataNotExists
+		}
+		logger.Error("[AdminService] failed to get user", zap.Uint("userID", userID), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+	return user, nil
+}
+
+// getTargetUser 获取被操作的用户，管理员不能修改自己的权限、配额与状态，避免误操作导致失去管理权限；
+// 也不能修改持有自己所没有的权限项的用户，防止借暂停、封禁或降级压制更高权限的管理员
+func (s *adminService) getTargetUser(ctx context.Context, userID uint, fields, preloads []string) (user *model.User, err error) {
+	logger := logger.WithCtx(ctx)
+
+	if userID == ctx.Value(constant.CtxKeyUserID).(uint) {
+		logger.Error("[AdminService] admin cannot modify themselves", zap.Uint("userID", userID))
+		return nil, protocol.ErrNoPermission
+	}
+
+	// 单独加载角色，避免随后的更新带上预加载的关联
+	target, err := s.getUser(ctx, userID, []string{"id"}, []string{"Roles"})
+	if err != nil {
+		return nil, err
+	}
+
+	scopes, _ := ctx.Value(constant.CtxKeyScopes).([]model.Scope)
+	if missing := lo.Filter(target.GetScopes(), func(scope model.Scope, _ int) bool { return !model.HasScope(scopes, scope) }); len(missing) > 0 {
+		logger.Error("[AdminService] target user holds scopes the operator lacks",
+			zap.Uint("userID", userID),
+			zap.Any("missingScopes", missing))
+		return nil, protocol.ErrNoPermission
+	}
+	return s.getUser(ctx, userID, fields, preloads)
+}
+
+// checkBuiltinRoleChange 未持有角色管理权限的管理员只能授予或撤销自己已持有的权限项，防止借修改权限等级提权
+func (s *adminService) checkBuiltinRoleChange(ctx context.Context, permissions ...model.Permission) error {
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	scopes, _ := ctx.Value(constant.CtxKeyScopes).([]model.Scope)
+	if model.HasScope(scopes, model.ScopeRoleManage) {
+		return nil
+	}
+
+	names := lo.Map(permissions, func(permission model.Permission, _ int) string { return string(permission) })
+	roles, err := s.roleDAO.BatchGetByNames(db, names, []string{"id", "name", "scopes"})
+	if err != nil {
+		logger.Error("[AdminService] failed to get builtin roles", zap.Strings("roles", names), zap.Error(err))
+		return protocol.ErrInternalError
+	}
+
+	for _, role := range *roles {
+		if missing := lo.Filter(role.Scopes, func(scope model.Scope, _ int) bool { return !model.HasScope(scopes, scope) }); len(missing) > 0 {
+			logger.Error("[AdminService] builtin role exceeds the operator's scopes",
+				zap.String("role", role.Name),
+				zap.Any("missingScopes", missing))
+			return protocol.ErrNoPermission
+		}
+	}
+	return nil
+}
+
+// updateWithAudit 在同一事务中更新用户并写入审计事件
+func (s *adminService) updateWithAudit(ctx context.Context, user *model.User, info map[string]interface{}, action model.AuditAction, before, after map[string]any) error {
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := s.userDAO.Update(tx, user, info); err != nil {
+			return err
+		}
+		// 权限等级与内置角色保持一致
+		if permission, ok := info["permission"].(model.Permission); ok {
+			if err := syncBuiltinRole(tx, user, permission); err != nil {
+				return err
+			}
+		}
+		return recordAuditEvent(ctx, tx, &model.AuditEvent{
+			Action:     action,
+			TargetType: model.AuditTargetTypeUser,
+			TargetID:   user.ID,
+			Before:     before,
+			After:      after,
+		})
+	})
+	if err != nil {
+		logger.Error("[AdminService] failed to update user", zap.Uint("userID", user.ID), zap.String("action", string(action)), zap.Error(err))
+		return protocol.ErrInternalError
+	}
+	return nil
+}
+
+func buildAdminUserDTO(user *model.User) *dto.AdminUser {
+	adminUser := &dto.AdminUser{
+		UserID:       user.ID,
+		Name:         user.Name,
+		Email:        user.Email,
+		Avatar:       user.Avatar,
+		Permission:   string(user.Permission),
+		Roles:        lo.Map(user.Roles, func(role model.Role, _ int) string { return role.Name }),
+		LLMQuota:     int(user.LLMQuota),
+		Status:       string(user.Status),
+		StatusReason: user.StatusReason,
+		CreatedAt:    user.CreatedAt.Format(time.DateTime),
+		LastLogin:    user.LastLogin.Format(time.DateTime),
+	}
+	if user.Status == model.UserStatusSuspended {
+		adminUser.SuspendedUntil = user.SuspendedUntil.Format(time.DateTime)
+	}
+	return adminUser
+}
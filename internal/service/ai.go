@@ -40,7 +40,7 @@ type AIService interface {
 	CreatePrompt(ctx context.Context, req *dto.CreatePromptRequest) (rsp *dto.EmptyResponse, err error)
 	GenerateContentCompletion(ctx context.Context, req *dto.GenerateContentCompletionRequest) (tokenChan <-chan string, errChan <-chan error)
 	GenerateArticleSummary(ctx context.Context, req *dto.GenerateArticleSummaryRequest) (tokenChan <-chan string, errChan <-chan error)
-	GenerateArticleTranslation(ctx context.Context, req *dto.GenerateArticleQARequest) (tokenChan <-chan string, errChan <-chan error)
+	GenerateArticleTranslation(ctx context.Context, req *dto.GenerateArticleTranslationRequest) (tokenChan <-chan string, errChan <-chan error)
 	GenerateArticleQA(ctx context.Context, req *dto.GenerateArticleQARequest) (tokenChan <-chan string, errChan <-chan error)
 	GenerateTermExplaination(ctx context.Context, req *dto.GenerateTermExplainationRequest) (tokenChan <-chan string, errChan <-chan error)
 }
@@ -271,7 +271,22 @@ func (s *aiService) CreatePrompt(ctx context.Context, req *dto.CreatePromptReque
 		Version:   prompt.Version + 1,
 	}
 
-	if err = s.promptDAO.Create(db, prompt); err != nil {
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := s.promptDAO.Create(tx, prompt); err != nil {
+			return err
+		}
+		return recordAuditEvent(ctx, tx, &model.AuditEvent{
+			Action:     model.AuditActionPromptCreate,
+			TargetType: model.AuditTargetTypePrompt,
+			TargetID:   prompt.ID,
+			After: map[string]any{
+				"task":      prompt.Task,
+				"version":   prompt.Version,
+				"variables": prompt.Variables,
+			},
+		})
+	})
+	if err != nil {
 		logger.Error("[AIService] failed to create prompt", zap.String("taskName", req.TaskName), zap.Error(err))
 		return nil, protocol.ErrInternalError
 	}
@@ -461,7 +476,7 @@ func (s *aiService) GenerateArticleSummary(ctx context.Context, req *dto.Generat
 		return nil, errCh
 	}
 
-	latestVersion, err := s.articleVersionDAO.GetLatestByArticleID(db, article.ID, []string{"id", "content"}, []string{})
+	latestVersion, err := s.articleVersionDAO.GetLatestByArticleID(db, article.ID, model.LanguageDefault, []string{"id", "content"}, []string{})
 	if err != nil {
 		logger.Error("[AIService] failed to get article version", zap.Uint("articleID", article.ID), zap.Error(err))
 		errCh <- protocol.ErrInternalError
@@ -577,15 +592,23 @@ func (s *aiService) GenerateArticleSummary(ctx context.Context, req *dto.Generat
 	return tokenCh, errCh
 }
 
+// 翻译在脱离请求的上下文中生成，该时长限制后台生成与保存的总耗时
+const articleTranslationTimeout = 10 * time.Minute
+
+var (
+	errTranslationEmpty             = errors.New("translation is empty, quota refunded")
+	errTranslationStructureMismatch = errors.New("translation markdown structure does not match the source, quota refunded")
+)
+
 // GenerateArticleTranslation 生成文章翻译
 //
 //	receiver s *aiService
-//	param req *dto.GenerateArticleQARequest
+//	param req *dto.GenerateArticleTranslationRequest
 //	return tokenChan <-chan string
 //	return errChan <-chan error
 //	author centonhuang
-//	update 2025-11-01 18:30:00
-func (s *aiService) GenerateArticleTranslation(ctx context.Context, req *dto.GenerateArticleQARequest) (tokenChan <-chan string, errChan <-chan error) {
+//	update 2025-11-08 15:20:11
+func (s *aiService) GenerateArticleTranslation(ctx context.Context, req *dto.GenerateArticleTranslationRequest) (tokenChan <-chan string, errChan <-chan error) {
 	errCh := make(chan error, 1)
 
 	if req == nil || req.Body == nil {
@@ -598,6 +621,15 @@ func (s *aiService) GenerateArticleTranslation(ctx context.Context, req *dto.Gen
 
 	userID := ctx.Value(constant.CtxKeyUserID).(uint)
 
+	targetLanguage := model.Language(req.Body.TargetLanguage)
+	languageName, ok := model.LanguageNameMapping[targetLanguage]
+	if !ok || targetLanguage == model.LanguageDefault {
+		logger.Error("[AIService] invalid target language", zap.String("targetLanguage", req.Body.TargetLanguage))
+		errCh <- protocol.ErrBadRequest
+		close(errCh)
+		return nil, errCh
+	}
+
 	user := lo.Must1(s.userDAO.GetByID(db, userID, []string{"id", "name", "llm_quota"}, []string{}))
 	if user.LLMQuota <= 0 {
 		logger.Info("[AIService] insufficient LLM quota", zap.Int("quota", int(user.LLMQuota)))
@@ -606,7 +638,7 @@ func (s *aiService) GenerateArticleTranslation(ctx context.Context, req *dto.Gen
 		return nil, errCh
 	}
 
-	article, err := s.articleDAO.GetByID(db, req.Body.ArticleID, []string{"id", "title"}, []string{})
+	article, err := s.articleDAO.GetByID(db, req.Body.ArticleID, []string{"id", "title", "user_id"}, []string{})
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			logger.Error("[AIService] article not found",
@@ -623,7 +655,15 @@ func (s *aiService) GenerateArticleTranslation(ctx context.Context, req *dto.Gen
 		return nil, errCh
 	}
 
-	latestVersion, err := s.articleVersionDAO.GetLatestByArticleID(db, article.ID, []string{"id", "content"}, []string{})
+	if article.UserID != userID {
+		logger.Error("[AIService] no permission to translate article",
+			zap.Uint("articleID", article.ID))
+		errCh <- protocol.ErrNoPermission
+		close(errCh)
+		return nil, errCh
+	}
+
+	latestVersion, err := s.articleVersionDAO.GetLatestByArticleID(db, article.ID, model.LanguageDefault, []string{"id", "article_id", "version", "content"}, []string{})
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			logger.Error("[AIService] article version not found",
@@ -689,8 +729,9 @@ func (s *aiService) GenerateArticleTranslation(ctx context.Context, req *dto.Gen
 	}
 
 	input := map[string]interface{}{
-		"title":   article.Title,
-		"content": latestVersion.Content,
+		"title":    article.Title,
+		"content":  latestVersion.Content,
+		"language": languageName,
 	}
 
 	userUniqueID := fmt.Sprintf("%s-%d", user.Name, userID)
@@ -704,6 +745,7 @@ func (s *aiService) GenerateArticleTranslation(ctx context.Context, req *dto.Gen
 		Tags: []string{
 			fmt.Sprintf("%d", req.Body.ArticleID),
 			string(latestPrompt.Task),
+			string(targetLanguage),
 		},
 	})
 	callbackHandlers := []callbacks.Handler{
@@ -711,42 +753,137 @@ func (s *aiService) GenerateArticleTranslation(ctx context.Context, req *dto.Gen
 		callback.NewLogCallbackHandler(),
 	}
 
+	// 先扣费再生成，未能保存译文时在生成协程中退还
+	lo.Must0(s.userDAO.Update(db, user, map[string]interface{}{"llm_quota": user.LLMQuota - 1}))
+
+	// 生成与保存脱离请求的生命周期，客户端断开后继续生成直至落库
+	streamCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), articleTranslationTimeout)
+
 	tokenCh := make(chan string)
 	go func() {
+		defer cancel()
 		defer close(tokenCh)
 		defer close(errCh)
 
-		sr, err := runnable.Stream(ctx, input, compose.WithCallbacks(callbackHandlers...))
+		sr, err := runnable.Stream(streamCtx, input, compose.WithCallbacks(callbackHandlers...))
 		if err != nil {
-			if errors.Is(err, io.EOF) {
-				return
-			}
 			logger.Error("[AIService] failed to stream", zap.Error(err))
+			s.refundArticleTranslation(streamCtx, userID)
 			errCh <- err
 			return
 		}
 		defer sr.Close()
 
+		var translation strings.Builder
+		clientGone := false
 		for {
 			chunk, err := sr.Recv()
 			if err != nil {
 				if errors.Is(err, io.EOF) {
-					return
+					break
 				}
 				logger.Error("[AIService] failed to receive stream", zap.Error(err))
+				s.refundArticleTranslation(streamCtx, userID)
 				errCh <- err
 				return
 			}
 
-			tokenCh <- chunk.Content
+			translation.WriteString(chunk.Content)
+			if clientGone {
+				continue
+			}
+			select {
+			case tokenCh <- chunk.Content:
+			case <-ctx.Done():
+				clientGone = true
+				logger.Info("[AIService] client disconnected, keep translating in background",
+					zap.Uint("articleID", article.ID),
+					zap.String("language", string(targetLanguage)))
+			}
 		}
-	}()
 
-	lo.Must0(s.userDAO.Update(db, user, map[string]interface{}{"llm_quota": user.LLMQuota - 1}))
+		if err := s.saveArticleTranslation(streamCtx, latestVersion, targetLanguage, translation.String()); err != nil {
+			s.refundArticleTranslation(streamCtx, userID)
+			errCh <- err
+		}
+	}()
 
 	return tokenCh, errCh
 }
 
+// refundArticleTranslation 退还翻译请求扣除的LLM配额
+func (s *aiService) refundArticleTranslation(ctx context.Context, userID uint) {
+	if err := s.userDAO.RefundLLMQuota(database.GetDBInstance(ctx), userID, 1); err != nil {
+		logger.WithCtx(ctx).Error("[AIService] failed to refund LLM quota", zap.Uint("userID", userID), zap.Error(err))
+	}
+}
+
+// saveArticleTranslation 保存文章翻译为对应语言的文章版本，译文为空或结构与原文不一致时拒绝保存并返回错误
+//
+//	receiver s *aiService
+//	param ctx context.Context
+//	param sourceVersion *model.ArticleVersion
+//	param language model.Language
+//	param content string
+//	return err error
+//	author centonhuang
+//	update 2025-11-19 10:12:26
+func (s *aiService) saveArticleTranslation(ctx context.Context, sourceVersion *model.ArticleVersion, language model.Language, content string) (err error) {
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	content = strings.TrimSpace(content)
+	if content == "" {
+		logger.Warn("[AIService] empty translation, reject saving",
+			zap.Uint("articleVersionID", sourceVersion.ID),
+			zap.String("language", string(language)))
+		return errTranslationEmpty
+	}
+
+	if !util.IsMarkdownStructureEqual(sourceVersion.Content, content) {
+		logger.Warn("[AIService] translation markdown structure mismatch, reject saving",
+			zap.Uint("articleVersionID", sourceVersion.ID),
+			zap.String("language", string(language)))
+		return errTranslationStructureMismatch
+	}
+
+	latestTranslation, err := s.articleVersionDAO.GetLatestByArticleID(db, sourceVersion.ArticleID, language, []string{"id", "version", "source_version_id"}, []string{})
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		logger.Error("[AIService] failed to get latest translation",
+			zap.Uint("articleID", sourceVersion.ArticleID),
+			zap.String("language", string(language)),
+			zap.Error(err))
+		return protocol.ErrInternalError
+	}
+
+	nextVersion := uint(1)
+	if latestTranslation != nil {
+		nextVersion = latestTranslation.Version + 1
+	}
+
+	translation := &model.ArticleVersion{
+		ArticleID:       sourceVersion.ArticleID,
+		Language:        language,
+		Version:         nextVersion,
+		Content:         content,
+		Description:     fmt.Sprintf("translated from v%d", sourceVersion.Version),
+		SourceVersionID: sourceVersion.ID,
+	}
+	if err = s.articleVersionDAO.Create(db, translation); err != nil {
+		logger.Error("[AIService] failed to save translation",
+			zap.Uint("articleID", sourceVersion.ArticleID),
+			zap.String("language", string(language)),
+			zap.Error(err))
+		return protocol.ErrInternalError
+	}
+
+	logger.Info("[AIService] translation saved",
+		zap.Uint("articleID", sourceVersion.ArticleID),
+		zap.String("language", string(language)),
+		zap.Uint("version", translation.Version))
+	return nil
+}
+
 // GenerateArticleQA 生成文章问答
 //
 //	receiver s *aiService
@@ -793,7 +930,7 @@ func (s *aiService) GenerateArticleQA(ctx context.Context, req *dto.GenerateArti
 		return nil, errCh
 	}
 
-	latestVersion, err := s.articleVersionDAO.GetLatestByArticleID(db, article.ID, []string{"id", "content"}, []string{})
+	latestVersion, err := s.articleVersionDAO.GetLatestByArticleID(db, article.ID, model.LanguageDefault, []string{"id", "content"}, []string{})
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			logger.Error("[AIService] article version not found",
@@ -967,7 +1104,7 @@ func (s *aiService) GenerateTermExplaination(ctx context.Context, req *dto.Gener
 		return nil, errCh
 	}
 
-	latestVersion, err := s.articleVersionDAO.GetLatestByArticleID(db, article.ID, []string{"id", "content"}, []string{})
+	latestVersion, err := s.articleVersionDAO.GetLatestByArticleID(db, article.ID, model.LanguageDefault, []string{"id", "content"}, []string{})
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			logger.Error("[AIService] article version not found", zap.Uint("articleID", article.ID))
@@ -1031,22 +1168,16 @@ func (s *aiService) GenerateTermExplaination(ctx context.Context, req *dto.Gener
 
 	contextWindowLen := 200
 
-	var left, right int
-	if req.Body.Position < contextWindowLen/2 {
-		left = 0
-		right = contextWindowLen
-	} else if req.Body.Position > len(latestVersion.Content)-contextWindowLen/2 {
-		left = len(latestVersion.Content) - contextWindowLen
-		right = len(latestVersion.Content)
-	} else {
-		left = req.Body.Position - contextWindowLen/2
-		right = req.Body.Position + contextWindowLen/2
-	}
+	contentRunes := []rune(latestVersion.Content)
+	position := min(max(req.Body.Position, 0), len(contentRunes))
+	left := max(position-contextWindowLen/2, 0)
+	right := min(left+contextWindowLen, len(contentRunes))
+	left = max(right-contextWindowLen, 0)
 
 	input := map[string]interface{}{
 		"title":   article.Title,
 		"content": latestVersion.Content,
-		"context": latestVersion.Content[left:right],
+		"context": string(contentRunes[left:right]),
 		"term":    req.Body.Term,
 	}
 
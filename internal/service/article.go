@@ -13,6 +13,7 @@ import (
 	"github.com/hcd233/aris-blog-api/internal/resource/database"
 	"github.com/hcd233/aris-blog-api/internal/resource/database/dao"
 	"github.com/hcd233/aris-blog-api/internal/resource/database/model"
+	"github.com/hcd233/aris-blog-api/internal/util"
 	"github.com/samber/lo"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -35,6 +36,8 @@ type articleService struct {
 	categoryDAO       *dao.CategoryDAO
 	articleDAO        *dao.ArticleDAO
 	articleVersionDAO *dao.ArticleVersionDAO
+
+	articleSuggestionService ArticleSuggestionService
 }
 
 // NewArticleService 创建文章服务
@@ -45,6 +48,8 @@ func NewArticleService() ArticleService {
 		categoryDAO:       dao.GetCategoryDAO(),
 		articleDAO:        dao.GetArticleDAO(),
 		articleVersionDAO: dao.GetArticleVersionDAO(),
+
+		articleSuggestionService: NewArticleSuggestionService(),
 	}
 }
 
@@ -150,6 +155,7 @@ func (s *articleService) CreateArticle(ctx context.Context, req *dto.CreateArtic
 		Views:       article.Views,
 		Tags:        nil,
 		Comments:    len(article.Comments),
+		Languages:   []string{},
 	}
 
 	return rsp, nil
@@ -193,7 +199,16 @@ func (s *articleService) GetArticleInfo(ctx context.Context, req *dto.GetArticle
 		return nil, protocol.ErrNoPermission
 	}
 
-	rsp.Article = s.buildArticleDTO(article)
+	languages, err := s.articleVersionDAO.ListLanguagesByArticleIDs(db, []uint{article.ID})
+	if err != nil {
+		logger.Error("[ArticleService] failed to list article languages",
+			zap.Uint("articleID", article.ID),
+			zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	rsp.Article = s.buildArticleDTO(article, languages[article.ID])
+	rsp.Article.Language = util.NegotiateLanguage(req.Lang, req.AcceptLanguage, rsp.Article.Languages, string(model.LanguageDefault))
 
 	return rsp, nil
 }
@@ -250,7 +265,16 @@ func (s *articleService) GetArticleInfoBySlug(ctx context.Context, req *dto.GetA
 		return nil, protocol.ErrNoPermission
 	}
 
-	rsp.Article = s.buildArticleDTO(article)
+	languages, err := s.articleVersionDAO.ListLanguagesByArticleIDs(db, []uint{article.ID})
+	if err != nil {
+		logger.Error("[ArticleService] failed to list article languages",
+			zap.Uint("articleID", article.ID),
+			zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	rsp.Article = s.buildArticleDTO(article, languages[article.ID])
+	rsp.Article.Language = util.NegotiateLanguage(req.Lang, req.AcceptLanguage, rsp.Article.Languages, string(model.LanguageDefault))
 
 	return rsp, nil
 }
@@ -358,6 +382,14 @@ func (s *articleService) UpdateArticleStatus(ctx context.Context, req *dto.Updat
 		return nil, protocol.ErrInternalError
 	}
 
+	if article.Status != model.ArticleStatusPublish && req.Body.Status == model.ArticleStatusPublish {
+		if err := s.articleSuggestionService.ScheduleArticleSuggestion(ctx, article.ID); err != nil {
+			logger.Error("[ArticleService] failed to schedule article suggestion",
+				zap.Uint("articleID", article.ID),
+				zap.Error(err))
+		}
+	}
+
 	return rsp, nil
 }
 
@@ -376,7 +408,7 @@ func (s *articleService) DeleteArticle(ctx context.Context, req *dto.DeleteArtic
 
 	db := database.GetDBInstance(ctx)
 
-	article, err := s.articleDAO.GetByID(db, req.ArticleID, []string{"id", "user_id"}, []string{})
+	article, err := s.articleDAO.GetByID(db, req.ArticleID, []string{"id", "user_id", "title", "slug", "status"}, []string{})
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			logger.Error("[ArticleService] article not found",
@@ -395,7 +427,22 @@ func (s *articleService) DeleteArticle(ctx context.Context, req *dto.DeleteArtic
 		return nil, protocol.ErrNoPermission
 	}
 
-	if err := s.articleDAO.Delete(db, article); err != nil {
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := s.articleDAO.Delete(tx, article); err != nil {
+			return err
+		}
+		return recordAuditEvent(ctx, tx, &model.AuditEvent{
+			Action:     model.AuditActionArticleDelete,
+			TargetType: model.AuditTargetTypeArticle,
+			TargetID:   article.ID,
+			Before: map[string]any{
+				"title":  article.Title,
+				"slug":   article.Slug,
+				"status": article.Status,
+			},
+		})
+	})
+	if err != nil {
 		logger.Error("[ArticleService] failed to delete article",
 			zap.Uint("articleID", article.ID),
 			zap.Error(err))
@@ -437,8 +484,17 @@ func (s *articleService) ListArticles(ctx context.Context, req *dto.ListArticleR
 		return nil, protocol.ErrInternalError
 	}
 
+	articleIDs := lo.Map(*articles, func(article model.Article, _ int) uint {
+		return article.ID
+	})
+	languages, err := s.articleVersionDAO.ListLanguagesByArticleIDs(db, articleIDs)
+	if err != nil {
+		logger.Error("[ArticleService] failed to list article languages", zap.Uints("articleIDs", articleIDs), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
 	rsp.Articles = lo.Map(*articles, func(article model.Article, _ int) *dto.Article {
-		return s.buildArticleDTO(&article)
+		return s.buildArticleDTO(&article, languages[article.ID])
 	})
 
 	rsp.PageInfo = &dto.PageInfo{
@@ -450,7 +506,7 @@ func (s *articleService) ListArticles(ctx context.Context, req *dto.ListArticleR
 	return rsp, nil
 }
 
-func (s *articleService) buildArticleDTO(article *model.Article) *dto.Article {
+func (s *articleService) buildArticleDTO(article *model.Article, languages []model.Language) *dto.Article {
 	return &dto.Article{
 		ArticleID: article.ID,
 		Title:     article.Title,
@@ -474,5 +530,8 @@ func (s *articleService) buildArticleDTO(article *model.Article) *dto.Article {
 			}
 		}),
 		Comments: len(article.Comments),
+		Languages: lo.Map(languages, func(language model.Language, _ int) string {
+			return string(language)
+		}),
 	}
 }
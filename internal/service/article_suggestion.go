@@ -0,0 +1,565 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/cloudwego/eino-ext/callbacks/langfuse"
+	"github.com/cloudwego/eino-ext/components/model/openai"
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/prompt"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+	"github.com/hcd233/aris-blog-api/internal/ai/callback"
+	"github.com/hcd233/aris-blog-api/internal/config"
+	"github.com/hcd233/aris-blog-api/internal/constant"
+	"github.com/hcd233/aris-blog-api/internal/job"
+	"github.com/hcd233/aris-blog-api/internal/logger"
+	"github.com/hcd233/aris-blog-api/internal/protocol"
+	dto "github.com/hcd233/aris-blog-api/internal/protocol/dto"
+	"github.com/hcd233/aris-blog-api/internal/resource/database"
+	"github.com/hcd233/aris-blog-api/internal/resource/database/dao"
+	"github.com/hcd233/aris-blog-api/internal/resource/database/model"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	articleSuggestionMaxRetries    = 5
+	articleSuggestionQuotaDelay    = time.Hour
+	articleSuggestionLease         = 10 * time.Minute
+	articleSuggestionMaxTags       = 5
+	articleSuggestionTagVocabulary = 200
+	articleSuggestionTemperature   = float32(0.3)
+)
+
+// ArticleSuggestionService 文章AI建议服务
+//
+//	author centonhuang
+//	update 2025-11-09 16:40:12
+type ArticleSuggestionService interface {
+	GetArticleSuggestion(ctx context.Context, req *dto.GetArticleSuggestionRequest) (rsp *dto.GetArticleSuggestionResponse, err error)
+	ReviewArticleSuggestion(ctx context.Context, req *dto.ReviewArticleSuggestionRequest) (rsp *dto.EmptyResponse, err error)
+	ScheduleArticleSuggestion(ctx context.Context, articleID uint) (err error)
+	HandleArticleSuggestionJob(ctx context.Context, payload *job.ArticleSuggestionPayload) (err error)
+}
+
+type articleSuggestionService struct {
+	userDAO              *dao.UserDAO
+	tagDAO               *dao.TagDAO
+	articleDAO           *dao.ArticleDAO
+	articleVersionDAO    *dao.ArticleVersionDAO
+	articleSuggestionDAO *dao.ArticleSuggestionDAO
+	promptDAO            *dao.PromptDAO
+}
+
+// NewArticleSuggestionService 创建文章AI建议服务
+//
+//	return ArticleSuggestionService
+//	author centonhuang
+//	update 2025-11-09 16:40:12
+func NewArticleSuggestionService() ArticleSuggestionService {
+	return &articleSuggestionService{
+		userDAO:              dao.GetUserDAO(),
+		tagDAO:               dao.GetTagDAO(),
+		articleDAO:           dao.GetArticleDAO(),
+		articleVersionDAO:    dao.GetArticleVersionDAO(),
+		articleSuggestionDAO: dao.GetArticleSuggestionDAO(),
+		promptDAO:            dao.GetPromptDAO(),
+	}
+}
+
+// GetArticleSuggestion 获取文章最新的AI建议
+//
+//	receiver s *articleSuggestionService
+//	param ctx context.Context
+//	param req *dto.GetArticleSuggestionRequest
+//	return rsp *dto.GetArticleSuggestionResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-09 16:40:12
+func (s *articleSuggestionService) GetArticleSuggestion(ctx context.Context, req *dto.GetArticleSuggestionRequest) (rsp *dto.GetArticleSuggestionResponse, err error) {
+	logger := logger.WithCtx(ctx)
+
+	rsp = &dto.GetArticleSuggestionResponse{}
+
+	db := database.GetDBInstance(ctx)
+	userID := ctx.Value(constant.CtxKeyUserID).(uint)
+
+	suggestion, err := s.getOwnedSuggestion(ctx, db, req.ArticleID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := []model.Tag{}
+	if len(suggestion.TagIDs) > 0 {
+		batchTags, err := s.tagDAO.BatchGetByIDs(db, suggestion.TagIDs, []string{"id", "name", "slug"}, []string{})
+		if err != nil {
+			logger.Error("[ArticleSuggestionService] failed to get suggested tags",
+				zap.Uints("tagIDs", suggestion.TagIDs),
+				zap.Error(err))
+			return nil, protocol.ErrInternalError
+		}
+		tags = *batchTags
+	}
+
+	rsp.Suggestion = &dto.ArticleSuggestion{
+		SuggestionID:     suggestion.ID,
+		ArticleID:        suggestion.ArticleID,
+		ArticleVersionID: suggestion.ArticleVersionID,
+		Status:           string(suggestion.Status),
+		Summary:          suggestion.Summary,
+		Tags: lo.Map(tags, func(tag model.Tag, _ int) *dto.Tag {
+			return &dto.Tag{
+				TagID: tag.ID,
+				Name:  tag.Name,
+				Slug:  tag.Slug,
+			}
+		}),
+		Retries:   suggestion.Retries,
+		LastError: suggestion.LastError,
+		CreatedAt: suggestion.CreatedAt.Format(time.DateTime),
+		UpdatedAt: suggestion.UpdatedAt.Format(time.DateTime),
+	}
+
+	return rsp, nil
+}
+
+// ReviewArticleSuggestion 采纳或拒绝文章AI建议
+//
+//	receiver s *articleSuggestionService
+//	param ctx context.Context
+//	param req *dto.ReviewArticleSuggestionRequest
+//	return rsp *dto.EmptyResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-09 16:40:12
+func (s *articleSuggestionService) ReviewArticleSuggestion(ctx context.Context, req *dto.ReviewArticleSuggestionRequest) (rsp *dto.EmptyResponse, err error) {
+	logger := logger.WithCtx(ctx)
+
+	if req == nil || req.Body == nil {
+		logger.Error("[ArticleSuggestionService] request body is nil")
+		return nil, protocol.ErrBadRequest
+	}
+
+	rsp = &dto.EmptyResponse{}
+
+	db := database.GetDBInstance(ctx)
+	userID := ctx.Value(constant.CtxKeyUserID).(uint)
+
+	suggestion, err := s.getOwnedSuggestion(ctx, db, req.ArticleID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if suggestion.Status != model.ArticleSuggestionStatusGenerated {
+		logger.Error("[ArticleSuggestionService] suggestion is not ready for review",
+			zap.Uint("suggestionID", suggestion.ID),
+			zap.String("status", string(suggestion.Status)))
+		return nil, protocol.ErrBadRequest
+	}
+
+	switch req.Body.Action {
+	case "accept":
+		err = s.acceptSuggestion(ctx, db, suggestion, req.Body.TagSlugs)
+	case "reject":
+		err = s.rejectSuggestion(ctx, db, suggestion)
+	default:
+		logger.Error("[ArticleSuggestionService] invalid review action", zap.String("action", req.Body.Action))
+		return nil, protocol.ErrBadRequest
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return rsp, nil
+}
+
+// ScheduleArticleSuggestion 为文章最新版本创建AI建议任务并在后台执行
+//
+//	receiver s *articleSuggestionService
+//	param ctx context.Context
+//	param articleID uint
+//	return err error
+//	author centonhuang
+//	update 2025-11-09 16:40:12
+func (s *articleSuggestionService) ScheduleArticleSuggestion(ctx context.Context, articleID uint) (err error) {
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	article, err := s.articleDAO.GetByID(db, articleID, []string{"id", "user_id"}, []string{})
+	if err != nil {
+		logger.Error("[ArticleSuggestionService] failed to get article", zap.Uint("articleID", articleID), zap.Error(err))
+		return err
+	}
+
+	latestVersion, err := s.articleVersionDAO.GetLatestByArticleID(db, article.ID, model.LanguageDefault, []string{"id"}, []string{})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Info("[ArticleSuggestionService] article has no version, skip suggestion", zap.Uint("articleID", article.ID))
+			return nil
+		}
+		logger.Error("[ArticleSuggestionService] failed to get latest version", zap.Uint("articleID", article.ID), zap.Error(err))
+		return err
+	}
+
+	latestSuggestion, err := s.articleSuggestionDAO.GetLatestByArticleID(db, article.ID, []string{"id", "article_version_id"}, []string{})
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		logger.Error("[ArticleSuggestionService] failed to get latest suggestion", zap.Uint("articleID", article.ID), zap.Error(err))
+		return err
+	}
+	if latestSuggestion != nil && latestSuggestion.ArticleVersionID == latestVersion.ID {
+		logger.Info("[ArticleSuggestionService] suggestion already exists for version",
+			zap.Uint("articleID", article.ID),
+			zap.Uint("articleVersionID", latestVersion.ID))
+		return nil
+	}
+
+	suggestion := &model.ArticleSuggestion{
+		ArticleID:        article.ID,
+		ArticleVersionID: latestVersion.ID,
+		UserID:           article.UserID,
+		Status:           model.ArticleSuggestionStatusPending,
+		TagIDs:           []uint{},
+		NextRetryAt:      time.Now().UTC(),
+	}
+	if err = s.articleSuggestionDAO.Create(db, suggestion); err != nil {
+		logger.Error("[ArticleSuggestionService] failed to create suggestion", zap.Uint("articleID", article.ID), zap.Error(err))
+		return err
+	}
+
+	if _, err = job.Enqueue(ctx, job.TypeArticleSuggestion, &job.ArticleSuggestionPayload{SuggestionID: suggestion.ID},
+		job.WithMaxRetries(articleSuggestionMaxRetries)); err != nil {
+		// 投递失败时删除建议，文章再次发布时重新创建
+		logger.Error("[ArticleSuggestionService] failed to enqueue suggestion job", zap.Uint("suggestionID", suggestion.ID), zap.Error(err))
+		if err := s.articleSuggestionDAO.Delete(db, suggestion); err != nil {
+			logger.Error("[ArticleSuggestionService] failed to delete unscheduled suggestion", zap.Uint("suggestionID", suggestion.ID), zap.Error(err))
+		}
+		return err
+	}
+
+	return nil
+}
+
+// HandleArticleSuggestionJob 处理文章AI建议后台任务
+//
+//	receiver s *articleSuggestionService
+//	param ctx context.Context
+//	param payload *job.ArticleSuggestionPayload
+//	return err error 生成失败时返回错误，由任务队列负责重试，超过重试次数后进入死信队列
+//	author centonhuang
+//	update 2025-11-19 11:02:37
+func (s *articleSuggestionService) HandleArticleSuggestionJob(ctx context.Context, payload *job.ArticleSuggestionPayload) (err error) {
+	db := database.GetDBInstance(ctx)
+
+	suggestion, err := s.articleSuggestionDAO.GetByID(db, payload.SuggestionID, []string{"id", "article_id", "article_version_id", "user_id", "status", "retries"}, []string{})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.WithCtx(ctx).Warn("[ArticleSuggestionService] suggestion not found, skip job", zap.Uint("suggestionID", payload.SuggestionID))
+			return nil
+		}
+		return err
+	}
+
+	return s.processArticleSuggestion(ctx, suggestion)
+}
+
+func (s *articleSuggestionService) processArticleSuggestion(ctx context.Context, suggestion *model.ArticleSuggestion) (err error) {
+	logger := logger.WithCtx(ctx).With(zap.Uint("suggestionID", suggestion.ID), zap.Uint("articleID", suggestion.ArticleID))
+	db := database.GetDBInstance(ctx)
+
+	now := time.Now().UTC()
+	claimed, err := s.articleSuggestionDAO.Claim(db, suggestion, now, now.Add(articleSuggestionLease))
+	if err != nil {
+		logger.Error("[ArticleSuggestionService] failed to claim suggestion", zap.Error(err))
+		return err
+	}
+	if !claimed {
+		logger.Info("[ArticleSuggestionService] suggestion claimed by others, skip")
+		return nil
+	}
+
+	user, err := s.userDAO.GetByID(db, suggestion.UserID, []string{"id", "name", "llm_quota"}, []string{})
+	if err != nil {
+		return s.failSuggestion(ctx, suggestion, err)
+	}
+	if user.LLMQuota <= 0 {
+		// 配额不足不计入失败次数，释放租约后延迟重新投递
+		logger.Info("[ArticleSuggestionService] insufficient LLM quota, postpone suggestion", zap.Int("quota", int(user.LLMQuota)))
+		if err := s.articleSuggestionDAO.Update(db, suggestion, map[string]interface{}{
+			"last_error":    protocol.ErrInsufficientQuota.Error(),
+			"next_retry_at": time.Now().UTC(),
+		}); err != nil {
+			logger.Error("[ArticleSuggestionService] failed to postpone suggestion", zap.Error(err))
+			return err
+		}
+		_, err = job.Enqueue(ctx, job.TypeArticleSuggestion, &job.ArticleSuggestionPayload{SuggestionID: suggestion.ID},
+			job.WithMaxRetries(articleSuggestionMaxRetries), job.WithDelay(articleSuggestionQuotaDelay))
+		return err
+	}
+
+	article, err := s.articleDAO.GetByID(db, suggestion.ArticleID, []string{"id", "title"}, []string{"Tags"})
+	if err != nil {
+		return s.failSuggestion(ctx, suggestion, err)
+	}
+
+	version, err := s.articleVersionDAO.GetByID(db, suggestion.ArticleVersionID, []string{"id", "content"}, []string{})
+	if err != nil {
+		return s.failSuggestion(ctx, suggestion, err)
+	}
+
+	summary, err := s.invokePrompt(ctx, user, model.TaskArticleSummary, map[string]interface{}{
+		"title":       article.Title,
+		"content":     version.Content,
+		"instruction": "",
+	})
+	if err != nil {
+		return s.failSuggestion(ctx, suggestion, err)
+	}
+
+	vocabulary, err := s.tagDAO.ListPopular(db, articleSuggestionTagVocabulary, []string{"id", "slug"})
+	if err != nil {
+		return s.failSuggestion(ctx, suggestion, err)
+	}
+
+	tagIDs := []uint{}
+	if len(*vocabulary) > 0 {
+		output, err := s.invokePrompt(ctx, user, model.TaskArticleTagSuggestion, map[string]interface{}{
+			"title":   article.Title,
+			"content": version.Content,
+			"tags": strings.Join(lo.Map(*vocabulary, func(tag model.Tag, _ int) string {
+				return tag.Slug
+			}), ", "),
+		})
+		if err != nil {
+			return s.failSuggestion(ctx, suggestion, err)
+		}
+		tagIDs = matchSuggestedTags(output, *vocabulary, article.Tags)
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := s.articleVersionDAO.Update(tx, version, map[string]interface{}{"summary": summary}); err != nil {
+			return err
+		}
+		if err := s.articleSuggestionDAO.Update(tx, suggestion, map[string]interface{}{
+			"status":     model.ArticleSuggestionStatusGenerated,
+			"summary":    summary,
+			"tag_ids":    lo.Must1(sonic.MarshalString(tagIDs)),
+			"last_error": "",
+		}); err != nil {
+			return err
+		}
+		return s.userDAO.Update(tx, user, map[string]interface{}{"llm_quota": user.LLMQuota - 1})
+	})
+	if err != nil {
+		return s.failSuggestion(ctx, suggestion, err)
+	}
+
+	logger.Info("[ArticleSuggestionService] suggestion generated", zap.Uints("tagIDs", tagIDs))
+	return nil
+}
+
+// failSuggestion 记录失败原因并释放租约，返回cause交由任务队列重试
+func (s *articleSuggestionService) failSuggestion(ctx context.Context, suggestion *model.ArticleSuggestion, cause error) error {
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	retries := suggestion.Retries + 1
+	logger.Error("[ArticleSuggestionService] failed to generate suggestion",
+		zap.Uint("suggestionID", suggestion.ID),
+		zap.Uint("retries", retries),
+		zap.Error(cause))
+
+	if err := s.articleSuggestionDAO.Update(db, suggestion, map[string]interface{}{
+		"status":        model.ArticleSuggestionStatusFailed,
+		"retries":       retries,
+		"last_error":    cause.Error(),
+		"next_retry_at": time.Now().UTC(),
+	}); err != nil {
+		logger.Error("[ArticleSuggestionService] failed to update suggestion", zap.Uint("suggestionID", suggestion.ID), zap.Error(err))
+	}
+	return cause
+}
+
+func (s *articleSuggestionService) invokePrompt(ctx context.Context, user *model.User, task model.Task, input map[string]interface{}) (output string, err error) {
+	db := database.GetDBInstance(ctx)
+
+	latestPrompt, err := s.promptDAO.GetLatestPromptByTask(db, task, []string{"id", "task", "templates"}, []string{})
+	if err != nil {
+		return "", fmt.Errorf("get latest prompt of %s: %w", task, err)
+	}
+
+	messages := lo.Map(latestPrompt.Templates, func(template model.Template, _ int) schema.MessagesTemplate {
+		return &schema.Message{
+			Name:    string(latestPrompt.Task),
+			Role:    schema.RoleType(template.Role),
+			Content: template.Content,
+		}
+	})
+
+	temperature := articleSuggestionTemperature
+	chatOpenAI, err := openai.NewChatModel(ctx, &openai.ChatModelConfig{
+		Model:       config.OpenAIModel,
+		APIKey:      config.OpenAIAPIKey,
+		BaseURL:     config.OpenAIBaseURL,
+		Temperature: &temperature,
+	})
+	if err != nil {
+		return "", fmt.Errorf("create chat openai: %w", err)
+	}
+
+	chain := compose.NewChain[map[string]any, *schema.Message]()
+	_ = chain.AppendChatTemplate(prompt.FromMessages(schema.GoTemplate, messages...))
+	_ = chain.AppendChatModel(chatOpenAI)
+	runnable, err := chain.Compile(ctx)
+	if err != nil {
+		return "", fmt.Errorf("compile chain: %w", err)
+	}
+
+	langfuseCallbackHandler, _ := langfuse.NewLangfuseHandler(&langfuse.Config{
+		Host:      config.LangfuseHost,
+		PublicKey: config.LangfusePublicKey,
+		SecretKey: config.LangfuseSecretKey,
+		UserID:    fmt.Sprintf("%s-%d", user.Name, user.ID),
+		Name:      fmt.Sprintf("%s-trace", string(latestPrompt.Task)),
+		Tags:      []string{string(latestPrompt.Task), "background"},
+	})
+	callbackHandlers := []callbacks.Handler{
+		langfuseCallbackHandler,
+		callback.NewLogCallbackHandler(),
+	}
+
+	message, err := runnable.Invoke(ctx, input, compose.WithCallbacks(callbackHandlers...))
+	if err != nil {
+		return "", fmt.Errorf("invoke chain: %w", err)
+	}
+
+	output = strings.TrimSpace(message.Content)
+	if output == "" {
+		return "", errors.New("empty model output")
+	}
+	return output, nil
+}
+
+func (s *articleSuggestionService) getOwnedSuggestion(ctx context.Context, db *gorm.DB, articleID, userID uint) (suggestion *model.ArticleSuggestion, err error) {
+	logger := logger.WithCtx(ctx)
+
+	article, err := s.articleDAO.GetByIDAndUserID(db, articleID, userID, []string{"id"}, []string{})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Error("[ArticleSuggestionService] article not found",
+				zap.Uint("articleID", articleID),
+				zap.Uint("userID", userID))
+			return nil, protocol.ErrDataNotExists
+		}
+		logger.Error("[ArticleSuggestionService] failed to get article",
+			zap.Uint("articleID", articleID),
+			zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	suggestion, err = s.articleSuggestionDAO.GetLatestByArticleID(db, article.ID,
+		[]string{"id", "article_id", "article_version_id", "status", "summary", "tag_ids", "retries", "last_error", "created_at", "updated_at"}, []string{})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Error("[ArticleSuggestionService] suggestion not found", zap.Uint("articleID", article.ID))
+			return nil, protocol.ErrDataNotExists
+		}
+		logger.Error("[ArticleSuggestionService] failed to get suggestion",
+			zap.Uint("articleID", article.ID),
+			zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+	return suggestion, nil
+}
+
+func (s *articleSuggestionService) acceptSuggestion(ctx context.Context, db *gorm.DB, suggestion *model.ArticleSuggestion, tagSlugs []string) (err error) {
+	logger := logger.WithCtx(ctx)
+
+	tags := []model.Tag{}
+	if len(suggestion.TagIDs) > 0 {
+		batchTags, err := s.tagDAO.BatchGetByIDs(db, suggestion.TagIDs, []string{"id", "slug"}, []string{})
+		if err != nil {
+			logger.Error("[ArticleSuggestionService] failed to get suggested tags",
+				zap.Uints("tagIDs", suggestion.TagIDs),
+				zap.Error(err))
+			return protocol.ErrInternalError
+		}
+		tags = *batchTags
+	}
+
+	if len(tagSlugs) > 0 {
+		suggestedSlugs := lo.Map(tags, func(tag model.Tag, _ int) string { return tag.Slug })
+		if invalidSlugs, _ := lo.Difference(tagSlugs, suggestedSlugs); len(invalidSlugs) > 0 {
+			logger.Error("[ArticleSuggestionService] tags are not suggested", zap.Strings("tagSlugs", invalidSlugs))
+			return protocol.ErrBadRequest
+		}
+		tags = lo.Filter(tags, func(tag model.Tag, _ int) bool { return lo.Contains(tagSlugs, tag.Slug) })
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if len(tags) > 0 {
+			if err := tx.Model(&model.Article{ID: suggestion.ArticleID}).Association("Tags").Append(&tags); err != nil {
+				return err
+			}
+		}
+		return s.articleSuggestionDAO.Update(tx, suggestion, map[string]interface{}{"status": model.ArticleSuggestionStatusAccepted})
+	})
+	if err != nil {
+		logger.Error("[ArticleSuggestionService] failed to accept suggestion",
+			zap.Uint("suggestionID", suggestion.ID),
+			zap.Error(err))
+		return protocol.ErrInternalError
+	}
+	return nil
+}
+
+func (s *articleSuggestionService) rejectSuggestion(ctx context.Context, db *gorm.DB, suggestion *model.ArticleSuggestion) (err error) {
+	logger := logger.WithCtx(ctx)
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		// 仅在作者未修改过摘要时撤回自动写入的摘要
+		if err := tx.Model(&model.ArticleVersion{}).
+			Where("id = ? AND summary = ?", suggestion.ArticleVersionID, suggestion.Summary).
+			Updates(map[string]interface{}{"summary": "", "updated_at": time.Now().UTC()}).Error; err != nil {
+			return err
+		}
+		return s.articleSuggestionDAO.Update(tx, suggestion, map[string]interface{}{"status": model.ArticleSuggestionStatusRejected})
+	})
+	if err != nil {
+		logger.Error("[ArticleSuggestionService] failed to reject suggestion",
+			zap.Uint("suggestionID", suggestion.ID),
+			zap.Error(err))
+		return protocol.ErrInternalError
+	}
+	return nil
+}
+
+// matchSuggestedTags 从模型输出中匹配已有标签，排除文章已有的标签
+func matchSuggestedTags(output string, vocabulary, existing []model.Tag) []uint {
+	slugTagMapping := lo.SliceToMap(vocabulary, func(tag model.Tag) (string, uint) {
+		return strings.ToLower(tag.Slug), tag.ID
+	})
+	existingIDs := lo.Map(existing, func(tag model.Tag, _ int) uint { return tag.ID })
+
+	tagIDs := []uint{}
+	for _, field := range strings.FieldsFunc(output, func(r rune) bool {
+		return r == ',' || r == '，' || r == '\n' || r == '、'
+	}) {
+		slug := strings.ToLower(strings.Trim(strings.TrimSpace(field), "\"'`#-*[] "))
+		tagID, ok := slugTagMapping[slug]
+		if !ok || lo.Contains(existingIDs, tagID) || lo.Contains(tagIDs, tagID) {
+			continue
+		}
+		tagIDs = append(tagIDs, tagID)
+		if len(tagIDs) >= articleSuggestionMaxTags {
+			break
+		}
+	}
+	return tagIDs
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/hcd233/aris-blog-api/internal/resource/database"
 	"github.com/hcd233/aris-blog-api/internal/resource/database/dao"
 	"github.com/hcd233/aris-blog-api/internal/resource/database/model"
+	"github.com/hcd233/aris-blog-api/internal/util"
 	"github.com/samber/lo"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -73,7 +74,12 @@ func (s *articleVersionService) CreateArticleVersion(ctx context.Context, req *d
 		return nil, protocol.ErrNoPermission
 	}
 
-	latestVersion, err := s.articleVersionDAO.GetLatestByArticleID(db, article.ID, []string{"version", "content"}, []string{})
+	language := model.LanguageDefault
+	if req.Body.Language != "" {
+		language = model.Language(req.Body.Language)
+	}
+
+	latestVersion, err := s.articleVersionDAO.GetLatestByArticleID(db, article.ID, language, []string{"version", "content"}, []string{})
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		logger.Error("[ArticleVersionService] failed to get latest version",
 			zap.Uint("articleID", article.ID),
@@ -95,6 +101,7 @@ func (s *articleVersionService) CreateArticleVersion(ctx context.Context, req *d
 
 	version := &model.ArticleVersion{
 		ArticleID: article.ID,
+		Language:  language,
 		Version:   nextVersion,
 		Content:   req.Body.Content,
 	}
@@ -111,6 +118,7 @@ func (s *articleVersionService) CreateArticleVersion(ctx context.Context, req *d
 		ArticleID:        version.ArticleID,
 		ArticleVersionID: version.ID,
 		VersionID:        version.Version,
+		Language:         string(version.Language),
 		Content:          version.Content,
 		CreatedAt:        version.CreatedAt.Format(time.DateTime),
 		UpdatedAt:        version.UpdatedAt.Format(time.DateTime),
@@ -142,7 +150,12 @@ func (s *articleVersionService) GetArticleVersionInfo(ctx context.Context, req *
 		return nil, protocol.ErrInternalError
 	}
 
-	version, err := s.articleVersionDAO.GetByArticleIDAndVersion(db, article.ID, req.Version, []string{"id", "article_id", "version", "content", "created_at", "updated_at"}, []string{})
+	language := model.LanguageDefault
+	if req.Lang != "" {
+		language = model.Language(req.Lang)
+	}
+
+	version, err := s.articleVersionDAO.GetByArticleIDAndVersion(db, article.ID, language, req.Version, []string{"id", "article_id", "language", "version", "content", "summary", "created_at", "updated_at"}, []string{})
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			logger.Error("[ArticleVersionService] version not found",
@@ -161,7 +174,9 @@ func (s *articleVersionService) GetArticleVersionInfo(ctx context.Context, req *
 		ArticleID:        version.ArticleID,
 		ArticleVersionID: version.ID,
 		VersionID:        version.Version,
+		Language:         string(version.Language),
 		Content:          version.Content,
+		Summary:          version.Summary,
 		CreatedAt:        version.CreatedAt.Format(time.DateTime),
 		UpdatedAt:        version.UpdatedAt.Format(time.DateTime),
 	}
@@ -198,11 +213,24 @@ func (s *articleVersionService) GetLatestArticleVersionInfo(ctx context.Context,
 		return nil, protocol.ErrNoPermission
 	}
 
-	version, err := s.articleVersionDAO.GetLatestByArticleID(db, article.ID, []string{"id", "article_id", "version", "content", "created_at", "updated_at"}, []string{})
+	languages, err := s.articleVersionDAO.ListLanguagesByArticleIDs(db, []uint{article.ID})
+	if err != nil {
+		logger.Error("[ArticleVersionService] failed to list article languages",
+			zap.Uint("articleID", article.ID),
+			zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	language := model.Language(util.NegotiateLanguage(req.Lang, req.AcceptLanguage, lo.Map(languages[article.ID], func(language model.Language, _ int) string {
+		return string(language)
+	}), string(model.LanguageDefault)))
+
+	version, err := s.articleVersionDAO.GetLatestByArticleID(db, article.ID, language, []string{"id", "article_id", "language", "version", "content", "summary", "created_at", "updated_at"}, []string{})
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			logger.Error("[ArticleVersionService] latest version not found",
-				zap.Uint("articleID", article.ID))
+				zap.Uint("articleID", article.ID),
+				zap.String("language", string(language)))
 			return nil, protocol.ErrDataNotExists
 		}
 		logger.Error("[ArticleVersionService] failed to get latest version",
@@ -215,7 +243,9 @@ func (s *articleVersionService) GetLatestArticleVersionInfo(ctx context.Context,
 		ArticleID:        version.ArticleID,
 		ArticleVersionID: version.ID,
 		VersionID:        version.Version,
+		Language:         string(version.Language),
 		Content:          version.Content,
+		Summary:          version.Summary,
 		CreatedAt:        version.CreatedAt.Format(time.DateTime),
 		UpdatedAt:        version.UpdatedAt.Format(time.DateTime),
 	}
@@ -263,8 +293,13 @@ func (s *articleVersionService) ListArticleVersions(ctx context.Context, req *dt
 		},
 	}
 
-	versions, pageInfo, err := s.articleVersionDAO.PaginateByArticleID(db, article.ID,
-		[]string{"id", "article_id", "version", "content", "created_at", "updated_at"}, []string{},
+	language := model.LanguageDefault
+	if req.Lang != "" {
+		language = model.Language(req.Lang)
+	}
+
+	versions, pageInfo, err := s.articleVersionDAO.PaginateByArticleID(db, article.ID, language,
+		[]string{"id", "article_id", "language", "version", "content", "created_at", "updated_at"}, []string{},
 		param)
 	if err != nil {
 		logger.Error("[ArticleVersionService] failed to paginate versions",
@@ -282,6 +317,7 @@ func (s *articleVersionService) ListArticleVersions(ctx context.Context, req *dt
 			ArticleID:        version.ArticleID,
 			ArticleVersionID: version.ID,
 			VersionID:        version.Version,
+			Language:         string(version.Language),
 			Content:          content,
 			CreatedAt:        version.CreatedAt.Format(time.DateTime),
 			UpdatedAt:        version.UpdatedAt.Format(time.DateTime),
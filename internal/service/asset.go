@@ -3,18 +3,28 @@ package service
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"image"
 	"io"
 	"net/url"
+	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/buckket/go-blurhash"
 	"github.com/disintegration/imaging"
+	"github.com/gen2brain/avif"
+	gowebp "github.com/gen2brain/webp"
+	"github.com/google/uuid"
+	"github.com/hcd233/aris-blog-api/internal/config"
 	"github.com/hcd233/aris-blog-api/internal/constant"
+	"github.com/hcd233/aris-blog-api/internal/job"
 	"github.com/hcd233/aris-blog-api/internal/logger"
 	"github.com/hcd233/aris-blog-api/internal/protocol"
 	"github.com/hcd233/aris-blog-api/internal/protocol/dto"
@@ -29,16 +39,59 @@ import (
 	"gorm.io/gorm"
 )
 
+const (
+	directUploadMaxImageSize int64 = 20 * 1024 * 1024
+
+	// stagingImageDir 直传图片的暂存目录，未确认的上传由孤立资产回收按宽限期清理
+	stagingImageDir = "staging"
+)
+
+var (
+	errImageDecode          = errors.New("failed to decode image")
+	errImageNameConflict    = errors.New("image name already used by different content")
+	errStorageQuotaExceeded = errors.New("storage quota exceeded")
+)
+
+var assetFields = []string{
+	"id", "created_at", "user_id", "object_name", "storage_name", "content_hash",
+	"content_type", "size", "width", "height", "blur_hash", "dominant_color",
+}
+
+var imageContentTypes = map[string]string{
+	".webp": "image/webp",
+	".png":  "image/png",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".gif":  "image/gif",
+}
+
+var imageVariantContentTypes = map[string]string{
+	"webp": "image/webp",
+	"avif": "image/avif",
+	"jpeg": "image/jpeg",
+	"png":  "image/png",
+}
+
 // AssetService 资产服务
 //
 //	author centonhuang
-//	update 2025-01-05 17:57:43
+//	update 2025-11-18 16:05:42
 type AssetService interface {
 	ListUserLikeArticles(ctx context.Context, req *dto.ListUserLikeArticlesRequest) (rsp *dto.ListUserLikeArticlesResponse, err error)
 	ListUserLikeComments(ctx context.Context, req *dto.ListUserLikeCommentsRequest) (rsp *dto.ListUserLikeCommentsResponse, err error)
 	ListUserLikeTags(ctx context.Context, req *dto.ListUserLikeTagsRequest) (rsp *dto.ListUserLikeTagsResponse, err error)
 	ListImages(ctx context.Context, req *dto.EmptyRequest) (rsp *dto.ListImagesResponse, err error)
 	UploadImage(ctx context.Context, req *dto.UploadImageRequest) (rsp *dto.EmptyResponse, err error)
+	PresignImageUpload(ctx context.Context, req *dto.PresignImageUploadRequest) (rsp *dto.PresignImageUploadResponse, err error)
+	ConfirmImageUpload(ctx context.Context, req *dto.ConfirmImageUploadRequest) (rsp *dto.ConfirmImageUploadResponse, err error)
+	GetImageVariant(ctx context.Context, req *dto.GetImageVariantRequest) (rsp *dto.URLResponse, err error)
+	HandleImageThumbnailJob(ctx context.Context, payload *job.ImageThumbnailPayload) (err error)
+	HandleImageVariantsJob(ctx context.Context, payload *job.ImageVariantsPayload) (err error)
+	BackfillImageAssets(ctx context.Context) (backfilled int, err error)
+	RecalculateStorageUsage(ctx context.Context, userID uint) (users int, err error)
+	CollectOrphanedAssets(ctx context.Context, dryRun bool) (result *AssetGCResult, err error)
+	ImportImage(ctx context.Context, req *dto.ImportImageRequest) (rsp *dto.ImportImageResponse, err error)
+	ImportArticleVersionImages(ctx context.Context, req *dto.ImportArticleVersionImagesRequest) (rsp *dto.ImportArticleVersionImagesResponse, err error)
 	GetImage(ctx context.Context, req *dto.GetImageRequest) (rsp *dto.URLResponse, err error)
 	DeleteImage(ctx context.Context, req *dto.DeleteImageRequest) (rsp *dto.EmptyResponse, err error)
 	ListUserViewArticles(ctx context.Context, req *dto.ListUserViewArticlesRequest) (rsp *dto.ListUserViewArticlesResponse, err error)
@@ -46,14 +99,20 @@ type AssetService interface {
 }
 
 type assetService struct {
-	userDAO         *dao.UserDAO
-	tagDAO          *dao.TagDAO
-	articleDAO      *dao.ArticleDAO
-	commentDAO      *dao.CommentDAO
-	userLikeDAO     *dao.UserLikeDAO
-	userViewDAO     *dao.UserViewDAO
-	imageObjDAO     objdao.ObjDAO
-	thumbnailObjDAO objdao.ObjDAO
+	userDAO                 *dao.UserDAO
+	tagDAO                  *dao.TagDAO
+	articleDAO              *dao.ArticleDAO
+	articleVersionDAO       *dao.ArticleVersionDAO
+	commentDAO              *dao.CommentDAO
+	userLikeDAO             *dao.UserLikeDAO
+	userViewDAO             *dao.UserViewDAO
+	assetDAO                *dao.AssetDAO
+	attachmentDAO           *dao.AttachmentDAO
+	imageObjDAO             objdao.ObjDAO
+	thumbnailObjDAO         objdao.ObjDAO
+	variantObjDAO           objdao.ObjDAO
+	attachmentObjDAO        objdao.ObjDAO
+	attachmentPreviewObjDAO objdao.ObjDAO
 }
 
 // NewAssetService 创建资产服务
@@ -63,14 +122,20 @@ type assetService struct {
 //	update 2025-01-05 16:41:39
 func NewAssetService() AssetService {
 	return &assetService{
-		userDAO:         dao.GetUserDAO(),
-		tagDAO:          dao.GetTagDAO(),
-		articleDAO:      dao.GetArticleDAO(),
-		commentDAO:      dao.GetCommentDAO(),
-		userLikeDAO:     dao.GetUserLikeDAO(),
-		userViewDAO:     dao.GetUserViewDAO(),
-		imageObjDAO:     objdao.GetImageObjDAO(),
-		thumbnailObjDAO: objdao.GetThumbnailObjDAO(),
+		userDAO:                 dao.GetUserDAO(),
+		tagDAO:                  dao.GetTagDAO(),
+		articleDAO:              dao.GetArticleDAO(),
+		articleVersionDAO:       dao.GetArticleVersionDAO(),
+		commentDAO:              dao.GetCommentDAO(),
+		userLikeDAO:             dao.GetUserLikeDAO(),
+		userViewDAO:             dao.GetUserViewDAO(),
+		assetDAO:                dao.GetAssetDAO(),
+		attachmentDAO:           dao.GetAttachmentDAO(),
+		imageObjDAO:             objdao.GetImageObjDAO(),
+		thumbnailObjDAO:         objdao.GetThumbnailObjDAO(),
+		variantObjDAO:           objdao.GetVariantObjDAO(),
+		attachmentObjDAO:        objdao.GetAttachmentObjDAO(),
+		attachmentPreviewObjDAO: objdao.GetAttachmentPreviewObjDAO(),
 	}
 }
 
@@ -144,6 +209,12 @@ func (s *assetService) ListUserLikeArticles(ctx context.Context, req *dto.ListUs
 		}
 	}
 
+	languages, err := s.articleVersionDAO.ListLanguagesByArticleIDs(db, articleIDs)
+	if err != nil {
+		logger.Error("[AssetService] failed to list article languages", zap.Uints("articleIDs", articleIDs), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
 	rsp.Articles = lo.Map(*articles, func(article model.Article, _ int) *dto.Article {
 		return &dto.Article{
 			ArticleID: article.ID,
@@ -168,6 +239,9 @@ func (s *assetService) ListUserLikeArticles(ctx context.Context, req *dto.ListUs
 				}
 			}),
 			Comments: len(article.Comments),
+			Languages: lo.Map(languages[article.ID], func(language model.Language, _ int) string {
+				return string(language)
+			}),
 		}
 	})
 	rsp.PageInfo = &dto.PageInfo{
@@ -331,46 +405,43 @@ func (s *assetService) ListUserLikeTags(ctx context.Context, req *dto.ListUserLi
 	return rsp, nil
 }
 
-// ListImages 列出图片
+// ListImages 列出图片，附带内容信息与预生成格式的响应式变体
 //
 //	receiver s *assetService
 //	param req *protocol.EmptyRequest
 //	return rsp *protocol.ListImagesResponse
 //	return err error
 //	author centonhuang
-//	update 2025-01-05 17:15:38
+//	update 2025-11-16 16:38:25
 func (s *assetService) ListImages(ctx context.Context, _ *dto.EmptyRequest) (rsp *dto.ListImagesResponse, err error) {
 	rsp = &dto.ListImagesResponse{}
 
 	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
 
 	userID := ctx.Value(constant.CtxKeyUserID).(uint)
 
-	objectInfos, err := s.imageObjDAO.ListObjects(ctx, userID)
+	assets, err := s.assetDAO.ListByUserID(db, userID, assetFields)
 	if err != nil {
 		logger.Error("[AssetService] failed to list images", zap.Error(err))
 		return nil, protocol.ErrInternalError
 	}
 
-	rsp.Images = lo.Map(objectInfos, func(objectInfo objdao.ObjectInfo, _ int) *dto.Image {
-		return &dto.Image{
-			Name:      objectInfo.ObjectName,
-			Size:      objectInfo.Size,
-			CreatedAt: objectInfo.LastModified.Format(time.DateTime),
-		}
+	rsp.Images = lo.Map(*assets, func(asset model.Asset, _ int) *dto.Image {
+		return imageFromAsset(&asset)
 	})
 
 	return rsp, nil
 }
 
-// UploadImage 上传图片
+// UploadImage 上传图片，去除元数据后按内容存储，同一用户内容相同的图片复用已存储的对象，新对象计入存储配额
 //
 //	receiver s *assetService
 //	param req *protocol.UploadImageRequest
 //	return rsp *protocol.EmptyResponse
 //	return err error
 //	author centonhuang
-//	update 2025-01-05 17:20:31
+//	update 2025-11-17 10:26:42
 func (s *assetService) UploadImage(ctx context.Context, req *dto.UploadImageRequest) (rsp *dto.EmptyResponse, err error) {
 	rsp = &dto.EmptyResponse{}
 
@@ -399,167 +470,779 @@ func (s *assetService) UploadImage(ctx context.Context, req *dto.UploadImageRequ
 		return nil, protocol.ErrBadRequest
 	}
 
-	var rawImage image.Image
-	var imageFormat imaging.Format
-
-	extension := strings.ToLower(filepath.Ext(fileName))
+	data, err := io.ReadAll(file)
+	if err != nil {
+		logger.Error("[AssetService] failed to read image", zap.String("fileName", fileName), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
 
-	switch extension {
-	case ".webp":
-		rawImage = lo.Must1(webp.Decode(file))
-		imageFormat = imaging.PNG
-	case ".png", ".jpg", ".jpeg", ".gif":
-		rawImage, _ = lo.Must2(image.Decode(file))
-		imageFormat = lo.Must1(imaging.FormatFromExtension(extension))
-	default:
-		panic(fmt.Sprintf("Invalid image extension: %s", extension))
+	asset, err := s.ingestImage(ctx, userID, fileName, data, true)
+	if err != nil {
+		return nil, s.mapIngestError(ctx, fileName, err)
 	}
 
-	// restrict image into 512*512 max size
-	x, y := rawImage.Bounds().Dx(), rawImage.Bounds().Dy()
+	logger.Info("[AssetService] image uploaded successfully",
+		zap.String("fileName", fileName),
+		zap.String("storageName", asset.StorageName),
+	)
+	return rsp, nil
+}
 
-	maxPixel := 512
+// GetImage 获取图片，缩略图尚未生成时返回原图
+//
+//	receiver s *assetService
+//	param req *protocol.GetImageRequest
+//	return rsp *protocol.GetImageResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-16 16:38:25
+func (s *assetService) GetImage(ctx context.Context, req *dto.GetImageRequest) (rsp *dto.URLResponse, err error) {
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
 
-	for x > maxPixel || y > maxPixel {
-		x, y = x/2, y/2
+	rsp = &dto.URLResponse{}
+
+	userID := ctx.Value(constant.CtxKeyUserID).(uint)
+
+	asset, err := s.assetDAO.GetByObjectName(db, userID, req.ObjectName, []string{"id", "storage_name"})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Error("[AssetService] image not found", zap.String("imageName", req.ObjectName))
+			return nil, protocol.ErrDataNotExists
+		}
+		logger.Error("[AssetService] failed to get image", zap.String("imageName", req.ObjectName), zap.Error(err))
+		return nil, protocol.ErrInternalError
 	}
 
-	thumbnailImage := imaging.Thumbnail(rawImage, x, y, imaging.Lanczos)
+	objDAO := s.imageObjDAO
+	if req.Quality != "raw" {
+		_, err = s.thumbnailObjDAO.StatObject(ctx, userID, asset.StorageName)
+		switch {
+		case err == nil:
+			objDAO = s.thumbnailObjDAO
+		case errors.Is(err, objdao.ErrObjectNotFound):
+			logger.Info("[AssetService] thumbnail not ready, fallback to raw image", zap.String("imageName", req.ObjectName))
+		default:
+			logger.Error("[AssetService] failed to stat thumbnail image", zap.String("imageName", req.ObjectName), zap.Error(err))
+			return nil, protocol.ErrInternalError
+		}
+	}
 
-	var thumbnailBuffer bytes.Buffer
-	err = imaging.Encode(&thumbnailBuffer, thumbnailImage, imageFormat)
+	presignedURL, err := objDAO.PresignObject(ctx, userID, asset.StorageName)
 	if err != nil {
-		logger.Error("[AssetService] failed to encode thumbnail image", zap.Error(err))
+		logger.Error("[AssetService] failed to presign object",
+			zap.String("imageName", req.ObjectName),
+			zap.String("quality", req.Quality), zap.Error(err))
 		return nil, protocol.ErrInternalError
 	}
-	file.Seek(0, io.SeekStart)
 
-	var wg sync.WaitGroup
-	var imageErr, thumbnailErr error
+	rsp.URL = presignedURL.String()
 
-	wg.Add(2)
+	logger.Info("[AssetService] presigned URL", zap.String("imageName", req.ObjectName), zap.String("quality", req.Quality), zap.String("url", rsp.URL))
 
-	go func() {
-		defer wg.Done()
-		imageErr = s.imageObjDAO.UploadObject(ctx, userID, fileName, fileSize, file)
-	}()
+	return rsp, nil
+}
 
-	go func() {
-		defer wg.Done()
-		thumbnailErr = s.thumbnailObjDAO.UploadObject(ctx, userID, fileName, int64(thumbnailBuffer.Len()), &thumbnailBuffer)
-	}()
+// PresignImageUpload 申请浏览器直传图片，剩余配额不足时拒绝，返回内容类型与大小已签名的暂存区上传链接
+//
+//	receiver s *assetService
+//	param ctx context.Context
+//	param req *dto.PresignImageUploadRequest
+//	return rsp *dto.PresignImageUploadResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-17 10:26:42
+func (s *assetService) PresignImageUpload(ctx context.Context, req *dto.PresignImageUploadRequest) (rsp *dto.PresignImageUploadResponse, err error) {
+	rsp = &dto.PresignImageUploadResponse{}
 
-	wg.Wait()
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
 
-	if imageErr != nil {
-		logger.Error("[AssetService] failed to upload image", zap.String("fileName", fileName), zap.Error(imageErr))
+	userID := ctx.Value(constant.CtxKeyUserID).(uint)
+
+	objectName := path.Base("/" + strings.ReplaceAll(req.Body.FileName, "\\", "/"))
+	if objectName == "/" || !util.IsValidImageFormat(objectName) || !isThumbnailSupported(strings.ToLower(filepath.Ext(objectName))) {
+		logger.Error("[AssetService] invalid image format", zap.String("fileName", req.Body.FileName))
+		return nil, protocol.ErrBadRequest
+	}
+
+	if !util.IsValidImageContentType(req.Body.ContentType) {
+		logger.Error("[AssetService] invalid image content type", zap.String("contentType", req.Body.ContentType))
+		return nil, protocol.ErrBadRequest
+	}
+
+	if req.Body.Size > directUploadMaxImageSize {
+		logger.Error("[AssetService] file size is too large", zap.Int64("fileSize", req.Body.Size), zap.Int64("maxFileSize", directUploadMaxImageSize))
+		return nil, protocol.ErrBadRequest
+	}
+
+	// 提前拒绝已被占用的名称，避免白白上传；内容相同的重复上传在确认时仍会成功
+	if _, err = s.assetDAO.GetByObjectName(db, userID, objectName, []string{"id"}); err == nil {
+		logger.Error("[AssetService] image name already exists", zap.String("objectName", objectName))
+		return nil, protocol.ErrDataExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		logger.Error("[AssetService] failed to get image", zap.String("objectName", objectName), zap.Error(err))
 		return nil, protocol.ErrInternalError
 	}
 
-	if thumbnailErr != nil {
-		logger.Error("[AssetService] failed to upload thumbnail image", zap.String("fileName", fileName), zap.Error(thumbnailErr))
+	// 去除元数据后的大小不会更大，按申请大小提前检查剩余配额
+	user, err := s.userDAO.GetByID(db, userID, []string{"id", "permission", "storage_usage"}, []string{})
+	if err != nil {
+		logger.Error("[AssetService] failed to get user", zap.Error(err))
 		return nil, protocol.ErrInternalError
 	}
+	if quota := model.PermissionStorageQuotaMapping[user.Permission]; user.StorageUsage+req.Body.Size > quota {
+		logger.Error("[AssetService] storage quota exceeded",
+			zap.Int64("usage", user.StorageUsage), zap.Int64("quota", quota), zap.Int64("size", req.Body.Size))
+		return nil, protocol.ErrStorageQuotaExceeded
+	}
 
-	logger.Info("[AssetService] image uploaded successfully",
-		zap.String("fileName", fileName),
-	)
+	uploadID := uuid.NewString()
+	stagingName := composeStagingImageName(uploadID, objectName)
+
+	upload, err := s.imageObjDAO.PresignUpload(ctx, userID, stagingName, &objdao.UploadConstraint{
+		ContentType: req.Body.ContentType,
+		Size:        req.Body.Size,
+	})
+	if err != nil {
+		logger.Error("[AssetService] failed to presign upload", zap.String("objectName", objectName), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	rsp.ObjectName = objectName
+	rsp.UploadID = uploadID
+	rsp.Upload = &dto.PresignedUpload{
+		Method:    upload.Method,
+		URL:       upload.URL,
+		Headers:   upload.Headers,
+		ExpiresAt: upload.ExpiresAt.Format(time.DateTime),
+	}
+
+	logger.Info("[AssetService] presigned image upload", zap.String("objectName", objectName), zap.String("uploadID", uploadID), zap.Int64("size", req.Body.Size))
 	return rsp, nil
 }
 
-// GetImage 获取图片
+// ConfirmImageUpload 确认直传图片已上传，去除元数据后从暂存区转存，缩略图在后台生成
 //
 //	receiver s *assetService
-//	param req *protocol.GetImageRequest
-//	return rsp *protocol.GetImageResponse
+//	param ctx context.Context
+//	param req *dto.ConfirmImageUploadRequest
+//	return rsp *dto.ConfirmImageUploadResponse
 //	return err error
 //	author centonhuang
-//	update 2025-01-05 17:49:39
-func (s *assetService) GetImage(ctx context.Context, req *dto.GetImageRequest) (rsp *dto.URLResponse, err error) {
+//	update 2025-11-16 16:38:25
+func (s *assetService) ConfirmImageUpload(ctx context.Context, req *dto.ConfirmImageUploadRequest) (rsp *dto.ConfirmImageUploadResponse, err error) {
+	rsp = &dto.ConfirmImageUploadResponse{}
+
 	logger := logger.WithCtx(ctx)
 
+	userID := ctx.Value(constant.CtxKeyUserID).(uint)
+
+	stagingName := composeStagingImageName(req.Body.UploadID, req.ObjectName)
+
+	objectInfo, err := s.imageObjDAO.StatObject(ctx, userID, stagingName)
+	if err != nil {
+		if errors.Is(err, objdao.ErrObjectNotFound) {
+			logger.Error("[AssetService] uploaded image not found", zap.String("objectName", req.ObjectName), zap.String("uploadID", req.Body.UploadID))
+			return nil, protocol.ErrDataNotExists
+		}
+		logger.Error("[AssetService] failed to stat uploaded image", zap.String("objectName", req.ObjectName), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	// 暂存对象无论转存成功与否均不再需要
+	defer func() {
+		if err := s.imageObjDAO.DeleteObject(ctx, userID, stagingName); err != nil {
+			logger.Error("[AssetService] failed to delete staging image", zap.String("stagingName", stagingName), zap.Error(err))
+		}
+	}()
+
+	contentType, _, _ := strings.Cut(objectInfo.ContentType, ";")
+	if objectInfo.Size > directUploadMaxImageSize ||
+		!util.IsValidImageContentType(strings.TrimSpace(contentType)) ||
+		!isThumbnailSupported(strings.ToLower(filepath.Ext(req.ObjectName))) {
+		logger.Error("[AssetService] uploaded object is not a valid image",
+			zap.String("objectName", req.ObjectName),
+			zap.String("contentType", objectInfo.ContentType),
+			zap.Int64("size", objectInfo.Size))
+		return nil, protocol.ErrBadRequest
+	}
+
+	var imageBuffer bytes.Buffer
+	if _, err = s.imageObjDAO.DownloadObject(ctx, userID, stagingName, &imageBuffer); err != nil {
+		logger.Error("[AssetService] failed to download uploaded image", zap.String("objectName", req.ObjectName), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	asset, err := s.ingestImage(ctx, userID, req.ObjectName, imageBuffer.Bytes(), true)
+	if err != nil {
+		return nil, s.mapIngestError(ctx, req.ObjectName, err)
+	}
+
+	rsp.Image = imageFromAsset(asset)
+
+	logger.Info("[AssetService] image upload confirmed",
+		zap.String("objectName", req.ObjectName),
+		zap.String("storageName", asset.StorageName),
+		zap.Int64("size", asset.Size))
+	return rsp, nil
+}
+
+// HandleImageThumbnailJob 处理图片的缩略图生成后台任务，计入存储用量后投递变体生成任务
+//
+//	receiver s *assetService
+//	param ctx context.Context
+//	param payload *job.ImageThumbnailPayload
+//	return err error
+//	author centonhuang
+//	update 2025-11-17 10:26:42
+func (s *assetService) HandleImageThumbnailJob(ctx context.Context, payload *job.ImageThumbnailPayload) (err error) {
+	logger := logger.WithCtx(ctx).With(zap.Uint("userID", payload.UserID), zap.String("objectName", payload.ObjectName))
+
+	if _, err = s.imageObjDAO.StatObject(ctx, payload.UserID, payload.ObjectName); err != nil {
+		if errors.Is(err, objdao.ErrObjectNotFound) {
+			logger.Warn("[AssetService] image not found, skip thumbnail job")
+			return nil
+		}
+		return err
+	}
+
+	var imageBuffer bytes.Buffer
+	if _, err = s.imageObjDAO.DownloadObject(ctx, payload.UserID, payload.ObjectName, &imageBuffer); err != nil {
+		return err
+	}
+
+	thumbnailBuffer, err := generateThumbnail(&imageBuffer, strings.ToLower(filepath.Ext(payload.ObjectName)))
+	if err != nil {
+		// 图片无法解码时重试无意义，原图保留，查看时回退到原图
+		logger.Error("[AssetService] failed to generate thumbnail image", zap.Error(err))
+		return nil
+	}
+
+	// 任务重试时覆盖已有缩略图，只计入大小差值
+	var previousSize int64
+	previous, err := s.thumbnailObjDAO.StatObject(ctx, payload.UserID, payload.ObjectName)
+	switch {
+	case err == nil:
+		previousSize = previous.Size
+	case !errors.Is(err, objdao.ErrObjectNotFound):
+		return err
+	}
+
+	thumbnailSize := int64(thumbnailBuffer.Len())
+	if err = s.thumbnailObjDAO.UploadObject(ctx, payload.UserID, payload.ObjectName, thumbnailSize, thumbnailBuffer); err != nil {
+		return err
+	}
+
+	// 缩略图体积很小且属于已接受的图片，只记账不检查配额
+	if err = s.userDAO.AdjustStorageUsage(database.GetDBInstance(ctx), payload.UserID, thumbnailSize-previousSize); err != nil {
+		logger.Error("[AssetService] failed to account thumbnail storage", zap.Error(err))
+	}
+
+	logger.Info("[AssetService] thumbnail generated", zap.Int64("size", thumbnailSize))
+
+	_, err = job.Enqueue(ctx, job.TypeImageVariants, &job.ImageVariantsPayload{UserID: payload.UserID, ObjectName: payload.ObjectName})
+	return err
+}
+
+// GetImageVariant 获取图片的响应式变体，缺失的变体即时生成并缓存
+//
+//	receiver s *assetService
+//	param ctx context.Context
+//	param req *dto.GetImageVariantRequest
+//	return rsp *dto.URLResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-16 16:38:25
+func (s *assetService) GetImageVariant(ctx context.Context, req *dto.GetImageVariantRequest) (rsp *dto.URLResponse, err error) {
 	rsp = &dto.URLResponse{}
 
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
 	userID := ctx.Value(constant.CtxKeyUserID).(uint)
 
-	for _, objDAO := range []objdao.ObjDAO{s.imageObjDAO, s.thumbnailObjDAO} {
-		exists, err := objDAO.CheckObjectExists(ctx, userID, req.ObjectName)
-		if err != nil {
-			logger.Error("[AssetService] failed to check object exists", zap.String("imageName", req.ObjectName), zap.Error(err))
-			return nil, protocol.ErrInternalError
-		}
-		if !exists {
-			logger.Error("[AssetService] object not found", zap.String("imageName", req.ObjectName))
+	asset, err := s.assetDAO.GetByObjectName(db, userID, req.ObjectName, []string{"id", "storage_name"})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Error("[AssetService] image not found", zap.String("imageName", req.ObjectName))
 			return nil, protocol.ErrDataNotExists
 		}
+		logger.Error("[AssetService] failed to get image", zap.String("imageName", req.ObjectName), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	if _, ok := imageVariantContentTypes[req.Format]; !ok {
+		logger.Error("[AssetService] invalid variant format", zap.String("format", req.Format))
+		return nil, protocol.ErrBadRequest
 	}
 
-	var presignedURL *url.URL
-	switch req.Quality {
-	case "thumb":
-		presignedURL, err = s.thumbnailObjDAO.PresignObject(ctx, userID, req.ObjectName)
-	case "raw":
-		presignedURL, err = s.imageObjDAO.PresignObject(ctx, userID, req.ObjectName)
+	width := snapImageVariantWidth(req.Width)
+	variantName := composeImageVariantName(asset.StorageName, width, req.Format)
+
+	_, err = s.variantObjDAO.StatObject(ctx, userID, variantName)
+	switch {
+	case err == nil:
+	case errors.Is(err, objdao.ErrObjectNotFound):
+		rawImage, err := s.downloadImage(ctx, userID, asset.StorageName)
+		if err != nil {
+			switch {
+			case errors.Is(err, errImageDecode):
+				logger.Error("[AssetService] failed to decode image", zap.String("imageName", req.ObjectName), zap.Error(err))
+				return nil, protocol.ErrBadRequest
+			case errors.Is(err, objdao.ErrObjectNotFound):
+				logger.Error("[AssetService] image object not found", zap.String("imageName", req.ObjectName), zap.String("storageName", asset.StorageName))
+				return nil, protocol.ErrDataNotExists
+			}
+			logger.Error("[AssetService] failed to download image", zap.String("imageName", req.ObjectName), zap.Error(err))
+			return nil, protocol.ErrInternalError
+		}
+
+		if err = s.renderImageVariant(ctx, userID, asset.StorageName, rawImage, width, req.Format); err != nil {
+			logger.Error("[AssetService] failed to render image variant", zap.String("variantName", variantName), zap.Error(err))
+			return nil, protocol.ErrInternalError
+		}
+		logger.Info("[AssetService] image variant rendered on demand", zap.String("variantName", variantName))
 	default:
-		presignedURL, err = s.imageObjDAO.PresignObject(ctx, userID, req.ObjectName)
+		logger.Error("[AssetService] failed to stat image variant", zap.String("variantName", variantName), zap.Error(err))
+		return nil, protocol.ErrInternalError
 	}
+
+	presignedURL, err := s.variantObjDAO.PresignObject(ctx, userID, variantName)
 	if err != nil {
-		logger.Error("[AssetService] failed to presign object",
-			zap.String("imageName", req.ObjectName),
-			zap.String("quality", req.Quality), zap.Error(err))
+		logger.Error("[AssetService] failed to presign object", zap.String("variantName", variantName), zap.Error(err))
 		return nil, protocol.ErrInternalError
 	}
 
 	rsp.URL = presignedURL.String()
+	return rsp, nil
+}
 
-	logger.Info("[AssetService] presigned URL", zap.String("imageName", req.ObjectName), zap.String("quality", req.Quality), zap.String("url", rsp.URL))
+// HandleImageVariantsJob 处理图片响应式变体生成后台任务，先清理旧变体再按配置生成
+//
+//	receiver s *assetService
+//	param ctx context.Context
+//	param payload *job.ImageVariantsPayload
+//	return err error
+//	author centonhuang
+//	update 2025-11-16 16:38:25
+func (s *assetService) HandleImageVariantsJob(ctx context.Context, payload *job.ImageVariantsPayload) (err error) {
+	logger := logger.WithCtx(ctx).With(zap.Uint("userID", payload.UserID), zap.String("objectName", payload.ObjectName))
 
-	return rsp, nil
+	// 任务重试或重新投递时，清理之前按需生成或未完成的变体
+	if err = s.deleteImageVariants(ctx, payload.UserID, payload.ObjectName); err != nil {
+		return err
+	}
+
+	if len(config.ImageVariantFormats) == 0 {
+		return nil
+	}
+
+	rawImage, err := s.downloadImage(ctx, payload.UserID, payload.ObjectName)
+	if err != nil {
+		switch {
+		case errors.Is(err, objdao.ErrObjectNotFound):
+			logger.Warn("[AssetService] image not found, skip variants job")
+			return nil
+		case errors.Is(err, errImageDecode):
+			logger.Error("[AssetService] failed to decode image, skip variants job", zap.Error(err))
+			return nil
+		}
+		return err
+	}
+
+	for _, format := range config.ImageVariantFormats {
+		for _, width := range config.ImageVariantWidths {
+			if err = s.renderImageVariant(ctx, payload.UserID, payload.ObjectName, rawImage, width, format); err != nil {
+				return err
+			}
+		}
+	}
+
+	logger.Info("[AssetService] image variants generated",
+		zap.Ints("widths", config.ImageVariantWidths),
+		zap.Strings("formats", config.ImageVariantFormats))
+	return nil
 }
 
-// DeleteImage 删除图片
+// BackfillImageAssets 为资产表上线前上传的图片补录资产，去除元数据后按内容转存并删除原对象
 //
 //	receiver s *assetService
-//	param req *protocol.DeleteImageRequest
-//	return rsp *protocol.DeleteImageResponse
+//	param ctx context.Context
+//	return backfilled int
 //	return err error
 //	author centonhuang
-//	update 2025-01-05 17:49:33
-func (s *assetService) DeleteImage(ctx context.Context, req *dto.DeleteImageRequest) (rsp *dto.EmptyResponse, err error) {
-	rsp = &dto.EmptyResponse{}
+//	update 2025-11-16 16:38:25
+func (s *assetService) BackfillImageAssets(ctx context.Context) (backfilled int, err error) {
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	userIDs, err := s.userDAO.ListIDs(db)
+	if err != nil {
+		return 0, fmt.Errorf("list users: %w", err)
+	}
 
+	for _, userID := range userIDs {
+		objectInfos, err := s.imageObjDAO.ListObjects(ctx, userID)
+		if err != nil {
+			return backfilled, fmt.Errorf("list images of user %d: %w", userID, err)
+		}
+
+		for _, objectInfo := range objectInfos {
+			legacyName := objectInfo.ObjectName
+
+			count, err := s.assetDAO.CountByStorageName(db, userID, legacyName)
+			if err != nil {
+				return backfilled, fmt.Errorf("count assets of %s: %w", legacyName, err)
+			}
+			if count > 0 {
+				continue
+			}
+
+			var imageBuffer bytes.Buffer
+			if _, err = s.imageObjDAO.DownloadObject(ctx, userID, legacyName, &imageBuffer); err != nil {
+				return backfilled, fmt.Errorf("download image %s: %w", legacyName, err)
+			}
+
+			// 历史图片已占用存储，补录时只记账不拒绝
+			asset, err := s.ingestImage(ctx, userID, legacyName, imageBuffer.Bytes(), false)
+			if err != nil {
+				// 无法解码的历史图片保持原样，不影响其余图片
+				logger.Error("[AssetService] failed to backfill image, skip",
+					zap.Uint("userID", userID), zap.String("objectName", legacyName), zap.Error(err))
+				continue
+			}
+
+			// 历史对象从未计入用量，删除时无需扣减
+			if asset.StorageName != legacyName {
+				if _, err = s.deleteImageObjects(ctx, userID, legacyName); err != nil {
+					return backfilled, fmt.Errorf("delete legacy image %s: %w", legacyName, err)
+				}
+			}
+			backfilled++
+		}
+	}
+
+	return backfilled, nil
+}
+
+// RecalculateStorageUsage 按对象存储中原图、缩略图、附件与附件预览图的实际大小校正用户的存储用量，userID为0时校正全部用户
+//
+//	receiver s *assetService
+//	param ctx context.Context
+//	param userID uint
+//	return users int
+//	return err error
+//	author centonhuang
+//	update 2025-11-18 10:21:37
+func (s *assetService) RecalculateStorageUsage(ctx context.Context, userID uint) (users int, err error) {
 	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
 
-	userID := ctx.Value(constant.CtxKeyUserID).(uint)
+	userIDs := []uint{userID}
+	if userID == 0 {
+		if userIDs, err = s.userDAO.ListIDs(db); err != nil {
+			return 0, fmt.Errorf("list users: %w", err)
+		}
+	}
 
-	var wg sync.WaitGroup
-	var imageErr, thumbnailErr error
+	sumSize := func(total int64, objectInfo objdao.ObjectInfo, _ int) int64 {
+		return total + objectInfo.Size
+	}
 
-	wg.Add(2)
+	for _, userID := range userIDs {
+		user, err := s.userDAO.GetByID(db, userID, []string{"id", "storage_usage"}, []string{})
+		if err != nil {
+			return users, fmt.Errorf("get user %d: %w", userID, err)
+		}
 
-	go func() {
-		defer wg.Done()
-		imageErr = s.imageObjDAO.DeleteObject(ctx, userID, req.ObjectName)
-	}()
+		var usage int64
+		for _, objDAO := range []objdao.ObjDAO{s.imageObjDAO, s.thumbnailObjDAO, s.attachmentObjDAO, s.attachmentPreviewObjDAO} {
+			objectInfos, err := objDAO.ListObjects(ctx, userID)
+			if err != nil {
+				return users, fmt.Errorf("list objects of user %d in %s: %w", userID, objDAO.GetBucketName(ctx), err)
+			}
+			usage += lo.Reduce(objectInfos, sumSize, 0)
+		}
+
+		if usage != user.StorageUsage {
+			if err = s.userDAO.SetStorageUsage(db, userID, usage); err != nil {
+				return users, fmt.Errorf("set storage usage of user %d: %w", userID, err)
+			}
+			logger.Info("[AssetService] storage usage corrected",
+				zap.Uint("userID", userID), zap.Int64("tracked", user.StorageUsage), zap.Int64("actual", usage))
+		}
+		users++
+	}
+
+	return users, nil
+}
+
+// ingestImage 去除图片元数据并按内容摘要存储，同一用户内容相同的图片复用已存储的对象且不重复计入用量；
+// 同名图片内容相同时返回已有资产，内容不同时返回errImageNameConflict，写入新对象超出配额时返回errStorageQuotaExceeded
+func (s *assetService) ingestImage(ctx context.Context, userID uint, objectName string, data []byte, enforceQuota bool) (asset *model.Asset, err error) {
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	extension := strings.ToLower(filepath.Ext(objectName))
+
+	stripped, err := util.StripImageMetadata(data, extension)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errImageDecode, err)
+	}
+
+	rawImage, err := decodeImage(bytes.NewReader(stripped), extension)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(stripped)
+	contentHash := hex.EncodeToString(sum[:])
+
+	existing, err := s.assetDAO.GetByObjectName(db, userID, objectName, assetFields)
+	switch {
+	case err == nil:
+		if existing.ContentHash == contentHash {
+			return existing, nil
+		}
+		return nil, errImageNameConflict
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, err
+	}
+
+	blurHash, dominantColor, err := summarizeImage(rawImage)
+	if err != nil {
+		return nil, err
+	}
+
+	asset = &model.Asset{
+		UserID:        userID,
+		ObjectName:    objectName,
+		ContentHash:   contentHash,
+		ContentType:   imageContentTypes[extension],
+		Size:          int64(len(stripped)),
+		Width:         rawImage.Bounds().Dx(),
+		Height:        rawImage.Bounds().Dy(),
+		BlurHash:      blurHash,
+		DominantColor: dominantColor,
+	}
+
+	stored := false
+	duplicate, err := s.assetDAO.GetByContentHash(db, userID, contentHash, []string{"id", "storage_name"})
+	switch {
+	case err == nil:
+		asset.StorageName = duplicate.StorageName
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		asset.StorageName = contentHash + extension
+		if err = s.reserveStorage(ctx, userID, asset.Size, enforceQuota); err != nil {
+			return nil, err
+		}
+		if err = s.imageObjDAO.UploadObject(ctx, userID, asset.StorageName, asset.Size, bytes.NewReader(stripped)); err != nil {
+			if err := s.userDAO.AdjustStorageUsage(db, userID, -asset.Size); err != nil {
+				logger.Error("[AssetService] failed to release reserved storage", zap.Int64("size", asset.Size), zap.Error(err))
+			}
+			return nil, err
+		}
+		stored = true
+	default:
+		return nil, err
+	}
+
+	if err = s.assetDAO.Create(db, asset); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, errImageNameConflict
+		}
+		return nil, err
+	}
+
+	// 复用的对象已有缩略图与变体；投递失败时查看回退到原图，变体在首次请求时生成
+	if stored {
+		if _, err := job.Enqueue(ctx, job.TypeImageThumbnail, &job.ImageThumbnailPayload{UserID: userID, ObjectName: asset.StorageName}); err != nil {
+			logger.Error("[AssetService] failed to enqueue thumbnail job", zap.String("storageName", asset.StorageName), zap.Error(err))
+		}
+	}
+
+	return asset, nil
+}
+
+// mapIngestError 记录图片入库错误并转换为协议错误
+func (s *assetService) mapIngestError(ctx context.Context, objectName string, err error) error {
+	logger := logger.WithCtx(ctx)
+
+	switch {
+	case errors.Is(err, errImageFetch):
+		logger.Error("[AssetService] failed to fetch remote image", zap.String("url", objectName), zap.Error(err))
+		return protocol.ErrBadRequest
+	case errors.Is(err, errImageDecode):
+		logger.Error("[AssetService] failed to decode image", zap.String("objectName", objectName), zap.Error(err))
+		return protocol.ErrBadRequest
+	case errors.Is(err, errImageNameConflict):
+		logger.Error("[AssetService] image name already used by different content", zap.String("objectName", objectName))
+		return protocol.ErrDataExists
+	case errors.Is(err, errStorageQuotaExceeded):
+		logger.Error("[AssetService] storage quota exceeded", zap.String("objectName", objectName))
+		return protocol.ErrStorageQuotaExceeded
+	default:
+		logger.Error("[AssetService] failed to store image", zap.String("objectName", objectName), zap.Error(err))
+		return protocol.ErrInternalError
+	}
+}
 
-	go func() {
+// reserveStorage 为即将写入的对象预占存储用量，enforceQuota为false时只记账不检查配额
+func (s *assetService) reserveStorage(ctx context.Context, userID uint, size int64, enforceQuota bool) error {
+	return reserveUserStorage(ctx, s.userDAO, userID, size, enforceQuota)
+}
+
+// reserveUserStorage 预占用户存储用量，超出权限对应的配额时返回errStorageQuotaExceeded
+func reserveUserStorage(ctx context.Context, userDAO *dao.UserDAO, userID uint, size int64, enforceQuota bool) error {
+	db := database.GetDBInstance(ctx)
+
+	if !enforceQuota {
+		return userDAO.AdjustStorageUsage(db, userID, size)
+	}
+
+	user, err := userDAO.GetByID(db, userID, []string{"id", "permission"}, []string{})
+	if err != nil {
+		return err
+	}
+
+	reserved, err := userDAO.ReserveStorage(db, userID, size, model.PermissionStorageQuotaMapping[user.Permission])
+	if err != nil {
+		return err
+	}
+	if !reserved {
+		return errStorageQuotaExceeded
+	}
+	return nil
+}
+
+// downloadImage 下载并解码原图
+func (s *assetService) downloadImage(ctx context.Context, userID uint, objectName string) (image.Image, error) {
+	if _, err := s.imageObjDAO.StatObject(ctx, userID, objectName); err != nil {
+		return nil, err
+	}
+
+	var imageBuffer bytes.Buffer
+	if _, err := s.imageObjDAO.DownloadObject(ctx, userID, objectName, &imageBuffer); err != nil {
+		return nil, err
+	}
+
+	return decodeImage(&imageBuffer, strings.ToLower(filepath.Ext(objectName)))
+}
+
+// renderImageVariant 将原图缩小到指定宽度（不放大）并编码上传
+func (s *assetService) renderImageVariant(ctx context.Context, userID uint, objectName string, rawImage image.Image, width int, format string) error {
+	resized := rawImage
+	if rawImage.Bounds().Dx() > width {
+		resized = imaging.Resize(rawImage, width, 0, imaging.Lanczos)
+	}
+
+	var variantBuffer bytes.Buffer
+	if err := encodeImageVariant(&variantBuffer, resized, format); err != nil {
+		return err
+	}
+
+	return s.variantObjDAO.UploadObject(ctx, userID, composeImageVariantName(objectName, width, format), int64(variantBuffer.Len()), &variantBuffer)
+}
+
+// deleteImageVariants 删除图片全部宽度与格式的变体，变体不存在时视为成功
+func (s *assetService) deleteImageVariants(ctx context.Context, userID uint, objectName string) error {
+	for format := range imageVariantContentTypes {
+		for _, width := range config.ImageVariantWidths {
+			if err := s.variantObjDAO.DeleteObject(ctx, userID, composeImageVariantName(objectName, width, format)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// deleteImageObjects 删除存储中的原图、缩略图与全部变体，返回原图与缩略图释放的字节数
+func (s *assetService) deleteImageObjects(ctx context.Context, userID uint, storageName string) (freed int64, err error) {
+	var wg sync.WaitGroup
+	var imageSize, thumbnailSize int64
+	var imageErr, thumbnailErr error
+
+	deleteObject := func(objDAO objdao.ObjDAO, size *int64, deleteErr *error) {
 		defer wg.Done()
-		thumbnailErr = s.thumbnailObjDAO.DeleteObject(ctx, userID, req.ObjectName)
-	}()
+		objectInfo, err := objDAO.StatObject(ctx, userID, storageName)
+		switch {
+		case err == nil:
+			*size = objectInfo.Size
+		case errors.Is(err, objdao.ErrObjectNotFound):
+			return
+		default:
+			*deleteErr = err
+			return
+		}
+		*deleteErr = objDAO.DeleteObject(ctx, userID, storageName)
+	}
 
+	wg.Add(2)
+	go deleteObject(s.imageObjDAO, &imageSize, &imageErr)
+	go deleteObject(s.thumbnailObjDAO, &thumbnailSize, &thumbnailErr)
 	wg.Wait()
 
 	if imageErr != nil {
-		logger.Error("[AssetService] failed to delete image", zap.String("imageName", req.ObjectName), zap.Error(imageErr))
+		return 0, fmt.Errorf("delete image: %w", imageErr)
+	}
+	if thumbnailErr != nil {
+		return imageSize, fmt.Errorf("delete thumbnail: %w", thumbnailErr)
+	}
+
+	return imageSize + thumbnailSize, s.deleteImageVariants(ctx, userID, storageName)
+}
+
+// DeleteImage 删除图片，没有其它同内容图片引用时一并删除存储的对象并释放用量
+//
+//	receiver s *assetService
+//	param req *protocol.DeleteImageRequest
+//	return rsp *protocol.DeleteImageResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-17 10:26:42
+func (s *assetService) DeleteImage(ctx context.Context, req *dto.DeleteImageRequest) (rsp *dto.EmptyResponse, err error) {
+	rsp = &dto.EmptyResponse{}
+
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	userID := ctx.Value(constant.CtxKeyUserID).(uint)
+
+	asset, err := s.assetDAO.GetByObjectName(db, userID, req.ObjectName, []string{"id", "storage_name"})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Error("[AssetService] image not found", zap.String("imageName", req.ObjectName))
+			return nil, protocol.ErrDataNotExists
+		}
+		logger.Error("[AssetService] failed to get image", zap.String("imageName", req.ObjectName), zap.Error(err))
 		return nil, protocol.ErrInternalError
 	}
 
-	if thumbnailErr != nil {
-		logger.Error("[AssetService] failed to delete thumbnail image", zap.String("imageName", req.ObjectName), zap.Error(thumbnailErr))
+	if err = s.assetDAO.HardDelete(db, asset); err != nil {
+		logger.Error("[AssetService] failed to delete image asset", zap.String("imageName", req.ObjectName), zap.Error(err))
 		return nil, protocol.ErrInternalError
 	}
 
-	logger.Info("[AssetService] image deleted successfully", zap.String("imageName", req.ObjectName))
+	references, err := s.assetDAO.CountByStorageName(db, userID, asset.StorageName)
+	if err != nil {
+		logger.Error("[AssetService] failed to count image references", zap.String("storageName", asset.StorageName), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	if references == 0 {
+		freed, err := s.deleteImageObjects(ctx, userID, asset.StorageName)
+		// 部分对象删除成功时也需扣减已释放的用量
+		if err := s.releaseStorage(ctx, userID, freed); err != nil {
+			logger.Error("[AssetService] failed to release storage usage", zap.Int64("freed", freed), zap.Error(err))
+		}
+		if err != nil {
+			logger.Error("[AssetService] failed to delete image objects", zap.String("storageName", asset.StorageName), zap.Error(err))
+			return nil, protocol.ErrInternalError
+		}
+	}
+
+	logger.Info("[AssetService] image deleted successfully", zap.String("imageName", req.ObjectName), zap.Int64("references", references))
 	return rsp, nil
 }
 
@@ -636,3 +1319,140 @@ func (s *assetService) DeleteUserView(ctx context.Context, req *dto.DeleteUserVi
 	logger.Info("[AssetService] user view deleted successfully", zap.Uint("viewID", req.ViewID))
 	return rsp, nil
 }
+
+func isThumbnailSupported(extension string) bool {
+	switch extension {
+	case ".webp", ".png", ".jpg", ".jpeg", ".gif":
+		return true
+	default:
+		return false
+	}
+}
+
+// decodeImage 按扩展名解码图片，失败时返回errImageDecode
+func decodeImage(reader io.Reader, extension string) (rawImage image.Image, err error) {
+	switch extension {
+	case ".webp":
+		rawImage, err = webp.Decode(reader)
+	case ".png", ".jpg", ".jpeg", ".gif":
+		rawImage, _, err = image.Decode(reader)
+	default:
+		err = fmt.Errorf("unsupported image extension: %s", extension)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errImageDecode, err)
+	}
+	return rawImage, nil
+}
+
+// generateThumbnail 将图片等比缩小到512*512以内，webp编码为png
+func generateThumbnail(reader io.Reader, extension string) (*bytes.Buffer, error) {
+	rawImage, err := decodeImage(reader, extension)
+	if err != nil {
+		return nil, err
+	}
+
+	imageFormat := imaging.PNG
+	if extension != ".webp" {
+		if imageFormat, err = imaging.FormatFromExtension(extension); err != nil {
+			return nil, err
+		}
+	}
+
+	// restrict image into 512*512 max size
+	x, y := rawImage.Bounds().Dx(), rawImage.Bounds().Dy()
+
+	maxPixel := 512
+
+	for x > maxPixel || y > maxPixel {
+		x, y = x/2, y/2
+	}
+
+	thumbnailImage := imaging.Thumbnail(rawImage, x, y, imaging.Lanczos)
+
+	var thumbnailBuffer bytes.Buffer
+	if err = imaging.Encode(&thumbnailBuffer, thumbnailImage, imageFormat); err != nil {
+		return nil, err
+	}
+	return &thumbnailBuffer, nil
+}
+
+// snapImageVariantWidth 向上取最近的配置宽度，限制变体数量，未指定或超过最大宽度时取最大宽度
+func snapImageVariantWidth(width int) int {
+	widths := config.ImageVariantWidths
+	if width > 0 {
+		if index := sort.SearchInts(widths, width); index < len(widths) {
+			return widths[index]
+		}
+	}
+	return widths[len(widths)-1]
+}
+
+func composeImageVariantName(objectName string, width int, format string) string {
+	return fmt.Sprintf("%s/%dw.%s", objectName, width, format)
+}
+
+func encodeImageVariant(writer io.Writer, img image.Image, format string) error {
+	switch format {
+	case "webp":
+		return gowebp.Encode(writer, img, gowebp.Options{Quality: config.ImageVariantQuality, Method: 4})
+	case "avif":
+		return avif.Encode(writer, img, avif.Options{Quality: config.ImageVariantQuality, Speed: 8})
+	case "jpeg":
+		return imaging.Encode(writer, img, imaging.JPEG, imaging.JPEGQuality(config.ImageVariantQuality))
+	case "png":
+		return imaging.Encode(writer, img, imaging.PNG)
+	default:
+		return fmt.Errorf("unsupported image variant format: %s", format)
+	}
+}
+
+// buildImageSrcSets 生成预生成格式的srcset，链接指向变体接口，缺失的变体在首次请求时生成
+func buildImageSrcSets(objectName string) []*dto.ImageSrcSet {
+	variantPath := fmt.Sprintf("/v1/asset/image/%s/variant", url.PathEscape(objectName))
+
+	return lo.Map(config.ImageVariantFormats, func(format string, _ int) *dto.ImageSrcSet {
+		candidates := lo.Map(config.ImageVariantWidths, func(width int, _ int) string {
+			return fmt.Sprintf("%s?w=%d&fmt=%s %dw", variantPath, width, format, width)
+		})
+		return &dto.ImageSrcSet{
+			Format: format,
+			Type:   imageVariantContentTypes[format],
+			SrcSet: strings.Join(candidates, ", "),
+		}
+	})
+}
+
+// composeStagingImageName 直传图片的暂存对象名，确认后转存为按内容命名的对象
+func composeStagingImageName(uploadID, objectName string) string {
+	return fmt.Sprintf("%s/%s/%s", stagingImageDir, uploadID, objectName)
+}
+
+// summarizeImage 计算BlurHash占位图与主色调，在缩小后的图片上计算以控制耗时
+func summarizeImage(img image.Image) (blurHash, dominantColor string, err error) {
+	small := imaging.Fit(img, 64, 64, imaging.Box)
+
+	if blurHash, err = blurhash.Encode(4, 3, small); err != nil {
+		return "", "", err
+	}
+
+	// 缩放到单个像素即为整体平均色
+	pixel := imaging.Resize(small, 1, 1, imaging.Box).NRGBAAt(0, 0)
+	dominantColor = fmt.Sprintf("#%02x%02x%02x", pixel.R, pixel.G, pixel.B)
+	return blurHash, dominantColor, nil
+}
+
+func imageFromAsset(asset *model.Asset) *dto.Image {
+	return &dto.Image{
+		Name:          asset.ObjectName,
+		Size:          asset.Size,
+		ContentType:   asset.ContentType,
+		Width:         asset.Width,
+		Height:        asset.Height,
+		Hash:          asset.ContentHash,
+		BlurHash:      asset.BlurHash,
+		DominantColor: asset.DominantColor,
+		CreatedAt:     asset.CreatedAt.Format(time.DateTime),
+		SrcSets:       buildImageSrcSets(asset.ObjectName),
+	}
+}
@@ -0,0 +1,355 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hcd233/aris-blog-api/internal/config"
+	"github.com/hcd233/aris-blog-api/internal/logger"
+	"github.com/hcd233/aris-blog-api/internal/resource/database"
+	"github.com/hcd233/aris-blog-api/internal/resource/database/dao"
+	"github.com/hcd233/aris-blog-api/internal/resource/database/model"
+	objdao "github.com/hcd233/aris-blog-api/internal/resource/storage/obj_dao"
+	"go.uber.org/zap"
+)
+
+// AssetGCResult 孤立图片回收结果，dry run时为将要回收的数量
+//
+//	author centonhuang
+//	update 2025-11-17 15:08:53
+type AssetGCResult struct {
+	Users       int
+	Failed      int
+	Assets      int
+	Images      int
+	Thumbnails  int
+	Attachments int
+	Staging     int
+	FreedBytes  int64
+}
+
+// imageReferences 用户全部文章版本内容与头像拼接成的文本，用于判断图片是否仍被引用
+type imageReferences string
+
+func newImageReferences(texts []string) imageReferences {
+	return imageReferences(strings.Join(texts, "\n"))
+}
+
+// contains 按原样、路径转义与查询转义匹配图片名，宁可多保留也不误删
+func (r imageReferences) contains(name string) bool {
+	text := string(r)
+	return strings.Contains(text, name) ||
+		strings.Contains(text, url.PathEscape(name)) ||
+		strings.Contains(text, url.QueryEscape(name))
+}
+
+// CollectOrphanedAssets 回收超过宽限期且未被文章内容或头像引用的图片，以及删除中途失败遗留的缩略图；
+// 同时回收已删除文章的附件、未确认上传遗留的附件对象与直传图片的暂存对象；dryRun为true时只报告不删除，单个用户失败时记录日志并继续处理其余用户
+//
+//	receiver s *assetService
+//	param ctx context.Context
+//	param dryRun bool
+//	return result *AssetGCResult
+//	return err error
+//	author centonhuang
+//	update 2025-11-20 15:48:26
+func (s *assetService) CollectOrphanedAssets(ctx context.Context, dryRun bool) (result *AssetGCResult, err error) {
+	logger := logger.WithCtx(ctx).With(zap.Bool("dryRun", dryRun))
+	db := database.GetDBInstance(ctx)
+
+	userIDs, err := s.userDAO.ListIDs(db)
+	if err != nil {
+		return nil, fmt.Errorf("list users: %w", err)
+	}
+
+	result = &AssetGCResult{}
+	cutoff := time.Now().Add(-config.AssetGCGracePeriod)
+
+	for _, userID := range userIDs {
+		if err := s.collectUserOrphanedAssets(ctx, userID, cutoff, dryRun, result); err != nil {
+			logger.Error("[AssetService] failed to collect orphaned assets", zap.Uint("userID", userID), zap.Error(err))
+			result.Failed++
+			continue
+		}
+		if err := s.collectUserOrphanedAttachments(ctx, userID, cutoff, dryRun, result); err != nil {
+			logger.Error("[AssetService] failed to collect orphaned attachments", zap.Uint("userID", userID), zap.Error(err))
+			result.Failed++
+			continue
+		}
+		if err := s.collectUserStagingImages(ctx, userID, cutoff, dryRun, result); err != nil {
+			logger.Error("[AssetService] failed to collect staging images", zap.Uint("userID", userID), zap.Error(err))
+			result.Failed++
+			continue
+		}
+		result.Users++
+	}
+
+	logger.Info("[AssetService] orphaned assets collected",
+		zap.Int("users", result.Users),
+		zap.Int("failed", result.Failed),
+		zap.Int("assets", result.Assets),
+		zap.Int("images", result.Images),
+		zap.Int("thumbnails", result.Thumbnails),
+		zap.Int("attachments", result.Attachments),
+		zap.Int("staging", result.Staging),
+		zap.Int64("freedBytes", result.FreedBytes))
+	return result, nil
+}
+
+// collectUserOrphanedAssets 回收单个用户的孤立图片，先删除资产记录，再删除不再被任何资产引用的对象
+func (s *assetService) collectUserOrphanedAssets(ctx context.Context, userID uint, cutoff time.Time, dryRun bool, result *AssetGCResult) error {
+	logger := logger.WithCtx(ctx).With(zap.Uint("userID", userID), zap.Bool("dryRun", dryRun))
+	db := database.GetDBInstance(ctx)
+
+	user, err := s.userDAO.GetByID(db, userID, []string{"id", "avatar"}, []string{})
+	if err != nil {
+		return fmt.Errorf("get user: %w", err)
+	}
+
+	contents, err := s.articleVersionDAO.ListContentsByUserID(db, userID)
+	if err != nil {
+		return fmt.Errorf("list article contents: %w", err)
+	}
+	references := newImageReferences(append(contents, user.Avatar))
+
+	assets, err := s.assetDAO.ListByUserID(db, userID, []string{"id", "created_at", "object_name", "storage_name"})
+	if err != nil {
+		return fmt.Errorf("list assets: %w", err)
+	}
+
+	// 内容相同的图片共用对象，只要有一条资产保留，对象就须保留
+	liveStorage := make(map[string]bool)
+	orphanedStorage := make(map[string]bool)
+	var orphans []model.Asset
+	for _, asset := range *assets {
+		if asset.CreatedAt.After(cutoff) || references.contains(asset.ObjectName) || references.contains(asset.StorageName) {
+			liveStorage[asset.StorageName] = true
+			continue
+		}
+		orphans = append(orphans, asset)
+		orphanedStorage[asset.StorageName] = true
+	}
+
+	images, err := s.imageObjDAO.ListObjects(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("list images: %w", err)
+	}
+
+	thumbnails, err := s.thumbnailObjDAO.ListObjects(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("list thumbnails: %w", err)
+	}
+	thumbnailSizes := make(map[string]int64, len(thumbnails))
+	for _, thumbnail := range thumbnails {
+		thumbnailSizes[thumbnail.ObjectName] = thumbnail.Size
+	}
+
+	for _, asset := range orphans {
+		logger.Info("[AssetService] orphaned image asset",
+			zap.String("objectName", asset.ObjectName),
+			zap.String("storageName", asset.StorageName),
+			zap.Time("createdAt", asset.CreatedAt))
+		if !dryRun {
+			if err = s.assetDAO.HardDelete(db, &asset); err != nil {
+				return fmt.Errorf("delete asset %s: %w", asset.ObjectName, err)
+			}
+		}
+		result.Assets++
+	}
+
+	imageNames := make(map[string]bool, len(images))
+	for _, image := range images {
+		imageNames[image.ObjectName] = true
+
+		if liveStorage[image.ObjectName] {
+			continue
+		}
+		// 没有资产记录的对象来自未完成的入库或未补录的历史图片，同样须超过宽限期且未被引用
+		if !orphanedStorage[image.ObjectName] && (image.LastModified.After(cutoff) || references.contains(image.ObjectName)) {
+			continue
+		}
+
+		logger.Info("[AssetService] orphaned image object",
+			zap.String("storageName", image.ObjectName),
+			zap.Int64("size", image.Size),
+			zap.Time("lastModified", image.LastModified))
+
+		freed := image.Size + thumbnailSizes[image.ObjectName]
+		if !dryRun {
+			// 删除资产记录后可能已有新上传的相同内容复用了该对象
+			count, err := s.assetDAO.CountByStorageName(db, userID, image.ObjectName)
+			if err != nil {
+				return fmt.Errorf("count assets of %s: %w", image.ObjectName, err)
+			}
+			if count > 0 {
+				continue
+			}
+
+			freed, err = s.deleteImageObjects(ctx, userID, image.ObjectName)
+			if releaseErr := s.releaseStorage(ctx, userID, freed); releaseErr != nil {
+				logger.Error("[AssetService] failed to release storage usage", zap.Int64("freed", freed), zap.Error(releaseErr))
+			}
+			if err != nil {
+				return fmt.Errorf("delete image objects %s: %w", image.ObjectName, err)
+			}
+		}
+		result.Images++
+		result.FreedBytes += freed
+	}
+
+	// 原图已不存在的缩略图，通常由删除图片中途失败遗留
+	for _, thumbnail := range thumbnails {
+		if imageNames[thumbnail.ObjectName] || thumbnail.LastModified.After(cutoff) {
+			continue
+		}
+
+		logger.Info("[AssetService] orphaned thumbnail object",
+			zap.String("storageName", thumbnail.ObjectName),
+			zap.Int64("size", thumbnail.Size),
+			zap.Time("lastModified", thumbnail.LastModified))
+
+		if !dryRun {
+			if err = s.thumbnailObjDAO.DeleteObject(ctx, userID, thumbnail.ObjectName); err != nil {
+				return fmt.Errorf("delete thumbnail %s: %w", thumbnail.ObjectName, err)
+			}
+			if err = s.releaseStorage(ctx, userID, thumbnail.Size); err != nil {
+				logger.Error("[AssetService] failed to release storage usage", zap.Int64("freed", thumbnail.Size), zap.Error(err))
+			}
+		}
+		result.Thumbnails++
+		result.FreedBytes += thumbnail.Size
+	}
+
+	return nil
+}
+
+// collectUserOrphanedAttachments 回收单个用户已删除文章的附件，以及没有附件记录且超过宽限期的附件对象与预览图
+func (s *assetService) collectUserOrphanedAttachments(ctx context.Context, userID uint, cutoff time.Time, dryRun bool, result *AssetGCResult) error {
+	logger := logger.WithCtx(ctx).With(zap.Uint("userID", userID), zap.Bool("dryRun", dryRun))
+	db := database.GetDBInstance(ctx)
+
+	deleted, err := s.attachmentDAO.ListOfDeletedArticles(db, userID, attachmentFields)
+	if err != nil {
+		return fmt.Errorf("list attachments of deleted articles: %w", err)
+	}
+	for _, attachment := range *deleted {
+		logger.Info("[AssetService] attachment of deleted article",
+			zap.Uint("articleID", attachment.ArticleID),
+			zap.String("fileName", attachment.FileName),
+			zap.String("storageName", attachment.StorageName))
+
+		freed := attachment.Size + attachment.PreviewSize
+		if !dryRun {
+			if err = s.attachmentDAO.HardDelete(db, &attachment); err != nil {
+				return fmt.Errorf("delete attachment %d: %w", attachment.ID, err)
+			}
+			freed, err = s.deleteAttachmentObjects(ctx, &attachment)
+			if releaseErr := s.releaseStorage(ctx, userID, freed); releaseErr != nil {
+				logger.Error("[AssetService] failed to release storage usage", zap.Int64("freed", freed), zap.Error(releaseErr))
+			}
+			if err != nil {
+				return fmt.Errorf("delete attachment objects %s: %w", attachment.StorageName, err)
+			}
+		}
+		result.Attachments++
+		result.FreedBytes += freed
+	}
+
+	attachments, err := s.attachmentDAO.ListByUserID(db, userID, []string{"id", "storage_name", "preview_name"})
+	if err != nil {
+		return fmt.Errorf("list attachments: %w", err)
+	}
+	liveObjects := make(map[string]bool, 2*len(*attachments))
+	for _, attachment := range *attachments {
+		liveObjects[attachment.StorageName] = true
+		// 预览图在记录写入前上传，按附件名推出的预览图名同样保留
+		liveObjects[composeAttachmentPreviewName(attachment.StorageName)] = true
+	}
+
+	// 未确认的直传与确认失败后未能删除的对象均没有附件记录
+	for _, objDAO := range []objdao.ObjDAO{s.attachmentObjDAO, s.attachmentPreviewObjDAO} {
+		objectInfos, err := objDAO.ListObjects(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("list objects in %s: %w", objDAO.GetBucketName(ctx), err)
+		}
+		for _, objectInfo := range objectInfos {
+			if liveObjects[objectInfo.ObjectName] || objectInfo.LastModified.After(cutoff) {
+				continue
+			}
+
+			logger.Info("[AssetService] orphaned attachment object",
+				zap.String("bucket", objDAO.GetBucketName(ctx)),
+				zap.String("storageName", objectInfo.ObjectName),
+				zap.Int64("size", objectInfo.Size),
+				zap.Time("lastModified", objectInfo.LastModified))
+
+			if !dryRun {
+				if err = objDAO.DeleteObject(ctx, userID, objectInfo.ObjectName); err != nil {
+					return fmt.Errorf("delete attachment object %s: %w", objectInfo.ObjectName, err)
+				}
+				// 未确认的直传未计入用量，只有预览图在上传时已计入
+				if objDAO == s.attachmentPreviewObjDAO {
+					if err = s.releaseStorage(ctx, userID, objectInfo.Size); err != nil {
+						logger.Error("[AssetService] failed to release storage usage", zap.Int64("freed", objectInfo.Size), zap.Error(err))
+					}
+				}
+			}
+			result.Attachments++
+			result.FreedBytes += objectInfo.Size
+		}
+	}
+
+	return nil
+}
+
+// collectUserStagingImages 回收超过宽限期仍未确认的直传图片暂存对象，ListObjects不列出子目录，须单独扫描暂存目录
+func (s *assetService) collectUserStagingImages(ctx context.Context, userID uint, cutoff time.Time, dryRun bool, result *AssetGCResult) error {
+	logger := logger.WithCtx(ctx).With(zap.Uint("userID", userID), zap.Bool("dryRun", dryRun))
+
+	objectInfos, err := s.imageObjDAO.ListDirObjects(ctx, userID, stagingImageDir)
+	if err != nil {
+		return fmt.Errorf("list staging images: %w", err)
+	}
+
+	for _, objectInfo := range objectInfos {
+		if objectInfo.LastModified.After(cutoff) {
+			continue
+		}
+
+		logger.Info("[AssetService] abandoned staging image",
+			zap.String("stagingName", objectInfo.ObjectName),
+			zap.Int64("size", objectInfo.Size),
+			zap.Time("lastModified", objectInfo.LastModified))
+
+		// 暂存对象在预签名时只检查配额不计入用量，删除后无需扣减
+		if !dryRun {
+			if err = s.imageObjDAO.DeleteObject(ctx, userID, objectInfo.ObjectName); err != nil {
+				return fmt.Errorf("delete staging image %s: %w", objectInfo.ObjectName, err)
+			}
+		}
+		result.Staging++
+		result.FreedBytes += objectInfo.Size
+	}
+
+	return nil
+}
+
+// deleteAttachmentObjects 删除附件对象与预览图，返回已释放的字节数
+func (s *assetService) deleteAttachmentObjects(ctx context.Context, attachment *model.Attachment) (freed int64, err error) {
+	return deleteAttachmentObjects(ctx, s.attachmentObjDAO, s.attachmentPreviewObjDAO, attachment)
+}
+
+// releaseStorage 扣减已删除对象占用的存储用量
+func (s *assetService) releaseStorage(ctx context.Context, userID uint, freed int64) error {
+	return releaseUserStorage(ctx, s.userDAO, userID, freed)
+}
+
+func releaseUserStorage(ctx context.Context, userDAO *dao.UserDAO, userID uint, freed int64) error {
+	if freed <= 0 {
+		return nil
+	}
+	return userDAO.AdjustStorageUsage(database.GetDBInstance(ctx), userID, -freed)
+}
@@ -0,0 +1,625 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/disintegration/imaging"
+	"github.com/google/uuid"
+	"github.com/hcd233/aris-blog-api/internal/constant"
+	"github.com/hcd233/aris-blog-api/internal/job"
+	"github.com/hcd233/aris-blog-api/internal/logger"
+	"github.com/hcd233/aris-blog-api/internal/protocol"
+	"github.com/hcd233/aris-blog-api/internal/protocol/dto"
+	"github.com/hcd233/aris-blog-api/internal/resource/database"
+	"github.com/hcd233/aris-blog-api/internal/resource/database/dao"
+	"github.com/hcd233/aris-blog-api/internal/resource/database/model"
+	objdao "github.com/hcd233/aris-blog-api/internal/resource/storage/obj_dao"
+	"github.com/hcd233/aris-blog-api/internal/util"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	attachmentPreviewMaxPixel    = 640
+	attachmentPreviewJPEGQuality = 85
+	attachmentPreviewExtension   = ".jpg"
+
+	// 超过该大小的附件不生成预览，下载须在对象存储超时内完成
+	attachmentPreviewMaxSourceSize int64 = 100 << 20
+)
+
+var errAttachmentHeadRead = errors.New("attachment head read")
+
+var attachmentFields = []string{
+	"id", "created_at", "user_id", "article_id", "file_name", "storage_name",
+	"kind", "content_type", "size", "preview_name", "preview_size",
+}
+
+// attachmentFormat 按扩展名登记的附件格式，文件头识别出的类型须在sniffed之中
+type attachmentFormat struct {
+	kind        model.AttachmentKind
+	contentType string
+	sniffed     []string
+}
+
+var attachmentFormats = map[string]*attachmentFormat{
+	".pdf":  {model.AttachmentKindDocument, "application/pdf", []string{"application/pdf"}},
+	".docx": {model.AttachmentKindDocument, "application/vnd.openxmlformats-officedocument.wordprocessingml.document", []string{"application/zip"}},
+	".pptx": {model.AttachmentKindDocument, "application/vnd.openxmlformats-officedocument.presentationml.presentation", []string{"application/zip"}},
+	".xlsx": {model.AttachmentKindDocument, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", []string{"application/zip"}},
+	".odp":  {model.AttachmentKindDocument, "application/vnd.oasis.opendocument.presentation", []string{"application/zip"}},
+	".key":  {model.AttachmentKindDocument, "application/vnd.apple.keynote", []string{"application/zip"}},
+	".mp3":  {model.AttachmentKindAudio, "audio/mpeg", []string{"audio/mpeg"}},
+	".m4a":  {model.AttachmentKindAudio, "audio/mp4", []string{"audio/mp4", "video/mp4"}},
+	".flac": {model.AttachmentKindAudio, "audio/flac", []string{"audio/flac"}},
+	".ogg":  {model.AttachmentKindAudio, "audio/ogg", []string{"audio/ogg"}},
+	".wav":  {model.AttachmentKindAudio, "audio/wav", []string{"audio/wav"}},
+	".mp4":  {model.AttachmentKindVideo, "video/mp4", []string{"video/mp4", "audio/mp4"}},
+	".m4v":  {model.AttachmentKindVideo, "video/mp4", []string{"video/mp4"}},
+	".mov":  {model.AttachmentKindVideo, "video/quicktime", []string{"video/quicktime", "video/mp4"}},
+	".webm": {model.AttachmentKindVideo, "video/webm", []string{"video/webm"}},
+	".mkv":  {model.AttachmentKindVideo, "video/x-matroska", []string{"video/x-matroska", "video/webm"}},
+	".zip":  {model.AttachmentKindArchive, "application/zip", []string{"application/zip"}},
+	".gz":   {model.AttachmentKindArchive, "application/gzip", []string{"application/gzip"}},
+	".tgz":  {model.AttachmentKindArchive, "application/gzip", []string{"application/gzip"}},
+	".tar":  {model.AttachmentKindArchive, "application/x-tar", []string{"application/x-tar"}},
+	".7z":   {model.AttachmentKindArchive, "application/x-7z-compressed", []string{"application/x-7z-compressed"}},
+	".rar":  {model.AttachmentKindArchive, "application/vnd.rar", []string{"application/vnd.rar"}},
+}
+
+// 可以在纯Go中提取预览图的内容类型
+var attachmentPreviewContentTypes = []string{"application/pdf", "audio/mpeg", "audio/mp4", "video/mp4", "video/quicktime"}
+
+// headWriter 只保留对象开头的若干字节，写满后返回errAttachmentHeadRead中止下载
+type headWriter struct {
+	buffer []byte
+	limit  int
+}
+
+func (w *headWriter) Write(p []byte) (int, error) {
+	n := min(len(p), w.limit-len(w.buffer))
+	w.buffer = append(w.buffer, p[:n]...)
+	if len(w.buffer) >= w.limit {
+		return n, errAttachmentHeadRead
+	}
+	return n, nil
+}
+
+// AttachmentService 文章附件服务
+//
+//	author centonhuang
+//	update 2025-11-18 10:21:37
+type AttachmentService interface {
+	ListArticleAttachments(ctx context.Context, req *dto.ListArticleAttachmentsRequest) (rsp *dto.ListArticleAttachmentsResponse, err error)
+	PresignAttachmentUpload(ctx context.Context, req *dto.PresignAttachmentUploadRequest) (rsp *dto.PresignAttachmentUploadResponse, err error)
+	ConfirmAttachmentUpload(ctx context.Context, req *dto.ConfirmAttachmentUploadRequest) (rsp *dto.ConfirmAttachmentUploadResponse, err error)
+	GetAttachment(ctx context.Context, req *dto.GetAttachmentRequest) (rsp *dto.URLResponse, err error)
+	DeleteAttachment(ctx context.Context, req *dto.DeleteAttachmentRequest) (rsp *dto.EmptyResponse, err error)
+	HandleAttachmentPreviewJob(ctx context.Context, payload *job.AttachmentPreviewPayload) (err error)
+}
+
+type attachmentService struct {
+	userDAO                 *dao.UserDAO
+	articleDAO              *dao.ArticleDAO
+	attachmentDAO           *dao.AttachmentDAO
+	attachmentObjDAO        objdao.ObjDAO
+	attachmentPreviewObjDAO objdao.ObjDAO
+}
+
+// NewAttachmentService 创建文章附件服务
+//
+//	return AttachmentService
+//	author centonhuang
+//	update 2025-11-18 10:21:37
+func NewAttachmentService() AttachmentService {
+	return &attachmentService{
+		userDAO:                 dao.GetUserDAO(),
+		articleDAO:              dao.GetArticleDAO(),
+		attachmentDAO:           dao.GetAttachmentDAO(),
+		attachmentObjDAO:        objdao.GetAttachmentObjDAO(),
+		attachmentPreviewObjDAO: objdao.GetAttachmentPreviewObjDAO(),
+	}
+}
+
+// ListArticleAttachments 列出文章的附件，已发布文章对所有人可见，草稿仅作者可见
+//
+//	receiver s *attachmentService
+//	param ctx context.Context
+//	param req *dto.ListArticleAttachmentsRequest
+//	return rsp *dto.ListArticleAttachmentsResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-18 10:21:37
+func (s *attachmentService) ListArticleAttachments(ctx context.Context, req *dto.ListArticleAttachmentsRequest) (rsp *dto.ListArticleAttachmentsResponse, err error) {
+	rsp = &dto.ListArticleAttachmentsResponse{}
+
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	if _, err = s.getArticle(ctx, req.ArticleID, false); err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.attachmentDAO.ListByArticleID(db, req.ArticleID, attachmentFields)
+	if err != nil {
+		logger.Error("[AttachmentService] failed to list attachments", zap.Uint("articleID", req.ArticleID), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	rsp.Attachments = lo.Map(*attachments, func(attachment model.Attachment, _ int) *dto.Attachment {
+		return attachmentToDTO(&attachment)
+	})
+	return rsp, nil
+}
+
+// PresignAttachmentUpload 申请浏览器直传附件，按扩展名确定类别与大小上限，剩余配额不足时拒绝
+//
+//	receiver s *attachmentService
+//	param ctx context.Context
+//	param req *dto.PresignAttachmentUploadRequest
+//	return rsp *dto.PresignAttachmentUploadResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-18 10:21:37
+func (s *attachmentService) PresignAttachmentUpload(ctx context.Context, req *dto.PresignAttachmentUploadRequest) (rsp *dto.PresignAttachmentUploadResponse, err error) {
+	rsp = &dto.PresignAttachmentUploadResponse{}
+
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	userID := ctx.Value(constant.CtxKeyUserID).(uint)
+
+	if _, err = s.getArticle(ctx, req.ArticleID, true); err != nil {
+		return nil, err
+	}
+
+	fileName, format, ok := parseAttachmentFileName(req.Body.FileName)
+	if !ok {
+		logger.Error("[AttachmentService] unsupported attachment format", zap.String("fileName", req.Body.FileName))
+		return nil, protocol.ErrBadRequest
+	}
+
+	if maxSize := model.AttachmentKindMaxSizeMapping[format.kind]; req.Body.Size > maxSize {
+		logger.Error("[AttachmentService] file size is too large",
+			zap.String("kind", string(format.kind)), zap.Int64("fileSize", req.Body.Size), zap.Int64("maxFileSize", maxSize))
+		return nil, protocol.ErrBadRequest
+	}
+
+	if _, err = s.attachmentDAO.GetByFileName(db, req.ArticleID, fileName, []string{"id"}); err == nil {
+		logger.Error("[AttachmentService] attachment name already exists", zap.Uint("articleID", req.ArticleID), zap.String("fileName", fileName))
+		return nil, protocol.ErrDataExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		logger.Error("[AttachmentService] failed to get attachment", zap.String("fileName", fileName), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	user, err := s.userDAO.GetByID(db, userID, []string{"id", "permission", "storage_usage"}, []string{})
+	if err != nil {
+		logger.Error("[AttachmentService] failed to get user", zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+	if quota := model.PermissionStorageQuotaMapping[user.Permission]; user.StorageUsage+req.Body.Size > quota {
+		logger.Error("[AttachmentService] storage quota exceeded",
+			zap.Int64("usage", user.StorageUsage), zap.Int64("quota", quota), zap.Int64("size", req.Body.Size))
+		return nil, protocol.ErrStorageQuotaExceeded
+	}
+
+	uploadID := uuid.NewString()
+	upload, err := s.attachmentObjDAO.PresignUpload(ctx, userID, composeAttachmentStorageName(uploadID, fileName), &objdao.UploadConstraint{
+		ContentType: format.contentType,
+		Size:        req.Body.Size,
+	})
+	if err != nil {
+		logger.Error("[AttachmentService] failed to presign upload", zap.String("fileName", fileName), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	rsp.FileName = fileName
+	rsp.UploadID = uploadID
+	rsp.Upload = &dto.PresignedUpload{
+		Method:    upload.Method,
+		URL:       upload.URL,
+		Headers:   upload.Headers,
+		ExpiresAt: upload.ExpiresAt.Format(time.DateTime),
+	}
+
+	logger.Info("[AttachmentService] presigned attachment upload",
+		zap.Uint("articleID", req.ArticleID), zap.String("fileName", fileName), zap.String("uploadID", uploadID), zap.Int64("size", req.Body.Size))
+	return rsp, nil
+}
+
+// ConfirmAttachmentUpload 确认直传附件已上传，按文件头识别真实类型并校验大小与配额，不合格的对象随即删除；预览图在后台生成
+//
+//	receiver s *attachmentService
+//	param ctx context.Context
+//	param req *dto.ConfirmAttachmentUploadRequest
+//	return rsp *dto.ConfirmAttachmentUploadResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-18 10:21:37
+func (s *attachmentService) ConfirmAttachmentUpload(ctx context.Context, req *dto.ConfirmAttachmentUploadRequest) (rsp *dto.ConfirmAttachmentUploadResponse, err error) {
+	rsp = &dto.ConfirmAttachmentUploadResponse{}
+
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	userID := ctx.Value(constant.CtxKeyUserID).(uint)
+
+	if _, err = s.getArticle(ctx, req.ArticleID, true); err != nil {
+		return nil, err
+	}
+
+	fileName, format, ok := parseAttachmentFileName(req.Body.FileName)
+	if !ok {
+		logger.Error("[AttachmentService] unsupported attachment format", zap.String("fileName", req.Body.FileName))
+		return nil, protocol.ErrBadRequest
+	}
+	storageName := composeAttachmentStorageName(req.Body.UploadID, fileName)
+
+	existing, err := s.attachmentDAO.GetByFileName(db, req.ArticleID, fileName, attachmentFields)
+	switch {
+	case err == nil && existing.StorageName == storageName:
+		// 重复确认同一次上传
+		rsp.Attachment = attachmentToDTO(existing)
+		return rsp, nil
+	case err == nil:
+		logger.Error("[AttachmentService] attachment name already exists", zap.Uint("articleID", req.ArticleID), zap.String("fileName", fileName))
+		return nil, protocol.ErrDataExists
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		logger.Error("[AttachmentService] failed to get attachment", zap.String("fileName", fileName), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	objectInfo, err := s.attachmentObjDAO.StatObject(ctx, userID, storageName)
+	if err != nil {
+		if errors.Is(err, objdao.ErrObjectNotFound) {
+			logger.Error("[AttachmentService] uploaded attachment not found", zap.String("fileName", fileName), zap.String("uploadID", req.Body.UploadID))
+			return nil, protocol.ErrDataNotExists
+		}
+		logger.Error("[AttachmentService] failed to stat uploaded attachment", zap.String("fileName", fileName), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	// 确认失败的上传不再需要
+	confirmed := false
+	defer func() {
+		if confirmed {
+			return
+		}
+		if err := s.attachmentObjDAO.DeleteObject(ctx, userID, storageName); err != nil {
+			logger.Error("[AttachmentService] failed to delete rejected attachment", zap.String("storageName", storageName), zap.Error(err))
+		}
+	}()
+
+	if maxSize := model.AttachmentKindMaxSizeMapping[format.kind]; objectInfo.Size > maxSize {
+		logger.Error("[AttachmentService] file size is too large",
+			zap.String("kind", string(format.kind)), zap.Int64("fileSize", objectInfo.Size), zap.Int64("maxFileSize", maxSize))
+		return nil, protocol.ErrBadRequest
+	}
+
+	head := &headWriter{limit: util.AttachmentSniffLength}
+	if _, err = s.attachmentObjDAO.DownloadObject(ctx, userID, storageName, head); err != nil && !errors.Is(err, errAttachmentHeadRead) {
+		logger.Error("[AttachmentService] failed to read uploaded attachment", zap.String("fileName", fileName), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+	if sniffed := util.SniffAttachmentContentType(head.buffer); !slices.Contains(format.sniffed, sniffed) {
+		logger.Error("[AttachmentService] attachment content does not match its extension",
+			zap.String("fileName", fileName), zap.String("sniffed", sniffed))
+		return nil, protocol.ErrBadRequest
+	}
+
+	if err = reserveUserStorage(ctx, s.userDAO, userID, objectInfo.Size, true); err != nil {
+		if errors.Is(err, errStorageQuotaExceeded) {
+			logger.Error("[AttachmentService] storage quota exceeded", zap.String("fileName", fileName), zap.Int64("size", objectInfo.Size))
+			return nil, protocol.ErrStorageQuotaExceeded
+		}
+		logger.Error("[AttachmentService] failed to reserve storage", zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	attachment := &model.Attachment{
+		UserID:      userID,
+		ArticleID:   req.ArticleID,
+		FileName:    fileName,
+		StorageName: storageName,
+		Kind:        format.kind,
+		ContentType: format.contentType,
+		Size:        objectInfo.Size,
+	}
+	if err = s.attachmentDAO.Create(db, attachment); err != nil {
+		if err := s.userDAO.AdjustStorageUsage(db, userID, -objectInfo.Size); err != nil {
+			logger.Error("[AttachmentService] failed to release reserved storage", zap.Int64("size", objectInfo.Size), zap.Error(err))
+		}
+		logger.Error("[AttachmentService] failed to create attachment", zap.String("fileName", fileName), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+	confirmed = true
+
+	if slices.Contains(attachmentPreviewContentTypes, attachment.ContentType) && attachment.Size <= attachmentPreviewMaxSourceSize {
+		if _, err := job.Enqueue(ctx, job.TypeAttachmentPreview, &job.AttachmentPreviewPayload{AttachmentID: attachment.ID}); err != nil {
+			logger.Error("[AttachmentService] failed to enqueue preview job", zap.Uint("attachmentID", attachment.ID), zap.Error(err))
+		}
+	}
+
+	rsp.Attachment = attachmentToDTO(attachment)
+
+	logger.Info("[AttachmentService] attachment upload confirmed",
+		zap.Uint("articleID", req.ArticleID),
+		zap.String("fileName", fileName),
+		zap.String("contentType", attachment.ContentType),
+		zap.Int64("size", attachment.Size))
+	return rsp, nil
+}
+
+// GetAttachment 获取附件或其预览图的预签名链接
+//
+//	receiver s *attachmentService
+//	param ctx context.Context
+//	param req *dto.GetAttachmentRequest
+//	return rsp *dto.URLResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-18 10:21:37
+func (s *attachmentService) GetAttachment(ctx context.Context, req *dto.GetAttachmentRequest) (rsp *dto.URLResponse, err error) {
+	rsp = &dto.URLResponse{}
+
+	logger := logger.WithCtx(ctx)
+
+	if _, err = s.getArticle(ctx, req.ArticleID, false); err != nil {
+		return nil, err
+	}
+
+	attachment, err := s.getAttachment(ctx, req.ArticleID, req.AttachmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	objDAO, objectName := s.attachmentObjDAO, attachment.StorageName
+	if req.Preview {
+		if attachment.PreviewName == "" {
+			logger.Error("[AttachmentService] attachment preview not available", zap.Uint("attachmentID", attachment.ID))
+			return nil, protocol.ErrDataNotExists
+		}
+		objDAO, objectName = s.attachmentPreviewObjDAO, attachment.PreviewName
+	}
+
+	// 对象存放在上传者目录下
+	presignedURL, err := objDAO.PresignObject(ctx, attachment.UserID, objectName)
+	if err != nil {
+		logger.Error("[AttachmentService] failed to presign object", zap.Uint("attachmentID", attachment.ID), zap.Bool("preview", req.Preview), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+	rsp.URL = presignedURL.String()
+
+	return rsp, nil
+}
+
+// DeleteAttachment 删除附件及其预览图并释放存储用量
+//
+//	receiver s *attachmentService
+//	param ctx context.Context
+//	param req *dto.DeleteAttachmentRequest
+//	return rsp *dto.EmptyResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-18 10:21:37
+func (s *attachmentService) DeleteAttachment(ctx context.Context, req *dto.DeleteAttachmentRequest) (rsp *dto.EmptyResponse, err error) {
+	rsp = &dto.EmptyResponse{}
+
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	if _, err = s.getArticle(ctx, req.ArticleID, true); err != nil {
+		return nil, err
+	}
+
+	attachment, err := s.getAttachment(ctx, req.ArticleID, req.AttachmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.attachmentDAO.HardDelete(db, attachment); err != nil {
+		logger.Error("[AttachmentService] failed to delete attachment", zap.Uint("attachmentID", attachment.ID), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	// 对象删除失败时由孤立对象回收兜底
+	freed, err := s.deleteAttachmentObjects(ctx, attachment)
+	if err := releaseUserStorage(ctx, s.userDAO, attachment.UserID, freed); err != nil {
+		logger.Error("[AttachmentService] failed to release storage usage", zap.Int64("freed", freed), zap.Error(err))
+	}
+	if err != nil {
+		logger.Error("[AttachmentService] failed to delete attachment objects", zap.Uint("attachmentID", attachment.ID), zap.Error(err))
+	}
+
+	logger.Info("[AttachmentService] attachment deleted", zap.Uint("attachmentID", attachment.ID), zap.String("fileName", attachment.FileName))
+	return rsp, nil
+}
+
+// HandleAttachmentPreviewJob 处理附件预览图生成后台任务，附件中没有可用图片时直接结束
+//
+//	receiver s *attachmentService
+//	param ctx context.Context
+//	param payload *job.AttachmentPreviewPayload
+//	return err error
+//	author centonhuang
+//	update 2025-11-18 10:21:37
+func (s *attachmentService) HandleAttachmentPreviewJob(ctx context.Context, payload *job.AttachmentPreviewPayload) (err error) {
+	logger := logger.WithCtx(ctx).With(zap.Uint("attachmentID", payload.AttachmentID))
+	db := database.GetDBInstance(ctx)
+
+	attachment, err := s.attachmentDAO.GetByID(db, payload.AttachmentID, attachmentFields, []string{})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Warn("[AttachmentService] attachment not found, skip preview job")
+			return nil
+		}
+		return err
+	}
+	if attachment.PreviewName != "" {
+		return nil
+	}
+
+	// 文档与媒体文件可能较大，落盘后按需随机读取
+	file, err := os.CreateTemp("", "attachment-preview-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		file.Close()
+		os.Remove(file.Name())
+	}()
+
+	if _, err = s.attachmentObjDAO.DownloadObject(ctx, attachment.UserID, attachment.StorageName, file); err != nil {
+		if errors.Is(err, objdao.ErrObjectNotFound) {
+			logger.Warn("[AttachmentService] attachment object not found, skip preview job")
+			return nil
+		}
+		return err
+	}
+
+	preview, err := util.ExtractAttachmentPreview(file, attachment.Size, attachment.ContentType)
+	if err != nil {
+		// 附件中没有可解码的图片时重试无意义
+		logger.Info("[AttachmentService] no preview available", zap.String("contentType", attachment.ContentType), zap.Error(err))
+		return nil
+	}
+
+	var previewBuffer bytes.Buffer
+	preview = imaging.Fit(preview, attachmentPreviewMaxPixel, attachmentPreviewMaxPixel, imaging.Lanczos)
+	if err = imaging.Encode(&previewBuffer, preview, imaging.JPEG, imaging.JPEGQuality(attachmentPreviewJPEGQuality)); err != nil {
+		return err
+	}
+
+	// 任务重试时覆盖已有预览图，只计入大小差值
+	previewName := composeAttachmentPreviewName(attachment.StorageName)
+	var previousSize int64
+	previous, err := s.attachmentPreviewObjDAO.StatObject(ctx, attachment.UserID, previewName)
+	switch {
+	case err == nil:
+		previousSize = previous.Size
+	case !errors.Is(err, objdao.ErrObjectNotFound):
+		return err
+	}
+
+	previewSize := int64(previewBuffer.Len())
+	if err = s.attachmentPreviewObjDAO.UploadObject(ctx, attachment.UserID, previewName, previewSize, &previewBuffer); err != nil {
+		return err
+	}
+	if delta := previewSize - previousSize; delta != 0 {
+		if err = s.userDAO.AdjustStorageUsage(db, attachment.UserID, delta); err != nil {
+			return err
+		}
+	}
+
+	if err = s.attachmentDAO.Update(db, attachment, map[string]interface{}{
+		"preview_name": previewName,
+		"preview_size": previewSize,
+	}); err != nil {
+		return err
+	}
+
+	logger.Info("[AttachmentService] attachment preview generated", zap.String("previewName", previewName), zap.Int64("size", previewSize))
+	return nil
+}
+
+// getArticle 获取附件所属文章，write为true时仅作者可操作，否则草稿仅作者可见
+func (s *attachmentService) getArticle(ctx context.Context, articleID uint, write bool) (*model.Article, error) {
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	userID := ctx.Value(constant.CtxKeyUserID).(uint)
+
+	article, err := s.articleDAO.GetByID(db, articleID, []string{"id", "status", "user_id"}, []string{})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Error("[AttachmentService] article not found", zap.Uint("articleID", articleID))
+			return nil, protocol.ErrDataNotExists
+		}
+		logger.Error("[AttachmentService] failed to get article", zap.Uint("articleID", articleID), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	if article.UserID != userID && (write || article.Status != model.ArticleStatusPublish) {
+		logger.Error("[AttachmentService] no permission to access article attachments", zap.Uint("articleID", articleID), zap.Bool("write", write))
+		return nil, protocol.ErrNoPermission
+	}
+	return article, nil
+}
+
+func (s *attachmentService) getAttachment(ctx context.Context, articleID, attachmentID uint) (*model.Attachment, error) {
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	attachment, err := s.attachmentDAO.GetByArticleID(db, articleID, attachmentID, attachmentFields)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Error("[AttachmentService] attachment not found", zap.Uint("articleID", articleID), zap.Uint("attachmentID", attachmentID))
+			return nil, protocol.ErrDataNotExists
+		}
+		logger.Error("[AttachmentService] failed to get attachment", zap.Uint("attachmentID", attachmentID), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+	return attachment, nil
+}
+
+// deleteAttachmentObjects 删除附件对象与预览图，返回已释放的字节数
+func (s *attachmentService) deleteAttachmentObjects(ctx context.Context, attachment *model.Attachment) (freed int64, err error) {
+	return deleteAttachmentObjects(ctx, s.attachmentObjDAO, s.attachmentPreviewObjDAO, attachment)
+}
+
+func deleteAttachmentObjects(ctx context.Context, attachmentObjDAO, previewObjDAO objdao.ObjDAO, attachment *model.Attachment) (freed int64, err error) {
+	if err = attachmentObjDAO.DeleteObject(ctx, attachment.UserID, attachment.StorageName); err != nil {
+		return 0, err
+	}
+	freed = attachment.Size
+
+	if attachment.PreviewName != "" {
+		if err = previewObjDAO.DeleteObject(ctx, attachment.UserID, attachment.PreviewName); err != nil {
+			return freed, err
+		}
+		freed += attachment.PreviewSize
+	}
+	return freed, nil
+}
+
+// parseAttachmentFileName 去除路径部分并按小写扩展名查找允许的附件格式
+func parseAttachmentFileName(name string) (fileName string, format *attachmentFormat, ok bool) {
+	fileName = path.Base("/" + strings.ReplaceAll(name, "\\", "/"))
+	if fileName == "/" {
+		return "", nil, false
+	}
+	format, ok = attachmentFormats[strings.ToLower(filepath.Ext(fileName))]
+	return fileName, format, ok
+}
+
+// composeAttachmentStorageName 附件按上传ID存储，文件名只记录在数据库中
+func composeAttachmentStorageName(uploadID, fileName string) string {
+	return uploadID + strings.ToLower(filepath.Ext(fileName))
+}
+
+func composeAttachmentPreviewName(storageName string) string {
+	return strings.TrimSuffix(storageName, filepath.Ext(storageName)) + attachmentPreviewExtension
+}
+
+func attachmentToDTO(attachment *model.Attachment) *dto.Attachment {
+	return &dto.Attachment{
+		AttachmentID: attachment.ID,
+		ArticleID:    attachment.ArticleID,
+		FileName:     attachment.FileName,
+		Kind:         string(attachment.Kind),
+		ContentType:  attachment.ContentType,
+		Size:         attachment.Size,
+		HasPreview:   attachment.PreviewName != "",
+		CreatedAt:    attachment.CreatedAt.Format(time.DateTime),
+	}
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/hcd233/aris-blog-api/internal/constant"
+	"github.com/hcd233/aris-blog-api/internal/logger"
+	"github.com/hcd233/aris-blog-api/internal/protocol"
+	dto "github.com/hcd233/aris-blog-api/internal/protocol/dto"
+	"github.com/hcd233/aris-blog-api/internal/resource/database"
+	"github.com/hcd233/aris-blog-api/internal/resource/database/dao"
+	"github.com/hcd233/aris-blog-api/internal/resource/database/model"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const auditExportBatchSize = 500
+
+var auditEventFields = []string{
+	"id", "actor_id", "action", "target_type", "target_id",
+	"before", "after", "ip", "user_agent", "trace_id", "created_at",
+}
+
+// recordAuditEvent 记录审计事件，所有服务都应通过该函数写入审计日志
+//
+// 未指定操作者时取当前登录用户，IP、UA与追踪ID取自请求上下文；
+// 同时给出before和after时只保留发生变化的字段。传入事务可保证审计与业务变更同时生效
+//
+//	param ctx context.Context
+//	param db *gorm.DB
+//	param event *model.AuditEvent
+//	return error
+//	author centonhuang
+//	update 2025-11-11 14:48:02
+func recordAuditEvent(ctx context.Context, db *gorm.DB, event *model.AuditEvent) error {
+	if event.ActorID == 0 {
+		event.ActorID, _ = ctx.Value(constant.CtxKeyUserID).(uint)
+	}
+	event.IP, _ = ctx.Value(constant.CtxKeyClientIP).(string)
+	event.UserAgent, _ = ctx.Value(constant.CtxKeyUserAgent).(string)
+	event.TraceID, _ = ctx.Value(constant.CtxKeyTraceID).(string)
+
+	if event.Before != nil && event.After != nil {
+		event.Before, event.After = diffAuditFields(event.Before, event.After)
+	}
+
+	return dao.GetAuditEventDAO().Create(db, event)
+}
+
+func diffAuditFields(before, after map[string]any) (changedBefore, changedAfter map[string]any) {
+	changedBefore, changedAfter = map[string]any{}, map[string]any{}
+	for _, key := range lo.Union(lo.Keys(before), lo.Keys(after)) {
+		b, bok := before[key]
+		a, aok := after[key]
+		if bok == aok && fmt.Sprint(b) == fmt.Sprint(a) {
+			continue
+		}
+		if bok {
+			changedBefore[key] = b
+		}
+		if aok {
+			changedAfter[key] = a
+		}
+	}
+	return changedBefore, changedAfter
+}
+
+// AuditService 审计服务
+//
+//	author centonhuang
+//	update 2025-11-11 14:48:02
+type AuditService interface {
+	ListAuditEvents(ctx context.Context, req *dto.ListAuditEventsRequest) (rsp *dto.ListAuditEventsResponse, err error)
+	ExportAuditEvents(ctx context.Context, req *dto.ExportAuditEventsRequest, writer io.Writer) (err error)
+}
+
+type auditService struct {
+	auditEventDAO *dao.AuditEventDAO
+}
+
+// NewAuditService 创建审计服务
+//
+//	return AuditService
+//	author centonhuang
+//	update 2025-11-11 14:48:02
+func NewAuditService() AuditService {
+	return &auditService{
+		auditEventDAO: dao.GetAuditEventDAO(),
+	}
+}
+
+// ListAuditEvents 分页查询审计事件
+//
+//	receiver s *auditService
+//	param ctx context.Context
+//	param req *dto.ListAuditEventsRequest
+//	return rsp *dto.ListAuditEventsResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-11 14:48:02
+func (s *auditService) ListAuditEvents(ctx context.Context, req *dto.ListAuditEventsRequest) (rsp *dto.ListAuditEventsResponse, err error) {
+	rsp = &dto.ListAuditEventsResponse{}
+
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	filter, err := buildAuditEventFilter(&req.AuditEventFilterParam)
+	if err != nil {
+		logger.Error("[AuditService] invalid filter", zap.Error(err))
+		return nil, protocol.ErrBadRequest
+	}
+
+	param := &dao.PageParam{
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}
+
+	events, pageInfo, err := s.auditEventDAO.PaginateByFilter(db, filter, auditEventFields, param)
+	if err != nil {
+		logger.Error("[AuditService] failed to list audit events", zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	rsp.Events = lo.Map(*events, func(event model.AuditEvent, _ int) *dto.AuditEvent {
+		return buildAuditEventDTO(&event)
+	})
+	rsp.PageInfo = &dto.PageInfo{
+		Page:     pageInfo.Page,
+		PageSize: pageInfo.PageSize,
+		Total:    pageInfo.Total,
+	}
+
+	return rsp, nil
+}
+
+// ExportAuditEvents 以NDJSON格式导出审计事件，每行一个事件
+//
+//	receiver s *auditService
+//	param ctx context.Context
+//	param req *dto.ExportAuditEventsRequest
+//	param writer io.Writer
+//	return err error
+//	author centonhuang
+//	update 2025-11-11 14:48:02
+func (s *auditService) ExportAuditEvents(ctx context.Context, req *dto.ExportAuditEventsRequest, writer io.Writer) (err error) {
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	filter, err := buildAuditEventFilter(&req.AuditEventFilterParam)
+	if err != nil {
+		logger.Error("[AuditService] invalid filter", zap.Error(err))
+		return protocol.ErrBadRequest
+	}
+
+	buffered := bufio.NewWriter(writer)
+	exported := 0
+
+	err = s.auditEventDAO.FindInBatchesByFilter(db, filter, auditEventFields, auditExportBatchSize, func(events []model.AuditEvent) error {
+		for _, event := range events {
+			line, err := sonic.Marshal(buildAuditEventDTO(&event))
+			if err != nil {
+				return err
+			}
+			if _, err = buffered.Write(append(line, '\n')); err != nil {
+				return err
+			}
+		}
+		exported += len(events)
+		return buffered.Flush()
+	})
+	if err != nil {
+		logger.Error("[AuditService] failed to export audit events", zap.Int("exported", exported), zap.Error(err))
+		return protocol.ErrInternalError
+	}
+
+	logger.Info("[AuditService] audit events exported", zap.Int("exported", exported))
+	return nil
+}
+
+func buildAuditEventFilter(param *dto.AuditEventFilterParam) (*dao.AuditEventFilter, error) {
+	if !param.Since.IsZero() && !param.Until.IsZero() && !param.Since.Before(param.Until) {
+		return nil, fmt.Errorf("since %s must be before until %s", param.Since.Format(time.RFC3339), param.Until.Format(time.RFC3339))
+	}
+	return &dao.AuditEventFilter{
+		ActorID:    param.ActorID,
+		Action:     model.AuditAction(param.Action),
+		TargetType: model.AuditTargetType(param.TargetType),
+		TargetID:   param.TargetID,
+		Since:      param.Since,
+		Until:      param.Until,
+	}, nil
+}
+
+func buildAuditEventDTO(event *model.AuditEvent) *dto.AuditEvent {
+	return &dto.AuditEvent{
+		EventID:    event.ID,
+		ActorID:    event.ActorID,
+		Action:     string(event.Action),
+		TargetType: string(event.TargetType),
+		TargetID:   event.TargetID,
+		Before:     event.Before,
+		After:      event.After,
+		IP:         event.IP,
+		UserAgent:  event.UserAgent,
+		TraceID:    event.TraceID,
+		CreatedAt:  event.CreatedAt.Format(time.DateTime),
+	}
+}
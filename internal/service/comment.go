@@ -144,8 +144,9 @@ func (s *commentService) DeleteComment(ctx context.Context, req *dto.DeleteComme
 	}
 
 	userID := ctx.Value(constant.CtxKeyUserID).(uint)
+	scopes, _ := ctx.Value(constant.CtxKeyScopes).([]model.Scope)
 
-	if article.UserID != userID && comment.UserID != userID {
+	if article.UserID != userID && comment.UserID != userID && !model.HasScope(scopes, model.ScopeCommentModerate) {
 		logger.Error("[CommentService] no permission to delete comment",
 			zap.Uint("commentUserID", comment.UserID))
 		return nil, protocol.ErrNoPermission
@@ -0,0 +1,343 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/hcd233/aris-blog-api/internal/config"
+	"github.com/hcd233/aris-blog-api/internal/constant"
+	"github.com/hcd233/aris-blog-api/internal/logger"
+	"github.com/hcd233/aris-blog-api/internal/protocol"
+	dto "github.com/hcd233/aris-blog-api/internal/protocol/dto"
+	"github.com/hcd233/aris-blog-api/internal/resource/database"
+	"github.com/hcd233/aris-blog-api/internal/resource/database/dao"
+	"github.com/hcd233/aris-blog-api/internal/resource/database/model"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var creatorApplicationFields = []string{
+	"id", "user_id", "reason", "links", "status",
+	"review_comment", "reviewed_at", "created_at",
+}
+
+var errCreatorApplicationReviewed = errors.New("creator application already reviewed")
+
+// CreatorApplicationService 创作者申请服务
+//
+//	author centonhuang
+//	update 2025-11-11 10:20:36
+type CreatorApplicationService interface {
+	SubmitCreatorApplication(ctx context.Context, req *dto.SubmitCreatorApplicationRequest) (rsp *dto.SubmitCreatorApplicationResponse, err error)
+	GetCurrentCreatorApplication(ctx context.Context, req *dto.EmptyRequest) (rsp *dto.GetCurrentCreatorApplicationResponse, err error)
+	ListCreatorApplications(ctx context.Context, req *dto.ListCreatorApplicationsRequest) (rsp *dto.ListCreatorApplicationsResponse, err error)
+	ReviewCreatorApplication(ctx context.Context, req *dto.ReviewCreatorApplicationRequest) (rsp *dto.EmptyResponse, err error)
+}
+
+type creatorApplicationService struct {
+	userDAO               *dao.UserDAO
+	creatorApplicationDAO *dao.CreatorApplicationDAO
+}
+
+// NewCreatorApplicationService 创建创作者申请服务
+//
+//	return CreatorApplicationService
+//	author centonhuang
+//	update 2025-11-11 10:20:36
+func NewCreatorApplicationService() CreatorApplicationService {
+	return &creatorApplicationService{
+		userDAO:               dao.GetUserDAO(),
+		creatorApplicationDAO: dao.GetCreatorApplicationDAO(),
+	}
+}
+
+// SubmitCreatorApplication 提交创作者申请
+//
+//	receiver s *creatorApplicationService
+//	param ctx context.Context
+//	param req *dto.SubmitCreatorApplicationRequest
+//	return rsp *dto.SubmitCreatorApplicationResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-20 10:15:42
+func (s *creatorApplicationService) SubmitCreatorApplication(ctx context.Context, req *dto.SubmitCreatorApplicationRequest) (rsp *dto.SubmitCreatorApplicationResponse, err error) {
+	rsp = &dto.SubmitCreatorApplicationResponse{}
+
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	if req.Body == nil {
+		logger.Error("[CreatorApplicationService] request body is nil")
+		return nil, protocol.ErrBadRequest
+	}
+
+	userID := ctx.Value(constant.CtxKeyUserID).(uint)
+	scopes, _ := ctx.Value(constant.CtxKeyScopes).([]model.Scope)
+
+	if model.HasScope(scopes, model.ScopeArticleWrite) {
+		logger.Error("[CreatorApplicationService] user can already write articles", zap.Any("scopes", scopes))
+		return nil, protocol.ErrBadRequest
+	}
+
+	for _, link := range req.Body.Links {
+		if u, parseErr := url.Parse(link); parseErr != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			logger.Error("[CreatorApplicationService] invalid link", zap.String("link", link))
+			return nil, protocol.ErrBadRequest
+		}
+	}
+
+	latest, err := s.creatorApplicationDAO.GetLatestByUserID(db, userID, []string{"id", "status", "reviewed_at"}, []string{})
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		logger.Error("[CreatorApplicationService] failed to get latest application", zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+	if latest != nil && latest.ID != 0 {
+		switch latest.Status {
+		case model.CreatorApplicationStatusPending:
+			logger.Error("[CreatorApplicationService] application already pending", zap.Uint("applicationID", latest.ID))
+			return nil, protocol.ErrDataExists
+		case model.CreatorApplicationStatusRejected:
+			if reapplyAt := latest.ReviewedAt.Add(config.CreatorApplicationCooldown); time.Now().UTC().Before(reapplyAt) {
+				logger.Error("[CreatorApplicationService] reapply in cooldown",
+					zap.Uint("applicationID", latest.ID),
+					zap.Time("reapplyAt", reapplyAt))
+				return nil, protocol.ErrTooManyRequests
+			}
+		}
+	}
+
+	application := &model.CreatorApplication{
+		UserID: userID,
+		Reason: req.Body.Reason,
+		Links:  lo.Ternary(req.Body.Links != nil, req.Body.Links, []string{}),
+		Status: model.CreatorApplicationStatusPending,
+	}
+	if err = s.creatorApplicationDAO.Create(db, application); err != nil {
+		logger.Error("[CreatorApplicationService] failed to create application", zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	logger.Info("[CreatorApplicationService] application submitted", zap.Uint("applicationID", application.ID))
+
+	rsp.Application = buildCreatorApplicationDTO(application)
+	return rsp, nil
+}
+
+// GetCurrentCreatorApplication 获取当前用户最近一次创作者申请
+//
+//	receiver s *creatorApplicationService
+//	param ctx context.Context
+//	param _ *dto.EmptyRequest
+//	return rsp *dto.GetCurrentCreatorApplicationResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-11 10:20:36
+func (s *creatorApplicationService) GetCurrentCreatorApplication(ctx context.Context, _ *dto.EmptyRequest) (rsp *dto.GetCurrentCreatorApplicationResponse, err error) {
+	rsp = &dto.GetCurrentCreatorApplicationResponse{}
+
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	userID := ctx.Value(constant.CtxKeyUserID).(uint)
+
+	application, err := s.creatorApplicationDAO.GetLatestByUserID(db, userID, creatorApplicationFields, []string{})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Info("[CreatorApplicationService] application not found")
+			return nil, protocol.ErrDataNotExists
+		}
+		logger.Error("[CreatorApplicationService] failed to get latest application", zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	rsp.Application = buildCreatorApplicationDTO(application)
+	return rsp, nil
+}
+
+// ListCreatorApplications 列出创作者申请
+//
+//	receiver s *creatorApplicationService
+//	param ctx context.Context
+//	param req *dto.ListCreatorApplicationsRequest
+//	return rsp *dto.ListCreatorApplicationsResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-11 10:20:36
+func (s *creatorApplicationService) ListCreatorApplications(ctx context.Context, req *dto.ListCreatorApplicationsRequest) (rsp *dto.ListCreatorApplicationsResponse, err error) {
+	rsp = &dto.ListCreatorApplicationsResponse{}
+
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	param := &dao.PageParam{
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}
+
+	applications, pageInfo, err := s.creatorApplicationDAO.PaginateByStatus(db, model.CreatorApplicationStatus(req.Status), creatorApplicationFields, []string{"User"}, param)
+	if err != nil {
+		logger.Error("[CreatorApplicationService] failed to list applications", zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	rsp.Applications = lo.Map(*applications, func(application model.CreatorApplication, _ int) *dto.CreatorApplication {
+		return buildCreatorApplicationDTO(&application)
+	})
+	rsp.PageInfo = &dto.PageInfo{
+		Page:     pageInfo.Page,
+		PageSize: pageInfo.PageSize,
+		Total:    pageInfo.Total,
+	}
+
+	return rsp, nil
+}
+
+// ReviewCreatorApplication 审核创作者申请，通过后升级用户权限并补足配额
+//
+//	receiver s *creatorApplicationService
+//	param ctx context.Context
+//	param req *dto.ReviewCreatorApplicationRequest
+//	return rsp *dto.EmptyResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-20 10:15:42
+func (s *creatorApplicationService) ReviewCreatorApplication(ctx context.Context, req *dto.ReviewCreatorApplicationRequest) (rsp *dto.EmptyResponse, err error) {
+	rsp = &dto.EmptyResponse{}
+
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	if req.Body == nil {
+		logger.Error("[CreatorApplicationService] request body is nil")
+		return nil, protocol.ErrBadRequest
+	}
+
+	reviewerID := ctx.Value(constant.CtxKeyUserID).(uint)
+
+	application, err := s.creatorApplicationDAO.GetByID(db, req.ApplicationID, []string{"id", "user_id", "status"}, []string{})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Error("[CreatorApplicationService] application not found", zap.Uint("applicationID", req.ApplicationID))
+			return nil, protocol.ErrDataNotExists
+		}
+		logger.Error("[CreatorApplicationService] failed to get application", zap.Uint("applicationID", req.ApplicationID), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	if application.Status != model.CreatorApplicationStatusPending {
+		logger.Error("[CreatorApplicationService] application already reviewed",
+			zap.Uint("applicationID", application.ID),
+			zap.String("status", string(application.Status)))
+		return nil, protocol.ErrBadRequest
+	}
+
+	if application.UserID == reviewerID {
+		logger.Error("[CreatorApplicationService] cannot review own application", zap.Uint("applicationID", application.ID))
+		return nil, protocol.ErrNoPermission
+	}
+
+	status, action := model.CreatorApplicationStatusRejected, model.AuditActionCreatorApplicationReject
+	if req.Body.Action == "approve" {
+		status, action = model.CreatorApplicationStatusApproved, model.AuditActionCreatorApplicationApprove
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		updated, err := s.creatorApplicationDAO.UpdatePending(tx, application, map[string]interface{}{
+			"status":         status,
+			"reviewer_id":    reviewerID,
+			"review_comment": req.Body.Comment,
+			"reviewed_at":    time.Now().UTC(),
+		})
+		if err != nil {
+			return err
+		}
+		if !updated {
+			return errCreatorApplicationReviewed
+		}
+
+		before := map[string]any{"status": application.Status}
+		after := map[string]any{"status": status, "reviewComment": req.Body.Comment}
+
+		if status == model.CreatorApplicationStatusApproved {
+			user, err := s.userDAO.GetByID(tx, application.UserID, []string{"id", "permission", "llm_quota"}, []string{"Roles"})
+			if err != nil {
+				return err
+			}
+
+			// 已通过其他角色获得撰写权限的用户不变更权限，权限等级也不做降级
+			info := map[string]interface{}{}
+			if !model.HasScope(user.GetScopes(), model.ScopeArticleWrite) &&
+				model.PermissionLevelMapping[user.Permission] < model.PermissionLevelMapping[model.PermissionCreator] {
+				info["permission"] = model.PermissionCreator
+			}
+			if user.LLMQuota < model.QuotaCreator {
+				info["llm_quota"] = model.QuotaCreator
+			}
+			if len(info) > 0 {
+				if err := s.userDAO.Update(tx, user, info); err != nil {
+					return err
+				}
+			}
+			if info["permission"] != nil {
+				if err := syncBuiltinRole(tx, user, model.PermissionCreator); err != nil {
+					return err
+				}
+			}
+
+			before["userPermission"], before["userLLMQuota"] = user.Permission, user.LLMQuota
+			after["userPermission"] = lo.Ternary(info["permission"] != nil, model.PermissionCreator, user.Permission)
+			after["userLLMQuota"] = max(user.LLMQuota, model.QuotaCreator)
+		}
+
+		return recordAuditEvent(ctx, tx, &model.AuditEvent{
+			Action:     action,
+			TargetType: model.AuditTargetTypeCreatorApplication,
+			TargetID:   application.ID,
+			Before:     before,
+			After:      after,
+		})
+	})
+	if errors.Is(err, errCreatorApplicationReviewed) {
+		logger.Error("[CreatorApplicationService] application reviewed concurrently", zap.Uint("applicationID", application.ID))
+		return nil, protocol.ErrBadRequest
+	}
+	if err != nil {
+		logger.Error("[CreatorApplicationService] failed to review application", zap.Uint("applicationID", application.ID), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	logger.Info("[CreatorApplicationService] application reviewed",
+		zap.Uint("applicationID", application.ID),
+		zap.Uint("userID", application.UserID),
+		zap.String("status", string(status)))
+
+	return rsp, nil
+}
+
+func buildCreatorApplicationDTO(application *model.CreatorApplication) *dto.CreatorApplication {
+	rsp := &dto.CreatorApplication{
+		ApplicationID: application.ID,
+		Reason:        application.Reason,
+		Links:         application.Links,
+		Status:        string(application.Status),
+		ReviewComment: application.ReviewComment,
+		CreatedAt:     application.CreatedAt.Format(time.DateTime),
+	}
+	if application.User != nil {
+		rsp.User = &dto.User{
+			UserID: application.User.ID,
+			Name:   application.User.Name,
+			Avatar: application.User.Avatar,
+		}
+	}
+	if application.Status != model.CreatorApplicationStatusPending {
+		rsp.ReviewedAt = application.ReviewedAt.Format(time.DateTime)
+	}
+	if application.Status == model.CreatorApplicationStatusRejected {
+		rsp.ReapplyAt = application.ReviewedAt.Add(config.CreatorApplicationCooldown).Format(time.DateTime)
+	}
+	return rsp
+}
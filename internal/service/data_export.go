@@ -0,0 +1,576 @@
+package service
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/hcd233/aris-blog-api/internal/config"
+	"github.com/hcd233/aris-blog-api/internal/constant"
+	"github.com/hcd233/aris-blog-api/internal/job"
+	"github.com/hcd233/aris-blog-api/internal/logger"
+	"github.com/hcd233/aris-blog-api/internal/protocol"
+	dto "github.com/hcd233/aris-blog-api/internal/protocol/dto"
+	"github.com/hcd233/aris-blog-api/internal/resource/database"
+	"github.com/hcd233/aris-blog-api/internal/resource/database/dao"
+	"github.com/hcd233/aris-blog-api/internal/resource/database/model"
+	objdao "github.com/hcd233/aris-blog-api/internal/resource/storage/obj_dao"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	dataExportMaxRetries     = 5
+	dataExportRetryBaseDelay = time.Minute
+	dataExportLease          = 30 * time.Minute
+	dataExportBatchSize      = 10
+	dataExportCooldown       = 24 * time.Hour
+)
+
+var dataExportFields = []string{
+	"id", "user_id", "status", "object_name", "size", "retries",
+	"created_at", "ready_at", "expires_at",
+}
+
+// dataExportArticle 归档中的文章，包含全部语言的全部版本
+type dataExportArticle struct {
+	ArticleID   uint                        `json:"articleID"`
+	Title       string                      `json:"title"`
+	Slug        string                      `json:"slug"`
+	Status      string                      `json:"status"`
+	Tags        []string                    `json:"tags"`
+	Likes       uint                        `json:"likes"`
+	Views       uint                        `json:"views"`
+	CreatedAt   string                      `json:"createdAt"`
+	UpdatedAt   string                      `json:"updatedAt"`
+	PublishedAt string                      `json:"publishedAt,omitempty"`
+	Versions    []*dataExportArticleVersion `json:"versions"`
+}
+
+type dataExportArticleVersion struct {
+	dto.ArticleVersion
+	Description string `json:"description,omitempty"`
+}
+
+type dataExportComment struct {
+	dto.Comment
+	ArticleID uint `json:"articleID"`
+}
+
+type dataExportLike struct {
+	ObjectType string `json:"objectType"`
+	ObjectID   uint   `json:"objectID"`
+	CreatedAt  string `json:"createdAt"`
+}
+
+// DataExportService 用户数据导出服务
+//
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+type DataExportService interface {
+	RequestDataExport(ctx context.Context, req *dto.EmptyRequest) (rsp *dto.RequestDataExportResponse, err error)
+	GetCurrentDataExport(ctx context.Context, req *dto.EmptyRequest) (rsp *dto.GetCurrentDataExportResponse, err error)
+	ProcessDueDataExports(ctx context.Context)
+	HandleDataExportJob(ctx context.Context, payload *job.DataExportPayload) (err error)
+}
+
+type dataExportService struct {
+	userDAO           *dao.UserDAO
+	articleDAO        *dao.ArticleDAO
+	articleVersionDAO *dao.ArticleVersionDAO
+	commentDAO        *dao.CommentDAO
+	userLikeDAO       *dao.UserLikeDAO
+	userViewDAO       *dao.UserViewDAO
+	dataExportDAO     *dao.DataExportDAO
+	assetDAO          *dao.AssetDAO
+	attachmentDAO     *dao.AttachmentDAO
+	imageObjDAO       objdao.ObjDAO
+	attachmentObjDAO  objdao.ObjDAO
+	exportObjDAO      objdao.ObjDAO
+}
+
+// NewDataExportService 创建用户数据导出服务
+//
+//	return DataExportService
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+func NewDataExportService() DataExportService {
+	return &dataExportService{
+		userDAO:           dao.GetUserDAO(),
+		articleDAO:        dao.GetArticleDAO(),
+		articleVersionDAO: dao.GetArticleVersionDAO(),
+		commentDAO:        dao.GetCommentDAO(),
+		userLikeDAO:       dao.GetUserLikeDAO(),
+		userViewDAO:       dao.GetUserViewDAO(),
+		dataExportDAO:     dao.GetDataExportDAO(),
+		assetDAO:          dao.GetAssetDAO(),
+		attachmentDAO:     dao.GetAttachmentDAO(),
+		imageObjDAO:       objdao.GetImageObjDAO(),
+		attachmentObjDAO:  objdao.GetAttachmentObjDAO(),
+		exportObjDAO:      objdao.GetExportObjDAO(),
+	}
+}
+
+// RequestDataExport 申请导出当前用户的全部数据，归档在后台打包
+//
+//	receiver s *dataExportService
+//	param ctx context.Context
+//	param _ *dto.EmptyRequest
+//	return rsp *dto.RequestDataExportResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+func (s *dataExportService) RequestDataExport(ctx context.Context, _ *dto.EmptyRequest) (rsp *dto.RequestDataExportResponse, err error) {
+	rsp = &dto.RequestDataExportResponse{}
+
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	if err := requireSessionAuth(ctx); err != nil {
+		return nil, err
+	}
+
+	userID := ctx.Value(constant.CtxKeyUserID).(uint)
+
+	latest, err := s.dataExportDAO.GetLatestByUserID(db, userID, []string{"id", "status", "retries", "created_at"})
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		logger.Error("[DataExportService] failed to get latest export", zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+	if latest != nil && latest.ID != 0 {
+		switch {
+		case latest.Status == model.DataExportStatusPending,
+			latest.Status == model.DataExportStatusFailed && latest.Retries < dataExportMaxRetries:
+			logger.Error("[DataExportService] export already in progress", zap.Uint("exportID", latest.ID))
+			return nil, protocol.ErrDataExists
+		case latest.Status == model.DataExportStatusReady && time.Since(latest.CreatedAt) < dataExportCooldown:
+			logger.Error("[DataExportService] export requested too frequently",
+				zap.Uint("exportID", latest.ID),
+				zap.Time("createdAt", latest.CreatedAt))
+			return nil, protocol.ErrTooManyRequests
+		}
+	}
+
+	export := &model.DataExport{
+		UserID:      userID,
+		Status:      model.DataExportStatusPending,
+		NextRetryAt: time.Now().UTC(),
+	}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := s.dataExportDAO.Create(tx, export); err != nil {
+			return err
+		}
+		return recordAuditEvent(ctx, tx, &model.AuditEvent{
+			Action:     model.AuditActionUserDataExport,
+			TargetType: model.AuditTargetTypeUser,
+			TargetID:   userID,
+			After:      map[string]any{"exportID": export.ID},
+		})
+	})
+	if err != nil {
+		logger.Error("[DataExportService] failed to create export", zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	if _, err = job.Enqueue(ctx, job.TypeDataExport, &job.DataExportPayload{ExportID: export.ID}); err != nil {
+		// 投递失败时由定时任务兜底执行
+		logger.Error("[DataExportService] failed to enqueue export job", zap.Uint("exportID", export.ID), zap.Error(err))
+	}
+
+	logger.Info("[DataExportService] export requested", zap.Uint("exportID", export.ID))
+
+	rsp.Export = buildDataExportDTO(export, "")
+	return rsp, nil
+}
+
+// GetCurrentDataExport 获取当前用户最近一次数据导出，打包完成时附带下载链接
+//
+//	receiver s *dataExportService
+//	param ctx context.Context
+//	param _ *dto.EmptyRequest
+//	return rsp *dto.GetCurrentDataExportResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+func (s *dataExportService) GetCurrentDataExport(ctx context.Context, _ *dto.EmptyRequest) (rsp *dto.GetCurrentDataExportResponse, err error) {
+	rsp = &dto.GetCurrentDataExportResponse{}
+
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	userID := ctx.Value(constant.CtxKeyUserID).(uint)
+
+	export, err := s.dataExportDAO.GetLatestByUserID(db, userID, dataExportFields)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Info("[DataExportService] export not found")
+			return nil, protocol.ErrDataNotExists
+		}
+		logger.Error("[DataExportService] failed to get latest export", zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	// 过期归档可能尚未被定时任务清理，不再提供下载
+	if export.Status == model.DataExportStatusReady && !time.Now().UTC().Before(export.ExpiresAt) {
+		export.Status = model.DataExportStatusExpired
+	}
+
+	downloadURL := ""
+	if export.Status == model.DataExportStatusReady {
+		presignedURL, err := s.exportObjDAO.PresignObject(ctx, userID, export.ObjectName)
+		if err != nil {
+			logger.Error("[DataExportService] failed to presign archive", zap.Uint("exportID", export.ID), zap.Error(err))
+			return nil, protocol.ErrInternalError
+		}
+		downloadURL = presignedURL.String()
+	}
+
+	rsp.Export = buildDataExportDTO(export, downloadURL)
+	return rsp, nil
+}
+
+// ProcessDueDataExports 打包到期的数据导出，包括失败重试，并清理过期归档
+//
+//	receiver s *dataExportService
+//	param ctx context.Context
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+func (s *dataExportService) ProcessDueDataExports(ctx context.Context) {
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	now := time.Now().UTC()
+
+	exports, err := s.dataExportDAO.ListDue(db, now, dataExportMaxRetries, dataExportBatchSize, []string{"id", "user_id", "status", "retries"})
+	if err != nil {
+		logger.Error("[DataExportService] failed to list due exports", zap.Error(err))
+	} else {
+		for _, export := range *exports {
+			s.processDataExport(ctx, &export)
+		}
+	}
+
+	expired, err := s.dataExportDAO.ListExpired(db, now, dataExportBatchSize, []string{"id", "user_id", "object_name"})
+	if err != nil {
+		logger.Error("[DataExportService] failed to list expired exports", zap.Error(err))
+		return
+	}
+	for _, export := range *expired {
+		if err := s.exportObjDAO.DeleteObject(ctx, export.UserID, export.ObjectName); err != nil {
+			logger.Error("[DataExportService] failed to delete expired archive", zap.Uint("exportID", export.ID), zap.Error(err))
+			continue
+		}
+		if err := s.dataExportDAO.Update(db, &export, map[string]interface{}{"status": model.DataExportStatusExpired}); err != nil {
+			logger.Error("[DataExportService] failed to expire export", zap.Uint("exportID", export.ID), zap.Error(err))
+		}
+	}
+}
+
+// HandleDataExportJob 处理用户数据导出后台任务
+//
+//	receiver s *dataExportService
+//	param ctx context.Context
+//	param payload *job.DataExportPayload
+//	return err error
+//	author centonhuang
+//	update 2025-11-14 10:35:21
+func (s *dataExportService) HandleDataExportJob(ctx context.Context, payload *job.DataExportPayload) (err error) {
+	db := database.GetDBInstance(ctx)
+
+	export, err := s.dataExportDAO.GetByID(db, payload.ExportID, []string{"id", "user_id", "status", "retries"}, []string{})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.WithCtx(ctx).Warn("[DataExportService] export not found, skip job", zap.Uint("exportID", payload.ExportID))
+			return nil
+		}
+		return err
+	}
+
+	// 失败重试由导出自身的状态与定时任务管理
+	s.processDataExport(ctx, export)
+	return nil
+}
+
+func (s *dataExportService) processDataExport(ctx context.Context, export *model.DataExport) {
+	logger := logger.WithCtx(ctx).With(zap.Uint("exportID", export.ID), zap.Uint("userID", export.UserID))
+	db := database.GetDBInstance(ctx)
+
+	now := time.Now().UTC()
+	claimed, err := s.dataExportDAO.Claim(db, export, now, now.Add(dataExportLease))
+	if err != nil {
+		logger.Error("[DataExportService] failed to claim export", zap.Error(err))
+		return
+	}
+	if !claimed {
+		logger.Info("[DataExportService] export claimed by others, skip")
+		return
+	}
+
+	objectName, size, err := s.buildArchive(ctx, export)
+	if err != nil {
+		s.failDataExport(ctx, export, err)
+		return
+	}
+
+	readyAt := time.Now().UTC()
+	if err := s.dataExportDAO.Update(db, export, map[string]interface{}{
+		"status":      model.DataExportStatusReady,
+		"object_name": objectName,
+		"size":        size,
+		"last_error":  "",
+		"ready_at":    readyAt,
+		"expires_at":  readyAt.Add(config.DataExportRetention),
+	}); err != nil {
+		s.failDataExport(ctx, export, err)
+		return
+	}
+
+	logger.Info("[DataExportService] export ready", zap.String("objectName", objectName), zap.Int64("size", size))
+}
+
+func (s *dataExportService) failDataExport(ctx context.Context, export *model.DataExport, cause error) {
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	retries := export.Retries + 1
+	logger.Error("[DataExportService] failed to build export",
+		zap.Uint("exportID", export.ID),
+		zap.Uint("retries", retries),
+		zap.Error(cause))
+
+	if err := s.dataExportDAO.Update(db, export, map[string]interface{}{
+		"status":        model.DataExportStatusFailed,
+		"retries":       retries,
+		"last_error":    cause.Error(),
+		"next_retry_at": time.Now().UTC().Add(dataExportRetryBaseDelay << min(retries, 10)),
+	}); err != nil {
+		logger.Error("[DataExportService] failed to update export", zap.Uint("exportID", export.ID), zap.Error(err))
+	}
+}
+
+// buildArchive 在临时文件中打包用户数据并上传到用户的export目录
+func (s *dataExportService) buildArchive(ctx context.Context, export *model.DataExport) (objectName string, size int64, err error) {
+	file, err := os.CreateTemp("", "aris-export-*.zip")
+	if err != nil {
+		return "", 0, err
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	zw := zip.NewWriter(file)
+	if err = s.writeArchive(ctx, zw, export.UserID); err != nil {
+		return "", 0, err
+	}
+	if err = zw.Close(); err != nil {
+		return "", 0, err
+	}
+
+	if size, err = file.Seek(0, io.SeekEnd); err != nil {
+		return "", 0, err
+	}
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		return "", 0, err
+	}
+
+	objectName = fmt.Sprintf("aris-export-%d-%s.zip", export.ID, time.Now().UTC().Format("20060102150405"))
+	if err = s.exportObjDAO.UploadObject(ctx, export.UserID, objectName, size, file); err != nil {
+		return "", 0, fmt.Errorf("upload archive: %w", err)
+	}
+	return objectName, size, nil
+}
+
+func (s *dataExportService) writeArchive(ctx context.Context, zw *zip.Writer, userID uint) error {
+	db := database.GetDBInstance(ctx)
+
+	user, err := s.userDAO.GetByID(db, userID,
+		[]string{"id", "name", "email", "avatar", "permission", "created_at", "last_login", "github_bind_id", "qq_bind_id", "google_bind_id"},
+		[]string{"OidcIdentities"})
+	if err != nil {
+		return fmt.Errorf("get user: %w", err)
+	}
+	if err = writeArchiveJSON(zw, "profile.json", &dto.User{
+		UserID:          user.ID,
+		Name:            user.Name,
+		Email:           user.Email,
+		Avatar:          user.Avatar,
+		CreatedAt:       user.CreatedAt.Format(time.DateTime),
+		LastLogin:       user.LastLogin.Format(time.DateTime),
+		Permission:      string(user.Permission),
+		LinkedProviders: linkedProviders(user.GetBindIDs()),
+	}); err != nil {
+		return err
+	}
+
+	articles, err := s.articleDAO.ListByUserID(db, userID,
+		[]string{"id", "title", "slug", "status", "likes", "views", "created_at", "updated_at", "published_at"},
+		[]string{"Tags"})
+	if err != nil {
+		return fmt.Errorf("list articles: %w", err)
+	}
+	for _, article := range *articles {
+		versions, err := s.articleVersionDAO.ListByArticleID(db, article.ID,
+			[]string{"id", "article_id", "language", "version", "content", "description", "summary", "created_at", "updated_at"})
+		if err != nil {
+			return fmt.Errorf("list versions of article %d: %w", article.ID, err)
+		}
+		if err = writeArchiveJSON(zw, fmt.Sprintf("articles/%d.json", article.ID), &dataExportArticle{
+			ArticleID:   article.ID,
+			Title:       article.Title,
+			Slug:        article.Slug,
+			Status:      string(article.Status),
+			Tags:        lo.Map(article.Tags, func(tag model.Tag, _ int) string { return tag.Slug }),
+			Likes:       article.Likes,
+			Views:       article.Views,
+			CreatedAt:   article.CreatedAt.Format(time.DateTime),
+			UpdatedAt:   article.UpdatedAt.Format(time.DateTime),
+			PublishedAt: lo.Ternary(article.PublishedAt.IsZero(), "", article.PublishedAt.Format(time.DateTime)),
+			Versions: lo.Map(*versions, func(version model.ArticleVersion, _ int) *dataExportArticleVersion {
+				return &dataExportArticleVersion{
+					ArticleVersion: dto.ArticleVersion{
+						ArticleVersionID: version.ID,
+						ArticleID:        version.ArticleID,
+						VersionID:        version.Version,
+						Language:         string(version.Language),
+						Content:          version.Content,
+						Summary:          version.Summary,
+						CreatedAt:        version.CreatedAt.Format(time.DateTime),
+						UpdatedAt:        version.UpdatedAt.Format(time.DateTime),
+					},
+					Description: version.Description,
+				}
+			}),
+		}); err != nil {
+			return err
+		}
+	}
+
+	comments, err := s.commentDAO.ListByUserID(db, userID, []string{"id", "article_id", "user_id", "content", "parent_id", "likes", "created_at"})
+	if err != nil {
+		return fmt.Errorf("list comments: %w", err)
+	}
+	if err = writeArchiveJSON(zw, "comments.json", lo.Map(*comments, func(comment model.Comment, _ int) *dataExportComment {
+		return &dataExportComment{
+			Comment: dto.Comment{
+				CommentID: comment.ID,
+				Content:   comment.Content,
+				UserID:    comment.UserID,
+				ReplyTo:   comment.ParentID,
+				CreatedAt: comment.CreatedAt.Format(time.DateTime),
+				Likes:     comment.Likes,
+			},
+			ArticleID: comment.ArticleID,
+		}
+	})); err != nil {
+		return err
+	}
+
+	likes, err := s.userLikeDAO.ListByUserID(db, userID, []string{"id", "object_id", "object_type", "created_at"})
+	if err != nil {
+		return fmt.Errorf("list likes: %w", err)
+	}
+	if err = writeArchiveJSON(zw, "likes.json", lo.Map(*likes, func(like model.UserLike, _ int) *dataExportLike {
+		return &dataExportLike{
+			ObjectType: string(like.ObjectType),
+			ObjectID:   like.ObjectID,
+			CreatedAt:  like.CreatedAt.Format(time.DateTime),
+		}
+	})); err != nil {
+		return err
+	}
+
+	views, err := s.userViewDAO.ListByUserID(db, userID, []string{"id", "user_id", "article_id", "progress", "last_viewed_at"})
+	if err != nil {
+		return fmt.Errorf("list views: %w", err)
+	}
+	if err = writeArchiveJSON(zw, "views.json", lo.Map(*views, func(view model.UserView, _ int) *dto.UserView {
+		return &dto.UserView{
+			ViewID:       view.ID,
+			Progress:     view.Progress,
+			LastViewedAt: view.LastViewedAt.Format(time.DateTime),
+			UserID:       view.UserID,
+			ArticleID:    view.ArticleID,
+		}
+	})); err != nil {
+		return err
+	}
+
+	assets, err := s.assetDAO.ListByUserID(db, userID, []string{"id", "created_at", "object_name", "storage_name"})
+	if err != nil {
+		return fmt.Errorf("list images: %w", err)
+	}
+	for _, asset := range *assets {
+		// 图片本身已压缩，直接存储即可；按用户看到的图片名称归档，内容相同的图片各存一份
+		w, err := zw.CreateHeader(&zip.FileHeader{
+			Name:     path.Join("images", asset.ObjectName),
+			Method:   zip.Store,
+			Modified: asset.CreatedAt,
+		})
+		if err != nil {
+			return err
+		}
+		if _, err = s.imageObjDAO.DownloadObject(ctx, userID, asset.StorageName, w); err != nil {
+			return fmt.Errorf("download image %s: %w", asset.ObjectName, err)
+		}
+	}
+
+	attachments, err := s.attachmentDAO.ListByUserID(db, userID, []string{"id", "created_at", "article_id", "file_name", "storage_name"})
+	if err != nil {
+		return fmt.Errorf("list attachments: %w", err)
+	}
+	articleIDs := lo.SliceToMap(*articles, func(article model.Article) (uint, bool) { return article.ID, true })
+	for _, attachment := range *attachments {
+		if !articleIDs[attachment.ArticleID] {
+			continue
+		}
+		// 附件多为已压缩格式，按文章归档并保留上传时的文件名
+		w, err := zw.CreateHeader(&zip.FileHeader{
+			Name:     path.Join("attachments", strconv.FormatUint(uint64(attachment.ArticleID), 10), attachment.FileName),
+			Method:   zip.Store,
+			Modified: attachment.CreatedAt,
+		})
+		if err != nil {
+			return err
+		}
+		if _, err = s.attachmentObjDAO.DownloadObject(ctx, userID, attachment.StorageName, w); err != nil {
+			return fmt.Errorf("download attachment %s of article %d: %w", attachment.FileName, attachment.ArticleID, err)
+		}
+	}
+
+	return nil
+}
+
+func writeArchiveJSON(zw *zip.Writer, name string, v any) error {
+	data, err := sonic.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal %s: %w", name, err)
+	}
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+func buildDataExportDTO(export *model.DataExport, downloadURL string) *dto.DataExport {
+	dataExport := &dto.DataExport{
+		ExportID:    export.ID,
+		Status:      string(export.Status),
+		Size:        export.Size,
+		DownloadURL: downloadURL,
+		CreatedAt:   export.CreatedAt.Format(time.DateTime),
+	}
+	if !export.ReadyAt.IsZero() {
+		dataExport.ReadyAt = export.ReadyAt.Format(time.DateTime)
+		dataExport.ExpiresAt = export.ExpiresAt.Format(time.DateTime)
+	}
+	return dataExport
+}
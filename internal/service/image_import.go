@@ -0,0 +1,306 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hcd233/aris-blog-api/internal/config"
+	"github.com/hcd233/aris-blog-api/internal/constant"
+	"github.com/hcd233/aris-blog-api/internal/logger"
+	"github.com/hcd233/aris-blog-api/internal/protocol"
+	"github.com/hcd233/aris-blog-api/internal/protocol/dto"
+	"github.com/hcd233/aris-blog-api/internal/resource/database"
+	"github.com/hcd233/aris-blog-api/internal/resource/database/model"
+	"github.com/hcd233/aris-blog-api/internal/util"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	// 单次批量导入处理的外部图片数量上限，其余链接保持不变
+	imageImportBatchLimit  = 30
+	imageImportConcurrency = 4
+
+	importedImageNameMaxLength = 100
+	importedImageDefaultName   = "image"
+
+	// 改写后的链接指向获取图片接口，由其重定向到对象存储
+	imageLinkPrefix = "/v1/asset/object/image/"
+)
+
+var errImageFetch = errors.New("failed to fetch remote image")
+
+// 按内容识别出的图片类型决定扩展名，不信任链接中的扩展名与响应头
+var remoteImageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+var remoteFetchClient = sync.OnceValue(func() *http.Client {
+	return util.NewRemoteFetchClient(config.ImageImportTimeout)
+})
+
+// ImportImage 从外部链接下载图片并按普通上传入库，只允许访问公网地址，受超时与大小上限约束
+//
+//	receiver s *assetService
+//	param ctx context.Context
+//	param req *dto.ImportImageRequest
+//	return rsp *dto.ImportImageResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-18 16:05:42
+func (s *assetService) ImportImage(ctx context.Context, req *dto.ImportImageRequest) (rsp *dto.ImportImageResponse, err error) {
+	rsp = &dto.ImportImageResponse{}
+
+	logger := logger.WithCtx(ctx)
+
+	userID := ctx.Value(constant.CtxKeyUserID).(uint)
+
+	asset, err := s.importRemoteImage(ctx, userID, req.Body.URL, req.Body.ObjectName)
+	if err != nil {
+		return nil, s.mapIngestError(ctx, req.Body.URL, err)
+	}
+
+	rsp.Image = imageFromAsset(asset)
+
+	logger.Info("[AssetService] image imported",
+		zap.String("url", req.Body.URL),
+		zap.String("objectName", asset.ObjectName),
+		zap.String("storageName", asset.StorageName))
+	return rsp, nil
+}
+
+// ImportArticleVersionImages 导入文章版本内容中引用的外部图片，以改写链接后的内容创建新版本；
+// 导入失败的链接保持不变，没有链接被改写时不创建新版本
+//
+//	receiver s *assetService
+//	param ctx context.Context
+//	param req *dto.ImportArticleVersionImagesRequest
+//	return rsp *dto.ImportArticleVersionImagesResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-18 16:05:42
+func (s *assetService) ImportArticleVersionImages(ctx context.Context, req *dto.ImportArticleVersionImagesRequest) (rsp *dto.ImportArticleVersionImagesResponse, err error) {
+	rsp = &dto.ImportArticleVersionImagesResponse{
+		Imported: []*dto.ImportedImageLink{},
+		Failed:   []*dto.FailedImageLink{},
+	}
+
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	userID := ctx.Value(constant.CtxKeyUserID).(uint)
+
+	article, err := s.articleDAO.GetByID(db, req.ArticleID, []string{"id", "user_id"}, []string{})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Error("[AssetService] article not found", zap.Uint("articleID", req.ArticleID))
+			return nil, protocol.ErrDataNotExists
+		}
+		logger.Error("[AssetService] failed to get article", zap.Uint("articleID", req.ArticleID), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	if article.UserID != userID {
+		logger.Error("[AssetService] no permission to import article images", zap.Uint("articleID", req.ArticleID))
+		return nil, protocol.ErrNoPermission
+	}
+
+	language := model.LanguageDefault
+	if req.Lang != "" {
+		language = model.Language(req.Lang)
+	}
+
+	version, err := s.articleVersionDAO.GetByArticleIDAndVersion(db, article.ID, language, req.Version, []string{"id", "version", "content"}, []string{})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Error("[AssetService] article version not found", zap.Uint("articleID", article.ID), zap.Uint("version", req.Version))
+			return nil, protocol.ErrDataNotExists
+		}
+		logger.Error("[AssetService] failed to get article version", zap.Uint("articleID", article.ID), zap.Uint("version", req.Version), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	links := util.ExternalImageLinks(version.Content)
+	if len(links) > imageImportBatchLimit {
+		for _, link := range links[imageImportBatchLimit:] {
+			rsp.Failed = append(rsp.Failed, &dto.FailedImageLink{URL: link, Reason: "batch limit reached, import again for the rest"})
+		}
+		links = links[:imageImportBatchLimit]
+	}
+
+	// 同一内容中的图片并发下载，同名冲突由importRemoteImage自行处理
+	objectNames := make(map[string]string, len(links))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, imageImportConcurrency)
+	for _, link := range links {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func() {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+
+			asset, err := s.importRemoteImage(ctx, userID, link, "")
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				logger.Warn("[AssetService] failed to import external image", zap.String("url", link), zap.Error(err))
+				rsp.Failed = append(rsp.Failed, &dto.FailedImageLink{URL: link, Reason: describeImageImportError(err)})
+				return
+			}
+			objectNames[link] = asset.ObjectName
+		}()
+	}
+	wg.Wait()
+
+	rewritten := util.RewriteImageLinks(version.Content, func(link string) string {
+		if objectName, ok := objectNames[link]; ok {
+			return composeImageLink(objectName)
+		}
+		return link
+	})
+	for _, link := range links {
+		if objectName, ok := objectNames[link]; ok {
+			rsp.Imported = append(rsp.Imported, &dto.ImportedImageLink{URL: link, ObjectName: objectName, Link: composeImageLink(objectName)})
+		}
+	}
+	if rewritten == version.Content {
+		logger.Info("[AssetService] no external image rewritten",
+			zap.Uint("articleID", article.ID), zap.Uint("version", version.Version), zap.Int("failed", len(rsp.Failed)))
+		return rsp, nil
+	}
+
+	latestVersion, err := s.articleVersionDAO.GetLatestByArticleID(db, article.ID, language, []string{"version"}, []string{})
+	if err != nil {
+		logger.Error("[AssetService] failed to get latest version", zap.Uint("articleID", article.ID), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	newVersion := &model.ArticleVersion{
+		ArticleID: article.ID,
+		Language:  language,
+		Version:   latestVersion.Version + 1,
+		Content:   rewritten,
+	}
+	if err = s.articleVersionDAO.Create(db, newVersion); err != nil {
+		logger.Error("[AssetService] failed to create version", zap.Uint("articleID", article.ID), zap.Uint("version", newVersion.Version), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	rsp.ArticleVersion = &dto.ArticleVersion{
+		ArticleID:        newVersion.ArticleID,
+		ArticleVersionID: newVersion.ID,
+		VersionID:        newVersion.Version,
+		Language:         string(newVersion.Language),
+		Content:          newVersion.Content,
+		CreatedAt:        newVersion.CreatedAt.Format(time.DateTime),
+		UpdatedAt:        newVersion.UpdatedAt.Format(time.DateTime),
+	}
+
+	logger.Info("[AssetService] external images imported",
+		zap.Uint("articleID", article.ID),
+		zap.Uint("fromVersion", version.Version),
+		zap.Uint("toVersion", newVersion.Version),
+		zap.Int("imported", len(rsp.Imported)),
+		zap.Int("failed", len(rsp.Failed)))
+	return rsp, nil
+}
+
+// importRemoteImage 下载外部图片并入库；未指定名称时按链接推出名称，与已有图片重名时追加内容摘要后重试一次
+func (s *assetService) importRemoteImage(ctx context.Context, userID uint, rawURL, objectName string) (*model.Asset, error) {
+	data, err := util.FetchRemoteFile(ctx, remoteFetchClient(), rawURL, config.ImageImportMaxSize)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errImageFetch, err)
+	}
+
+	extension, ok := remoteImageExtensions[http.DetectContentType(data)]
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported content type %s", errImageDecode, http.DetectContentType(data))
+	}
+
+	explicit := objectName != ""
+	if !explicit {
+		objectName = importedImageNameFromURL(rawURL)
+	}
+	base := sanitizeImportedImageName(objectName)
+
+	asset, err := s.ingestImage(ctx, userID, base+extension, data, true)
+	if errors.Is(err, errImageNameConflict) && !explicit {
+		sum := sha256.Sum256(data)
+		asset, err = s.ingestImage(ctx, userID, base+"-"+hex.EncodeToString(sum[:4])+extension, data, true)
+	}
+	return asset, err
+}
+
+// importedImageNameFromURL 取链接路径的最后一段作为图片名称
+func importedImageNameFromURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return importedImageDefaultName
+	}
+	return path.Base(u.Path)
+}
+
+// sanitizeImportedImageName 去除扩展名，只保留字母、数字、连字符、下划线与点，避免名称在链接与对象路径中产生歧义
+func sanitizeImportedImageName(name string) string {
+	name = strings.TrimSuffix(name, filepath.Ext(name))
+
+	var builder strings.Builder
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			builder.WriteRune(r)
+		default:
+			builder.WriteRune('-')
+		}
+	}
+
+	sanitized := strings.Trim(builder.String(), "-.")
+	if len(sanitized) > importedImageNameMaxLength {
+		sanitized = sanitized[:importedImageNameMaxLength]
+	}
+	if sanitized == "" {
+		return importedImageDefaultName
+	}
+	return sanitized
+}
+
+func composeImageLink(objectName string) string {
+	return imageLinkPrefix + url.PathEscape(objectName)
+}
+
+// describeImageImportError 转换为返回给作者的失败原因，不暴露内部错误细节
+func describeImageImportError(err error) string {
+	switch {
+	case errors.Is(err, util.ErrRemoteAddressForbidden):
+		return "address not allowed"
+	case errors.Is(err, util.ErrRemoteResourceTooLarge):
+		return "image too large"
+	case errors.Is(err, util.ErrRemoteResourceUnavailable):
+		return "image unavailable"
+	case errors.Is(err, errImageFetch):
+		return "failed to download image"
+	case errors.Is(err, errImageDecode):
+		return "not a supported image"
+	case errors.Is(err, errStorageQuotaExceeded):
+		return "storage quota exceeded"
+	default:
+		return "failed to store image"
+	}
+}
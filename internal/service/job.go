@@ -0,0 +1,22 @@
+package service
+
+import "github.com/hcd233/aris-blog-api/internal/job"
+
+// RegisterJobHandlers 注册后台任务处理函数
+//
+//	author centonhuang
+//	update 2025-11-18 10:21:37
+func RegisterJobHandlers() {
+	articleSuggestionService := NewArticleSuggestionService()
+	job.Register(job.TypeArticleSuggestion, articleSuggestionService.HandleArticleSuggestionJob)
+
+	dataExportService := NewDataExportService()
+	job.Register(job.TypeDataExport, dataExportService.HandleDataExportJob)
+
+	assetService := NewAssetService()
+	job.Register(job.TypeImageThumbnail, assetService.HandleImageThumbnailJob)
+	job.Register(job.TypeImageVariants, assetService.HandleImageVariantsJob)
+
+	attachmentService := NewAttachmentService()
+	job.Register(job.TypeAttachmentPreview, attachmentService.HandleAttachmentPreviewJob)
+}
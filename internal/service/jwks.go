@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+
+	"github.com/hcd233/aris-blog-api/internal/jwt"
+	"github.com/hcd233/aris-blog-api/internal/logger"
+	"github.com/hcd233/aris-blog-api/internal/protocol"
+	dto "github.com/hcd233/aris-blog-api/internal/protocol/dto"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+// JWKSService JWKS服务
+//
+//	author centonhuang
+//	update 2025-11-12 10:24:06
+type JWKSService interface {
+	GetJWKS(ctx context.Context, req *dto.EmptyRequest) (rsp *dto.JWKS, err error)
+}
+
+type jwksService struct{}
+
+// NewJWKSService 创建JWKS服务
+//
+//	return JWKSService
+//	author centonhuang
+//	update 2025-11-12 10:24:06
+func NewJWKSService() JWKSService {
+	return &jwksService{}
+}
+
+// GetJWKS 获取全部未退役签名密钥的公钥
+//
+//	receiver s *jwksService
+//	param ctx context.Context
+//	param req *dto.EmptyRequest
+//	return rsp *dto.JWKS
+//	return err error
+//	author centonhuang
+//	update 2025-11-12 10:24:06
+func (s *jwksService) GetJWKS(ctx context.Context, _ *dto.EmptyRequest) (rsp *dto.JWKS, err error) {
+	keys, err := jwt.GetJWKS()
+	if err != nil {
+		logger.WithCtx(ctx).Error("[JWKSService] failed to get jwks", zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	rsp = &dto.JWKS{
+		Keys: lo.Map(keys, func(key *jwt.JWK, _ int) *dto.JWK {
+			return &dto.JWK{Kty: key.Kty, Kid: key.Kid, Use: key.Use, Alg: key.Alg, N: key.N, E: key.E, Crv: key.Crv, X: key.X}
+		}),
+	}
+	return rsp, nil
+}
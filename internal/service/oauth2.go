@@ -3,11 +3,14 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/url"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
-	"github.com/hcd233/aris-blog-api/internal/config"
-	"github.com/hcd233/aris-blog-api/internal/jwt"
+	"github.com/hcd233/aris-blog-api/internal/constant"
 	"github.com/hcd233/aris-blog-api/internal/logger"
 	"github.com/hcd233/aris-blog-api/internal/protocol"
 	"github.com/hcd233/aris-blog-api/internal/protocol/dto"
@@ -18,6 +21,7 @@ import (
 
 	"github.com/hcd233/aris-blog-api/internal/oauth2"
 	"github.com/hcd233/aris-blog-api/internal/util"
+	"github.com/samber/lo"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
@@ -28,41 +32,80 @@ import (
 //	update 2025-01-05 21:00:00
 type Oauth2Service interface {
 	Login(ctx context.Context, req *dto.LoginRequest) (rsp *dto.LoginResponse, err error)
+	LinkLogin(ctx context.Context, req *dto.LoginRequest) (rsp *dto.LoginResponse, err error)
 	Callback(ctx context.Context, req *dto.CallbackRequest) (rsp *dto.CallbackResponse, err error)
+	Link(ctx context.Context, req *dto.LinkOauth2Request) (rsp *dto.EmptyResponse, err error)
+	Unlink(ctx context.Context, req *dto.UnlinkOauth2Request) (rsp *dto.EmptyResponse, err error)
 }
 
+var userBindFields = []string{"id", "github_bind_id", "qq_bind_id", "google_bind_id"}
+
 // oauth2Service OAuth2服务基础实现
 type oauth2Service struct {
-	provider           oauth2.Provider
-	userDAO            *dao.UserDAO
-	imageObjDAO        objdao.ObjDAO
-	thumbnailObjDAO    objdao.ObjDAO
-	accessTokenSigner  jwt.TokenSigner
-	refreshTokenSigner jwt.TokenSigner
+	platform        model.Platform
+	provider        oauth2.Provider
+	userDAO         *dao.UserDAO
+	oidcIdentityDAO *dao.OidcIdentityDAO
+	imageObjDAO     objdao.ObjDAO
+	thumbnailObjDAO objdao.ObjDAO
 }
 
 // NewGithubOauth2Service 创建Github OAuth2服务
 func NewGithubOauth2Service() Oauth2Service {
 	return &oauth2Service{
-		provider:           oauth2.NewGithubProvider(),
-		userDAO:            dao.GetUserDAO(),
-		imageObjDAO:        objdao.GetImageObjDAO(),
-		thumbnailObjDAO:    objdao.GetThumbnailObjDAO(),
-		accessTokenSigner:  jwt.GetAccessTokenSigner(),
-		refreshTokenSigner: jwt.GetRefreshTokenSigner(),
+		platform:        model.PlatformGithub,
+		provider:        oauth2.NewGithubProvider(),
+		userDAO:         dao.GetUserDAO(),
+		oidcIdentityDAO: dao.GetOidcIdentityDAO(),
+		imageObjDAO:     objdao.GetImageObjDAO(),
+		thumbnailObjDAO: objdao.GetThumbnailObjDAO(),
 	}
 }
 
 // NewGoogleOauth2Service 创建Google OAuth2服务
 func NewGoogleOauth2Service() Oauth2Service {
 	return &oauth2Service{
-		provider:           oauth2.NewGoogleProvider(),
-		userDAO:            dao.GetUserDAO(),
-		imageObjDAO:        objdao.GetImageObjDAO(),
-		thumbnailObjDAO:    objdao.GetThumbnailObjDAO(),
-		accessTokenSigner:  jwt.GetAccessTokenSigner(),
-		refreshTokenSigner: jwt.GetRefreshTokenSigner(),
+		platform:        model.PlatformGoogle,
+		provider:        oauth2.NewGoogleProvider(),
+		userDAO:         dao.GetUserDAO(),
+		oidcIdentityDAO: dao.GetOidcIdentityDAO(),
+		imageObjDAO:     objdao.GetImageObjDAO(),
+		thumbnailObjDAO: objdao.GetThumbnailObjDAO(),
+	}
+}
+
+// NewQQOauth2Service 创建QQ OAuth2服务
+func NewQQOauth2Service() Oauth2Service {
+	return &oauth2Service{
+		platform:        model.PlatformQQ,
+		provider:        oauth2.NewQQProvider(),
+		userDAO:         dao.GetUserDAO(),
+		oidcIdentityDAO: dao.GetOidcIdentityDAO(),
+		imageObjDAO:     objdao.GetImageObjDAO(),
+		thumbnailObjDAO: objdao.GetThumbnailObjDAO(),
+	}
+}
+
+// NewOIDCOauth2Service 创建通用OIDC服务
+//
+//	param name string
+//	return Oauth2Service
+//	return bool 未配置该名称的OIDC提供商时为false
+//	author centonhuang
+//	update 2025-11-13 19:26:03
+func NewOIDCOauth2Service(name string) (Oauth2Service, bool) {
+	provider, ok := oauth2.GetOIDCProvider(name)
+	if !ok {
+		return nil, false
 	}
+	return &oauth2Service{
+		platform:        model.Platform(name),
+		provider:        provider,
+		userDAO:         dao.GetUserDAO(),
+		oidcIdentityDAO: dao.GetOidcIdentityDAO(),
+		imageObjDAO:     objdao.GetImageObjDAO(),
+		thumbnailObjDAO: objdao.GetThumbnailObjDAO(),
+	}, true
 }
 
 // Login 登录
@@ -77,12 +120,42 @@ func NewGoogleOauth2Service() Oauth2Service {
 func (s *oauth2Service) Login(ctx context.Context, req *dto.LoginRequest) (rsp *dto.LoginResponse, err error) {
 	rsp = &dto.LoginResponse{}
 
-	logger := logger.WithCtx(ctx)
+	authURL, err := s.authorize(ctx, req.RedirectURL, 0)
+	if err != nil {
+		return nil, err
+	}
+	rsp.RedirectURL = authURL
 
-	url := s.provider.GetAuthURL()
-	rsp.RedirectURL = url
+	logger.WithCtx(ctx).Info("[Oauth2Service] login", zap.String("provider", req.Provider), zap.String("redirectURL", authURL))
 
-	logger.Info("[Oauth2Service] login", zap.String("provider", req.Provider), zap.String("redirectURL", url))
+	return rsp, nil
+}
+
+// LinkLogin 为当前用户发起绑定第三方账号的授权，签发的state只能用于绑定当前用户
+//
+//	receiver s *oauth2Service
+//	param ctx context.Context
+//	param req *dto.LoginRequest
+//	return rsp *dto.LoginResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-13 15:40:18
+func (s *oauth2Service) LinkLogin(ctx context.Context, req *dto.LoginRequest) (rsp *dto.LoginResponse, err error) {
+	rsp = &dto.LoginResponse{}
+
+	if err := requireSessionAuth(ctx); err != nil {
+		return nil, err
+	}
+
+	userID := ctx.Value(constant.CtxKeyUserID).(uint)
+
+	authURL, err := s.authorize(ctx, req.RedirectURL, userID)
+	if err != nil {
+		return nil, err
+	}
+	rsp.RedirectURL = authURL
+
+	logger.WithCtx(ctx).Info("[Oauth2Service] link login", zap.String("provider", req.Provider), zap.String("redirectURL", authURL))
 
 	return rsp, nil
 }
@@ -102,60 +175,56 @@ func (s *oauth2Service) Callback(ctx context.Context, req *dto.CallbackRequest)
 	logger := logger.WithCtx(ctx)
 	db := database.GetDBInstance(ctx)
 
-	if req.State != config.Oauth2StateString {
-		logger.Error("[Oauth2Service] invalid state",
-			zap.String("provider", req.Provider),
-			zap.String("state", req.State),
-			zap.String("expectedState", config.Oauth2StateString))
-		return nil, protocol.ErrUnauthorized
-	}
-
-	logger.Info("[Oauth2Service] exchanging token",
-		zap.String("provider", req.Provider),
-		zap.String("code", req.Code),
-		zap.String("state", req.State))
-
-	token, err := s.provider.ExchangeToken(ctx, req.Code)
-	if err != nil {
-		logger.Error("[Oauth2Service] failed to exchange token",
-			zap.String("provider", req.Provider),
-			zap.String("code", req.Code),
-			zap.Error(err))
-		return nil, protocol.ErrUnauthorized
-	}
-
-	logger.Info("[Oauth2Service] token exchange successful",
-		zap.String("provider", req.Provider),
-		zap.String("tokenType", token.TokenType),
-		zap.Bool("valid", token.Valid()))
-
-	userInfo, err := s.provider.GetUserInfo(ctx, token)
+	userInfo, state, err := s.fetchUserInfo(ctx, req.Code, req.State, 0)
 	if err != nil {
-		logger.Error("[Oauth2Service] failed to get user info",
-			zap.String("provider", req.Provider),
-			zap.Error(err))
-		return nil, protocol.ErrInternalError
+		return nil, err
 	}
 
 	thirdPartyID := userInfo.GetID()
 	userName, email, avatar := userInfo.GetName(), userInfo.GetEmail(), userInfo.GetAvatar()
-
-	user, err := s.userDAO.GetByEmail(db, email, []string{"id", "name", "avatar"}, []string{})
-	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		logger.Error("[Oauth2Service] failed to get user by email",
+	user, err := s.findLoginUser(db, thirdPartyID, email)
+	if err != nil {
+		logger.Error("[Oauth2Service] failed to find login user",
 			zap.String("provider", req.Provider),
+			zap.String("thirdPartyID", thirdPartyID),
 			zap.String("email", email),
 			zap.Error(err))
 		return nil, protocol.ErrInternalError
 	}
 
-	if user.ID != 0 {
-		// 更新已存在用户的登录时间
-		if err := s.userDAO.Update(db, user, map[string]interface{}{
-			"last_login": time.Now().UTC(),
-		}); err != nil {
-			logger.Error("[Oauth2Service] failed to update user login time",
+	if user != nil {
+		if user.IsBlocked(time.Now().UTC()) {
+			logger.Info("[Oauth2Service] user is blocked",
+				zap.String("provider", req.Provider),
+				zap.Uint("userID", user.ID),
+				zap.String("status", string(user.Status)))
+			return nil, protocol.ErrNoPermission
+		}
+
+		// 通过邮箱匹配到的用户已绑定该平台的其他账号时拒绝登录，避免邮箱变更导致账号被接管
+		boundID, bound := user.GetBindIDs()[s.platform]
+		if bound && boundID != thirdPartyID {
+			logger.Error("[Oauth2Service] email matched a user bound to another third party account",
 				zap.String("provider", req.Provider),
+				zap.Uint("userID", user.ID),
+				zap.String("thirdPartyID", thirdPartyID))
+			return nil, protocol.ErrUnauthorized
+		}
+
+		// 更新已存在用户的登录时间，首次通过邮箱匹配时写入绑定ID
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := s.userDAO.Update(tx, user, map[string]interface{}{"last_login": time.Now().UTC()}); err != nil {
+				return err
+			}
+			if bound {
+				return nil
+			}
+			return s.bind(tx, user.ID, thirdPartyID)
+		})
+		if err != nil {
+			logger.Error("[Oauth2Service] failed to update user login",
+				zap.String("provider", req.Provider),
+				zap.String("thirdPartyID", thirdPartyID),
 				zap.Error(err))
 			return nil, protocol.ErrInternalError
 		}
@@ -164,6 +233,10 @@ func (s *oauth2Service) Callback(ctx context.Context, req *dto.CallbackRequest)
 		if validateErr := util.ValidateUserName(userName); validateErr != nil {
 			userName = "ArisUser" + strconv.FormatInt(time.Now().UTC().Unix(), 10)
 		}
+		// 不提供邮箱的平台使用保留域名下的占位邮箱
+		if email == "" {
+			email = fmt.Sprintf("%s@%s.oauth2.invalid", thirdPartyID, s.platform)
+		}
 		defaultCategory := &model.Category{Name: userName}
 
 		user = &model.User{
@@ -176,7 +249,16 @@ func (s *oauth2Service) Callback(ctx context.Context, req *dto.CallbackRequest)
 			Categories: []model.Category{*defaultCategory},
 		}
 
-		if err := s.userDAO.Create(db, user); err != nil {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := s.userDAO.Create(tx, user); err != nil {
+				return err
+			}
+			if err := s.bind(tx, user.ID, thirdPartyID); err != nil {
+				return err
+			}
+			return syncBuiltinRole(tx, user, user.Permission)
+		})
+		if err != nil {
 			logger.Error("[Oauth2Service] failed to create user",
 				zap.String("provider", req.Provider),
 				zap.String("userName", userName),
@@ -203,43 +285,331 @@ func (s *oauth2Service) Callback(ctx context.Context, req *dto.CallbackRequest)
 		logger.Info("[Oauth2Service] thumbnail dir created", zap.String("provider", req.Provider))
 	}
 
-	// 更新第三方平台绑定ID
-	bindField := s.provider.GetBindField()
-	updateData := map[string]interface{}{
-		bindField: thirdPartyID,
+	accessToken, refreshToken, err := issueSessionTokens(ctx, db, user.ID)
+	if err != nil {
+		logger.Error("[Oauth2Service] failed to issue session tokens",
+			zap.String("provider", req.Provider),
+			zap.Error(err))
+		return nil, protocol.ErrInternalError
 	}
 
-	if err := s.userDAO.Update(db, user, updateData); err != nil {
-		logger.Error("[Oauth2Service] failed to update third party bind id",
+	if err := recordAuditEvent(ctx, db, &model.AuditEvent{
+		ActorID:    user.ID,
+		Action:     model.AuditActionUserLogin,
+		TargetType: model.AuditTargetTypeUser,
+		TargetID:   user.ID,
+		After:      map[string]any{"provider": req.Provider},
+	}); err != nil {
+		logger.Error("[Oauth2Service] failed to record login audit event",
+			zap.String("provider", req.Provider),
+			zap.Error(err))
+	}
+
+	logger.Info("[Oauth2Service] callback success",
+		zap.String("provider", req.Provider),
+		zap.Uint("userID", user.ID))
+
+	rsp.AccessToken = accessToken
+	rsp.RefreshToken = refreshToken
+	rsp.RedirectURL = state.RedirectURL
+
+	return rsp, nil
+}
+
+// Link 为当前用户绑定第三方账号
+//
+//	receiver s *oauth2Service
+//	param ctx context.Context
+//	param req *dto.LinkOauth2Request
+//	return rsp *dto.EmptyResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-13 10:12:37
+func (s *oauth2Service) Link(ctx context.Context, req *dto.LinkOauth2Request) (rsp *dto.EmptyResponse, err error) {
+	rsp = &dto.EmptyResponse{}
+
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	if err := requireSessionAuth(ctx); err != nil {
+		return nil, err
+	}
+
+	userID := ctx.Value(constant.CtxKeyUserID).(uint)
+
+	userInfo, _, err := s.fetchUserInfo(ctx, req.Body.Code, req.Body.State, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	thirdPartyID := userInfo.GetID()
+
+	bound, err := s.getUserByBindID(db, thirdPartyID, []string{"id"}, []string{})
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		logger.Error("[Oauth2Service] failed to get user by bind id",
 			zap.String("provider", req.Provider),
-			zap.String("bindField", bindField),
 			zap.String("thirdPartyID", thirdPartyID),
 			zap.Error(err))
 		return nil, protocol.ErrInternalError
 	}
+	if err == nil {
+		if bound.ID == userID {
+			return rsp, nil
+		}
+		logger.Error("[Oauth2Service] third party account already linked to another user",
+			zap.String("provider", req.Provider),
+			zap.Uint("boundUserID", bound.ID))
+		return nil, protocol.ErrDataExists
+	}
 
-	accessToken, err := s.accessTokenSigner.EncodeToken(user.ID)
+	user, err := s.userDAO.GetByID(db, userID, userBindFields, []string{"OidcIdentities"})
 	if err != nil {
-		logger.Error("[Oauth2Service] failed to encode access token",
+		logger.Error("[Oauth2Service] failed to get user", zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	before := user.GetBindIDs()
+	if _, ok := before[s.platform]; ok {
+		logger.Error("[Oauth2Service] provider already linked to another third party account", zap.String("provider", req.Provider))
+		return nil, protocol.ErrDataExists
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := s.bind(tx, userID, thirdPartyID); err != nil {
+			return err
+		}
+		return recordAuditEvent(ctx, tx, &model.AuditEvent{
+			Action:     model.AuditActionUserOauth2Link,
+			TargetType: model.AuditTargetTypeUser,
+			TargetID:   userID,
+			Before:     map[string]any{"providers": linkedProviders(before)},
+			After:      map[string]any{"providers": linkedProviders(lo.Assign(before, map[model.Platform]string{s.platform: thirdPartyID}))},
+		})
+	})
+	if err != nil {
+		logger.Error("[Oauth2Service] failed to link third party account",
 			zap.String("provider", req.Provider),
+			zap.String("thirdPartyID", thirdPartyID),
 			zap.Error(err))
 		return nil, protocol.ErrInternalError
 	}
 
-	refreshToken, err := s.refreshTokenSigner.EncodeToken(user.ID)
+	logger.Info("[Oauth2Service] third party account linked", zap.String("provider", req.Provider))
+
+	return rsp, nil
+}
+
+// Unlink 解绑当前用户的第三方账号，不允许解绑最后一种登录方式
+//
+//	receiver s *oauth2Service
+//	param ctx context.Context
+//	param req *dto.UnlinkOauth2Request
+//	return rsp *dto.EmptyResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-13 10:12:37
+func (s *oauth2Service) Unlink(ctx context.Context, req *dto.UnlinkOauth2Request) (rsp *dto.EmptyResponse, err error) {
+	rsp = &dto.EmptyResponse{}
+
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	if err := requireSessionAuth(ctx); err != nil {
+		return nil, err
+	}
+
+	userID := ctx.Value(constant.CtxKeyUserID).(uint)
+
+	user, err := s.userDAO.GetByID(db, userID, userBindFields, []string{"OidcIdentities"})
 	if err != nil {
-		logger.Error("[Oauth2Service] failed to encode refresh token",
+		logger.Error("[Oauth2Service] failed to get user", zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	before := user.GetBindIDs()
+	if _, ok := before[s.platform]; !ok {
+		logger.Error("[Oauth2Service] provider not linked", zap.String("provider", req.Provider))
+		return nil, protocol.ErrDataNotExists
+	}
+	if len(before) == 1 {
+		logger.Error("[Oauth2Service] refuse to unlink the last login method", zap.String("provider", req.Provider))
+		return nil, protocol.ErrBadRequest
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := s.unbind(tx, userID); err != nil {
+			return err
+		}
+		return recordAuditEvent(ctx, tx, &model.AuditEvent{
+			Action:     model.AuditActionUserOauth2Unlink,
+			TargetType: model.AuditTargetTypeUser,
+			TargetID:   userID,
+			Before:     map[string]any{"providers": linkedProviders(before)},
+			After:      map[string]any{"providers": linkedProviders(lo.OmitByKeys(before, []model.Platform{s.platform}))},
+		})
+	})
+	if err != nil {
+		logger.Error("[Oauth2Service] failed to unlink third party account",
 			zap.String("provider", req.Provider),
 			zap.Error(err))
 		return nil, protocol.ErrInternalError
 	}
 
-	logger.Info("[Oauth2Service] callback success",
-		zap.String("provider", req.Provider),
-		zap.Uint("userID", user.ID))
-
-	rsp.AccessToken = accessToken
-	rsp.RefreshToken = refreshToken
+	logger.Info("[Oauth2Service] third party account unlinked", zap.String("provider", req.Provider))
 
 	return rsp, nil
 }
+
+// authorize 保存授权上下文并生成提供商授权URL，userID非0时表示绑定流程
+func (s *oauth2Service) authorize(ctx context.Context, redirectURL string, userID uint) (string, error) {
+	logger := logger.WithCtx(ctx)
+
+	if !isRelativeRedirect(redirectURL) {
+		logger.Error("[Oauth2Service] invalid redirect url", zap.String("redirectURL", redirectURL))
+		return "", protocol.ErrBadRequest
+	}
+
+	data := &oauth2.State{
+		Provider:    oauth2.ProviderType(s.platform),
+		RedirectURL: redirectURL,
+		UserID:      userID,
+	}
+	state, err := oauth2.SaveState(ctx, data)
+	if err != nil {
+		logger.Error("[Oauth2Service] failed to save state", zap.String("provider", string(s.platform)), zap.Error(err))
+		return "", protocol.ErrInternalError
+	}
+
+	authURL, err := s.provider.GetAuthURL(ctx, state, data.Verifier)
+	if err != nil {
+		logger.Error("[Oauth2Service] failed to get auth url", zap.String("provider", string(s.platform)), zap.Error(err))
+		return "", protocol.ErrInternalError
+	}
+	return authURL, nil
+}
+
+// fetchUserInfo 消费state并通过授权码获取第三方用户信息，state必须由同一用户发起，登录流程userID为0
+func (s *oauth2Service) fetchUserInfo(ctx context.Context, code, state string, userID uint) (oauth2.UserInfo, *oauth2.State, error) {
+	logger := logger.WithCtx(ctx)
+
+	data, err := oauth2.ConsumeState(ctx, oauth2.ProviderType(s.platform), state)
+	if err != nil {
+		if errors.Is(err, oauth2.ErrStateNotFound) {
+			logger.Error("[Oauth2Service] invalid state",
+				zap.String("provider", string(s.platform)),
+				zap.String("state", state))
+			return nil, nil, protocol.ErrUnauthorized
+		}
+		logger.Error("[Oauth2Service] failed to consume state",
+			zap.String("provider", string(s.platform)),
+			zap.Error(err))
+		return nil, nil, protocol.ErrInternalError
+	}
+	if data.UserID != userID {
+		logger.Error("[Oauth2Service] state issued for another flow",
+			zap.String("provider", string(s.platform)),
+			zap.Uint("stateUserID", data.UserID),
+			zap.Uint("userID", userID))
+		return nil, nil, protocol.ErrUnauthorized
+	}
+
+	logger.Info("[Oauth2Service] exchanging token",
+		zap.String("provider", string(s.platform)),
+		zap.String("code", code))
+
+	token, err := s.provider.ExchangeToken(ctx, code, data.Verifier)
+	if err != nil {
+		logger.Error("[Oauth2Service] failed to exchange token",
+			zap.String("provider", string(s.platform)),
+			zap.String("code", code),
+			zap.Error(err))
+		return nil, nil, protocol.ErrUnauthorized
+	}
+
+	logger.Info("[Oauth2Service] token exchange successful",
+		zap.String("provider", string(s.platform)),
+		zap.String("tokenType", token.TokenType),
+		zap.Bool("valid", token.Valid()))
+
+	userInfo, err := s.provider.GetUserInfo(ctx, token)
+	if err != nil {
+		logger.Error("[Oauth2Service] failed to get user info",
+			zap.String("provider", string(s.platform)),
+			zap.Error(err))
+		return nil, nil, protocol.ErrInternalError
+	}
+
+	return userInfo, data, nil
+}
+
+// findLoginUser 优先按第三方绑定ID匹配用户，未绑定时回退到邮箱匹配，均未找到时返回nil
+func (s *oauth2Service) findLoginUser(db *gorm.DB, thirdPartyID, email string) (*model.User, error) {
+	fields := append([]string{"name", "avatar", "status", "suspended_until"}, userBindFields...)
+
+	preloads := []string{"OidcIdentities"}
+
+	user, err := s.getUserByBindID(db, thirdPartyID, fields, preloads)
+	if err == nil {
+		return user, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	if email == "" {
+		return nil, nil
+	}
+
+	user, err = s.userDAO.GetByEmail(db, email, fields, preloads)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return user, err
+}
+
+// isRelativeRedirect 登录后跳转地址只允许站内相对路径，防止开放重定向
+func isRelativeRedirect(redirectURL string) bool {
+	if redirectURL == "" {
+		return true
+	}
+	if !strings.HasPrefix(redirectURL, "/") || strings.HasPrefix(redirectURL, "//") || strings.Contains(redirectURL, "\\") {
+		return false
+	}
+	u, err := url.Parse(redirectURL)
+	return err == nil && u.Scheme == "" && u.Host == ""
+}
+
+// getUserByBindID 按当前提供商的绑定ID获取用户，OIDC提供商通过身份表查找
+func (s *oauth2Service) getUserByBindID(db *gorm.DB, bindID string, fields, preloads []string) (*model.User, error) {
+	if bindField := s.provider.GetBindField(); bindField != "" {
+		return s.userDAO.GetByBindID(db, bindField, bindID, fields, preloads)
+	}
+
+	identity, err := s.oidcIdentityDAO.GetBySubject(db, string(s.platform), bindID, []string{"user_id"})
+	if err != nil {
+		return nil, err
+	}
+	return s.userDAO.GetByID(db, identity.UserID, fields, preloads)
+}
+
+// bind 为用户写入当前提供商的绑定ID
+func (s *oauth2Service) bind(db *gorm.DB, userID uint, bindID string) error {
+	if bindField := s.provider.GetBindField(); bindField != "" {
+		return s.userDAO.Update(db, &model.User{ID: userID}, map[string]interface{}{bindField: bindID})
+	}
+	return s.oidcIdentityDAO.Create(db, &model.OidcIdentity{UserID: userID, Provider: string(s.platform), Subject: bindID})
+}
+
+// unbind 清除用户在当前提供商的绑定ID
+func (s *oauth2Service) unbind(db *gorm.DB, userID uint) error {
+	if bindField := s.provider.GetBindField(); bindField != "" {
+		return s.userDAO.Update(db, &model.User{ID: userID}, map[string]interface{}{bindField: nil})
+	}
+	return s.oidcIdentityDAO.DeleteByUserID(db, userID, string(s.platform))
+}
+
+func linkedProviders(bindIDs map[model.Platform]string) []string {
+	providers := lo.Map(lo.Keys(bindIDs), func(platform model.Platform, _ int) string { return string(platform) })
+	slices.Sort(providers)
+	return providers
+}
@@ -0,0 +1,307 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"sync"
+
+	"github.com/bytedance/sonic"
+	"github.com/hcd233/aris-blog-api/internal/logger"
+	"github.com/hcd233/aris-blog-api/internal/resource/database"
+	"github.com/hcd233/aris-blog-api/internal/resource/database/dao"
+	"github.com/hcd233/aris-blog-api/internal/resource/storage"
+	objdao "github.com/hcd233/aris-blog-api/internal/resource/storage/obj_dao"
+	"go.uber.org/zap"
+)
+
+const objectMigrationStatePerm = 0o600
+
+// 变体可按需重新生成，导出归档有效期很短，只迁移原图、缩略图、附件与附件预览图
+var objectMigrationTypes = []objdao.ObjectType{
+	objdao.ObjectTypeImage, objdao.ObjectTypeThumbnail, objdao.ObjectTypeAttachment, objdao.ObjectTypeAttachmentPreview,
+}
+
+// ObjectMigrationOptions 对象迁移参数
+//
+//	author centonhuang
+//	update 2025-11-17 19:42:16
+type ObjectMigrationOptions struct {
+	From        storage.Provider
+	To          storage.Provider
+	Concurrency int
+	StateFile   string
+	DryRun      bool
+}
+
+// ObjectMigrationResult 对象迁移结果，dry run时为待复制的数量
+//
+//	author centonhuang
+//	update 2025-11-17 19:42:16
+type ObjectMigrationResult struct {
+	Users       int
+	Copied      int
+	Skipped     int
+	Failed      int
+	CopiedBytes int64
+}
+
+// objectMigrationState 可续传的迁移进度，记录已校验完成的对象及其SHA-256摘要
+type objectMigrationState struct {
+	From      storage.Provider  `json:"from"`
+	To        storage.Provider  `json:"to"`
+	Completed map[string]string `json:"completed"`
+
+	mu   sync.Mutex
+	path string
+}
+
+// loadObjectMigrationState 读取进度文件，文件不存在时从头开始，源与目标不一致时拒绝续传
+func loadObjectMigrationState(statePath string, from, to storage.Provider) (*objectMigrationState, error) {
+	state := &objectMigrationState{From: from, To: to, Completed: make(map[string]string), path: statePath}
+
+	data, err := os.ReadFile(statePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err = sonic.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("parse state file %s: %w", statePath, err)
+	}
+	if state.From != from || state.To != to {
+		return nil, fmt.Errorf("state file %s records a migration from %s to %s", statePath, state.From, state.To)
+	}
+	if state.Completed == nil {
+		state.Completed = make(map[string]string)
+	}
+	return state, nil
+}
+
+func composeObjectMigrationKey(objectType objdao.ObjectType, userID uint, objectName string) string {
+	return path.Join(string(objectType), strconv.FormatUint(uint64(userID), 10), objectName)
+}
+
+func (s *objectMigrationState) isCompleted(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.Completed[key]
+	return ok
+}
+
+func (s *objectMigrationState) complete(key, checksum string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Completed[key] = checksum
+}
+
+// save 先写临时文件再重命名，中断时不会留下损坏的进度文件
+func (s *objectMigrationState) save() error {
+	s.mu.Lock()
+	data, err := sonic.Marshal(s)
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	tmpPath := s.path + ".tmp"
+	if err = os.WriteFile(tmpPath, data, objectMigrationStatePerm); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, s.path)
+}
+
+// ObjectMigrationService 对象存储迁移服务
+//
+//	author centonhuang
+//	update 2025-11-17 19:42:16
+type ObjectMigrationService interface {
+	MigrateObjects(ctx context.Context, opts *ObjectMigrationOptions) (result *ObjectMigrationResult, err error)
+}
+
+type objectMigrationService struct {
+	userDAO *dao.UserDAO
+}
+
+// NewObjectMigrationService 创建对象存储迁移服务
+//
+//	return ObjectMigrationService
+//	author centonhuang
+//	update 2025-11-17 19:42:16
+func NewObjectMigrationService() ObjectMigrationService {
+	return &objectMigrationService{
+		userDAO: dao.GetUserDAO(),
+	}
+}
+
+// MigrateObjects 按用户目录将原图、缩略图、附件与附件预览图从源提供商复制到目标提供商，复制后重新下载校验摘要；
+// 已完成的对象记录在进度文件中，重复执行时跳过，单个对象失败时记录日志并继续
+//
+//	receiver s *objectMigrationService
+//	param ctx context.Context
+//	param opts *ObjectMigrationOptions
+//	return result *ObjectMigrationResult
+//	return err error
+//	author centonhuang
+//	update 2025-11-17 19:42:16
+func (s *objectMigrationService) MigrateObjects(ctx context.Context, opts *ObjectMigrationOptions) (result *ObjectMigrationResult, err error) {
+	logger := logger.WithCtx(ctx).With(
+		zap.String("from", string(opts.From)),
+		zap.String("to", string(opts.To)),
+		zap.Bool("dryRun", opts.DryRun))
+
+	if opts.From == opts.To {
+		return nil, fmt.Errorf("source and destination provider are both %s", opts.From)
+	}
+	concurrency := max(opts.Concurrency, 1)
+
+	state, err := loadObjectMigrationState(opts.StateFile, opts.From, opts.To)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs, err := s.userDAO.ListIDs(database.GetDBInstance(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("list users: %w", err)
+	}
+
+	result = &ObjectMigrationResult{Users: len(userIDs)}
+	var mu sync.Mutex
+
+	for _, objectType := range objectMigrationTypes {
+		src := objdao.NewObjDAO(opts.From, objectType)
+		dst := objdao.NewObjDAO(opts.To, objectType)
+
+		for _, userID := range userIDs {
+			objectInfos, err := src.ListObjects(ctx, userID)
+			if err != nil {
+				return result, fmt.Errorf("list %s objects of user %d: %w", objectType, userID, err)
+			}
+
+			var pending []objdao.ObjectInfo
+			for _, objectInfo := range objectInfos {
+				if state.isCompleted(composeObjectMigrationKey(objectType, userID, objectInfo.ObjectName)) {
+					result.Skipped++
+					continue
+				}
+				pending = append(pending, objectInfo)
+			}
+			if len(pending) == 0 {
+				continue
+			}
+
+			if opts.DryRun {
+				for _, objectInfo := range pending {
+					result.Copied++
+					result.CopiedBytes += objectInfo.Size
+				}
+				logger.Info("[ObjectMigrationService] objects to migrate",
+					zap.String("objectType", string(objectType)),
+					zap.Uint("userID", userID),
+					zap.Int("objects", len(pending)))
+				continue
+			}
+
+			objectInfoChan := make(chan objdao.ObjectInfo)
+			var wg sync.WaitGroup
+			for range concurrency {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for objectInfo := range objectInfoChan {
+						checksum, err := copyObject(ctx, src, dst, userID, &objectInfo)
+
+						mu.Lock()
+						if err != nil {
+							logger.Error("[ObjectMigrationService] failed to migrate object",
+								zap.String("objectType", string(objectType)),
+								zap.Uint("userID", userID),
+								zap.String("objectName", objectInfo.ObjectName),
+								zap.Error(err))
+							result.Failed++
+						} else {
+							result.Copied++
+							result.CopiedBytes += objectInfo.Size
+						}
+						mu.Unlock()
+
+						if err == nil {
+							state.complete(composeObjectMigrationKey(objectType, userID, objectInfo.ObjectName), checksum)
+						}
+					}
+				}()
+			}
+			for _, objectInfo := range pending {
+				objectInfoChan <- objectInfo
+			}
+			close(objectInfoChan)
+			wg.Wait()
+
+			// 每个用户目录完成后保存进度，中断后从该目录继续
+			if err = state.save(); err != nil {
+				return result, fmt.Errorf("save state file %s: %w", opts.StateFile, err)
+			}
+			logger.Info("[ObjectMigrationService] user directory migrated",
+				zap.String("objectType", string(objectType)),
+				zap.Uint("userID", userID),
+				zap.Int("objects", len(pending)))
+		}
+	}
+
+	logger.Info("[ObjectMigrationService] objects migrated",
+		zap.Int("users", result.Users),
+		zap.Int("copied", result.Copied),
+		zap.Int("skipped", result.Skipped),
+		zap.Int("failed", result.Failed),
+		zap.Int64("copiedBytes", result.CopiedBytes))
+	return result, nil
+}
+
+// copyObject 复制单个对象并从目标重新下载，大小与SHA-256摘要一致才视为完成；附件可能较大，经临时文件中转
+func copyObject(ctx context.Context, src, dst objdao.ObjDAO, userID uint, objectInfo *objdao.ObjectInfo) (checksum string, err error) {
+	file, err := os.CreateTemp("", "object-migrate-*")
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		file.Close()
+		os.Remove(file.Name())
+	}()
+
+	srcHash := sha256.New()
+	if _, err = src.DownloadObject(ctx, userID, objectInfo.ObjectName, io.MultiWriter(file, srcHash)); err != nil {
+		return "", fmt.Errorf("download source: %w", err)
+	}
+	size, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return "", err
+	}
+	if size != objectInfo.Size {
+		return "", fmt.Errorf("source size mismatch: listed %d, downloaded %d", objectInfo.Size, size)
+	}
+	checksum = hex.EncodeToString(srcHash.Sum(nil))
+
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	if err = dst.UploadObject(ctx, userID, objectInfo.ObjectName, size, file); err != nil {
+		return "", fmt.Errorf("upload destination: %w", err)
+	}
+
+	dstHash := sha256.New()
+	if _, err = dst.DownloadObject(ctx, userID, objectInfo.ObjectName, dstHash); err != nil {
+		return "", fmt.Errorf("download destination: %w", err)
+	}
+	if dstChecksum := hex.EncodeToString(dstHash.Sum(nil)); dstChecksum != checksum {
+		return "", fmt.Errorf("checksum mismatch: source %s, destination %s", checksum, dstChecksum)
+	}
+	return checksum, nil
+}
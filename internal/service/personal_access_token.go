@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hcd233/aris-blog-api/internal/constant"
+	"github.com/hcd233/aris-blog-api/internal/logger"
+	"github.com/hcd233/aris-blog-api/internal/protocol"
+	dto "github.com/hcd233/aris-blog-api/internal/protocol/dto"
+	"github.com/hcd233/aris-blog-api/internal/resource/database"
+	"github.com/hcd233/aris-blog-api/internal/resource/database/dao"
+	"github.com/hcd233/aris-blog-api/internal/resource/database/model"
+	"github.com/hcd233/aris-blog-api/internal/util"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const patDisplayPrefixLength = len(util.PersonalAccessTokenPrefix) + 4
+
+var patFields = []string{"id", "name", "prefix", "scopes", "created_at", "expires_at", "last_used_at", "last_used_ip"}
+
+// PersonalAccessTokenService 个人访问令牌服务
+//
+//	author centonhuang
+//	update 2025-11-12 14:02:51
+type PersonalAccessTokenService interface {
+	ListTokens(ctx context.Context, req *dto.EmptyRequest) (rsp *dto.ListPersonalAccessTokensResponse, err error)
+	CreateToken(ctx context.Context, req *dto.CreatePersonalAccessTokenRequest) (rsp *dto.CreatePersonalAccessTokenResponse, err error)
+	RevokeToken(ctx context.Context, req *dto.RevokePersonalAccessTokenRequest) (rsp *dto.EmptyResponse, err error)
+}
+
+type personalAccessTokenService struct {
+	patDAO *dao.PersonalAccessTokenDAO
+}
+
+// NewPersonalAccessTokenService 创建个人访问令牌服务
+//
+//	return PersonalAccessTokenService
+//	author centonhuang
+//	update 2025-11-12 14:02:51
+func NewPersonalAccessTokenService() PersonalAccessTokenService {
+	return &personalAccessTokenService{
+		patDAO: dao.GetPersonalAccessTokenDAO(),
+	}
+}
+
+// ListTokens 列出当前用户未吊销的个人访问令牌
+//
+//	receiver s *personalAccessTokenService
+//	param ctx context.Context
+//	param req *dto.EmptyRequest
+//	return rsp *dto.ListPersonalAccessTokensResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-12 14:02:51
+func (s *personalAccessTokenService) ListTokens(ctx context.Context, _ *dto.EmptyRequest) (rsp *dto.ListPersonalAccessTokensResponse, err error) {
+	rsp = &dto.ListPersonalAccessTokensResponse{}
+
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	userID := ctx.Value(constant.CtxKeyUserID).(uint)
+
+	tokens, err := s.patDAO.ListByUserID(db, userID, patFields)
+	if err != nil {
+		logger.Error("[PersonalAccessTokenService] failed to list tokens", zap.Uint("userID", userID), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	rsp.Tokens = lo.Map(*tokens, func(token model.PersonalAccessToken, _ int) *dto.PersonalAccessToken {
+		return buildPersonalAccessTokenDTO(&token)
+	})
+
+	return rsp, nil
+}
+
+// CreateToken 创建个人访问令牌，令牌权限项不能超出当前用户持有的权限项
+//
+//	receiver s *personalAccessTokenService
+//	param ctx context.Context
+//	param req *dto.CreatePersonalAccessTokenRequest
+//	return rsp *dto.CreatePersonalAccessTokenResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-20 17:12:45
+func (s *personalAccessTokenService) CreateToken(ctx context.Context, req *dto.CreatePersonalAccessTokenRequest) (rsp *dto.CreatePersonalAccessTokenResponse, err error) {
+	rsp = &dto.CreatePersonalAccessTokenResponse{}
+
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	if err := requireSessionAuth(ctx); err != nil {
+		return nil, err
+	}
+
+	if req.Body == nil {
+		logger.Error("[PersonalAccessTokenService] request body is nil")
+		return nil, protocol.ErrBadRequest
+	}
+
+	userID := ctx.Value(constant.CtxKeyUserID).(uint)
+	userScopes, _ := ctx.Value(constant.CtxKeyScopes).([]model.Scope)
+
+	scopes, err := parseScopes(req.Body.Scopes)
+	if err != nil {
+		logger.Error("[PersonalAccessTokenService] invalid scopes", zap.Strings("scopes", req.Body.Scopes), zap.Error(err))
+		return nil, protocol.ErrBadRequest
+	}
+	// 逐项检查，*只有持有*的用户才能申请，否则令牌会随用户日后获得的权限自动扩权
+	if !lo.EveryBy(scopes, func(scope model.Scope) bool { return model.HasScope(userScopes, scope) }) {
+		logger.Error("[PersonalAccessTokenService] scopes exceed the user's permissions",
+			zap.Strings("scopes", req.Body.Scopes),
+			zap.Any("userScopes", userScopes))
+		return nil, protocol.ErrNoPermission
+	}
+
+	plainToken, err := util.GeneratePersonalAccessToken()
+	if err != nil {
+		logger.Error("[PersonalAccessTokenService] failed to generate token", zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	token := &model.PersonalAccessToken{
+		UserID:    userID,
+		Name:      req.Body.Name,
+		TokenHash: util.HashToken(plainToken),
+		Prefix:    plainToken[:patDisplayPrefixLength],
+		Scopes:    scopes,
+		ExpiresAt: time.Now().UTC().AddDate(0, 0, req.Body.ExpiresInDays),
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := s.patDAO.Create(tx, token); err != nil {
+			return err
+		}
+		return recordAuditEvent(ctx, tx, &model.AuditEvent{
+			Action:     model.AuditActionPersonalAccessTokenCreate,
+			TargetType: model.AuditTargetTypePersonalAccessToken,
+			TargetID:   token.ID,
+			After:      map[string]any{"name": token.Name, "scopes": token.Scopes, "expiresAt": token.ExpiresAt},
+		})
+	})
+	if err != nil {
+		logger.Error("[PersonalAccessTokenService] failed to create token", zap.Uint("userID", userID), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	logger.Info("[PersonalAccessTokenService] token created", zap.Uint("userID", userID), zap.Uint("tokenID", token.ID))
+
+	rsp.Token = buildPersonalAccessTokenDTO(token)
+	rsp.PlainToken = plainToken
+	return rsp, nil
+}
+
+// RevokeToken 吊销当前用户的个人访问令牌
+//
+//	receiver s *personalAccessTokenService
+//	param ctx context.Context
+//	param req *dto.RevokePersonalAccessTokenRequest
+//	return rsp *dto.EmptyResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-12 14:02:51
+func (s *personalAccessTokenService) RevokeToken(ctx context.Context, req *dto.RevokePersonalAccessTokenRequest) (rsp *dto.EmptyResponse, err error) {
+	rsp = &dto.EmptyResponse{}
+
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	if err := requireSessionAuth(ctx); err != nil {
+		return nil, err
+	}
+
+	userID := ctx.Value(constant.CtxKeyUserID).(uint)
+
+	token, err := s.patDAO.GetByID(db, req.TokenID, []string{"id", "user_id", "name", "revoked_at"}, []string{})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Error("[PersonalAccessTokenService] token not found", zap.Uint("tokenID", req.TokenID))
+			return nil, protocol.ErrDataNotExists
+		}
+		logger.Error("[PersonalAccessTokenService] failed to get token", zap.Uint("tokenID", req.TokenID), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	if token.UserID != userID {
+		logger.Error("[PersonalAccessTokenService] no permission to revoke token",
+			zap.Uint("tokenID", token.ID),
+			zap.Uint("tokenUserID", token.UserID))
+		return nil, protocol.ErrNoPermission
+	}
+
+	if !token.RevokedAt.IsZero() {
+		return rsp, nil
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := s.patDAO.Revoke(tx, token); err != nil {
+			return err
+		}
+		return recordAuditEvent(ctx, tx, &model.AuditEvent{
+			Action:     model.AuditActionPersonalAccessTokenRevoke,
+			TargetType: model.AuditTargetTypePersonalAccessToken,
+			TargetID:   token.ID,
+			Before:     map[string]any{"name": token.Name},
+		})
+	})
+	if err != nil {
+		logger.Error("[PersonalAccessTokenService] failed to revoke token", zap.Uint("tokenID", token.ID), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	logger.Info("[PersonalAccessTokenService] token revoked", zap.Uint("tokenID", token.ID))
+
+	return rsp, nil
+}
+
+// requireSessionAuth 要求请求使用登录会话签发的访问令牌，个人访问令牌不能管理凭据
+func requireSessionAuth(ctx context.Context) error {
+	if _, ok := ctx.Value(constant.CtxKeySessionID).(uint); !ok {
+		logger.WithCtx(ctx).Error("[Auth] operation requires a session token")
+		return protocol.ErrNoPermission
+	}
+	return nil
+}
+
+func buildPersonalAccessTokenDTO(token *model.PersonalAccessToken) *dto.PersonalAccessToken {
+	pat := &dto.PersonalAccessToken{
+		TokenID:   token.ID,
+		Name:      token.Name,
+		Prefix:    token.Prefix,
+		Scopes:    lo.Map(token.Scopes, func(scope model.Scope, _ int) string { return string(scope) }),
+		Expired:   !time.Now().UTC().Before(token.ExpiresAt),
+		CreatedAt: token.CreatedAt.Format(time.DateTime),
+		ExpiresAt: token.ExpiresAt.Format(time.DateTime),
+	}
+	if !token.LastUsedAt.IsZero() {
+		pat.LastUsedAt = token.LastUsedAt.Format(time.DateTime)
+		pat.LastUsedIP = token.LastUsedIP
+	}
+	return pat
+}
@@ -0,0 +1,335 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/hcd233/aris-blog-api/internal/logger"
+	"github.com/hcd233/aris-blog-api/internal/protocol"
+	dto "github.com/hcd233/aris-blog-api/internal/protocol/dto"
+	"github.com/hcd233/aris-blog-api/internal/resource/database"
+	"github.com/hcd233/aris-blog-api/internal/resource/database/dao"
+	"github.com/hcd233/aris-blog-api/internal/resource/database/model"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var roleFields = []string{"id", "name", "description", "scopes", "built_in", "created_at", "updated_at"}
+
+// syncBuiltinRole 将用户的内置角色替换为与权限等级同名的内置角色，保留自定义角色
+//
+//	param tx *gorm.DB
+//	param user *model.User
+//	param permission model.Permission
+//	return error
+//	author centonhuang
+//	update 2025-11-11 17:05:24
+func syncBuiltinRole(tx *gorm.DB, user *model.User, permission model.Permission) error {
+	roleDAO, userDAO := dao.GetRoleDAO(), dao.GetUserDAO()
+
+	builtinRole, err := roleDAO.GetByName(tx, string(permission), []string{"id", "name"}, []string{})
+	if err != nil {
+		return err
+	}
+
+	var roles []model.Role
+	if err := tx.Model(user).Select("id", "built_in").Association("Roles").Find(&roles); err != nil {
+		return err
+	}
+	roles = lo.Filter(roles, func(role model.Role, _ int) bool { return !role.BuiltIn })
+
+	return userDAO.ReplaceRoles(tx, user, append(roles, *builtinRole))
+}
+
+// RoleService 角色服务
+//
+//	author centonhuang
+//	update 2025-11-11 17:05:24
+type RoleService interface {
+	ListRoles(ctx context.Context, req *dto.EmptyRequest) (rsp *dto.ListRolesResponse, err error)
+	ListScopes(ctx context.Context, req *dto.EmptyRequest) (rsp *dto.ListScopesResponse, err error)
+	CreateRole(ctx context.Context, req *dto.CreateRoleRequest) (rsp *dto.CreateRoleResponse, err error)
+	UpdateRole(ctx context.Context, req *dto.UpdateRoleRequest) (rsp *dto.EmptyResponse, err error)
+	DeleteRole(ctx context.Context, req *dto.DeleteRoleRequest) (rsp *dto.EmptyResponse, err error)
+}
+
+type roleService struct {
+	roleDAO *dao.RoleDAO
+}
+
+// NewRoleService 创建角色服务
+//
+//	return RoleService
+//	author centonhuang
+//	update 2025-11-11 17:05:24
+func NewRoleService() RoleService {
+	return &roleService{
+		roleDAO: dao.GetRoleDAO(),
+	}
+}
+
+// ListRoles 列出全部角色
+//
+//	receiver s *roleService
+//	param ctx context.Context
+//	param req *dto.EmptyRequest
+//	return rsp *dto.ListRolesResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-11 17:05:24
+func (s *roleService) ListRoles(ctx context.Context, _ *dto.EmptyRequest) (rsp *dto.ListRolesResponse, err error) {
+	rsp = &dto.ListRolesResponse{}
+
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	roles, err := s.roleDAO.List(db, roleFields)
+	if err != nil {
+		logger.Error("[RoleService] failed to list roles", zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	rsp.Roles = lo.Map(*roles, func(role model.Role, _ int) *dto.Role {
+		return buildRoleDTO(&role)
+	})
+
+	return rsp, nil
+}
+
+// ListScopes 列出全部可分配的权限项
+//
+//	receiver s *roleService
+//	param ctx context.Context
+//	param req *dto.EmptyRequest
+//	return rsp *dto.ListScopesResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-11 17:05:24
+func (s *roleService) ListScopes(_ context.Context, _ *dto.EmptyRequest) (rsp *dto.ListScopesResponse, err error) {
+	rsp = &dto.ListScopesResponse{
+		Scopes: lo.Map(model.Scopes, func(scope model.Scope, _ int) string { return string(scope) }),
+	}
+	return rsp, nil
+}
+
+// CreateRole 创建自定义角色
+//
+//	receiver s *roleService
+//	param ctx context.Context
+//	param req *dto.CreateRoleRequest
+//	return rsp *dto.CreateRoleResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-11 17:05:24
+func (s *roleService) CreateRole(ctx context.Context, req *dto.CreateRoleRequest) (rsp *dto.CreateRoleResponse, err error) {
+	rsp = &dto.CreateRoleResponse{}
+
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	if req.Body == nil {
+		logger.Error("[RoleService] request body is nil")
+		return nil, protocol.ErrBadRequest
+	}
+
+	scopes, err := parseScopes(req.Body.Scopes)
+	if err != nil {
+		logger.Error("[RoleService] invalid scopes", zap.Strings("scopes", req.Body.Scopes), zap.Error(err))
+		return nil, protocol.ErrBadRequest
+	}
+
+	_, err = s.roleDAO.GetByName(db, req.Body.Name, []string{"id"}, []string{})
+	if err == nil {
+		logger.Error("[RoleService] role already exists", zap.String("name", req.Body.Name))
+		return nil, protocol.ErrDataExists
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		logger.Error("[RoleService] failed to get role", zap.String("name", req.Body.Name), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	role := &model.Role{
+		Name:        req.Body.Name,
+		Description: req.Body.Description,
+		Scopes:      scopes,
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := s.roleDAO.Create(tx, role); err != nil {
+			return err
+		}
+		return recordAuditEvent(ctx, tx, &model.AuditEvent{
+			Action:     model.AuditActionRoleCreate,
+			TargetType: model.AuditTargetTypeRole,
+			TargetID:   role.ID,
+			After:      map[string]any{"name": role.Name, "description": role.Description, "scopes": role.Scopes},
+		})
+	})
+	if err != nil {
+		logger.Error("[RoleService] failed to create role", zap.String("name", req.Body.Name), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	logger.Info("[RoleService] role created", zap.Uint("roleID", role.ID), zap.String("name", role.Name))
+
+	rsp.Role = buildRoleDTO(role)
+	return rsp, nil
+}
+
+// UpdateRole 更新角色描述与权限项
+//
+//	receiver s *roleService
+//	param ctx context.Context
+//	param req *dto.UpdateRoleRequest
+//	return rsp *dto.EmptyResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-11 17:05:24
+func (s *roleService) UpdateRole(ctx context.Context, req *dto.UpdateRoleRequest) (rsp *dto.EmptyResponse, err error) {
+	rsp = &dto.EmptyResponse{}
+
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	if req.Body == nil {
+		logger.Error("[RoleService] request body is nil")
+		return nil, protocol.ErrBadRequest
+	}
+
+	role, err := s.getRole(ctx, req.RoleID)
+	if err != nil {
+		return nil, err
+	}
+
+	before, after := map[string]any{}, map[string]any{}
+	info := map[string]interface{}{}
+
+	if req.Body.Description != nil {
+		info["description"] = *req.Body.Description
+		before["description"], after["description"] = role.Description, *req.Body.Description
+	}
+	if req.Body.Scopes != nil {
+		// 内置管理员角色持有全部权限，修改后可能导致无人能够管理系统
+		if role.BuiltIn && role.Name == string(model.PermissionAdmin) {
+			logger.Error("[RoleService] builtin admin role scopes cannot be changed", zap.Uint("roleID", role.ID))
+			return nil, protocol.ErrNoPermission
+		}
+		scopes, err := parseScopes(req.Body.Scopes)
+		if err != nil {
+			logger.Error("[RoleService] invalid scopes", zap.Strings("scopes", req.Body.Scopes), zap.Error(err))
+			return nil, protocol.ErrBadRequest
+		}
+		info["scopes"] = lo.Must1(sonic.MarshalString(scopes))
+		before["scopes"], after["scopes"] = role.Scopes, scopes
+	}
+	if len(info) == 0 {
+		return rsp, nil
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := s.roleDAO.Update(tx, role, info); err != nil {
+			return err
+		}
+		return recordAuditEvent(ctx, tx, &model.AuditEvent{
+			Action:     model.AuditActionRoleUpdate,
+			TargetType: model.AuditTargetTypeRole,
+			TargetID:   role.ID,
+			Before:     before,
+			After:      after,
+		})
+	})
+	if err != nil {
+		logger.Error("[RoleService] failed to update role", zap.Uint("roleID", role.ID), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	logger.Info("[RoleService] role updated", zap.Uint("roleID", role.ID), zap.String("name", role.Name))
+
+	return rsp, nil
+}
+
+// DeleteRole 删除自定义角色，持有该角色的用户将失去对应权限
+//
+//	receiver s *roleService
+//	param ctx context.Context
+//	param req *dto.DeleteRoleRequest
+//	return rsp *dto.EmptyResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-11 17:05:24
+func (s *roleService) DeleteRole(ctx context.Context, req *dto.DeleteRoleRequest) (rsp *dto.EmptyResponse, err error) {
+	rsp = &dto.EmptyResponse{}
+
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	role, err := s.getRole(ctx, req.RoleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if role.BuiltIn {
+		logger.Error("[RoleService] builtin role cannot be deleted", zap.Uint("roleID", role.ID), zap.String("name", role.Name))
+		return nil, protocol.ErrNoPermission
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := s.roleDAO.Delete(tx, role); err != nil {
+			return err
+		}
+		return recordAuditEvent(ctx, tx, &model.AuditEvent{
+			Action:     model.AuditActionRoleDelete,
+			TargetType: model.AuditTargetTypeRole,
+			TargetID:   role.ID,
+			Before:     map[string]any{"name": role.Name, "description": role.Description, "scopes": role.Scopes},
+		})
+	})
+	if err != nil {
+		logger.Error("[RoleService] failed to delete role", zap.Uint("roleID", role.ID), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	logger.Info("[RoleService] role deleted", zap.Uint("roleID", role.ID), zap.String("name", role.Name))
+
+	return rsp, nil
+}
+
+func (s *roleService) getRole(ctx context.Context, roleID uint) (role *model.Role, err error) {
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	role, err = s.roleDAO.GetByID(db, roleID, roleFields, []string{})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Error("[RoleService] role not found", zap.Uint("roleID", roleID))
+			return nil, protocol.ErrDataNotExists
+		}
+		logger.Error("[RoleService] failed to get role", zap.Uint("roleID", roleID), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+	return role, nil
+}
+
+func parseScopes(raw []string) ([]model.Scope, error) {
+	scopes := lo.Uniq(lo.Map(raw, func(scope string, _ int) model.Scope { return model.Scope(scope) }))
+	if invalid, _ := lo.Difference(scopes, model.Scopes); len(invalid) > 0 {
+		return nil, fmt.Errorf("unknown scopes: %v", invalid)
+	}
+	return scopes, nil
+}
+
+func buildRoleDTO(role *model.Role) *dto.Role {
+	return &dto.Role{
+		RoleID:      role.ID,
+		Name:        role.Name,
+		Description: role.Description,
+		Scopes:      lo.Map(role.Scopes, func(scope model.Scope, _ int) string { return string(scope) }),
+		BuiltIn:     role.BuiltIn,
+		CreatedAt:   role.CreatedAt.Format(time.DateTime),
+		UpdatedAt:   role.UpdatedAt.Format(time.DateTime),
+	}
+}
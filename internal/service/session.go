@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hcd233/aris-blog-api/internal/constant"
+	"github.com/hcd233/aris-blog-api/internal/logger"
+	"github.com/hcd233/aris-blog-api/internal/protocol"
+	dto "github.com/hcd233/aris-blog-api/internal/protocol/dto"
+	"github.com/hcd233/aris-blog-api/internal/resource/database"
+	"github.com/hcd233/aris-blog-api/internal/resource/database/dao"
+	"github.com/hcd233/aris-blog-api/internal/resource/database/model"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// SessionService 登录会话服务
+//
+//	author centonhuang
+//	update 2025-11-11 20:12:40
+type SessionService interface {
+	ListSessions(ctx context.Context, req *dto.EmptyRequest) (rsp *dto.ListSessionsResponse, err error)
+	RevokeSession(ctx context.Context, req *dto.RevokeSessionRequest) (rsp *dto.EmptyResponse, err error)
+	RevokeAllSessions(ctx context.Context, req *dto.EmptyRequest) (rsp *dto.RevokeAllSessionsResponse, err error)
+}
+
+type sessionService struct {
+	sessionDAO *dao.SessionDAO
+}
+
+// NewSessionService 创建登录会话服务
+//
+//	return SessionService
+//	author centonhuang
+//	update 2025-11-11 20:12:40
+func NewSessionService() SessionService {
+	return &sessionService{
+		sessionDAO: dao.GetSessionDAO(),
+	}
+}
+
+// ListSessions 列出当前用户的有效会话
+//
+//	receiver s *sessionService
+//	param ctx context.Context
+//	param req *dto.EmptyRequest
+//	return rsp *dto.ListSessionsResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-11 20:12:40
+func (s *sessionService) ListSessions(ctx context.Context, _ *dto.EmptyRequest) (rsp *dto.ListSessionsResponse, err error) {
+	rsp = &dto.ListSessionsResponse{}
+
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	userID := ctx.Value(constant.CtxKeyUserID).(uint)
+	currentSessionID, _ := ctx.Value(constant.CtxKeySessionID).(uint)
+
+	sessions, err := s.sessionDAO.ListActiveByUserID(db, userID, []string{"id", "ip", "user_agent", "created_at", "last_used_at", "expires_at"})
+	if err != nil {
+		logger.Error("[SessionService] failed to list sessions", zap.Uint("userID", userID), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	rsp.Sessions = lo.Map(*sessions, func(session model.Session, _ int) *dto.Session {
+		return &dto.Session{
+			SessionID:  session.ID,
+			IP:         session.IP,
+			UserAgent:  session.UserAgent,
+			Current:    session.ID == currentSessionID,
+			CreatedAt:  session.CreatedAt.Format(time.DateTime),
+			LastUsedAt: session.LastUsedAt.Format(time.DateTime),
+			ExpiresAt:  session.ExpiresAt.Format(time.DateTime),
+		}
+	})
+
+	return rsp, nil
+}
+
+// RevokeSession 吊销当前用户的指定会话
+//
+//	receiver s *sessionService
+//	param ctx context.Context
+//	param req *dto.RevokeSessionRequest
+//	return rsp *dto.EmptyResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-11 20:12:40
+func (s *sessionService) RevokeSession(ctx context.Context, req *dto.RevokeSessionRequest) (rsp *dto.EmptyResponse, err error) {
+	rsp = &dto.EmptyResponse{}
+
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	if err := requireSessionAuth(ctx); err != nil {
+		return nil, err
+	}
+
+	userID := ctx.Value(constant.CtxKeyUserID).(uint)
+
+	session, err := s.sessionDAO.GetByID(db, req.SessionID, []string{"id", "user_id"}, []string{})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Error("[SessionService] session not found", zap.Uint("sessionID", req.SessionID))
+			return nil, protocol.ErrDataNotExists
+		}
+		logger.Error("[SessionService] failed to get session", zap.Uint("sessionID", req.SessionID), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	if session.UserID != userID {
+		logger.Error("[SessionService] no permission to revoke session",
+			zap.Uint("sessionID", session.ID),
+			zap.Uint("sessionUserID", session.UserID))
+		return nil, protocol.ErrNoPermission
+	}
+
+	if err := s.sessionDAO.Revoke(db, session, model.SessionRevokeReasonRevoked); err != nil {
+		logger.Error("[SessionService] failed to revoke session", zap.Uint("sessionID", session.ID), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	logger.Info("[SessionService] session revoked", zap.Uint("sessionID", session.ID))
+
+	return rsp, nil
+}
+
+// RevokeAllSessions 吊销当前用户的全部会话，包括当前会话
+//
+//	receiver s *sessionService
+//	param ctx context.Context
+//	param req *dto.EmptyRequest
+//	return rsp *dto.RevokeAllSessionsResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-11 20:12:40
+func (s *sessionService) RevokeAllSessions(ctx context.Context, _ *dto.EmptyRequest) (rsp *dto.RevokeAllSessionsResponse, err error) {
+	rsp = &dto.RevokeAllSessionsResponse{}
+
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	if err := requireSessionAuth(ctx); err != nil {
+		return nil, err
+	}
+
+	userID := ctx.Value(constant.CtxKeyUserID).(uint)
+
+	revoked, err := s.sessionDAO.RevokeByUserID(db, userID, model.SessionRevokeReasonRevoked)
+	if err != nil {
+		logger.Error("[SessionService] failed to revoke sessions", zap.Uint("userID", userID), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	logger.Info("[SessionService] all sessions revoked", zap.Uint("userID", userID), zap.Int64("revoked", revoked))
+
+	rsp.Revoked = revoked
+	return rsp, nil
+}
@@ -63,7 +63,22 @@ func (s *tagService) CreateTag(ctx context.Context, req *dto.CreateTagRequest) (
 		UserID:      userID,
 	}
 
-	if err := s.tagDAO.Create(db, tag); err != nil {
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := s.tagDAO.Create(tx, tag); err != nil {
+			return err
+		}
+		return recordAuditEvent(ctx, tx, &model.AuditEvent{
+			Action:     model.AuditActionTagCreate,
+			TargetType: model.AuditTargetTypeTag,
+			TargetID:   tag.ID,
+			After: map[string]any{
+				"name":        tag.Name,
+				"slug":        tag.Slug,
+				"description": tag.Description,
+			},
+		})
+	})
+	if err != nil {
 		if errors.Is(err, gorm.ErrDuplicatedKey) {
 			logger.Error("[TagService] tag already exists", zap.String("name", req.Body.Name), zap.String("slug", req.Body.Slug), zap.Error(err))
 			return nil, protocol.ErrDataExists
@@ -131,7 +146,7 @@ func (s *tagService) UpdateTag(ctx context.Context, req *dto.UpdateTagRequest) (
 
 	userID := ctx.Value(constant.CtxKeyUserID).(uint)
 
-	tag, err := s.tagDAO.GetByID(db, req.TagID, []string{"id", "user_id"}, []string{})
+	tag, err := s.tagDAO.GetByID(db, req.TagID, []string{"id", "user_id", "name", "slug", "description"}, []string{})
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			logger.Error("[TagService] tag not found", zap.Uint("tagID", req.TagID))
@@ -164,7 +179,22 @@ func (s *tagService) UpdateTag(ctx context.Context, req *dto.UpdateTagRequest) (
 		return rsp, nil
 	}
 
-	if err := s.tagDAO.Update(db, tag, updateFields); err != nil {
+	before := map[string]any{"name": tag.Name, "slug": tag.Slug, "description": tag.Description}
+	after := lo.Assign(before, updateFields)
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := s.tagDAO.Update(tx, tag, updateFields); err != nil {
+			return err
+		}
+		return recordAuditEvent(ctx, tx, &model.AuditEvent{
+			Action:     model.AuditActionTagUpdate,
+			TargetType: model.AuditTargetTypeTag,
+			TargetID:   tag.ID,
+			Before:     before,
+			After:      after,
+		})
+	})
+	if err != nil {
 		logger.Error("[TagService] update tag failed",
 			zap.Uint("tagID", req.TagID),
 			zap.Any("updateFields", updateFields),
@@ -200,7 +230,18 @@ func (s *tagService) DeleteTag(ctx context.Context, req *dto.DeleteTagRequest) (
 		return nil, protocol.ErrNoPermission
 	}
 
-	if err := s.tagDAO.Delete(db, tag); err != nil {
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := s.tagDAO.Delete(tx, tag); err != nil {
+			return err
+		}
+		return recordAuditEvent(ctx, tx, &model.AuditEvent{
+			Action:     model.AuditActionTagDelete,
+			TargetType: model.AuditTargetTypeTag,
+			TargetID:   tag.ID,
+			Before:     map[string]any{"name": tag.Name, "slug": tag.Slug},
+		})
+	})
+	if err != nil {
 		logger.Error("[TagService] delete tag failed", zap.Uint("tagID", req.TagID), zap.Error(err))
 		return nil, protocol.ErrInternalError
 	}
@@ -6,27 +6,68 @@ package service
 import (
 	"context"
 	"errors"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/hcd233/aris-blog-api/internal/config"
+	"github.com/hcd233/aris-blog-api/internal/constant"
 	"github.com/hcd233/aris-blog-api/internal/jwt"
 	"github.com/hcd233/aris-blog-api/internal/logger"
 	"github.com/hcd233/aris-blog-api/internal/protocol"
 	"github.com/hcd233/aris-blog-api/internal/protocol/dto"
 	"github.com/hcd233/aris-blog-api/internal/resource/database"
 	"github.com/hcd233/aris-blog-api/internal/resource/database/dao"
+	"github.com/hcd233/aris-blog-api/internal/resource/database/model"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
+// issueSessionTokens 为用户创建登录会话，并签发绑定该会话的访问令牌与刷新令牌
+//
+//	param ctx context.Context
+//	param db *gorm.DB
+//	param userID uint
+//	return accessToken string
+//	return refreshToken string
+//	return err error
+//	author centonhuang
+//	update 2025-11-11 20:12:40
+func issueSessionTokens(ctx context.Context, db *gorm.DB, userID uint) (accessToken, refreshToken string, err error) {
+	ip, _ := ctx.Value(constant.CtxKeyClientIP).(string)
+	userAgent, _ := ctx.Value(constant.CtxKeyUserAgent).(string)
+	now := time.Now().UTC()
+
+	session := &model.Session{
+		UserID:     userID,
+		TokenID:    uuid.NewString(),
+		IP:         ip,
+		UserAgent:  userAgent,
+		LastUsedAt: now,
+		ExpiresAt:  now.Add(config.JwtRefreshTokenExpired),
+	}
+	if err = dao.GetSessionDAO().Create(db, session); err != nil {
+		return
+	}
+
+	if accessToken, err = jwt.GetAccessTokenSigner().EncodeToken(userID, session.ID, ""); err != nil {
+		return
+	}
+	refreshToken, err = jwt.GetRefreshTokenSigner().EncodeToken(userID, session.ID, session.TokenID)
+	return
+}
+
 // TokenService 令牌服务
 //
 //	author centonhuang
-//	update 2025-01-05 21:00:00
+//	update 2025-11-11 20:12:40
 type TokenService interface {
 	RefreshToken(ctx context.Context, req *dto.RefreshTokenRequest) (rsp *dto.RefreshTokenResponse, err error)
+	Logout(ctx context.Context, req *dto.EmptyRequest) (rsp *dto.EmptyResponse, err error)
 }
 
 type tokenService struct {
 	userDAO            *dao.UserDAO
+	sessionDAO         *dao.SessionDAO
 	accessTokenSigner  jwt.TokenSigner
 	refreshTokenSigner jwt.TokenSigner
 }
@@ -39,6 +80,7 @@ type tokenService struct {
 func NewTokenService() TokenService {
 	return &tokenService{
 		userDAO:            dao.GetUserDAO(),
+		sessionDAO:         dao.GetSessionDAO(),
 		accessTokenSigner:  jwt.GetAccessTokenSigner(),
 		refreshTokenSigner: jwt.GetRefreshTokenSigner(),
 	}
@@ -46,51 +88,155 @@ func NewTokenService() TokenService {
 
 // RefreshToken 刷新令牌
 //
+// 每次刷新都会轮换刷新令牌，旧令牌随即失效；若已轮换的旧令牌再次出现，视为令牌泄露并吊销整个会话
+//
 //	receiver s *tokenService
 //	param ctx context.Context
 //	param req *dto.RefreshTokenRequest
 //	return rsp *dto.RefreshTokenResponse
 //	return err error
 //	author centonhuang
-//	update 2025-01-05 21:00:00
+//	update 2025-11-11 20:12:40
 func (s *tokenService) RefreshToken(ctx context.Context, req *dto.RefreshTokenRequest) (rsp *dto.RefreshTokenResponse, err error) {
 	rsp = &dto.RefreshTokenResponse{}
 
 	logger := logger.WithCtx(ctx)
 	db := database.GetDBInstance(ctx)
 
-	userID, err := s.refreshTokenSigner.DecodeToken(req.Body.RefreshToken)
+	claims, err := s.refreshTokenSigner.DecodeToken(req.Body.RefreshToken)
 	if err != nil {
 		logger.Error("[TokenService] failed to decode refresh token", zap.String("refreshToken", req.Body.RefreshToken), zap.Error(err))
 		return nil, protocol.ErrUnauthorized
 	}
 
-	_, err = s.userDAO.GetByID(db, userID, []string{"id"}, []string{})
+	session, err := s.sessionDAO.GetByID(db, claims.SessionID, []string{"id", "user_id", "token_id", "expires_at", "revoked_at"}, []string{})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Error("[TokenService] session not found", zap.Uint("sessionID", claims.SessionID))
+			return nil, protocol.ErrUnauthorized
+		}
+		logger.Error("[TokenService] failed to get session", zap.Uint("sessionID", claims.SessionID), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	if session.UserID != claims.UserID || !session.IsActive(time.Now().UTC()) {
+		logger.Info("[TokenService] session is inactive", zap.Uint("sessionID", session.ID), zap.Uint("userID", claims.UserID))
+		return nil, protocol.ErrUnauthorized
+	}
+
+	if claims.ID != session.TokenID {
+		s.revokeReusedSession(ctx, session, claims.ID)
+		return nil, protocol.ErrUnauthorized
+	}
+
+	user, err := s.userDAO.GetByID(db, session.UserID, []string{"id", "status", "suspended_until"}, []string{})
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			logger.Error("[TokenService] user not found", zap.Uint("userID", userID))
+			logger.Error("[TokenService] user not found", zap.Uint("userID", session.UserID))
 			return nil, protocol.ErrDataNotExists
 		}
 		logger.Error("[TokenService] failed to get user by id", zap.Error(err))
 		return nil, protocol.ErrInternalError
 	}
 
-	accessToken, err := s.accessTokenSigner.EncodeToken(userID)
+	if user.IsBlocked(time.Now().UTC()) {
+		logger.Info("[TokenService] user is blocked", zap.Uint("userID", user.ID), zap.String("status", string(user.Status)))
+		return nil, protocol.ErrNoPermission
+	}
+
+	ip, _ := ctx.Value(constant.CtxKeyClientIP).(string)
+	userAgent, _ := ctx.Value(constant.CtxKeyUserAgent).(string)
+	tokenID := uuid.NewString()
+
+	rotated, err := s.sessionDAO.Rotate(db, session, claims.ID, map[string]interface{}{
+		"token_id":     tokenID,
+		"ip":           ip,
+		"user_agent":   userAgent,
+		"last_used_at": time.Now().UTC(),
+	})
+	if err != nil {
+		logger.Error("[TokenService] failed to rotate refresh token", zap.Uint("sessionID", session.ID), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+	// 并发请求已先一步使用同一刷新令牌完成轮换
+	if !rotated {
+		s.revokeReusedSession(ctx, session, claims.ID)
+		return nil, protocol.ErrUnauthorized
+	}
+
+	accessToken, err := s.accessTokenSigner.EncodeToken(user.ID, session.ID, "")
 	if err != nil {
 		logger.Error("[TokenService] failed to encode access token", zap.Error(err))
 		return nil, protocol.ErrInternalError
 	}
 
-	refreshToken, err := s.refreshTokenSigner.EncodeToken(userID)
+	refreshToken, err := s.refreshTokenSigner.EncodeToken(user.ID, session.ID, tokenID)
 	if err != nil {
 		logger.Error("[TokenService] failed to encode refresh token", zap.Error(err))
 		return nil, protocol.ErrInternalError
 	}
 
-	logger.Info("[TokenService] refresh token success", zap.Uint("userID", userID))
+	logger.Info("[TokenService] refresh token success", zap.Uint("userID", user.ID), zap.Uint("sessionID", session.ID))
 
 	rsp.AccessToken = accessToken
 	rsp.RefreshToken = refreshToken
 
 	return rsp, nil
 }
+
+// Logout 登出当前会话，会话的刷新令牌与访问令牌同时失效
+//
+//	receiver s *tokenService
+//	param ctx context.Context
+//	param req *dto.EmptyRequest
+//	return rsp *dto.EmptyResponse
+//	return err error
+//	author centonhuang
+//	update 2025-11-11 20:12:40
+func (s *tokenService) Logout(ctx context.Context, _ *dto.EmptyRequest) (rsp *dto.EmptyResponse, err error) {
+	rsp = &dto.EmptyResponse{}
+
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	if err := requireSessionAuth(ctx); err != nil {
+		return nil, err
+	}
+
+	sessionID := ctx.Value(constant.CtxKeySessionID).(uint)
+
+	if err := s.sessionDAO.Revoke(db, &model.Session{ID: sessionID}, model.SessionRevokeReasonLogout); err != nil {
+		logger.Error("[TokenService] failed to revoke session", zap.Uint("sessionID", sessionID), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	logger.Info("[TokenService] logout success", zap.Uint("sessionID", sessionID))
+
+	return rsp, nil
+}
+
+func (s *tokenService) revokeReusedSession(ctx context.Context, session *model.Session, tokenID string) {
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	logger.Warn("[TokenService] refresh token reuse detected, revoking session",
+		zap.Uint("sessionID", session.ID),
+		zap.Uint("userID", session.UserID),
+		zap.String("tokenID", tokenID))
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := s.sessionDAO.Revoke(tx, session, model.SessionRevokeReasonReuse); err != nil {
+			return err
+		}
+		return recordAuditEvent(ctx, tx, &model.AuditEvent{
+			ActorID:    session.UserID,
+			Action:     model.AuditActionSessionReuseDetected,
+			TargetType: model.AuditTargetTypeSession,
+			TargetID:   session.ID,
+			After:      map[string]any{"tokenID": tokenID},
+		})
+	})
+	if err != nil {
+		logger.Error("[TokenService] failed to revoke reused session", zap.Uint("sessionID", session.ID), zap.Error(err))
+	}
+}
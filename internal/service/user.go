@@ -45,7 +45,7 @@ func NewUserService() UserService {
 	}
 }
 
-// GetCurUserInfo 获取当前用户信息
+// GetCurUserInfo 获取当前用户信息，附带存储用量与剩余配额
 //
 //	receiver s *userService
 //	param ctx context.Context
@@ -53,7 +53,7 @@ func NewUserService() UserService {
 //	return rsp *protocol.GetCurUserInfoResponse
 //	return err error
 //	author centonhuang
-//	update 2025-01-04 21:04:03
+//	update 2025-11-17 10:26:42
 func (s *userService) GetCurUserInfo(ctx context.Context, _ *dto.EmptyRequest) (rsp *dto.GetCurrentUserResponse, err error) {
 	rsp = &dto.GetCurrentUserResponse{}
 
@@ -62,7 +62,7 @@ func (s *userService) GetCurUserInfo(ctx context.Context, _ *dto.EmptyRequest) (
 	logger := logger.WithCtx(ctx)
 	db := database.GetDBInstance(ctx)
 
-	user, err := s.userDAO.GetByID(db, userID, []string{"id", "name", "email", "avatar", "created_at", "last_login", "permission"}, []string{})
+	user, err := s.userDAO.GetByID(db, userID, []string{"id", "name", "email", "avatar", "created_at", "last_login", "permission", "github_bind_id", "qq_bind_id", "google_bind_id", "storage_usage"}, []string{"OidcIdentities"})
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			logger.Error("[UserService] user not found")
@@ -73,13 +73,15 @@ func (s *userService) GetCurUserInfo(ctx context.Context, _ *dto.EmptyRequest) (
 	}
 
 	rsp.User = &dto.User{
-		UserID:     user.ID,
-		Name:       user.Name,
-		Email:      user.Email,
-		Avatar:     user.Avatar,
-		CreatedAt:  user.CreatedAt.Format(time.DateTime),
-		LastLogin:  user.LastLogin.Format(time.DateTime),
-		Permission: string(user.Permission),
+		UserID:          user.ID,
+		Name:            user.Name,
+		Email:           user.Email,
+		Avatar:          user.Avatar,
+		CreatedAt:       user.CreatedAt.Format(time.DateTime),
+		LastLogin:       user.LastLogin.Format(time.DateTime),
+		Permission:      string(user.Permission),
+		LinkedProviders: linkedProviders(user.GetBindIDs()),
+		Storage:         buildStorageQuota(user),
 	}
 
 	logger.Info("[UserService] get cur user info",
@@ -159,3 +161,13 @@ func (s *userService) UpdateUserInfo(ctx context.Context, req *dto.UpdateUserReq
 
 	return rsp, nil
 }
+
+// buildStorageQuota 按用户权限等级的存储配额计算剩余可用空间，需查询storage_usage与permission
+func buildStorageQuota(user *model.User) *dto.StorageQuota {
+	quota := model.PermissionStorageQuotaMapping[user.Permission]
+	return &dto.StorageQuota{
+		UsedBytes:      user.StorageUsage,
+		QuotaBytes:     quota,
+		RemainingBytes: max(quota-user.StorageUsage, 0),
+	}
+}
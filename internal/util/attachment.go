@@ -0,0 +1,92 @@
+package util
+
+import (
+	"bytes"
+)
+
+// AttachmentSniffLength 识别附件内容类型所需读取的文件头长度
+//
+//	update 2025-11-18 10:21:37
+const AttachmentSniffLength = 4096
+
+const tarMagicOffset = 257
+
+var (
+	pdfMagic      = []byte("%PDF-")
+	zipMagic      = []byte("PK\x03\x04")
+	zipEmptyMagic = []byte("PK\x05\x06")
+	gzipMagic     = []byte("\x1f\x8b")
+	sevenZipMagic = []byte("7z\xbc\xaf\x27\x1c")
+	rarMagic      = []byte("Rar!\x1a\x07")
+	tarMagic      = []byte("ustar")
+	id3Magic      = []byte("ID3")
+	flacMagic     = []byte("fLaC")
+	oggMagic      = []byte("OggS")
+	ebmlMagic     = []byte("\x1a\x45\xdf\xa3")
+)
+
+// SniffAttachmentContentType 按文件头的魔数识别附件的真实内容类型，不依赖扩展名与客户端声明的类型；
+// ZIP容器格式（如docx、pptx）只能识别为application/zip，无法识别时返回空字符串
+//
+//	param head []byte 文件头，建议不少于AttachmentSniffLength字节
+//	return contentType string
+//	author centonhuang
+//	update 2025-11-18 10:21:37
+func SniffAttachmentContentType(head []byte) (contentType string) {
+	switch {
+	case bytes.HasPrefix(head, pdfMagic):
+		return "application/pdf"
+	case bytes.HasPrefix(head, zipMagic), bytes.HasPrefix(head, zipEmptyMagic):
+		return "application/zip"
+	case bytes.HasPrefix(head, gzipMagic):
+		return "application/gzip"
+	case bytes.HasPrefix(head, sevenZipMagic):
+		return "application/x-7z-compressed"
+	case bytes.HasPrefix(head, rarMagic):
+		return "application/vnd.rar"
+	case len(head) >= tarMagicOffset+len(tarMagic) && bytes.Equal(head[tarMagicOffset:tarMagicOffset+len(tarMagic)], tarMagic):
+		return "application/x-tar"
+	case bytes.HasPrefix(head, id3Magic), isMPEGAudioFrame(head):
+		return "audio/mpeg"
+	case bytes.HasPrefix(head, flacMagic):
+		return "audio/flac"
+	case bytes.HasPrefix(head, oggMagic):
+		return "audio/ogg"
+	case len(head) >= 12 && string(head[:4]) == "RIFF" && string(head[8:12]) == "WAVE":
+		return "audio/wav"
+	case len(head) >= 12 && string(head[4:8]) == "ftyp":
+		return sniffISOBaseMediaBrand(string(head[8:12]))
+	case bytes.HasPrefix(head, ebmlMagic):
+		// EBML头中的DocType区分WebM与Matroska
+		if bytes.Contains(head[:min(len(head), 64)], []byte("webm")) {
+			return "video/webm"
+		}
+		return "video/x-matroska"
+	default:
+		return ""
+	}
+}
+
+// isMPEGAudioFrame 判断是否以没有ID3标签的MPEG音频帧开头：11位同步字、非保留的层与比特率
+func isMPEGAudioFrame(head []byte) bool {
+	if len(head) < 3 || head[0] != 0xFF || head[1]&0xE0 != 0xE0 {
+		return false
+	}
+	layer := (head[1] >> 1) & 0x03
+	bitrate := head[2] >> 4
+	return layer != 0 && bitrate != 0x0F
+}
+
+// sniffISOBaseMediaBrand 按ftyp的主品牌区分MP4音频、QuickTime与MP4视频，HEIF等图片格式不作为附件
+func sniffISOBaseMediaBrand(brand string) string {
+	switch brand {
+	case "M4A ", "M4B ":
+		return "audio/mp4"
+	case "qt  ":
+		return "video/quicktime"
+	case "heic", "heix", "mif1", "msf1", "avif":
+		return ""
+	default:
+		return "video/mp4"
+	}
+}
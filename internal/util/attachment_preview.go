@@ -0,0 +1,273 @@
+package util
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"sync"
+
+	"github.com/disintegration/imaging"
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	pdfmodel "github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+)
+
+// ErrNoAttachmentPreview 附件中没有可用作预览的图片
+//
+//	update 2025-11-18 10:21:37
+var ErrNoAttachmentPreview = errors.New("no attachment preview")
+
+const (
+	// 内嵌封面的大小上限，超出时视为结构异常
+	maxEmbeddedCoverSize = 16 << 20
+
+	id3HeaderSize       = 10
+	id3FrameHeaderSize  = 10
+	id3PictureTypeFront = 3
+)
+
+var pdfConfigOnce sync.Once
+
+// ExtractAttachmentPreview 从附件中提取预览图：PDF取首页内嵌的最大图片，MP3取ID3封面，MP4、M4A与MOV取iTunes元数据中的封面；
+// 纯Go无法栅格化PDF的矢量内容，也无法解码视频帧，没有内嵌图片时返回ErrNoAttachmentPreview
+//
+//	param r io.ReaderAt
+//	param size int64
+//	param contentType string 识别出的内容类型
+//	return preview image.Image
+//	return err error
+//	author centonhuang
+//	update 2025-11-18 10:21:37
+func ExtractAttachmentPreview(r io.ReaderAt, size int64, contentType string) (preview image.Image, err error) {
+	var cover []byte
+	switch contentType {
+	case "application/pdf":
+		return extractPDFPreview(io.NewSectionReader(r, 0, size))
+	case "audio/mpeg":
+		cover, err = readID3Cover(io.NewSectionReader(r, 0, size))
+	case "audio/mp4", "video/mp4", "video/quicktime":
+		cover, err = readMP4Cover(r, size)
+	default:
+		return nil, ErrNoAttachmentPreview
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return imaging.Decode(bytes.NewReader(cover))
+}
+
+// extractPDFPreview 取首页内嵌图片中面积最大的一张，扫描件与导出为图片的幻灯片通常即为整页
+func extractPDFPreview(rs io.ReadSeeker) (preview image.Image, err error) {
+	pdfConfigOnce.Do(api.DisableConfigDir)
+
+	// pdfcpu解析异常文件时可能panic，转为错误避免中断后台任务
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("parse pdf: %v", r)
+		}
+	}()
+
+	pages, err := api.ExtractImagesRaw(rs, []string{"1"}, pdfmodel.NewDefaultConfiguration())
+	if err != nil {
+		return nil, err
+	}
+
+	var largest *pdfmodel.Image
+	for _, images := range pages {
+		for _, img := range images {
+			if img.IsImgMask || img.Thumb {
+				continue
+			}
+			if largest == nil || img.Width*img.Height > largest.Width*largest.Height {
+				largest = &img
+			}
+		}
+	}
+	if largest == nil {
+		return nil, ErrNoAttachmentPreview
+	}
+
+	return imaging.Decode(largest)
+}
+
+// readID3Cover 读取ID3v2.3与v2.4标签中的图片帧，优先使用封面；不支持整体反同步的标签
+func readID3Cover(r io.Reader) ([]byte, error) {
+	header := make([]byte, id3HeaderSize)
+	if _, err := io.ReadFull(r, header); err != nil || !bytes.HasPrefix(header, id3Magic) {
+		return nil, ErrNoAttachmentPreview
+	}
+
+	major, flags := header[3], header[5]
+	if (major != 3 && major != 4) || flags&0x80 != 0 {
+		return nil, ErrNoAttachmentPreview
+	}
+
+	tagSize := decodeSyncsafe(header[6:10])
+	if tagSize > maxEmbeddedCoverSize {
+		return nil, ErrNoAttachmentPreview
+	}
+	tag := make([]byte, tagSize)
+	if _, err := io.ReadFull(r, tag); err != nil {
+		return nil, fmt.Errorf("%w: truncated id3 tag", ErrNoAttachmentPreview)
+	}
+
+	i := 0
+	if flags&0x40 != 0 && len(tag) >= 4 {
+		// v2.3扩展头长度不含自身，v2.4为同步安全整数且包含自身
+		if major == 3 {
+			i = 4 + int(binary.BigEndian.Uint32(tag[:4]))
+		} else {
+			i = decodeSyncsafe(tag[:4])
+		}
+	}
+
+	var cover []byte
+	for i >= 0 && i+id3FrameHeaderSize <= len(tag) && tag[i] != 0 {
+		frameID := string(tag[i : i+4])
+		frameSize := int(binary.BigEndian.Uint32(tag[i+4 : i+8]))
+		if major == 4 {
+			frameSize = decodeSyncsafe(tag[i+4 : i+8])
+		}
+		frameFlags := tag[i+9]
+
+		start := i + id3FrameHeaderSize
+		end := start + frameSize
+		if frameSize < 0 || end > len(tag) {
+			break
+		}
+		i = end
+
+		if frameID != "APIC" {
+			continue
+		}
+		// 跳过压缩、加密与帧级反同步的图片帧
+		frame := tag[start:end]
+		if (major == 3 && frameFlags&0xC0 != 0) || (major == 4 && frameFlags&0x0E != 0) {
+			continue
+		}
+		if major == 4 && frameFlags&0x01 != 0 {
+			if len(frame) < 4 {
+				continue
+			}
+			frame = frame[4:]
+		}
+
+		pictureType, data, ok := parseID3Picture(frame)
+		if !ok {
+			continue
+		}
+		if pictureType == id3PictureTypeFront {
+			return data, nil
+		}
+		if cover == nil {
+			cover = data
+		}
+	}
+
+	if cover == nil {
+		return nil, ErrNoAttachmentPreview
+	}
+	return cover, nil
+}
+
+// parseID3Picture 解析APIC帧：文本编码、MIME类型、图片类型、描述与图片数据
+func parseID3Picture(frame []byte) (pictureType byte, data []byte, ok bool) {
+	if len(frame) < 4 {
+		return 0, nil, false
+	}
+	encoding := frame[0]
+
+	mimeEnd := bytes.IndexByte(frame[1:], 0)
+	if mimeEnd < 0 || 1+mimeEnd+2 > len(frame) {
+		return 0, nil, false
+	}
+	pictureType = frame[1+mimeEnd+1]
+	description := frame[1+mimeEnd+2:]
+
+	// UTF-16编码的描述以两个零字节结尾
+	if encoding == 1 || encoding == 2 {
+		for j := 0; j+1 < len(description); j += 2 {
+			if description[j] == 0 && description[j+1] == 0 {
+				return pictureType, description[j+2:], true
+			}
+		}
+		return 0, nil, false
+	}
+
+	descriptionEnd := bytes.IndexByte(description, 0)
+	if descriptionEnd < 0 {
+		return 0, nil, false
+	}
+	return pictureType, description[descriptionEnd+1:], true
+}
+
+func decodeSyncsafe(b []byte) int {
+	return int(b[0]&0x7F)<<21 | int(b[1]&0x7F)<<14 | int(b[2]&0x7F)<<7 | int(b[3]&0x7F)
+}
+
+// readMP4Cover 按moov/udta/meta/ilst/covr/data路径读取iTunes元数据中的封面
+func readMP4Cover(r io.ReaderAt, size int64) ([]byte, error) {
+	start, end := int64(0), size
+	for _, boxType := range []string{"moov", "udta", "meta", "ilst", "covr", "data"} {
+		var err error
+		if start, end, err = findMP4Box(r, start, end, boxType); err != nil {
+			return nil, err
+		}
+
+		if boxType == "meta" {
+			// ISO格式的meta带有4字节版本与标志，QuickTime格式的meta直接以子box开头
+			peek := make([]byte, 8)
+			if _, err = r.ReadAt(peek, start); err != nil {
+				return nil, fmt.Errorf("%w: truncated meta box", ErrNoAttachmentPreview)
+			}
+			if string(peek[4:8]) != "hdlr" {
+				start += 4
+			}
+		}
+	}
+
+	// data box内容以4字节类型与4字节区域设置开头
+	start += 8
+	if end-start <= 0 || end-start > maxEmbeddedCoverSize {
+		return nil, ErrNoAttachmentPreview
+	}
+
+	cover := make([]byte, end-start)
+	if _, err := r.ReadAt(cover, start); err != nil {
+		return nil, fmt.Errorf("%w: truncated cover", ErrNoAttachmentPreview)
+	}
+	return cover, nil
+}
+
+// findMP4Box 在[start, end)内查找指定类型的box，返回其内容的起止位置
+func findMP4Box(r io.ReaderAt, start, end int64, boxType string) (contentStart, contentEnd int64, err error) {
+	header := make([]byte, 16)
+	for offset := start; offset+8 <= end; {
+		if _, err = r.ReadAt(header[:8], offset); err != nil {
+			return 0, 0, fmt.Errorf("%w: truncated box header", ErrNoAttachmentPreview)
+		}
+
+		boxSize, headerSize := int64(binary.BigEndian.Uint32(header[:4])), int64(8)
+		switch boxSize {
+		case 0:
+			boxSize = end - offset
+		case 1:
+			if _, err = r.ReadAt(header[8:16], offset+8); err != nil {
+				return 0, 0, fmt.Errorf("%w: truncated box header", ErrNoAttachmentPreview)
+			}
+			boxSize, headerSize = int64(binary.BigEndian.Uint64(header[8:16])), 16
+		}
+		if boxSize < headerSize || boxSize > end-offset {
+			return 0, 0, fmt.Errorf("%w: invalid box size", ErrNoAttachmentPreview)
+		}
+
+		if string(header[4:8]) == boxType {
+			return offset + headerSize, offset + boxSize, nil
+		}
+		offset += boxSize
+	}
+	return 0, 0, ErrNoAttachmentPreview
+}
@@ -0,0 +1,321 @@
+package util
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/disintegration/imaging"
+)
+
+// ErrMalformedImage 图片结构无法解析
+//
+//	update 2025-11-16 16:38:25
+var ErrMalformedImage = errors.New("malformed image")
+
+const (
+	jpegMarkerSOI   = 0xD8
+	jpegMarkerEOI   = 0xD9
+	jpegMarkerSOS   = 0xDA
+	jpegMarkerAPP1  = 0xE1
+	jpegMarkerAPP2  = 0xE2
+	jpegMarkerAPP13 = 0xED
+	jpegMarkerCOM   = 0xFE
+
+	exifOrientationTag = 0x0112
+
+	webpFlagXMP  = 0x04
+	webpFlagEXIF = 0x08
+
+	// 元数据须经旋转后重新编码时使用的JPEG质量
+	reorientJPEGQuality = 95
+)
+
+var (
+	pngSignature       = []byte("\x89PNG\r\n\x1a\n")
+	pngMetadataChunks  = map[string]bool{"tEXt": true, "zTXt": true, "iTXt": true, "eXIf": true, "tIME": true}
+	webpMetadataChunks = map[string]bool{"EXIF": true, "XMP ": true}
+	gifXMPIdentifier   = []byte("XMP DataXMP")
+)
+
+// StripImageMetadata 去除图片中的EXIF、XMP、IPTC与注释等元数据，不重新编码像素；
+// JPEG带有非默认EXIF方向时按方向旋转后重新编码，避免去除元数据后图片方向错误
+//
+//	param data []byte
+//	param extension string 小写扩展名
+//	return stripped []byte
+//	return err error
+//	author centonhuang
+//	update 2025-11-16 16:38:25
+func StripImageMetadata(data []byte, extension string) (stripped []byte, err error) {
+	switch extension {
+	case ".jpg", ".jpeg":
+		return stripJPEGMetadata(data)
+	case ".png":
+		return stripPNGMetadata(data)
+	case ".webp":
+		return stripWebPMetadata(data)
+	case ".gif":
+		return stripGIFMetadata(data)
+	default:
+		return nil, fmt.Errorf("unsupported image extension: %s", extension)
+	}
+}
+
+func stripJPEGMetadata(data []byte) ([]byte, error) {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != jpegMarkerSOI {
+		return nil, fmt.Errorf("%w: missing jpeg SOI", ErrMalformedImage)
+	}
+
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write(data[:2])
+
+	orientation := 1
+	for i := 2; ; {
+		if i+1 >= len(data) || data[i] != 0xFF {
+			return nil, fmt.Errorf("%w: invalid jpeg marker at %d", ErrMalformedImage, i)
+		}
+		// 跳过填充字节
+		for i+1 < len(data) && data[i+1] == 0xFF {
+			i++
+		}
+		if i+1 >= len(data) {
+			return nil, fmt.Errorf("%w: truncated jpeg", ErrMalformedImage)
+		}
+		marker := data[i+1]
+
+		if marker == jpegMarkerEOI {
+			// EOI之后的附加数据（如多图格式中的副图）可能携带元数据，一并丢弃
+			out.Write([]byte{0xFF, jpegMarkerEOI})
+			break
+		}
+
+		if i+4 > len(data) {
+			return nil, fmt.Errorf("%w: truncated jpeg segment", ErrMalformedImage)
+		}
+		end := i + 2 + int(binary.BigEndian.Uint16(data[i+2:i+4]))
+		if end > len(data) {
+			return nil, fmt.Errorf("%w: truncated jpeg segment", ErrMalformedImage)
+		}
+		payload := data[i+4 : end]
+
+		switch {
+		case marker == jpegMarkerAPP1:
+			if bytes.HasPrefix(payload, []byte("Exif\x00\x00")) {
+				orientation = readExifOrientation(payload[6:])
+			}
+		case marker == jpegMarkerAPP13, marker == jpegMarkerCOM:
+		case marker == jpegMarkerAPP2 && bytes.HasPrefix(payload, []byte("MPF\x00")):
+		default:
+			out.Write(data[i:end])
+		}
+		i = end
+
+		if marker == jpegMarkerSOS {
+			// 熵编码数据中0xFF后只会跟随0x00填充或RST标记，遇到其它字节即为下一个标记
+			start := i
+			for i+1 < len(data) && (data[i] != 0xFF || data[i+1] == 0x00 || (data[i+1] >= 0xD0 && data[i+1] <= 0xD7)) {
+				i++
+			}
+			if i+1 >= len(data) {
+				return nil, fmt.Errorf("%w: truncated jpeg scan", ErrMalformedImage)
+			}
+			out.Write(data[start:i])
+		}
+	}
+
+	if orientation < 2 || orientation > 8 {
+		return out.Bytes(), nil
+	}
+
+	img, err := imaging.Decode(bytes.NewReader(data), imaging.AutoOrientation(true))
+	if err != nil {
+		return nil, err
+	}
+	var buffer bytes.Buffer
+	if err = imaging.Encode(&buffer, img, imaging.JPEG, imaging.JPEGQuality(reorientJPEGQuality)); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// readExifOrientation 从TIFF结构的IFD0中读取方向标签，读取失败时视为默认方向
+func readExifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	offset := int(order.Uint32(tiff[4:8]))
+	if offset+2 > len(tiff) || offset < 8 {
+		return 1
+	}
+	count := int(order.Uint16(tiff[offset : offset+2]))
+	for n := 0; n < count; n++ {
+		entry := offset + 2 + n*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:entry+2]) == exifOrientationTag {
+			return int(order.Uint16(tiff[entry+8 : entry+10]))
+		}
+	}
+	return 1
+}
+
+func stripPNGMetadata(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, pngSignature) {
+		return nil, fmt.Errorf("%w: missing png signature", ErrMalformedImage)
+	}
+
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write(pngSignature)
+
+	for i := len(pngSignature); ; {
+		if i+8 > len(data) {
+			return nil, fmt.Errorf("%w: truncated png chunk", ErrMalformedImage)
+		}
+		// 长度、类型、数据与CRC
+		end := i + 12 + int(binary.BigEndian.Uint32(data[i:i+4]))
+		if end > len(data) || end < i {
+			return nil, fmt.Errorf("%w: truncated png chunk", ErrMalformedImage)
+		}
+		chunkType := string(data[i+4 : i+8])
+		if !pngMetadataChunks[chunkType] {
+			out.Write(data[i:end])
+		}
+		i = end
+
+		if chunkType == "IEND" {
+			return out.Bytes(), nil
+		}
+	}
+}
+
+func stripWebPMetadata(data []byte) ([]byte, error) {
+	if len(data) < 12 || string(data[:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return nil, fmt.Errorf("%w: missing webp header", ErrMalformedImage)
+	}
+
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write(data[:12])
+
+	riffEnd := min(8+int(binary.LittleEndian.Uint32(data[4:8])), len(data))
+	for i := 12; i < riffEnd; {
+		if i+8 > riffEnd {
+			return nil, fmt.Errorf("%w: truncated webp chunk", ErrMalformedImage)
+		}
+		chunkSize := int(binary.LittleEndian.Uint32(data[i+4 : i+8]))
+		// 块数据按偶数字节对齐
+		end := i + 8 + chunkSize + chunkSize&1
+		if end > riffEnd || end < i {
+			return nil, fmt.Errorf("%w: truncated webp chunk", ErrMalformedImage)
+		}
+
+		fourCC := string(data[i : i+4])
+		switch {
+		case webpMetadataChunks[fourCC]:
+		case fourCC == "VP8X" && chunkSize > 0:
+			chunk := bytes.Clone(data[i:end])
+			chunk[8] &^= webpFlagXMP | webpFlagEXIF
+			out.Write(chunk)
+		default:
+			out.Write(data[i:end])
+		}
+		i = end
+	}
+
+	stripped := out.Bytes()
+	binary.LittleEndian.PutUint32(stripped[4:8], uint32(len(stripped)-8))
+	return stripped, nil
+}
+
+func stripGIFMetadata(data []byte) ([]byte, error) {
+	if len(data) < 13 || (string(data[:6]) != "GIF87a" && string(data[:6]) != "GIF89a") {
+		return nil, fmt.Errorf("%w: missing gif header", ErrMalformedImage)
+	}
+
+	// 文件头、逻辑屏幕描述符与全局颜色表
+	i := 13
+	if flags := data[10]; flags&0x80 != 0 {
+		i += 3 << ((flags & 0x07) + 1)
+	}
+	if i > len(data) {
+		return nil, fmt.Errorf("%w: truncated gif color table", ErrMalformedImage)
+	}
+
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write(data[:i])
+
+	for i < len(data) {
+		start := i
+		switch data[i] {
+		case 0x21:
+			if i+2 > len(data) {
+				return nil, fmt.Errorf("%w: truncated gif extension", ErrMalformedImage)
+			}
+			label := data[i+1]
+			end, err := skipGIFSubBlocks(data, i+2)
+			if err != nil {
+				return nil, err
+			}
+			i = end
+
+			// 丢弃注释扩展与XMP应用扩展，保留图形控制与循环播放等扩展
+			isComment := label == 0xFE
+			isXMP := label == 0xFF && start+3+len(gifXMPIdentifier) <= len(data) &&
+				data[start+2] == byte(len(gifXMPIdentifier)) &&
+				bytes.Equal(data[start+3:start+3+len(gifXMPIdentifier)], gifXMPIdentifier)
+			if !isComment && !isXMP {
+				out.Write(data[start:end])
+			}
+		case 0x2C:
+			// 图像描述符、局部颜色表与LZW最小码长
+			i += 10
+			if i > len(data) {
+				return nil, fmt.Errorf("%w: truncated gif image descriptor", ErrMalformedImage)
+			}
+			if flags := data[i-1]; flags&0x80 != 0 {
+				i += 3 << ((flags & 0x07) + 1)
+			}
+			end, err := skipGIFSubBlocks(data, i+1)
+			if err != nil {
+				return nil, err
+			}
+			i = end
+			out.Write(data[start:end])
+		case 0x3B:
+			out.WriteByte(0x3B)
+			return out.Bytes(), nil
+		default:
+			return nil, fmt.Errorf("%w: unknown gif block 0x%02x", ErrMalformedImage, data[i])
+		}
+	}
+
+	return nil, fmt.Errorf("%w: missing gif trailer", ErrMalformedImage)
+}
+
+// skipGIFSubBlocks 跳过以长度0结尾的数据子块序列，返回其后的位置
+func skipGIFSubBlocks(data []byte, i int) (int, error) {
+	for {
+		if i >= len(data) {
+			return 0, fmt.Errorf("%w: truncated gif sub-block", ErrMalformedImage)
+		}
+		size := int(data[i])
+		i++
+		if size == 0 {
+			return i, nil
+		}
+		i += size
+	}
+}
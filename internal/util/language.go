@@ -0,0 +1,85 @@
+package util
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type acceptLanguage struct {
+	tag     string
+	quality float64
+}
+
+// ParseAcceptLanguage 解析Accept-Language请求头，按权重降序返回主语言标签
+//
+//	param header string
+//	return tags []string
+//	author centonhuang
+//	update 2025-11-09 10:12:36
+func ParseAcceptLanguage(header string) (tags []string) {
+	var langs []acceptLanguage
+	for _, part := range strings.Split(header, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		tag, params, _ := strings.Cut(part, ";")
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if quality <= 0 {
+			continue
+		}
+
+		primary, _, _ := strings.Cut(strings.TrimSpace(tag), "-")
+		langs = append(langs, acceptLanguage{tag: strings.ToLower(primary), quality: quality})
+	}
+
+	sort.SliceStable(langs, func(i, j int) bool {
+		return langs[i].quality > langs[j].quality
+	})
+
+	for _, lang := range langs {
+		if !slices.Contains(tags, lang.tag) {
+			tags = append(tags, lang.tag)
+		}
+	}
+	return tags
+}
+
+// NegotiateLanguage 根据显式语言参数与Accept-Language请求头从可用语言中选择最佳语言
+//
+//	param lang string 显式指定的语言，优先级最高
+//	param acceptLanguageHeader string
+//	param available []string
+//	param fallback string 无匹配时的默认语言
+//	return string
+//	author centonhuang
+//	update 2025-11-09 10:12:36
+func NegotiateLanguage(lang, acceptLanguageHeader string, available []string, fallback string) string {
+	if lang != "" && slices.Contains(available, lang) {
+		return lang
+	}
+
+	for _, tag := range ParseAcceptLanguage(acceptLanguageHeader) {
+		if tag == "*" {
+			break
+		}
+		if slices.Contains(available, tag) {
+			return tag
+		}
+	}
+
+	if slices.Contains(available, fallback) || len(available) == 0 {
+		return fallback
+	}
+	return available[0]
+}
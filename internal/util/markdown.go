@@ -0,0 +1,128 @@
+package util
+
+import (
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	// ![alt](link "title")，链接可用尖括号包裹，允许一层成对的括号
+	markdownImagePattern = regexp.MustCompile(`!\[[^\]]*\]\(\s*<?((?:[^\s<>()]|\([^\s<>()]*\))+)>?`)
+	// <img ... src="link" ...>
+	htmlImagePattern = regexp.MustCompile(`(?i)<img\b[^>]*?\bsrc\s*=\s*["']([^"']+)["']`)
+)
+
+// MarkdownOutline 提取Markdown结构大纲，包括标题层级序列与代码块数量
+//
+//	param content string
+//	return headings []int
+//	return codeFences int
+//	author centonhuang
+//	update 2025-11-08 15:20:11
+func MarkdownOutline(content string) (headings []int, codeFences int) {
+	inCodeBlock := false
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			if !inCodeBlock {
+				codeFences++
+			}
+			inCodeBlock = !inCodeBlock
+			continue
+		}
+		if inCodeBlock {
+			continue
+		}
+
+		level := 0
+		for level < len(trimmed) && trimmed[level] == '#' {
+			level++
+		}
+		if level > 0 && level <= 6 && (len(trimmed) == level || trimmed[level] == ' ') {
+			headings = append(headings, level)
+		}
+	}
+	return headings, codeFences
+}
+
+// IsMarkdownStructureEqual 判断两段Markdown的结构是否一致
+//
+//	param source string
+//	param target string
+//	return bool
+//	author centonhuang
+//	update 2025-11-08 15:20:11
+func IsMarkdownStructureEqual(source, target string) bool {
+	sourceHeadings, sourceCodeFences := MarkdownOutline(source)
+	targetHeadings, targetCodeFences := MarkdownOutline(target)
+	return slices.Equal(sourceHeadings, targetHeadings) && sourceCodeFences == targetCodeFences
+}
+
+// ExternalImageLinks 提取Markdown中以http或https绝对地址引用的图片链接，包括内嵌的HTML img标签，
+// 代码块中的内容不计入，按首次出现的顺序去重
+//
+//	param content string
+//	return links []string
+//	author centonhuang
+//	update 2025-11-18 16:05:42
+func ExternalImageLinks(content string) (links []string) {
+	seen := make(map[string]bool)
+	RewriteImageLinks(content, func(link string) string {
+		if isExternalLink(link) && !seen[link] {
+			seen[link] = true
+			links = append(links, link)
+		}
+		return link
+	})
+	return links
+}
+
+// RewriteImageLinks 按rewrite的返回值替换Markdown中的图片链接，代码块中的内容保持不变
+//
+//	param content string
+//	param rewrite func(link string) string 返回原链接表示不替换
+//	return rewritten string
+//	author centonhuang
+//	update 2025-11-18 16:05:42
+func RewriteImageLinks(content string, rewrite func(link string) string) (rewritten string) {
+	lines := strings.Split(content, "\n")
+	inCodeBlock := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inCodeBlock = !inCodeBlock
+			continue
+		}
+		if inCodeBlock {
+			continue
+		}
+		lines[i] = rewriteLinkGroups(rewriteLinkGroups(line, markdownImagePattern, rewrite), htmlImagePattern, rewrite)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// rewriteLinkGroups 只替换正则第一个分组匹配到的链接，其余文本原样保留
+func rewriteLinkGroups(line string, pattern *regexp.Regexp, rewrite func(link string) string) string {
+	matches := pattern.FindAllStringSubmatchIndex(line, -1)
+	if len(matches) == 0 {
+		return line
+	}
+
+	var builder strings.Builder
+	last := 0
+	for _, match := range matches {
+		start, end := match[2], match[3]
+		builder.WriteString(line[last:start])
+		builder.WriteString(rewrite(line[start:end]))
+		last = end
+	}
+	builder.WriteString(line[last:])
+	return builder.String()
+}
+
+func isExternalLink(link string) bool {
+	u, err := url.Parse(link)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
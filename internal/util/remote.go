@@ -0,0 +1,174 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const remoteFetchMaxRedirects = 3
+
+var (
+	// ErrRemoteAddressForbidden 外部链接指向内网、回环等非公网地址，或使用了不允许的协议
+	//
+	//	update 2025-11-18 16:05:42
+	ErrRemoteAddressForbidden = errors.New("remote address forbidden")
+
+	// ErrRemoteResourceTooLarge 外部资源超过大小上限
+	//
+	//	update 2025-11-18 16:05:42
+	ErrRemoteResourceTooLarge = errors.New("remote resource too large")
+
+	// ErrRemoteResourceUnavailable 外部资源返回了非200状态码
+	//
+	//	update 2025-11-18 16:05:42
+	ErrRemoteResourceUnavailable = errors.New("remote resource unavailable")
+)
+
+// 不属于公网的地址段，netip的IsPrivate、IsLoopback等判断之外补充的部分
+var forbiddenPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("192.0.2.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("198.51.100.0/24"),
+	netip.MustParsePrefix("203.0.113.0/24"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("2001:db8::/32"),
+}
+
+// NewRemoteFetchClient 创建只访问公网地址的HTTP客户端：在建立连接时校验解析后的IP，防止DNS重绑定绕过；
+// 不使用环境变量中的代理，重定向不超过3次且只允许http与https
+//
+//	param timeout time.Duration 包含连接、重定向与读取响应体的总超时
+//	return client *http.Client
+//	author centonhuang
+//	update 2025-11-18 16:05:42
+func NewRemoteFetchClient(timeout time.Duration) (client *http.Client) {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			if !IsPublicAddr(addrPort.Addr()) {
+				return fmt.Errorf("%w: %s", ErrRemoteAddressForbidden, addrPort.Addr())
+			}
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			Proxy:                 nil,
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   timeout,
+			ResponseHeaderTimeout: timeout,
+			MaxIdleConns:          16,
+			IdleConnTimeout:       90 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= remoteFetchMaxRedirects {
+				return fmt.Errorf("stopped after %d redirects", remoteFetchMaxRedirects)
+			}
+			return validateRemoteURL(req.URL)
+		},
+	}
+}
+
+// IsPublicAddr 判断IP是否为可从公网访问的单播地址
+//
+//	param addr netip.Addr
+//	return bool
+//	author centonhuang
+//	update 2025-11-18 16:05:42
+func IsPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsUnspecified() || addr.IsLoopback() || addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() || addr.IsMulticast() {
+		return false
+	}
+	for _, prefix := range forbiddenPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// FetchRemoteFile 下载外部链接的内容，超过maxSize时中止并返回ErrRemoteResourceTooLarge
+//
+//	param ctx context.Context
+//	param client *http.Client 应由NewRemoteFetchClient创建
+//	param rawURL string
+//	param maxSize int64
+//	return data []byte
+//	return err error
+//	author centonhuang
+//	update 2025-11-18 16:05:42
+func FetchRemoteFile(ctx context.Context, client *http.Client, rawURL string, maxSize int64) (data []byte, err error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if err = validateRemoteURL(u); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "image/*")
+
+	rsp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: status %d", ErrRemoteResourceUnavailable, rsp.StatusCode)
+	}
+	if rsp.ContentLength > maxSize {
+		return nil, fmt.Errorf("%w: %d bytes", ErrRemoteResourceTooLarge, rsp.ContentLength)
+	}
+
+	// 不信任Content-Length，多读一个字节判断是否超限
+	data, err = io.ReadAll(io.LimitReader(rsp.Body, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxSize {
+		return nil, fmt.Errorf("%w: more than %d bytes", ErrRemoteResourceTooLarge, maxSize)
+	}
+	return data, nil
+}
+
+// validateRemoteURL 只允许不含用户信息的http与https链接，字面量IP在此提前拒绝，域名在连接时校验
+func validateRemoteURL(u *url.URL) error {
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.User != nil {
+		return fmt.Errorf("%w: %s", ErrRemoteAddressForbidden, u.Redacted())
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("%w: %s", ErrRemoteAddressForbidden, host)
+	}
+	if addr, err := netip.ParseAddr(host); err == nil && !IsPublicAddr(addr) {
+		return fmt.Errorf("%w: %s", ErrRemoteAddressForbidden, host)
+	}
+	return nil
+}
@@ -103,7 +103,7 @@ func transformError(err error) (statusErr huma.StatusError) {
 	switch err {
 	case protocol.ErrDataNotExists: // 404
 		statusErr = huma.Error404NotFound(err.Error())
-	case protocol.ErrDataExists, protocol.ErrBadRequest, protocol.ErrInsufficientQuota: // 400
+	case protocol.ErrDataExists, protocol.ErrBadRequest, protocol.ErrInsufficientQuota, protocol.ErrStorageQuotaExceeded: // 400
 		statusErr = huma.Error400BadRequest(err.Error())
 	case protocol.ErrUnauthorized: // 401
 		statusErr = huma.Error401Unauthorized(err.Error())
@@ -0,0 +1,39 @@
+package util
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// PersonalAccessTokenPrefix 个人访问令牌前缀，便于识别认证方式和扫描泄露的令牌
+//
+//	update 2025-11-12 14:02:51
+const PersonalAccessTokenPrefix = "aris_pat_"
+
+// GeneratePersonalAccessToken 生成个人访问令牌
+//
+//	return token string 明文令牌，仅在创建时返回给用户
+//	return err error
+//	author centonhuang
+//	update 2025-11-12 14:02:51
+func GeneratePersonalAccessToken() (token string, err error) {
+	buf := make([]byte, 32)
+	if _, err = rand.Read(buf); err != nil {
+		return
+	}
+	token = PersonalAccessTokenPrefix + base64.RawURLEncoding.EncodeToString(buf)
+	return
+}
+
+// HashToken 计算令牌的SHA-256摘要，数据库只保存摘要
+//
+//	param token string
+//	return string
+//	author centonhuang
+//	update 2025-11-12 14:02:51
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}